@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &AlertSilenceResource{}
+
+func newAlertSilenceResource() resource.Resource {
+	return &AlertSilenceResource{}
+}
+
+// AlertSilenceResource manages an Alertmanager silence through the dashboard's
+// Alertmanager proxy. Alertmanager never edits a silence in place, so every
+// attribute forces replacement.
+type AlertSilenceResource struct {
+	client *CephAPIClient
+}
+
+type AlertSilenceResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Matchers  types.List   `tfsdk:"matchers"`
+	Duration  types.String `tfsdk:"duration"`
+	Comment   types.String `tfsdk:"comment"`
+	CreatedBy types.String `tfsdk:"created_by"`
+	StartsAt  types.String `tfsdk:"starts_at"`
+	EndsAt    types.String `tfsdk:"ends_at"`
+}
+
+type AlertSilenceMatcherModel struct {
+	Name    types.String `tfsdk:"name"`
+	Value   types.String `tfsdk:"value"`
+	IsRegex types.Bool   `tfsdk:"is_regex"`
+	IsEqual types.Bool   `tfsdk:"is_equal"`
+}
+
+var alertSilenceMatcherObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":     types.StringType,
+		"value":    types.StringType,
+		"is_regex": types.BoolType,
+		"is_equal": types.BoolType,
+	},
+}
+
+func (r *AlertSilenceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert_silence"
+}
+
+func (r *AlertSilenceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages an Alertmanager silence through the Ceph dashboard, for example to mute alerts during maintenance. " +
+			"The silence starts when the resource is created and is expired when it is destroyed. " +
+			"Any change replaces the silence. Once it has expired on its own, the resource stays in state until it is destroyed.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The Alertmanager silence ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"matchers": resourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The label matchers an alert must satisfy to be silenced",
+				Required:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: resourceSchema.NestedAttributeObject{
+					Attributes: map[string]resourceSchema.Attribute{
+						"name": resourceSchema.StringAttribute{
+							MarkdownDescription: "The label name, e.g. `alertname`",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"value": resourceSchema.StringAttribute{
+							MarkdownDescription: "The label value or regular expression",
+							Required:            true,
+						},
+						"is_regex": resourceSchema.BoolAttribute{
+							MarkdownDescription: "Whether `value` is a regular expression. Defaults to `false`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+						"is_equal": resourceSchema.BoolAttribute{
+							MarkdownDescription: "Whether the label must match (`true`) or must not match (`false`). Defaults to `true`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+						},
+					},
+				},
+			},
+			"duration": resourceSchema.StringAttribute{
+				MarkdownDescription: "How long the silence lasts from creation, e.g. `2h`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					PositiveDuration(),
+				},
+			},
+			"comment": resourceSchema.StringAttribute{
+				MarkdownDescription: "Why the alerts are silenced",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"created_by": resourceSchema.StringAttribute{
+				MarkdownDescription: "Who created the silence. Defaults to `terraform`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("terraform"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"starts_at": resourceSchema.StringAttribute{
+				MarkdownDescription: "When the silence started, in RFC 3339 format",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ends_at": resourceSchema.StringAttribute{
+				MarkdownDescription: "When the silence ends, in RFC 3339 format",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AlertSilenceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AlertSilenceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AlertSilenceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var matchers []AlertSilenceMatcherModel
+	resp.Diagnostics.Append(data.Matchers.ElementsAs(ctx, &matchers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	duration, err := time.ParseDuration(data.Duration.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Duration",
+			fmt.Sprintf("Unable to parse duration %q: %s", data.Duration.ValueString(), err),
+		)
+		return
+	}
+
+	startsAt := time.Now().UTC().Truncate(time.Second)
+	silence := CephAPIAlertSilence{
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(duration),
+		CreatedBy: data.CreatedBy.ValueString(),
+		Comment:   data.Comment.ValueString(),
+	}
+	for _, matcher := range matchers {
+		isEqual := matcher.IsEqual.ValueBool()
+		silence.Matchers = append(silence.Matchers, CephAPIAlertSilenceMatcher{
+			Name:    matcher.Name.ValueString(),
+			Value:   matcher.Value.ValueString(),
+			IsRegex: matcher.IsRegex.ValueBool(),
+			IsEqual: &isEqual,
+		})
+	}
+
+	id, err := r.client.CreateAlertSilence(ctx, silence)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to create alert silence: %s", err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(id)
+	data.StartsAt = types.StringValue(silence.StartsAt.Format(time.RFC3339))
+	data.EndsAt = types.StringValue(silence.EndsAt.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AlertSilenceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AlertSilenceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	silences, err := r.client.ListAlertSilences(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read alert silences: %s", err),
+		)
+		return
+	}
+
+	var silence *CephAPIAlertSilence
+	for i := range silences {
+		if silences[i].ID == data.ID.ValueString() {
+			silence = &silences[i]
+			break
+		}
+	}
+
+	if silence == nil {
+		// Alertmanager garbage collects expired silences, which must not
+		// make Terraform create the silence again.
+		if alertSilenceExpired(data) {
+			return
+		}
+		removeMissingResource(ctx, resp, fmt.Sprintf("Alert silence %s", data.ID.ValueString()))
+		return
+	}
+
+	matchers := make([]AlertSilenceMatcherModel, 0, len(silence.Matchers))
+	for _, matcher := range silence.Matchers {
+		isEqual := matcher.IsEqual == nil || *matcher.IsEqual
+		matchers = append(matchers, AlertSilenceMatcherModel{
+			Name:    types.StringValue(matcher.Name),
+			Value:   types.StringValue(matcher.Value),
+			IsRegex: types.BoolValue(matcher.IsRegex),
+			IsEqual: types.BoolValue(isEqual),
+		})
+	}
+
+	matchersValue, diags := types.ListValueFrom(ctx, alertSilenceMatcherObjectType, matchers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Matchers = matchersValue
+	data.Comment = types.StringValue(silence.Comment)
+	data.CreatedBy = types.StringValue(silence.CreatedBy)
+	data.StartsAt = types.StringValue(silence.StartsAt.UTC().Format(time.RFC3339))
+	data.EndsAt = types.StringValue(silence.EndsAt.UTC().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Every attribute requires replacement, so there is nothing to update.
+func (r *AlertSilenceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AlertSilenceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AlertSilenceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AlertSilenceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Alertmanager refuses to expire a silence that has already expired.
+	if alertSilenceExpired(data) {
+		return
+	}
+
+	err := r.client.DeleteAlertSilence(ctx, data.ID.ValueString())
+	if err != nil && !isCephAPINotFound(err) {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to expire alert silence: %s", err),
+		)
+		return
+	}
+}
+
+func alertSilenceExpired(data AlertSilenceResourceModel) bool {
+	endsAt, err := time.Parse(time.RFC3339, data.EndsAt.ValueString())
+	return err == nil && !endsAt.After(time.Now())
+}
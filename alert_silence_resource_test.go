@@ -0,0 +1,58 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The test cluster has no Alertmanager for the dashboard to proxy to, so this
+// covers validation and the error path, and no silence can be expired out of
+// band.
+func TestAccCephAlertSilenceResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_alert_silence" "test" {
+					  matchers = [{ name = "alertname", value = "CephOSDDown" }]
+					  duration = "-1h"
+					  comment  = "maintenance"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Duration`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_alert_silence" "test" {
+					  matchers = []
+					  duration = "1h"
+					  comment  = "maintenance"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)at least 1`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_alert_silence" "test" {
+					  matchers = [
+					    { name = "alertname", value = "CephOSDDown" },
+					    { name = "instance", value = "host-[0-9]+", is_regex = true },
+					  ]
+					  duration = "2h"
+					  comment  = "maintenance"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Unable to create alert silence`),
+			},
+		},
+	})
+}
@@ -4,20 +4,317 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+const (
+	defaultRequestTimeout   = 10 * time.Second
+	defaultOperationTimeout = 20 * time.Minute
+	defaultMaxRetries       = 3
+	defaultRetryBackoff     = time.Second
+	maxRetryBackoff         = 30 * time.Second
+
+	requestIDHeader = "X-Request-ID"
+)
+
+// CephAPIError is returned by the client when the dashboard responds with an
+// unexpected status code.
+type CephAPIError struct {
+	StatusCode int
+	Component  string
+	Detail     string
+}
+
+func newCephAPIError(statusCode int, body []byte) *CephAPIError {
+	apiErr := &CephAPIError{
+		StatusCode: statusCode,
+		Detail:     strings.TrimSpace(string(body)),
+	}
+
+	var errBody struct {
+		Detail    string `json:"detail"`
+		Component string `json:"component"`
+	}
+	if err := json.Unmarshal(body, &errBody); err == nil && errBody.Detail != "" {
+		apiErr.Detail = errBody.Detail
+		apiErr.Component = errBody.Component
+	}
+
+	return apiErr
+}
+
+func (e *CephAPIError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("ceph API returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("ceph API returned status %d: %s", e.StatusCode, e.Detail)
+}
+
+// NotFound reports whether the requested object does not exist.
+func (e *CephAPIError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// Retryable reports whether the request failed for a transient reason and may
+// succeed if sent again.
+func (e *CephAPIError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func isCephAPINotFound(err error) bool {
+	var apiErr *CephAPIError
+	return errors.As(err, &apiErr) && apiErr.NotFound()
+}
+
+func isCephAPIStatus(err error, statusCode int) bool {
+	var apiErr *CephAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
 type CephAPIClient struct {
-	endpoint *url.URL
-	token    string
-	client   *http.Client
+	endpoint   *url.URL
+	token      string
+	client     *http.Client
+	cli        *CephCLI
+	rgwAdmin   *RGWAdminOpsClient
+	tokenCache *tokenCache
+	release    cephRelease
+	// rgwDaemon is the default daemon_name sent with dashboard RGW requests.
+	rgwDaemon string
+}
+
+var ErrCLIBackendDisabled = errors.New("this operation is not available through the Ceph Dashboard API and requires the CLI backend; set cli_backend = true in the provider configuration")
+
+// CLI returns the ceph/radosgw-admin backend used for operations the
+// dashboard API does not expose.
+func (c *CephAPIClient) CLI() (*CephCLI, error) {
+	if c.cli == nil {
+		return nil, ErrCLIBackendDisabled
+	}
+	return c.cli, nil
+}
+
+var ErrRGWAdminOpsDisabled = errors.New("this operation is not available through the Ceph Dashboard API and requires the RGW admin ops API; set rgw_admin_endpoint, rgw_admin_access_key and rgw_admin_secret_key in the provider configuration")
+
+// RGWAdmin returns the radosgw client used for RGW operations the dashboard
+// API does not expose.
+func (c *CephAPIClient) RGWAdmin() (*RGWAdminOpsClient, error) {
+	if c.rgwAdmin == nil {
+		return nil, ErrRGWAdminOpsDisabled
+	}
+	return c.rgwAdmin, nil
+}
+
+// concurrencyLimitedTransport caps the number of requests in flight through
+// the shared client so parallel resources don't overwhelm the mgr.
+type concurrencyLimitedTransport struct {
+	base http.RoundTripper
+	sem  chan struct{}
+}
+
+func newConcurrencyLimitedTransport(base http.RoundTripper, limit int) *concurrencyLimitedTransport {
+	return &concurrencyLimitedTransport{
+		base: base,
+		sem:  make(chan struct{}, limit),
+	}
+}
+
+func (t *concurrencyLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		if req.Body != nil {
+			req.Body.Close() //nolint:errcheck
+		}
+		return nil, req.Context().Err()
+	}
+	defer func() { <-t.sem }()
+
+	return t.base.RoundTrip(req)
+}
+
+// headerTransport adds fixed headers to every request, such as the
+// X-Request-ID that lets a Terraform run be found in proxy and mgr logs.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// standbyRedirectTransport follows the redirect a standby mgr's dashboard
+// sends to the active mgr. The redirect points at the active dashboard's
+// root and is a 303, which an http.Client would follow as a GET of the root
+// page, so the request is instead resent unchanged to the same path on the
+// active mgr. This keeps requests working when the mgr fails over during a
+// run.
+type standbyRedirectTransport struct {
+	base http.RoundTripper
+}
+
+func (t *standbyRedirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusSeeOther {
+		return resp, err
+	}
+
+	active, ok := standbyRedirectTarget(req.URL, resp.Header.Get("Location"))
+	if !ok || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck
+	resp.Body.Close()              //nolint:errcheck
+
+	redirected := req.Clone(req.Context())
+	redirected.URL = active
+	redirected.Host = ""
+	if req.GetBody != nil {
+		redirected.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tflog.Debug(req.Context(), "Following standby mgr redirect to the active mgr", map[string]any{
+		"standby": req.URL.Host,
+		"active":  active.Host,
+	})
+	return t.base.RoundTrip(redirected)
+}
+
+// standbyRedirectTarget returns the URL on the active mgr that corresponds to
+// requestURL, given the Location a standby answered with, e.g. a request for
+// "https://mgr-b:8443/api/pool" redirected to "https://mgr-a:8443/" becomes
+// "https://mgr-a:8443/api/pool". Redirects to the same host are not standby
+// redirects.
+func standbyRedirectTarget(requestURL *url.URL, location string) (*url.URL, bool) {
+	target, err := requestURL.Parse(location)
+	if err != nil || target.Host == requestURL.Host {
+		return nil, false
+	}
+
+	active := *requestURL
+	active.Scheme = target.Scheme
+	active.Host = target.Host
+	if i := strings.Index(requestURL.Path, "/api/"); i >= 0 {
+		active.Path = strings.TrimRight(target.Path, "/") + requestURL.Path[i:]
+		active.RawPath = ""
+	} else {
+		active.Path = target.Path
+		active.RawPath = target.RawPath
+	}
+	return &active, true
+}
+
+// retryTransport resends requests that fail with a retryable status code or
+// time out, so every resource gets the same behavior for transient mgr
+// errors. A 429 means
+// the request was not processed, so any request is resent. A 5xx may come
+// from a proxy after the mgr applied the request, and resending a PUT or
+// DELETE then could fail or undo a change made in between, so only GET and
+// HEAD are resent, and the same goes for a timeout.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+
+	// metrics, if set, counts the retries.
+	metrics *apiMetrics
+}
+
+type noRetryContextKey struct{}
+
+// withoutRetries marks requests made with ctx as not retryable, for callers
+// that treat a retryable status as an answer in itself.
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryContextKey{}, true)
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Context().Value(noRetryContextKey{}) != nil {
+		return t.base.RoundTrip(req)
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.maxRetries || !retryable(req, resp, err) {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		wait := t.backoff << attempt
+		fields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+				wait = time.Duration(retryAfter) * time.Second
+			}
+			resp.Body.Close() //nolint:errcheck
+			fields["status"] = resp.StatusCode
+		}
+		wait = min(wait, maxRetryBackoff)
+		fields["wait_ms"] = wait.Milliseconds()
+		t.metrics.retried(req)
+
+		tflog.Warn(req.Context(), "Retrying Ceph API request", fields)
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryable reports whether req is worth sending again after the response
+// or error it got. A GET or HEAD that hit request_timeout is resent as long
+// as the operation deadline has not passed.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	safe := req.Method == http.MethodGet || req.Method == http.MethodHead
+	if err != nil {
+		return safe && errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil
+	}
+	if !(&CephAPIError{StatusCode: resp.StatusCode}).Retryable() {
+		return false
+	}
+	return safe || resp.StatusCode == http.StatusTooManyRequests
 }
 
 func logAPIRequest(ctx context.Context, req *http.Request) func(*http.Response, error) {
@@ -38,6 +335,11 @@ func logAPIRequest(ctx context.Context, req *http.Request) func(*http.Response,
 
 		if resp != nil {
 			fields["status"] = resp.StatusCode
+
+			// Headers added by the transport are only on the request it sent.
+			if resp.Request != nil && resp.Request.Header.Get(requestIDHeader) != "" {
+				fields["request_id"] = resp.Request.Header.Get(requestIDHeader)
+			}
 		}
 
 		if err != nil {
@@ -50,8 +352,153 @@ func logAPIRequest(ctx context.Context, req *http.Request) func(*http.Response,
 	}
 }
 
+type noResponseTraceContextKey struct{}
+
+// withoutResponseTrace stops do from tracing response bodies read with ctx,
+// for callers that mask the secrets in a response before tracing it
+// themselves, or that never trace it.
+func withoutResponseTrace(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noResponseTraceContextKey{}, true)
+}
+
+type rgwDaemonContextKey struct{}
+
+// withRGWDaemon makes dashboard RGW requests sent with ctx go to the given
+// radosgw daemon instead of the provider rgw_daemon, so that a resource can
+// target a zone of a multisite cluster. An empty daemon keeps the default.
+func withRGWDaemon(ctx context.Context, daemon string) context.Context {
+	if daemon == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, rgwDaemonContextKey{}, daemon)
+}
+
+// setRGWDaemon adds the daemon_name query parameter to dashboard RGW
+// requests, which the dashboard otherwise sends to a radosgw of its choosing.
+func (c *CephAPIClient) setRGWDaemon(ctx context.Context, req *http.Request) {
+	daemon, _ := ctx.Value(rgwDaemonContextKey{}).(string)
+	if daemon == "" {
+		daemon = c.rgwDaemon
+	}
+	if daemon == "" {
+		return
+	}
+
+	prefix := ""
+	if c.endpoint != nil {
+		prefix = strings.TrimSuffix(c.endpoint.Path, "/")
+	}
+	if !strings.HasPrefix(req.URL.Path, prefix+"/api/rgw/") {
+		return
+	}
+
+	query := req.URL.Query()
+	if query.Has("daemon_name") {
+		return
+	}
+	query.Set("daemon_name", daemon)
+	req.URL.RawQuery = query.Encode()
+}
+
+// send sends a dashboard API request for the given API version, e.g. "1.0".
+// body is encoded as JSON unless it is nil, and traced, so callers sending
+// secrets must mask them in ctx first. The response status must be one of
+// expectedStatus, or 200 if none are given; otherwise the response is closed
+// and returned as a CephAPIError.
+func (c *CephAPIClient) send(ctx context.Context, method, url, version string, body any, expectedStatus []int) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		jsonPayload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode request payload: %w", err)
+		}
+
+		tflog.Trace(ctx, "Ceph API request body", map[string]any{
+			"request_body": string(jsonPayload),
+		})
+		reqBody = bytes.NewReader(jsonPayload)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+	c.setRGWDaemon(ctx, httpReq)
+
+	httpReq.Header.Set("Accept", cephAPIMediaType(version))
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+
+	logRequest := logAPIRequest(ctx, httpReq)
+	httpResp, err := c.client.Do(httpReq)
+	logRequest(httpResp, err)
+	if err != nil {
+		return nil, fmt.Errorf("unable to make request to Ceph API: %w", err)
+	}
+
+	if len(expectedStatus) == 0 {
+		expectedStatus = []int{http.StatusOK}
+	}
+	if !slices.Contains(expectedStatus, httpResp.StatusCode) {
+		defer httpResp.Body.Close() //nolint:errcheck
+		respBody, _ := io.ReadAll(httpResp.Body)
+		return nil, newCephAPIError(httpResp.StatusCode, respBody)
+	}
+
+	return httpResp, nil
+}
+
+// do sends a request as described by send and decodes the JSON response as
+// T. The response body is traced unless ctx comes from withoutResponseTrace;
+// a json.RawMessage T returns it undecoded.
+func do[T any](ctx context.Context, c *CephAPIClient, method, url, version string, body any, expectedStatus ...int) (T, error) {
+	var result T
+
+	httpResp, err := c.send(ctx, method, url, version, body, expectedStatus)
+	if err != nil {
+		return result, err
+	}
+	defer httpResp.Body.Close() //nolint:errcheck
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return result, fmt.Errorf("unable to read response body: %w", err)
+	}
+
+	if ctx.Value(noResponseTraceContextKey{}) == nil {
+		tflog.Trace(ctx, "Ceph API response body", map[string]any{
+			"response_body": string(respBody),
+			"status_code":   httpResp.StatusCode,
+		})
+	}
+
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		var zero T
+		return zero, fmt.Errorf("unable to decode JSON response: %w", err)
+	}
+
+	return result, nil
+}
+
+// doRequest sends a request as described by send and discards the response.
+func (c *CephAPIClient) doRequest(ctx context.Context, method, url, version string, body any, expectedStatus ...int) error {
+	httpResp, err := c.send(ctx, method, url, version, body, expectedStatus)
+	if err != nil {
+		return err
+	}
+	httpResp.Body.Close() //nolint:errcheck
+
+	return nil
+}
+
 func (c *CephAPIClient) Configure(ctx context.Context, endpoints []*url.URL, username, password, token string) error {
-	endpoint, err := queryEndpoints(ctx, endpoints)
+	if c.client == nil {
+		c.client = &http.Client{
+			Timeout: defaultRequestTimeout,
+		}
+	}
+
+	endpoint, err := c.queryEndpoints(ctx, endpoints)
 	if err != nil {
 		return fmt.Errorf("unable to query endpoints: %w", err)
 	}
@@ -61,12 +508,6 @@ func (c *CephAPIClient) Configure(ctx context.Context, endpoints []*url.URL, use
 		"endpoint": endpoint.String(),
 	})
 
-	if c.client == nil {
-		c.client = &http.Client{
-			Timeout: 10 * time.Second,
-		}
-	}
-
 	if token != "" {
 		c.token = token
 
@@ -77,12 +518,21 @@ func (c *CephAPIClient) Configure(ctx context.Context, endpoints []*url.URL, use
 			return fmt.Errorf("provided token is invalid or expired")
 		}
 	} else if username != "" && password != "" {
+		if cachedToken, ok := c.tokenCache.get(ctx, endpoint.String(), username, password); ok {
+			c.token = cachedToken
+			if valid, err := c.AuthCheck(ctx); err == nil && valid {
+				tflog.Debug(ctx, "Reusing cached Ceph API token")
+				return nil
+			}
+		}
+
 		authToken, err := c.Auth(ctx, username, password)
 		if err != nil {
 			return fmt.Errorf("failed to authenticate with credentials: %w", err)
 		}
 
 		c.token = authToken
+		c.tokenCache.put(ctx, endpoint.String(), username, password, authToken)
 	} else {
 		return fmt.Errorf("either token or username/password must be provided")
 	}
@@ -90,11 +540,11 @@ func (c *CephAPIClient) Configure(ctx context.Context, endpoints []*url.URL, use
 	return nil
 }
 
-func queryEndpoints(ctx context.Context, endpoints []*url.URL) (*url.URL, error) {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
+func (c *CephAPIClient) queryEndpoints(ctx context.Context, endpoints []*url.URL) (*url.URL, error) {
+	// Standby mgrs either redirect to the active mgr or answer with an error
+	// status (standby_behaviour), which should move on to the next endpoint
+	// rather than be retried.
+	ctx = withoutRetries(ctx)
 	for _, endpoint := range endpoints {
 		httpReq, err := http.NewRequestWithContext(ctx, "GET", endpoint.String(), nil)
 		if err != nil {
@@ -102,16 +552,34 @@ func queryEndpoints(ctx context.Context, endpoints []*url.URL) (*url.URL, error)
 		}
 
 		done := logAPIRequest(ctx, httpReq)
-		httpResp, err := client.Do(httpReq)
+		httpResp, err := c.client.Do(httpReq)
 		done(httpResp, err)
 		if err != nil {
 			continue
 		}
+		httpResp.Body.Close() //nolint:errcheck
 
-		if httpResp.StatusCode == http.StatusServiceUnavailable {
+		if httpResp.StatusCode >= http.StatusInternalServerError {
 			continue
 		}
 
+		// The response came from the active mgr if the request was
+		// redirected, so use it directly rather than going through the
+		// standby for every request.
+		if httpResp.Request != nil && httpResp.Request.URL.Host != endpoint.Host {
+			final := httpResp.Request.URL
+			active := *endpoint
+			active.Scheme = final.Scheme
+			active.Host = final.Host
+			active.Path = strings.TrimRight(final.Path, "/")
+			active.RawPath = strings.TrimRight(final.RawPath, "/")
+			tflog.Info(ctx, "Standby mgr redirected to the active mgr", map[string]any{
+				"standby": endpoint.String(),
+				"active":  active.String(),
+			})
+			return &active, nil
+		}
+
 		return endpoint, nil
 	}
 
@@ -231,51 +699,58 @@ func (c *CephAPIClient) Auth(ctx context.Context, username string, password stri
 	return authResp.Token, nil
 }
 
-// https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-cluster-user-export
-
-type CephAPIClusterUserExportRequest struct {
-	Entities []string `json:"entities"`
-}
-
-func (c *CephAPIClient) ClusterExportUser(ctx context.Context, entity string) (string, error) {
-	requestBody := CephAPIClusterUserExportRequest{
-		Entities: []string{entity},
-	}
-
-	jsonPayload, err := json.Marshal(requestBody)
-	if err != nil {
-		return "", fmt.Errorf("unable to encode request payload: %w", err)
-	}
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-auth-logout>
 
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
+func (c *CephAPIClient) Logout(ctx context.Context, token string) error {
+	ctx = tflog.MaskLogStrings(ctx, token)
 
-	url := c.endpoint.JoinPath("/api/cluster/user/export").String()
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
+	url := c.endpoint.JoinPath("/api/auth/logout").String()
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, nil)
 	if err != nil {
-		return "", fmt.Errorf("unable to create request: %w", err)
+		return fmt.Errorf("unable to create logout request: %w", err)
 	}
 
 	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	httpReq.Header.Set("Authorization", "Bearer "+token)
 
-	logRequest := logAPIRequest(ctx, httpReq)
+	done := logAPIRequest(ctx, httpReq)
 	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
+	done(httpResp, err)
 	if err != nil {
-		return "", fmt.Errorf("unable to make request to Ceph API: %w", err)
+		return fmt.Errorf("unable to make logout request: %w", err)
 	}
 	defer httpResp.Body.Close() //nolint:errcheck
 
-	if httpResp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("ceph API returned status %d", httpResp.StatusCode)
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(httpResp.Body)
+		return newCephAPIError(httpResp.StatusCode, body)
 	}
 
-	body, err := io.ReadAll(httpResp.Body)
+	return nil
+}
+
+// https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-cluster-user-export
+
+type CephAPIClusterUserExportRequest struct {
+	Entities []string `json:"entities"`
+}
+
+func (c *CephAPIClient) ClusterExportUser(ctx context.Context, entity string) (string, error) {
+	requestBody := CephAPIClusterUserExportRequest{
+		Entities: []string{entity},
+	}
+
+	url := c.endpoint.JoinPath("/api/cluster/user/export").String()
+	body, err := do[json.RawMessage](withoutResponseTrace(ctx), c, "POST", url, "1.0", requestBody)
 	if err != nil {
-		return "", fmt.Errorf("unable to read response body: %w", err)
+		// The dashboard reports a missing entity as a 400 with the mon's
+		// "failed to find client.foo in keyring" message.
+		var apiErr *CephAPIError
+		if errors.As(err, &apiErr) && strings.Contains(apiErr.Detail, "failed to find") {
+			apiErr.StatusCode = http.StatusNotFound
+		}
+		return "", err
 	}
 
 	var keyringRaw string
@@ -295,7 +770,6 @@ func (c *CephAPIClient) ClusterExportUser(ctx context.Context, entity string) (s
 
 	tflog.Trace(ctx, "Ceph API response body", map[string]any{
 		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
 	})
 
 	return keyringRaw, nil
@@ -349,39 +823,8 @@ func (c *CephAPIClient) ClusterCreateUser(ctx context.Context, entity string, ca
 		requestBody.Capabilities = capabilitySlice
 	}
 
-	jsonPayload, err := json.Marshal(requestBody)
-	if err != nil {
-		return fmt.Errorf("unable to encode request payload: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
-
 	url := c.endpoint.JoinPath("/api/cluster/user").String()
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
+	return c.doRequest(ctx, "POST", url, "1.0", requestBody, http.StatusCreated, http.StatusAccepted)
 }
 
 func (c *CephAPIClient) ClusterImportUser(ctx context.Context, importData string) error {
@@ -391,40 +834,9 @@ func (c *CephAPIClient) ClusterImportUser(ctx context.Context, importData string
 		requestBody.ImportData = &importData
 	}
 
-	jsonPayload, err := json.Marshal(requestBody)
-	if err != nil {
-		return fmt.Errorf("unable to encode request payload: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
-
 	url := c.endpoint.JoinPath("/api/cluster/user").String()
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
-}
+	return c.doRequest(ctx, "POST", url, "1.0", requestBody, http.StatusCreated, http.StatusAccepted)
+}
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#put--api-cluster-user>
 
@@ -441,124 +853,125 @@ func (c *CephAPIClient) ClusterUpdateUser(ctx context.Context, entity string, ca
 		Capabilities: capabilitySlice,
 	}
 
-	jsonPayload, err := json.Marshal(requestBody)
-	if err != nil {
-		return fmt.Errorf("unable to encode request payload: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
-
 	url := c.endpoint.JoinPath("/api/cluster/user").String()
-	httpReq, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
+	return c.doRequest(ctx, "PUT", url, "1.0", requestBody, http.StatusOK, http.StatusAccepted)
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-cluster-user-user_entities>
 
 func (c *CephAPIClient) ClusterDeleteUser(ctx context.Context, userEntities string) error {
-	url := c.endpoint.JoinPath("/api/cluster/user", userEntities).String()
-	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
+	ctx, reqURL := c.apiURL(ctx, "/api/cluster/user/{user_entities}", userEntities)
+	url := reqURL.String()
+	return c.doRequest(ctx, "DELETE", url, "1.0", nil, http.StatusAccepted, http.StatusNoContent)
+}
+
+// rgwUserID returns the RGW user ID for uid within tenant, in the
+// "tenant$uid" form RGW uses for users outside the default tenant.
+func rgwUserID(tenant, uid string) string {
+	if tenant == "" {
+		return uid
 	}
+	return tenant + "$" + uid
+}
 
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+// splitRGWUserID is the inverse of rgwUserID.
+func splitRGWUserID(id string) (tenant, uid string) {
+	if tenant, uid, ok := strings.Cut(id, "$"); ok {
+		return tenant, uid
+	}
+	return "", id
+}
 
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
+// rgwBucketName returns the name the admin APIs use for bucket within tenant.
+func rgwBucketName(tenant, bucket string) string {
+	if tenant == "" {
+		return bucket
 	}
-	defer httpResp.Body.Close() //nolint:errcheck
+	return tenant + "/" + bucket
+}
 
-	if httpResp.StatusCode != http.StatusAccepted && httpResp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
+// rgwS3BucketName returns the name S3 requests use for a tenanted bucket.
+func rgwS3BucketName(tenant, bucket string) string {
+	if tenant == "" {
+		return bucket
 	}
+	return tenant + ":" + bucket
+}
 
-	return nil
+// joinPathSegment appends segment to u as a single path segment, escaping any
+// "/" so tenanted names such as "tenant/bucket" are not split.
+func joinPathSegment(u *url.URL, segment string) *url.URL {
+	joined := *u
+	joined.Path = strings.TrimSuffix(u.Path, "/") + "/" + segment
+	joined.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + "/" + url.PathEscape(segment)
+	return &joined
+}
+
+// apiURL returns the URL of an API route such as "/api/pool/{pool_name}",
+// with each placeholder replaced by the next of params as a single path
+// segment, and ctx marked with the route so requests are counted by route
+// rather than by name in the metrics.
+func (c *CephAPIClient) apiURL(ctx context.Context, route string, params ...string) (context.Context, *url.URL) {
+	u := c.endpoint
+	for _, segment := range strings.Split(strings.Trim(route, "/"), "/") {
+		if strings.HasPrefix(segment, "{") && len(params) > 0 {
+			u = joinPathSegment(u, params[0])
+			params = params[1:]
+		} else {
+			u = u.JoinPath(segment)
+		}
+	}
+	return withMetricsRoute(ctx, route), u
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-rgw-bucket-bucket>
 
 type CephAPIRGWBucket struct {
-	Bucket        string `json:"bucket"`
-	Zonegroup     string `json:"zonegroup"`
-	PlacementRule string `json:"placement_rule"`
-	ID            string `json:"id"`
-	Owner         string `json:"owner"`
-	CreationTime  string `json:"creation_time"`
-	ACL           string `json:"acl"`
-	Bid           string `json:"bid"`
+	Bucket        string                           `json:"bucket"`
+	Zonegroup     string                           `json:"zonegroup"`
+	PlacementRule string                           `json:"placement_rule"`
+	ID            string                           `json:"id"`
+	Owner         string                           `json:"owner"`
+	CreationTime  string                           `json:"creation_time"`
+	ACL           string                           `json:"acl"`
+	Bid           string                           `json:"bid"`
+	Tagset        map[string]string                `json:"tagset"`
+	NumShards     int                              `json:"num_shards"`
+	Usage         map[string]CephAPIRGWBucketUsage `json:"usage"`
+}
+
+// CephAPIRGWBucketUsage is one category of the bucket stats usage, such as
+// "rgw.main" for regular objects or "rgw.multimeta" for multipart uploads.
+type CephAPIRGWBucketUsage struct {
+	Size         int64 `json:"size"`
+	SizeActual   int64 `json:"size_actual"`
+	SizeUtilized int64 `json:"size_utilized"`
+	NumObjects   int64 `json:"num_objects"`
 }
 
 func (c *CephAPIClient) RGWGetBucket(ctx context.Context, bucketName string) (CephAPIRGWBucket, error) {
-	url := c.endpoint.JoinPath("/api/rgw/bucket", bucketName).String()
-
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return CephAPIRGWBucket{}, fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return CephAPIRGWBucket{}, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK {
-		return CephAPIRGWBucket{}, fmt.Errorf("ceph API returned status %d", httpResp.StatusCode)
+	if c.rgwAdmin != nil {
+		return c.rgwAdmin.GetBucket(ctx, bucketName)
 	}
+	return c.rgwGetDashboardBucket(ctx, bucketName)
+}
 
-	body, err := io.ReadAll(httpResp.Body)
+// RGWGetBucketACL returns the bucket's S3 AccessControlPolicy XML. Only the
+// dashboard includes it in the bucket info, so it never uses admin ops.
+func (c *CephAPIClient) RGWGetBucketACL(ctx context.Context, bucketName string) (string, error) {
+	bucket, err := c.rgwGetDashboardBucket(ctx, bucketName)
 	if err != nil {
-		return CephAPIRGWBucket{}, fmt.Errorf("unable to read response body: %w", err)
+		return "", err
 	}
+	return bucket.ACL, nil
+}
 
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
-
-	var bucket CephAPIRGWBucket
-	err = json.Unmarshal(body, &bucket)
-	if err != nil {
-		return CephAPIRGWBucket{}, fmt.Errorf("unable to decode JSON response: %w", err)
-	}
+func (c *CephAPIClient) rgwGetDashboardBucket(ctx context.Context, bucketName string) (CephAPIRGWBucket, error) {
+	ctx, reqURL := c.apiURL(ctx, "/api/rgw/bucket/{bucket}", bucketName)
+	url := reqURL.String()
 
-	return bucket, nil
+	bucket, err := do[CephAPIRGWBucket](ctx, c, "GET", url, "1.0", nil)
+	return bucket, rgwDashboardError(err)
 }
 
 type CephAPIRGWBucketCreateRequest struct {
@@ -570,82 +983,156 @@ type CephAPIRGWBucketCreateRequest struct {
 func (c *CephAPIClient) RGWCreateBucket(ctx context.Context, req CephAPIRGWBucketCreateRequest) (CephAPIRGWBucket, error) {
 	url := c.endpoint.JoinPath("/api/rgw/bucket").String()
 
-	reqBody, err := json.Marshal(req)
-	if err != nil {
-		return CephAPIRGWBucket{}, fmt.Errorf("unable to marshal request: %w", err)
-	}
+	return do[CephAPIRGWBucket](ctx, c, "POST", url, "1.0", req, http.StatusCreated, http.StatusOK)
+}
 
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(reqBody),
-	})
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#put--api-rgw-bucket-bucket>
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqBody))
-	if err != nil {
-		return CephAPIRGWBucket{}, fmt.Errorf("unable to create request: %w", err)
+type CephAPIRGWBucketUpdateRequest struct {
+	BucketID string `json:"bucket_id"`
+	UID      string `json:"uid"`
+}
+
+// RGWUpdateBucket links the bucket to req.UID when it differs from the
+// current owner.
+func (c *CephAPIClient) RGWUpdateBucket(ctx context.Context, bucketName string, req CephAPIRGWBucketUpdateRequest) error {
+	if c.rgwAdmin != nil {
+		return c.rgwAdmin.LinkBucket(ctx, bucketName, req.BucketID, req.UID)
 	}
 
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	ctx, reqURL := c.apiURL(ctx, "/api/rgw/bucket/{bucket}", bucketName)
+	url := reqURL.String()
+	return c.doRequest(ctx, "PUT", url, "1.0", req, http.StatusOK, http.StatusAccepted)
+}
 
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return CephAPIRGWBucket{}, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
+// CephAPIRGWBucketEncryption is a bucket's default server-side encryption.
+// Both fields are empty when the bucket has none.
+type CephAPIRGWBucketEncryption struct {
+	SSEAlgorithm   string `json:"SSEAlgorithm"`
+	KMSMasterKeyID string `json:"KMSMasterKeyID"`
+}
 
-	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return CephAPIRGWBucket{}, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-rgw-bucket-getEncryption>
+
+func (c *CephAPIClient) RGWGetBucketEncryption(ctx context.Context, bucketName string) (CephAPIRGWBucketEncryption, error) {
+	reqURL := c.endpoint.JoinPath("/api/rgw/bucket/getEncryption")
+	reqURL.RawQuery = url.Values{"bucket_name": {bucketName}}.Encode()
+
+	return do[CephAPIRGWBucketEncryption](ctx, c, "GET", reqURL.String(), "1.0", nil)
+}
+
+type rgwBucketEncryptionRequest struct {
+	BucketID        string `json:"bucket_id"`
+	UID             string `json:"uid"`
+	EncryptionState string `json:"encryption_state"`
+	EncryptionType  string `json:"encryption_type"`
+	KeyID           string `json:"key_id,omitempty"`
+}
+
+// RGWSetBucketEncryption sets the bucket's default encryption through the
+// bucket update endpoint, which also needs the bucket ID and current owner.
+// There is no admin ops equivalent, so it always goes through the dashboard.
+func (c *CephAPIClient) RGWSetBucketEncryption(ctx context.Context, bucketName, bucketID, uid, algorithm, keyID string) error {
+	req := rgwBucketEncryptionRequest{
+		BucketID:        bucketID,
+		UID:             uid,
+		EncryptionState: "true",
+		EncryptionType:  algorithm,
+		KeyID:           keyID,
 	}
 
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return CephAPIRGWBucket{}, fmt.Errorf("unable to read response body: %w", err)
+	ctx, reqURL := c.apiURL(ctx, "/api/rgw/bucket/{bucket}", bucketName)
+	url := reqURL.String()
+	return c.doRequest(ctx, "PUT", url, "1.0", req, http.StatusOK, http.StatusAccepted)
+}
+
+type rgwBucketACLRequest struct {
+	BucketID  string `json:"bucket_id"`
+	UID       string `json:"uid"`
+	CannedACL string `json:"canned_acl"`
+}
+
+// RGWSetBucketACL applies a canned ACL through the bucket update endpoint,
+// which also needs the bucket ID and current owner.
+func (c *CephAPIClient) RGWSetBucketACL(ctx context.Context, bucketName, bucketID, uid, cannedACL string) error {
+	req := rgwBucketACLRequest{
+		BucketID:  bucketID,
+		UID:       uid,
+		CannedACL: cannedACL,
 	}
 
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
+	ctx, reqURL := c.apiURL(ctx, "/api/rgw/bucket/{bucket}", bucketName)
+	url := reqURL.String()
+	return c.doRequest(ctx, "PUT", url, "1.0", req, http.StatusOK, http.StatusAccepted)
+}
+
+type rgwBucketTagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	TagSet  struct {
+		Tags []rgwBucketTag `xml:"Tag"`
+	} `xml:"TagSet"`
+}
+
+type rgwBucketTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type rgwBucketTagsRequest struct {
+	BucketID string `json:"bucket_id"`
+	UID      string `json:"uid"`
+	Tags     string `json:"tags"`
+}
+
+// RGWSetBucketTags replaces the bucket's tags through the bucket update
+// endpoint, which takes them as an S3 Tagging document. An empty map removes
+// all tags.
+func (c *CephAPIClient) RGWSetBucketTags(ctx context.Context, bucketName, bucketID, uid string, tags map[string]string) error {
+	var tagging rgwBucketTagging
+	for key, value := range tags {
+		tagging.TagSet.Tags = append(tagging.TagSet.Tags, rgwBucketTag{Key: key, Value: value})
+	}
+	sort.Slice(tagging.TagSet.Tags, func(i, j int) bool {
+		return tagging.TagSet.Tags[i].Key < tagging.TagSet.Tags[j].Key
 	})
 
-	var bucket CephAPIRGWBucket
-	err = json.Unmarshal(body, &bucket)
+	taggingXML, err := xml.Marshal(tagging)
 	if err != nil {
-		return CephAPIRGWBucket{}, fmt.Errorf("unable to decode JSON response: %w", err)
+		return fmt.Errorf("unable to marshal tags: %w", err)
+	}
+
+	req := rgwBucketTagsRequest{
+		BucketID: bucketID,
+		UID:      uid,
+		Tags:     string(taggingXML),
 	}
 
-	return bucket, nil
+	ctx, reqURL := c.apiURL(ctx, "/api/rgw/bucket/{bucket}", bucketName)
+	url := reqURL.String()
+	return c.doRequest(ctx, "PUT", url, "1.0", req, http.StatusOK, http.StatusAccepted)
 }
 
-func (c *CephAPIClient) RGWDeleteBucket(ctx context.Context, bucketName string) error {
-	url := c.endpoint.JoinPath("/api/rgw/bucket", bucketName).String()
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-rgw-bucket-deleteEncryption>
 
-	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
+func (c *CephAPIClient) RGWDeleteBucketEncryption(ctx context.Context, bucketName string) error {
+	reqURL := c.endpoint.JoinPath("/api/rgw/bucket/deleteEncryption")
+	reqURL.RawQuery = url.Values{"bucket_name": {bucketName}}.Encode()
 
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	return c.doRequest(ctx, "DELETE", reqURL.String(), "1.0", nil, http.StatusOK, http.StatusNoContent)
+}
 
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-rgw-bucket-bucket>
 
-	if httpResp.StatusCode != http.StatusNoContent && httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
+func (c *CephAPIClient) RGWDeleteBucket(ctx context.Context, bucketName string, purgeObjects bool) error {
+	if c.rgwAdmin != nil {
+		return c.rgwAdmin.DeleteBucket(ctx, bucketName, purgeObjects)
 	}
 
-	return nil
+	ctx, reqURL := c.apiURL(ctx, "/api/rgw/bucket/{bucket}", bucketName)
+	reqURL.RawQuery = url.Values{"purge_objects": {strconv.FormatBool(purgeObjects)}}.Encode()
+	url := reqURL.String()
+
+	return c.doRequest(ctx, "DELETE", url, "1.0", nil, http.StatusNoContent, http.StatusOK)
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-rgw-user-ratelimit>
@@ -671,231 +1158,135 @@ type CephAPIRGWSubuser struct {
 }
 
 type CephAPIRGWUser struct {
-	Tenant      string               `json:"tenant"`
-	UserID      string               `json:"user_id"`
-	DisplayName string               `json:"display_name"`
-	Email       string               `json:"email"`
-	Suspended   int                  `json:"suspended"`
-	MaxBuckets  int                  `json:"max_buckets"`
-	Subusers    []CephAPIRGWSubuser  `json:"subusers"`
-	Keys        []CephAPIRGWS3Key    `json:"keys"`
-	SwiftKeys   []CephAPIRGWSwiftKey `json:"swift_keys"`
-	System      bool                 `json:"system"`
-	Admin       bool                 `json:"admin"`
+	Tenant              string               `json:"tenant"`
+	UserID              string               `json:"user_id"`
+	DisplayName         string               `json:"display_name"`
+	Email               string               `json:"email"`
+	Suspended           int                  `json:"suspended"`
+	MaxBuckets          int                  `json:"max_buckets"`
+	Subusers            []CephAPIRGWSubuser  `json:"subusers"`
+	Keys                []CephAPIRGWS3Key    `json:"keys"`
+	SwiftKeys           []CephAPIRGWSwiftKey `json:"swift_keys"`
+	System              bool                 `json:"system"`
+	Admin               bool                 `json:"admin"`
+	OpMask              string               `json:"op_mask"`
+	DefaultPlacement    string               `json:"default_placement"`
+	DefaultStorageClass string               `json:"default_storage_class"`
+	AccountID           string               `json:"account_id"`
+	Type                string               `json:"type"`
 }
 
 func (c *CephAPIClient) RGWGetUser(ctx context.Context, uid string) (CephAPIRGWUser, error) {
-	url := c.endpoint.JoinPath("/api/rgw/user", uid).String()
-
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK {
-		return CephAPIRGWUser{}, fmt.Errorf("ceph API returned status %d", httpResp.StatusCode)
+	if c.rgwAdmin != nil {
+		return c.rgwAdmin.GetUser(ctx, uid)
 	}
 
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to read response body: %w", err)
-	}
+	ctx, reqURL := c.apiURL(ctx, "/api/rgw/user/{uid}", uid)
+	url := reqURL.String()
 
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
+	user, err := do[CephAPIRGWUser](ctx, c, "GET", url, "1.0", nil)
+	return user, rgwDashboardError(err)
+}
 
-	var user CephAPIRGWUser
-	err = json.Unmarshal(body, &user)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to decode JSON response: %w", err)
+// rgwDashboardError maps RGW "not found" errors to a 404. The dashboard
+// reports missing users and buckets as a 500 with the RGW error code.
+func rgwDashboardError(err error) error {
+	var apiErr *CephAPIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusBadRequest && (strings.Contains(apiErr.Detail, "NoSuchUser") || strings.Contains(apiErr.Detail, "NoSuchBucket")) {
+		apiErr.StatusCode = http.StatusNotFound
 	}
-
-	return user, nil
+	return err
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-rgw-user>
 
 type CephAPIRGWUserCreateRequest struct {
-	UID         string  `json:"uid"`
-	DisplayName string  `json:"display_name"`
-	Email       *string `json:"email,omitempty"`
-	MaxBuckets  *int    `json:"max_buckets,omitempty"`
-	Suspended   *int    `json:"suspended,omitempty"`
-	System      *bool   `json:"system,omitempty"`
-	GenerateKey bool    `json:"generate_key"`
+	UID                 string  `json:"uid"`
+	DisplayName         string  `json:"display_name"`
+	Email               *string `json:"email,omitempty"`
+	MaxBuckets          *int    `json:"max_buckets,omitempty"`
+	Suspended           *int    `json:"suspended,omitempty"`
+	System              *bool   `json:"system,omitempty"`
+	GenerateKey         bool    `json:"generate_key"`
+	OpMask              *string `json:"op_mask,omitempty"`
+	DefaultPlacement    *string `json:"default_placement,omitempty"`
+	DefaultStorageClass *string `json:"default_storage_class,omitempty"`
+	AccountID           *string `json:"account_id,omitempty"`
+	AccountRootUser     *bool   `json:"account_root_user,omitempty"`
 }
 
 func (c *CephAPIClient) RGWCreateUser(ctx context.Context, req CephAPIRGWUserCreateRequest) (CephAPIRGWUser, error) {
-	jsonPayload, err := json.Marshal(req)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to encode request payload: %w", err)
+	if c.rgwAdmin != nil {
+		return c.rgwAdmin.CreateUser(ctx, req)
 	}
 
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
-
 	url := c.endpoint.JoinPath("/api/rgw/user").String()
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(httpResp.Body)
-		return CephAPIRGWUser{}, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to read response body: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
 
-	var user CephAPIRGWUser
-	err = json.Unmarshal(body, &user)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to decode JSON response: %w", err)
-	}
-
-	return user, nil
+	return do[CephAPIRGWUser](ctx, c, "POST", url, "1.0", req, http.StatusOK, http.StatusCreated)
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#put--api-rgw-user-uid>
 
 type CephAPIRGWUserUpdateRequest struct {
-	DisplayName *string `json:"display_name,omitempty"`
-	Email       *string `json:"email,omitempty"`
-	MaxBuckets  *int    `json:"max_buckets,omitempty"`
-	Suspended   *int    `json:"suspended,omitempty"`
-	System      *bool   `json:"system,omitempty"`
+	DisplayName         *string `json:"display_name,omitempty"`
+	Email               *string `json:"email,omitempty"`
+	MaxBuckets          *int    `json:"max_buckets,omitempty"`
+	Suspended           *int    `json:"suspended,omitempty"`
+	System              *bool   `json:"system,omitempty"`
+	OpMask              *string `json:"op_mask,omitempty"`
+	DefaultPlacement    *string `json:"default_placement,omitempty"`
+	DefaultStorageClass *string `json:"default_storage_class,omitempty"`
+	AccountID           *string `json:"account_id,omitempty"`
+	AccountRootUser     *bool   `json:"account_root_user,omitempty"`
 }
 
 func (c *CephAPIClient) RGWUpdateUser(ctx context.Context, uid string, req CephAPIRGWUserUpdateRequest) (CephAPIRGWUser, error) {
-	jsonPayload, err := json.Marshal(req)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to encode request payload: %w", err)
+	if c.rgwAdmin != nil {
+		return c.rgwAdmin.UpdateUser(ctx, uid, req)
 	}
 
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
-
-	url := c.endpoint.JoinPath("/api/rgw/user", uid).String()
-	httpReq, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to create request: %w", err)
-	}
+	ctx, reqURL := c.apiURL(ctx, "/api/rgw/user/{uid}", uid)
+	url := reqURL.String()
 
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	return do[CephAPIRGWUser](ctx, c, "PUT", url, "1.0", req, http.StatusOK, http.StatusAccepted)
+}
 
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-rgw-user-uid>
 
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return CephAPIRGWUser{}, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
+// RGWDeleteUser removes the user. The dashboard cannot purge a user's data, so
+// purgeData falls back to radosgw-admin when going through the dashboard.
+func (c *CephAPIClient) RGWDeleteUser(ctx context.Context, uid string, purgeData bool) error {
+	if c.rgwAdmin != nil {
+		return c.rgwAdmin.DeleteUser(ctx, uid, purgeData)
 	}
 
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to read response body: %w", err)
+	if purgeData {
+		cli, err := c.CLI()
+		if err != nil {
+			return fmt.Errorf("unable to purge user data: %w", err)
+		}
+		return cli.RgwUserRemove(ctx, uid, true)
 	}
 
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
+	ctx, reqURL := c.apiURL(ctx, "/api/rgw/user/{uid}", uid)
+	url := reqURL.String()
+	return c.doRequest(ctx, "DELETE", url, "1.0", nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
 
-	var user CephAPIRGWUser
-	err = json.Unmarshal(body, &user)
-	if err != nil {
-		return CephAPIRGWUser{}, fmt.Errorf("unable to decode JSON response: %w", err)
-	}
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-rgw-user-uid-key>
 
-	return user, nil
+type rgwS3KeyCreateRequest struct {
+	UID         string  `json:"uid"`
+	KeyType     string  `json:"key_type"`
+	SubUser     *string `json:"subuser,omitempty"`
+	AccessKey   *string `json:"access_key,omitempty"`
+	SecretKey   *string `json:"secret_key,omitempty"`
+	GenerateKey *bool   `json:"generate_key,omitempty"`
 }
 
-// <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-rgw-user-uid>
-
-func (c *CephAPIClient) RGWDeleteUser(ctx context.Context, uid string) error {
-	url := c.endpoint.JoinPath("/api/rgw/user", uid).String()
-	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusAccepted && httpResp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
-}
-
-// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-rgw-user-uid-key>
-
-type rgwS3KeyCreateRequest struct {
-	UID         string  `json:"uid"`
-	KeyType     string  `json:"key_type"`
-	SubUser     *string `json:"subuser,omitempty"`
-	AccessKey   *string `json:"access_key,omitempty"`
-	SecretKey   *string `json:"secret_key,omitempty"`
-	GenerateKey *bool   `json:"generate_key,omitempty"`
-}
-
-func (c *CephAPIClient) RGWCreateS3Key(ctx context.Context, uid string, subuser *string, accessKey *string, secretKey *string, generateKey *bool) ([]CephAPIRGWS3Key, error) {
-	if accessKey != nil {
-		ctx = tflog.MaskLogStrings(ctx, *accessKey)
+func (c *CephAPIClient) RGWCreateS3Key(ctx context.Context, uid string, subuser *string, accessKey *string, secretKey *string, generateKey *bool) ([]CephAPIRGWS3Key, error) {
+	if accessKey != nil {
+		ctx = tflog.MaskLogStrings(ctx, *accessKey)
 	}
 	if secretKey != nil {
 		ctx = tflog.MaskLogStrings(ctx, *secretKey)
@@ -910,41 +1301,11 @@ func (c *CephAPIClient) RGWCreateS3Key(ctx context.Context, uid string, subuser
 		GenerateKey: generateKey,
 	}
 
-	jsonPayload, err := json.Marshal(payload)
+	ctx, reqURL := c.apiURL(ctx, "/api/rgw/user/{uid}/key", uid)
+	url := reqURL.String()
+	body, err := do[json.RawMessage](withoutResponseTrace(ctx), c, "POST", url, "1.0", payload, http.StatusOK, http.StatusCreated)
 	if err != nil {
-		return nil, fmt.Errorf("unable to encode request payload: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
-
-	url := c.endpoint.JoinPath("/api/rgw/user", uid, "key").String()
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return nil, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
+		return nil, err
 	}
 
 	var keys []CephAPIRGWS3Key
@@ -959,7 +1320,6 @@ func (c *CephAPIClient) RGWCreateS3Key(ctx context.Context, uid string, subuser
 
 	tflog.Trace(ctx, "Ceph API response body", map[string]any{
 		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
 	})
 
 	return keys, nil
@@ -970,7 +1330,7 @@ func (c *CephAPIClient) RGWCreateS3Key(ctx context.Context, uid string, subuser
 func (c *CephAPIClient) RGWDeleteS3Key(ctx context.Context, uid string, accessKey string, subuser *string) error {
 	ctx = tflog.MaskLogStrings(ctx, accessKey)
 
-	endpoint := c.endpoint.JoinPath("/api/rgw/user", uid, "key")
+	ctx, endpoint := c.apiURL(ctx, "/api/rgw/user/{uid}/key", uid)
 	query := url.Values{}
 	query.Add("key_type", "s3")
 	query.Add("access_key", accessKey)
@@ -979,29 +1339,7 @@ func (c *CephAPIClient) RGWDeleteS3Key(ctx context.Context, uid string, accessKe
 	}
 	endpoint.RawQuery = query.Encode()
 
-	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", endpoint.String(), nil)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusAccepted && httpResp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
+	return c.doRequest(ctx, "DELETE", endpoint.String(), "1.0", nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
 }
 
 // https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-cluster_conf
@@ -1013,101 +1351,35 @@ type CephAPIClusterConfValue struct {
 
 type CephAPIClusterConf struct {
 	Name               string                    `json:"name"`
+	Type               string                    `json:"type"`
 	Level              string                    `json:"level"`
 	CanUpdateAtRuntime bool                      `json:"can_update_at_runtime"`
 	Value              []CephAPIClusterConfValue `json:"value,omitempty"`
 }
 
-func (c *CephAPIClient) ClusterListConf(ctx context.Context) ([]CephAPIClusterConf, error) {
-	url := c.endpoint.JoinPath("/api/cluster_conf").String()
-
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return nil, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
+// SectionValue returns the value set for exactly the given section, if any.
+func (c CephAPIClusterConf) SectionValue(section string) (string, bool) {
+	for _, v := range c.Value {
+		if v.Section == section {
+			return v.Value, true
+		}
 	}
+	return "", false
+}
 
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
-
-	var configs []CephAPIClusterConf
-	err = json.Unmarshal(body, &configs)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode JSON response: %w", err)
-	}
+func (c *CephAPIClient) ClusterListConf(ctx context.Context) ([]CephAPIClusterConf, error) {
+	url := c.endpoint.JoinPath("/api/cluster_conf").String()
 
-	return configs, nil
+	return do[[]CephAPIClusterConf](ctx, c, "GET", url, "1.0", nil)
 }
 
 // https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-cluster_conf-name
 
 func (c *CephAPIClient) ClusterGetConf(ctx context.Context, name string) (CephAPIClusterConf, error) {
-	encodedName := url.PathEscape(name)
-	endpoint := c.endpoint.JoinPath("/api/cluster_conf", encodedName)
+	ctx, endpoint := c.apiURL(ctx, "/api/cluster_conf/{name}", name)
 	url := endpoint.String()
 
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return CephAPIClusterConf{}, fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return CephAPIClusterConf{}, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return CephAPIClusterConf{}, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return CephAPIClusterConf{}, fmt.Errorf("unable to read response body: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
-
-	var config CephAPIClusterConf
-	err = json.Unmarshal(body, &config)
-	if err != nil {
-		return CephAPIClusterConf{}, fmt.Errorf("unable to decode JSON response: %w", err)
-	}
-
-	return config, nil
+	return do[CephAPIClusterConf](ctx, c, "GET", url, "1.0", nil)
 }
 
 // https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-cluster_conf
@@ -1123,73 +1395,37 @@ func (c *CephAPIClient) ClusterUpdateConf(ctx context.Context, name string, sect
 		},
 	}
 
-	jsonPayload, err := json.Marshal(requestBody)
-	if err != nil {
-		return fmt.Errorf("unable to encode request payload: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
-
 	url := c.endpoint.JoinPath("/api/cluster_conf").String()
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	return c.doRequest(ctx, "POST", url, "1.0", requestBody, http.StatusCreated, http.StatusAccepted)
+}
 
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
+// https://docs.ceph.com/en/latest/mgr/ceph_api/#put--api-cluster_conf
+//
+// The dashboard rejects the whole request, before setting anything, if any
+// of the options cannot be updated at runtime.
 
-	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
+func (c *CephAPIClient) ClusterBulkUpdateConf(ctx context.Context, section string, values map[string]string) error {
+	options := make(map[string]map[string]string, len(values))
+	for name, value := range values {
+		options[name] = map[string]string{
+			"section": section,
+			"value":   value,
+		}
 	}
 
-	return nil
+	url := c.endpoint.JoinPath("/api/cluster_conf").String()
+	return c.doRequest(ctx, "PUT", url, "1.0", map[string]any{"options": options}, http.StatusOK, http.StatusAccepted)
 }
 
 // https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-cluster_conf-name
 
 func (c *CephAPIClient) ClusterDeleteConf(ctx context.Context, name string, section string) error {
-	encodedName := url.PathEscape(name)
-	endpoint := c.endpoint.JoinPath("/api/cluster_conf", encodedName)
+	ctx, endpoint := c.apiURL(ctx, "/api/cluster_conf/{name}", name)
 	query := url.Values{}
 	query.Add("section", section)
 	endpoint.RawQuery = query.Encode()
 
-	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", endpoint.String(), nil)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusAccepted && httpResp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
+	return c.doRequest(ctx, "DELETE", endpoint.String(), "1.0", nil, http.StatusAccepted, http.StatusNoContent)
 }
 
 type CephAPIMgrModuleOption struct {
@@ -1201,47 +1437,10 @@ type CephAPIMgrModuleOption struct {
 type CephAPIMgrModuleConfig map[string]any
 
 func (c *CephAPIClient) MgrGetModuleConfig(ctx context.Context, moduleName string) (CephAPIMgrModuleConfig, error) {
-	url := c.endpoint.JoinPath("/api/mgr/module", moduleName).String()
-
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return nil, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
+	ctx, reqURL := c.apiURL(ctx, "/api/mgr/module/{module_name}", moduleName)
+	url := reqURL.String()
 
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
-
-	var config CephAPIMgrModuleConfig
-	err = json.Unmarshal(body, &config)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode JSON response: %w", err)
-	}
-
-	return config, nil
+	return do[CephAPIMgrModuleConfig](ctx, c, "GET", url, "1.0", nil)
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#put--api-mgr-module-module_name>
@@ -1255,146 +1454,37 @@ func (c *CephAPIClient) MgrSetModuleConfig(ctx context.Context, moduleName strin
 		Config: config,
 	}
 
-	jsonPayload, err := json.Marshal(requestBody)
-	if err != nil {
-		return fmt.Errorf("unable to encode request payload: %w", err)
-	}
+	ctx, reqURL := c.apiURL(ctx, "/api/mgr/module/{module_name}", moduleName)
+	url := reqURL.String()
+	return c.doRequest(ctx, "PUT", url, "1.0", requestBody, http.StatusOK, http.StatusCreated, http.StatusAccepted)
+}
 
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-mgr-module-module_name-disable>
 
-	url := c.endpoint.JoinPath("/api/mgr/module", moduleName).String()
-	httpReq, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
+func (c *CephAPIClient) MgrDisableModule(ctx context.Context, moduleName string) error {
+	ctx, reqURL := c.apiURL(ctx, "/api/mgr/module/{module_name}/disable", moduleName)
+	url := reqURL.String()
 
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	return c.doRequest(ctx, "POST", url, "1.0", nil, http.StatusOK, http.StatusCreated, http.StatusAccepted)
+}
 
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-mgr-module-module_name-enable>
 
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
+func (c *CephAPIClient) MgrEnableModule(ctx context.Context, moduleName string) error {
+	ctx, reqURL := c.apiURL(ctx, "/api/mgr/module/{module_name}/enable", moduleName)
+	url := reqURL.String()
 
-	return nil
+	return c.doRequest(ctx, "POST", url, "1.0", nil, http.StatusOK, http.StatusCreated, http.StatusAccepted)
 }
 
-// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-mgr-module-module_name-disable>
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-mgr-module-module_name-options>
 
-func (c *CephAPIClient) MgrDisableModule(ctx context.Context, moduleName string) error {
-	url := c.endpoint.JoinPath("/api/mgr/module", moduleName, "disable").String()
+func (c *CephAPIClient) MgrGetModuleOptions(ctx context.Context, moduleName string) (map[string]CephAPIMgrModuleOption, error) {
+	ctx, reqURL := c.apiURL(ctx, "/api/mgr/module/{module_name}/options", moduleName)
+	url := reqURL.String()
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, nil)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
-}
-
-// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-mgr-module-module_name-enable>
-
-func (c *CephAPIClient) MgrEnableModule(ctx context.Context, moduleName string) error {
-	url := c.endpoint.JoinPath("/api/mgr/module", moduleName, "enable").String()
-
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, nil)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
-}
-
-// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-mgr-module-module_name-options>
-
-func (c *CephAPIClient) MgrGetModuleOptions(ctx context.Context, moduleName string) (map[string]CephAPIMgrModuleOption, error) {
-	url := c.endpoint.JoinPath("/api/mgr/module", moduleName, "options").String()
-
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return nil, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
-
-	var options map[string]CephAPIMgrModuleOption
-	err = json.Unmarshal(body, &options)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode JSON response: %w", err)
-	}
-
-	return options, nil
-}
+	return do[map[string]CephAPIMgrModuleOption](ctx, c, "GET", url, "1.0", nil)
+}
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-pool>
 
@@ -1408,6 +1498,11 @@ type CephAPIPoolOptions struct {
 	QuotaMaxBytes            int     `json:"quota_max_bytes"`
 	PGNumMin                 int     `json:"pg_num_min"`
 	PGNumMax                 int     `json:"pg_num_max"`
+	// Recovery and scrub options are only present once set on the pool.
+	RecoveryPriority  *int     `json:"recovery_priority"`
+	ScrubMinInterval  *float64 `json:"scrub_min_interval"`
+	ScrubMaxInterval  *float64 `json:"scrub_max_interval"`
+	DeepScrubInterval *float64 `json:"deep_scrub_interval"`
 }
 
 type CephAPIPool struct {
@@ -1424,6 +1519,7 @@ type CephAPIPool struct {
 	Application         string             `json:"application"`
 	ApplicationMetadata []string           `json:"application_metadata"`
 	Flags               int                `json:"flags"`
+	FlagsNames          string             `json:"flags_names"`
 	ErasureCodeProfile  string             `json:"erasure_code_profile"`
 	PGAutoscaleMode     string             `json:"pg_autoscale_mode"`
 	QuotaMaxObjects     int                `json:"quota_max_objects"`
@@ -1431,51 +1527,14 @@ type CephAPIPool struct {
 	TargetSizeRatioRel  float64            `json:"target_size_ratio_rel"`
 	MinPGNum            int                `json:"min_pg_num"`
 	PGAutoscalerProfile string             `json:"pg_autoscaler_profile"`
+	FastRead            bool               `json:"fast_read"`
 	Options             CephAPIPoolOptions `json:"options"`
 }
 
 func (c *CephAPIClient) ListPools(ctx context.Context) ([]CephAPIPool, error) {
 	url := c.endpoint.JoinPath("/api/pool").String()
 
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return nil, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
-
-	var pools []CephAPIPool
-	err = json.Unmarshal(body, &pools)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode JSON response: %w", err)
-	}
-
-	return pools, nil
+	return do[[]CephAPIPool](ctx, c, "GET", url, "1.0", nil)
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-pool>
@@ -1502,111 +1561,27 @@ type CephAPIPoolCreateRequest struct {
 }
 
 func (c *CephAPIClient) CreatePool(ctx context.Context, req CephAPIPoolCreateRequest) error {
-	jsonPayload, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("unable to encode request payload: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
-
 	url := c.endpoint.JoinPath("/api/pool").String()
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
+	return c.doRequest(ctx, "POST", url, "1.0", req, http.StatusCreated, http.StatusAccepted)
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-pool--pool_name>
 
 func (c *CephAPIClient) DeletePool(ctx context.Context, poolName string) error {
-	url := c.endpoint.JoinPath("/api/pool", poolName).String()
-	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusAccepted && httpResp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
+	ctx, reqURL := c.apiURL(ctx, "/api/pool/{pool_name}", poolName)
+	url := reqURL.String()
+	return c.doRequest(ctx, "DELETE", url, "1.0", nil, http.StatusAccepted, http.StatusNoContent)
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-pool--pool_name>
 
 func (c *CephAPIClient) GetPool(ctx context.Context, poolName string) (*CephAPIPool, error) {
-	url := c.endpoint.JoinPath("/api/pool", poolName).String()
-
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return nil, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
+	ctx, reqURL := c.apiURL(ctx, "/api/pool/{pool_name}", poolName)
+	url := reqURL.String()
 
-	var pool CephAPIPool
-	err = json.Unmarshal(body, &pool)
+	pool, err := do[CephAPIPool](ctx, c, "GET", url, "1.0", nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to decode JSON response: %w", err)
+		return nil, err
 	}
 
 	return &pool, nil
@@ -1631,104 +1606,48 @@ type CephAPIPoolUpdateRequest struct {
 	CompressionMaxBlobSize   *int     `json:"compression_max_blob_size,omitempty"`
 	ApplicationMetadata      []string `json:"application_metadata,omitempty"`
 	Flags                    []string `json:"flags,omitempty"`
+	// Configuration sets RBD config overrides on the pool. A nil value
+	// removes the override.
+	Configuration map[string]*string `json:"configuration,omitempty"`
 }
 
 func (c *CephAPIClient) UpdatePool(ctx context.Context, poolName string, req CephAPIPoolUpdateRequest) error {
-	jsonPayload, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("unable to encode request payload: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
-
-	url := c.endpoint.JoinPath("/api/pool", poolName).String()
-	httpReq, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
+	ctx, reqURL := c.apiURL(ctx, "/api/pool/{pool_name}", poolName)
+	url := reqURL.String()
+	return c.doRequest(ctx, "PUT", url, "1.0", req, http.StatusOK, http.StatusAccepted)
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-pool--pool_name-configuration>
 
+// Sources of a pool configuration value.
+const (
+	CephAPIPoolConfigSourceDefault = 0
+	CephAPIPoolConfigSourcePool    = 1
+)
+
 type CephAPIPoolConfigItem struct {
-	Name  string `json:"name"`
-	Value any    `json:"value"`
+	Name   string `json:"name"`
+	Value  any    `json:"value"`
+	Source int    `json:"source"`
 }
 
 type CephAPIPoolConfiguration []CephAPIPoolConfigItem
 
 func (c *CephAPIClient) GetPoolConfiguration(ctx context.Context, poolName string) (CephAPIPoolConfiguration, error) {
-	url := c.endpoint.JoinPath("/api/pool", poolName, "configuration").String()
-
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return nil, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
+	ctx, reqURL := c.apiURL(ctx, "/api/pool/{pool_name}/configuration", poolName)
+	url := reqURL.String()
 
-	var config CephAPIPoolConfiguration
-	err = json.Unmarshal(body, &config)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode JSON response: %w", err)
-	}
-
-	return config, nil
+	return do[CephAPIPoolConfiguration](ctx, c, "GET", url, "1.0", nil)
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-crush_rule>
 
 type CephAPICrushRuleStep struct {
-	Op   string `json:"op"`
-	Num  int    `json:"num"`
-	Type string `json:"type"`
-	Item int    `json:"item,omitempty"`
+	Op       string `json:"op"`
+	Num      int    `json:"num"`
+	Type     string `json:"type"`
+	Item     int    `json:"item,omitempty"`
+	ItemName string `json:"item_name,omitempty"`
 }
 
 type CephAPICrushRule struct {
@@ -1744,48 +1663,10 @@ type CephAPICrushRule struct {
 func (c *CephAPIClient) ListCrushRules(ctx context.Context) ([]CephAPICrushRule, error) {
 	url := c.endpoint.JoinPath("/api/crush_rule").String()
 
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %w", err)
-	}
+	return do[[]CephAPICrushRule](ctx, c, "GET", url, "2.0", nil)
+}
 
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v2.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return nil, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
-
-	var rules []CephAPICrushRule
-	err = json.Unmarshal(body, &rules)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode JSON response: %w", err)
-	}
-
-	return rules, nil
-}
-
-// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-crush_rule>
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-crush_rule>
 
 type CephAPICrushRuleCreateRequest struct {
 	Name          string  `json:"name"`
@@ -1797,111 +1678,27 @@ type CephAPICrushRuleCreateRequest struct {
 }
 
 func (c *CephAPIClient) CreateCrushRule(ctx context.Context, req CephAPICrushRuleCreateRequest) error {
-	jsonPayload, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("unable to encode request payload: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
-
 	url := c.endpoint.JoinPath("/api/crush_rule").String()
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
+	return c.doRequest(ctx, "POST", url, "1.0", req, http.StatusCreated, http.StatusAccepted)
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-crush_rule--name>
 
 func (c *CephAPIClient) DeleteCrushRule(ctx context.Context, name string) error {
-	url := c.endpoint.JoinPath("/api/crush_rule", name).String()
-	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusAccepted && httpResp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	return nil
+	ctx, reqURL := c.apiURL(ctx, "/api/crush_rule/{name}", name)
+	url := reqURL.String()
+	return c.doRequest(ctx, "DELETE", url, "1.0", nil, http.StatusAccepted, http.StatusNoContent)
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-crush_rule--name>
 
 func (c *CephAPIClient) GetCrushRule(ctx context.Context, name string) (*CephAPICrushRule, error) {
-	url := c.endpoint.JoinPath("/api/crush_rule", name).String()
-
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %w", err)
-	}
+	ctx, reqURL := c.apiURL(ctx, "/api/crush_rule/{name}", name)
+	url := reqURL.String()
 
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v2.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
-
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
-	if err != nil {
-		return nil, fmt.Errorf("unable to make request to Ceph API: %w", err)
-	}
-	defer httpResp.Body.Close() //nolint:errcheck
-
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(httpResp.Body)
+	rule, err := do[CephAPICrushRule](ctx, c, "GET", url, "2.0", nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
-	}
-
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
-
-	var rule CephAPICrushRule
-	err = json.Unmarshal(body, &rule)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode JSON response: %w", err)
+		return nil, err
 	}
 
 	return &rule, nil
@@ -1919,6 +1716,37 @@ type CephAPIErasureCodeProfile struct {
 	CrushRoot          string `json:"crush-root,omitempty"`
 	CrushDeviceClass   string `json:"crush-device-class,omitempty"`
 	Directory          string `json:"directory,omitempty"`
+	ScalarMDS          string `json:"scalar_mds,omitempty"`
+	D                  string `json:"d,omitempty"`
+	L                  string `json:"l,omitempty"`
+
+	// Params holds every parameter of the profile as Ceph stores it,
+	// including the defaults the plugin filled in (e.g. "w") and the ones
+	// without a field, such as the "layers" of an lrc profile.
+	Params map[string]string `json:"-"`
+}
+
+// erasureCodeProfileFields are the profile parameters that have their own
+// field in CephAPIErasureCodeProfile.
+var erasureCodeProfileFields = []string{"name", "k", "m", "plugin", "crush-failure-domain", "technique", "crush-root", "crush-device-class", "directory", "scalar_mds", "d", "l"}
+
+func (p *CephAPIErasureCodeProfile) UnmarshalJSON(data []byte) error {
+	type profile CephAPIErasureCodeProfile
+	if err := json.Unmarshal(data, (*profile)(p)); err != nil {
+		return err
+	}
+
+	var params map[string]any
+	if err := json.Unmarshal(data, &params); err != nil {
+		return err
+	}
+	p.Params = make(map[string]string)
+	for key, value := range params {
+		if key != "name" {
+			p.Params[key] = fmt.Sprint(value)
+		}
+	}
+	return nil
 }
 
 // <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-erasure_code_profile>
@@ -1933,115 +1761,601 @@ type CephAPIErasureCodeProfileCreateRequest struct {
 	CrushRoot          *string `json:"crush-root,omitempty"`
 	CrushDeviceClass   *string `json:"crush-device-class,omitempty"`
 	Directory          *string `json:"directory,omitempty"`
+	ScalarMDS          *string `json:"scalar_mds,omitempty"`
+	D                  *string `json:"d,omitempty"`
+	L                  *string `json:"l,omitempty"`
+
+	// Params are passed to the plugin alongside the other parameters. The
+	// dashboard forwards every key of the request to the profile.
+	Params map[string]string `json:"-"`
+}
+
+func (r CephAPIErasureCodeProfileCreateRequest) MarshalJSON() ([]byte, error) {
+	type request CephAPIErasureCodeProfileCreateRequest
+	data, err := json.Marshal(request(r))
+	if err != nil || len(r.Params) == 0 {
+		return data, err
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	for key, value := range r.Params {
+		if _, ok := body[key]; !ok {
+			body[key] = value
+		}
+	}
+	return json.Marshal(body)
 }
 
 func (c *CephAPIClient) CreateErasureCodeProfile(ctx context.Context, req CephAPIErasureCodeProfileCreateRequest) error {
-	jsonPayload, err := json.Marshal(req)
+	url := c.endpoint.JoinPath("/api/erasure_code_profile").String()
+	return c.doRequest(ctx, "POST", url, "1.0", req, http.StatusCreated, http.StatusAccepted)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-erasure_code_profile--name>
+
+func (c *CephAPIClient) DeleteErasureCodeProfile(ctx context.Context, name string) error {
+	ctx, reqURL := c.apiURL(ctx, "/api/erasure_code_profile/{name}", name)
+	url := reqURL.String()
+	return c.doRequest(ctx, "DELETE", url, "1.0", nil, http.StatusAccepted, http.StatusNoContent)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-erasure_code_profile--name>
+
+func (c *CephAPIClient) GetErasureCodeProfile(ctx context.Context, name string) (*CephAPIErasureCodeProfile, error) {
+	ctx, reqURL := c.apiURL(ctx, "/api/erasure_code_profile/{name}", name)
+	url := reqURL.String()
+
+	profile, err := do[CephAPIErasureCodeProfile](ctx, c, "GET", url, "1.0", nil)
 	if err != nil {
-		return fmt.Errorf("unable to encode request payload: %w", err)
+		return nil, err
 	}
 
-	tflog.Trace(ctx, "Ceph API request body", map[string]any{
-		"request_body": string(jsonPayload),
-	})
+	return &profile, nil
+}
 
-	url := c.endpoint.JoinPath("/api/erasure_code_profile").String()
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-health-minimal>
+
+type CephAPIHealthCheck struct {
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	Summary  struct {
+		Message string `json:"message"`
+	} `json:"summary"`
+}
+
+type CephAPIHealth struct {
+	Status string               `json:"status"`
+	Checks []CephAPIHealthCheck `json:"checks"`
+}
+
+type CephAPIHealthMinimal struct {
+	Health CephAPIHealth `json:"health"`
+}
+
+func (c *CephAPIClient) GetHealthMinimal(ctx context.Context) (*CephAPIHealthMinimal, error) {
+	url := c.endpoint.JoinPath("/api/health/minimal").String()
+
+	health, err := do[CephAPIHealthMinimal](ctx, c, "GET", url, "1.0", nil)
 	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
+		return nil, err
 	}
 
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	return &health, nil
+}
 
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-health-full>
+
+type CephAPIMgrStandby struct {
+	Name string `json:"name"`
+}
+
+type CephAPIMgrMap struct {
+	ActiveName string              `json:"active_name"`
+	Available  bool                `json:"available"`
+	Standbys   []CephAPIMgrStandby `json:"standbys"`
+	Services   map[string]string   `json:"services"`
+}
+
+type CephAPIHealthFull struct {
+	Health CephAPIHealth `json:"health"`
+	MgrMap CephAPIMgrMap `json:"mgr_map"`
+}
+
+func (c *CephAPIClient) GetHealthFull(ctx context.Context) (*CephAPIHealthFull, error) {
+	url := c.endpoint.JoinPath("/api/health/full").String()
+
+	health, err := do[CephAPIHealthFull](ctx, c, "GET", url, "1.0", nil)
 	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
+		return nil, err
 	}
-	defer httpResp.Body.Close() //nolint:errcheck
 
-	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
+	return &health, nil
+}
+
+// The dashboard proxies these to the Alertmanager API.
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-prometheus-silences>
+
+type CephAPIAlertSilenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual *bool  `json:"isEqual,omitempty"`
+}
+
+type CephAPIAlertSilence struct {
+	ID        string                       `json:"id,omitempty"`
+	Matchers  []CephAPIAlertSilenceMatcher `json:"matchers"`
+	StartsAt  time.Time                    `json:"startsAt"`
+	EndsAt    time.Time                    `json:"endsAt"`
+	CreatedBy string                       `json:"createdBy"`
+	Comment   string                       `json:"comment"`
+	Status    *struct {
+		State string `json:"state"`
+	} `json:"status,omitempty"`
+}
+
+func (c *CephAPIClient) ListAlertSilences(ctx context.Context) ([]CephAPIAlertSilence, error) {
+	url := c.endpoint.JoinPath("/api/prometheus/silences").String()
+
+	return do[[]CephAPIAlertSilence](ctx, c, "GET", url, "1.0", nil)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-prometheus-silence>
+
+type CephAPIAlertSilenceCreateResponse struct {
+	SilenceID string `json:"silenceID"`
+}
+
+// CreateAlertSilence creates the silence and returns its ID.
+func (c *CephAPIClient) CreateAlertSilence(ctx context.Context, silence CephAPIAlertSilence) (string, error) {
+	url := c.endpoint.JoinPath("/api/prometheus/silence").String()
+
+	created, err := do[CephAPIAlertSilenceCreateResponse](ctx, c, "POST", url, "1.0", silence, http.StatusCreated, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+	if created.SilenceID == "" {
+		return "", errors.New("response did not include a silence ID")
 	}
 
-	return nil
+	return created.SilenceID, nil
 }
 
-// <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-erasure_code_profile--name>
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-prometheus-silence--s_id>
 
-func (c *CephAPIClient) DeleteErasureCodeProfile(ctx context.Context, name string) error {
-	url := c.endpoint.JoinPath("/api/erasure_code_profile", name).String()
-	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+func (c *CephAPIClient) DeleteAlertSilence(ctx context.Context, id string) error {
+	ctx, reqURL := c.apiURL(ctx, "/api/prometheus/silence/{s_id}", id)
+	url := reqURL.String()
+	return c.doRequest(ctx, "DELETE", url, "1.0", nil, http.StatusOK, http.StatusNoContent)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-block-mirroring-pool--pool_name--bootstrap-token>
+
+type CephAPIRBDMirrorBootstrapToken struct {
+	Token string `json:"token"`
+}
+
+// CreateRBDMirrorBootstrapToken returns a bootstrap token a peer cluster can
+// import to mirror the pool. Ceph reuses the same rbd-mirror-peer user each
+// time, so repeated calls return an equivalent token.
+func (c *CephAPIClient) CreateRBDMirrorBootstrapToken(ctx context.Context, poolName string) (string, error) {
+	ctx, reqURL := c.apiURL(ctx, "/api/block/mirroring/pool/{pool_name}/bootstrap/token", poolName)
+	url := reqURL.String()
+
+	token, err := do[CephAPIRBDMirrorBootstrapToken](withoutResponseTrace(ctx), c, "POST", url, "1.0", nil, http.StatusCreated, http.StatusOK)
 	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
+		return "", err
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("response did not include a bootstrap token")
 	}
 
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	return token.Token, nil
+}
 
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-cephfs>
+
+type CephAPIMDSInfo struct {
+	GID   int64  `json:"gid"`
+	Name  string `json:"name"`
+	Rank  int    `json:"rank"`
+	State string `json:"state"`
+}
+
+type CephAPIMDSMapFlags struct {
+	Joinable           bool `json:"joinable"`
+	AllowSnaps         bool `json:"allow_snaps"`
+	AllowMultiMDSSnaps bool `json:"allow_multimds_snaps"`
+	AllowStandbyReplay bool `json:"allow_standby_replay"`
+}
+
+type CephAPIMDSMap struct {
+	FsName       string                    `json:"fs_name"`
+	Enabled      bool                      `json:"enabled"`
+	MaxMDS       int                       `json:"max_mds"`
+	MetadataPool int                       `json:"metadata_pool"`
+	DataPools    []int                     `json:"data_pools"`
+	Info         map[string]CephAPIMDSInfo `json:"info"`
+	FlagsState   CephAPIMDSMapFlags        `json:"flags_state"`
+}
+
+type CephAPICephFS struct {
+	ID     int           `json:"id"`
+	MDSMap CephAPIMDSMap `json:"mdsmap"`
+}
+
+func (c *CephAPIClient) ListCephFS(ctx context.Context) ([]CephAPICephFS, error) {
+	url := c.endpoint.JoinPath("/api/cephfs").String()
+
+	return do[[]CephAPICephFS](ctx, c, "GET", url, "1.0", nil)
+}
+
+// CephFSID returns the ID (fscid) of the filesystem named fsName, which the
+// dashboard uses to address a filesystem.
+func (c *CephAPIClient) CephFSID(ctx context.Context, fsName string) (int, error) {
+	filesystems, err := c.ListCephFS(ctx)
 	if err != nil {
-		return fmt.Errorf("unable to make request to Ceph API: %w", err)
+		return 0, err
 	}
-	defer httpResp.Body.Close() //nolint:errcheck
 
-	if httpResp.StatusCode != http.StatusAccepted && httpResp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(httpResp.Body)
-		return fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
+	for _, fs := range filesystems {
+		if fs.MDSMap.FsName == fsName {
+			return fs.ID, nil
+		}
 	}
 
-	return nil
+	return 0, &CephAPIError{StatusCode: http.StatusNotFound, Detail: fmt.Sprintf("filesystem %s does not exist", fsName)}
 }
 
-// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-erasure_code_profile--name>
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-cephfs-fs_id-get_quotas>
 
-func (c *CephAPIClient) GetErasureCodeProfile(ctx context.Context, name string) (*CephAPIErasureCodeProfile, error) {
-	url := c.endpoint.JoinPath("/api/erasure_code_profile", name).String()
+type CephAPICephFSQuotas struct {
+	MaxBytes int64 `json:"max_bytes"`
+	MaxFiles int64 `json:"max_files"`
+}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %w", err)
+func (c *CephAPIClient) CephFSGetQuotas(ctx context.Context, fsID int, path string) (CephAPICephFSQuotas, error) {
+	ctx, reqURL := c.apiURL(ctx, "/api/cephfs/{fs_id}/get_quotas", strconv.Itoa(fsID))
+	reqURL.RawQuery = url.Values{"path": {path}}.Encode()
+
+	return doCephFS[CephAPICephFSQuotas](ctx, c, "GET", reqURL.String(), nil)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#put--api-cephfs-fs_id-quota>
+
+type CephAPICephFSQuotaRequest struct {
+	Path     string `json:"path"`
+	MaxBytes int64  `json:"max_bytes"`
+	MaxFiles int64  `json:"max_files"`
+}
+
+// CephFSSetQuotas sets the ceph.quota.max_bytes and ceph.quota.max_files
+// attributes of a directory. A value of 0 removes that quota.
+func (c *CephAPIClient) CephFSSetQuotas(ctx context.Context, fsID int, req CephAPICephFSQuotaRequest) error {
+	ctx, reqURL := c.apiURL(ctx, "/api/cephfs/{fs_id}/quota", strconv.Itoa(fsID))
+	url := reqURL.String()
+	return c.doCephFSRequest(ctx, "PUT", url, req, http.StatusOK, http.StatusAccepted)
+}
+
+// doCephFS is do for the CephFS endpoints, see cephFSError.
+func doCephFS[T any](ctx context.Context, c *CephAPIClient, method, url string, body any, expectedStatus ...int) (T, error) {
+	result, err := do[T](ctx, c, method, url, "1.0", body, expectedStatus...)
+	return result, cephFSError(err)
+}
+
+// doCephFSRequest is doRequest for the CephFS endpoints, see cephFSError.
+func (c *CephAPIClient) doCephFSRequest(ctx context.Context, method, url string, body any, expectedStatus ...int) error {
+	return cephFSError(c.doRequest(ctx, method, url, "1.0", body, expectedStatus...))
+}
+
+// cephFSError maps CephFS "not found" errors to a 404. The dashboard reports
+// missing directories, subvolumes and snapshots as a 400 or 500 with the
+// libcephfs or volumes module message.
+func cephFSError(err error) error {
+	var apiErr *CephAPIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusBadRequest && (strings.Contains(apiErr.Detail, "does not exist") || strings.Contains(apiErr.Detail, "No such file or directory")) {
+		apiErr.StatusCode = http.StatusNotFound
 	}
+	return err
+}
 
-	httpReq.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-cephfs-subvolume-vol_name-info>
 
-	logRequest := logAPIRequest(ctx, httpReq)
-	httpResp, err := c.client.Do(httpReq)
-	logRequest(httpResp, err)
+type CephAPICephFSSubvolumeInfo struct {
+	Path      string `json:"path"`
+	State     string `json:"state"`
+	Type      string `json:"type"`
+	DataPool  string `json:"data_pool"`
+	CreatedAt string `json:"created_at"`
+}
+
+func (c *CephAPIClient) CephFSGetSubvolume(ctx context.Context, volName, subvolName, groupName string) (CephAPICephFSSubvolumeInfo, error) {
+	ctx, reqURL := c.apiURL(ctx, "/api/cephfs/subvolume/{vol_name}/info", volName)
+	reqURL.RawQuery = url.Values{"subvol_name": {subvolName}, "group_name": {groupName}}.Encode()
+
+	return doCephFS[CephAPICephFSSubvolumeInfo](ctx, c, "GET", reqURL.String(), nil)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-cephfs-subvolume-vol_name>
+
+func (c *CephAPIClient) CephFSDeleteSubvolume(ctx context.Context, volName, subvolName, groupName string) error {
+	ctx, reqURL := c.apiURL(ctx, "/api/cephfs/subvolume/{vol_name}", volName)
+	reqURL.RawQuery = url.Values{"subvol_name": {subvolName}, "group_name": {groupName}}.Encode()
+
+	return c.doCephFSRequest(ctx, "DELETE", reqURL.String(), nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-cephfs-subvolume-snapshot-vol_name-subvol_name-info>
+
+type CephAPICephFSSubvolumeSnapshotInfo struct {
+	CreatedAt        string `json:"created_at"`
+	DataPool         string `json:"data_pool"`
+	HasPendingClones string `json:"has_pending_clones"`
+}
+
+func (c *CephAPIClient) CephFSGetSubvolumeSnapshot(ctx context.Context, volName, subvolName, snapName, groupName string) (CephAPICephFSSubvolumeSnapshotInfo, error) {
+	ctx, reqURL := c.apiURL(ctx, "/api/cephfs/subvolume/snapshot/{vol_name}/{subvol_name}/info", volName, subvolName)
+	reqURL.RawQuery = url.Values{"snap_name": {snapName}, "group_name": {groupName}}.Encode()
+
+	return doCephFS[CephAPICephFSSubvolumeSnapshotInfo](ctx, c, "GET", reqURL.String(), nil)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-cephfs-subvolume-snapshot>
+
+type CephAPICephFSSubvolumeSnapshotCreateRequest struct {
+	VolName    string `json:"vol_name"`
+	SubvolName string `json:"subvol_name"`
+	SnapName   string `json:"snap_name"`
+	GroupName  string `json:"group_name,omitempty"`
+}
+
+func (c *CephAPIClient) CephFSCreateSubvolumeSnapshot(ctx context.Context, req CephAPICephFSSubvolumeSnapshotCreateRequest) error {
+	url := c.endpoint.JoinPath("/api/cephfs/subvolume/snapshot").String()
+	return c.doCephFSRequest(ctx, "POST", url, req, http.StatusOK, http.StatusCreated, http.StatusAccepted)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-cephfs-subvolume-snapshot-vol_name-subvol_name>
+
+func (c *CephAPIClient) CephFSDeleteSubvolumeSnapshot(ctx context.Context, volName, subvolName, snapName, groupName string) error {
+	ctx, reqURL := c.apiURL(ctx, "/api/cephfs/subvolume/snapshot/{vol_name}/{subvol_name}", volName, subvolName)
+	reqURL.RawQuery = url.Values{"snap_name": {snapName}, "group_name": {groupName}, "force": {"false"}}.Encode()
+
+	return c.doCephFSRequest(ctx, "DELETE", reqURL.String(), nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-cephfs-subvolume-snapshot-clone>
+
+type CephAPICephFSSubvolumeSnapshotCloneRequest struct {
+	VolName         string `json:"vol_name"`
+	SubvolName      string `json:"subvol_name"`
+	SnapName        string `json:"snap_name"`
+	CloneName       string `json:"clone_name"`
+	GroupName       string `json:"group_name,omitempty"`
+	TargetGroupName string `json:"target_group_name,omitempty"`
+}
+
+// CephFSCloneSubvolumeSnapshot starts cloning a snapshot into a new
+// subvolume. The copy runs asynchronously in the volumes module; the clone
+// reports as not ready until it completes.
+func (c *CephAPIClient) CephFSCloneSubvolumeSnapshot(ctx context.Context, req CephAPICephFSSubvolumeSnapshotCloneRequest) error {
+	url := c.endpoint.JoinPath("/api/cephfs/subvolume/snapshot/clone").String()
+	return c.doCephFSRequest(ctx, "POST", url, req, http.StatusOK, http.StatusCreated, http.StatusAccepted)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-nfs-ganesha-cluster>
+
+func (c *CephAPIClient) ListNFSClusters(ctx context.Context) ([]string, error) {
+	url := c.endpoint.JoinPath("/api/nfs-ganesha/cluster").String()
+
+	return do[[]string](ctx, c, "GET", url, "0.1", nil)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-nfs-ganesha-export>
+
+type CephAPINFSExportClient struct {
+	Addresses  []string `json:"addresses"`
+	AccessType string   `json:"access_type"`
+	Squash     string   `json:"squash"`
+}
+
+// CephAPINFSExportFSAL omits the RGW access and secret keys the API also
+// returns.
+type CephAPINFSExportFSAL struct {
+	Name   string `json:"name"`
+	FsName string `json:"fs_name"`
+	UserID string `json:"user_id"`
+}
+
+type CephAPINFSExport struct {
+	ExportID      int                      `json:"export_id"`
+	ClusterID     string                   `json:"cluster_id"`
+	Path          string                   `json:"path"`
+	Pseudo        string                   `json:"pseudo"`
+	AccessType    string                   `json:"access_type"`
+	Squash        string                   `json:"squash"`
+	SecurityLabel bool                     `json:"security_label"`
+	Protocols     []int                    `json:"protocols"`
+	Transports    []string                 `json:"transports"`
+	FSAL          CephAPINFSExportFSAL     `json:"fsal"`
+	Clients       []CephAPINFSExportClient `json:"clients"`
+}
+
+func (c *CephAPIClient) ListNFSExports(ctx context.Context) ([]CephAPINFSExport, error) {
+	url := c.endpoint.JoinPath("/api/nfs-ganesha/export").String()
+
+	// The response body is not traced because RGW exports include the
+	// secret key of their user.
+	return do[[]CephAPINFSExport](withoutResponseTrace(ctx), c, "GET", url, "1.0", nil)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-monitor>
+
+type CephAPIMonAddr struct {
+	Type  string `json:"type"`
+	Addr  string `json:"addr"`
+	Nonce int    `json:"nonce"`
+}
+
+type CephAPIMonMapMon struct {
+	Name        string `json:"name"`
+	Rank        int    `json:"rank"`
+	PublicAddrs struct {
+		AddrVec []CephAPIMonAddr `json:"addrvec"`
+	} `json:"public_addrs"`
+}
+
+type CephAPIMonMap struct {
+	FSID string             `json:"fsid"`
+	Mons []CephAPIMonMapMon `json:"mons"`
+}
+
+type CephAPIMonitor struct {
+	MonStatus struct {
+		MonMap CephAPIMonMap `json:"monmap"`
+	} `json:"mon_status"`
+}
+
+func (c *CephAPIClient) GetMonitor(ctx context.Context) (*CephAPIMonitor, error) {
+	url := c.endpoint.JoinPath("/api/monitor").String()
+
+	monitor, err := do[CephAPIMonitor](ctx, c, "GET", url, "1.0", nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to make request to Ceph API: %w", err)
+		return nil, err
 	}
-	defer httpResp.Body.Close() //nolint:errcheck
 
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ceph API returned status %d: %s", httpResp.StatusCode, string(body))
+	return &monitor, nil
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-summary>
+
+type CephAPISummary struct {
+	Version string `json:"version"`
+}
+
+func (c *CephAPIClient) GetSummary(ctx context.Context) (*CephAPISummary, error) {
+	url := c.endpoint.JoinPath("/api/summary").String()
+
+	summary, err := do[CephAPISummary](ctx, c, "GET", url, "1.0", nil)
+	if err != nil {
+		return nil, err
 	}
 
-	body, err := io.ReadAll(httpResp.Body)
+	return &summary, nil
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-cluster-upgrade-status>
+
+type CephAPIOrchUpgradeStatus struct {
+	TargetImage string `json:"target_image"`
+	InProgress  bool   `json:"in_progress"`
+	Which       string `json:"which"`
+	Progress    string `json:"progress"`
+	Message     string `json:"message"`
+	IsPaused    bool   `json:"is_paused"`
+}
+
+func (c *CephAPIClient) OrchUpgradeStatus(ctx context.Context) (*CephAPIOrchUpgradeStatus, error) {
+	url := c.endpoint.JoinPath("/api/cluster/upgrade/status").String()
+
+	status, err := do[CephAPIOrchUpgradeStatus](ctx, c, "GET", url, "1.0", nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
+		return nil, err
 	}
 
-	tflog.Trace(ctx, "Ceph API response body", map[string]any{
-		"response_body": string(body),
-		"status_code":   httpResp.StatusCode,
-	})
+	return &status, nil
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#post--api-cluster-upgrade-start>
+
+type CephAPIOrchUpgradeStartRequest struct {
+	Version string `json:"version,omitempty"`
+	Image   string `json:"image,omitempty"`
+}
+
+func (c *CephAPIClient) OrchUpgradeStart(ctx context.Context, req CephAPIOrchUpgradeStartRequest) error {
+	url := c.endpoint.JoinPath("/api/cluster/upgrade/start").String()
+	return c.doRequest(ctx, "POST", url, "1.0", req, http.StatusOK, http.StatusCreated, http.StatusAccepted)
+}
+
+// OrchUpgradeControl pauses, resumes or stops the upgrade in progress.
+//
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#put--api-cluster-upgrade-pause>
+func (c *CephAPIClient) OrchUpgradeControl(ctx context.Context, action string) error {
+	ctx, reqURL := c.apiURL(ctx, "/api/cluster/upgrade/{action}", action)
+	url := reqURL.String()
+
+	return c.doRequest(ctx, "PUT", url, "1.0", nil, http.StatusOK, http.StatusAccepted)
+}
+
+type CephAPIHost struct {
+	Hostname string   `json:"hostname"`
+	Addr     string   `json:"addr"`
+	Labels   []string `json:"labels"`
+	Status   string   `json:"status"`
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-host-hostname>
+
+func (c *CephAPIClient) HostGet(ctx context.Context, hostname string) (*CephAPIHost, error) {
+	ctx, reqURL := c.apiURL(ctx, "/api/host/{hostname}", hostname)
+	url := reqURL.String()
 
-	var profile CephAPIErasureCodeProfile
-	err = json.Unmarshal(body, &profile)
+	host, err := do[CephAPIHost](ctx, c, "GET", url, "1.0", nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to decode JSON response: %w", err)
+		return nil, err
 	}
 
-	return &profile, nil
+	return &host, nil
+}
+
+// HostSetLabels replaces every label on an orchestrator host.
+//
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#put--api-host-hostname>
+func (c *CephAPIClient) HostSetLabels(ctx context.Context, hostname string, labels []string) error {
+	requestBody := map[string]any{
+		"update_labels": true,
+		"labels":        labels,
+	}
+
+	ctx, reqURL := c.apiURL(ctx, "/api/host/{hostname}", hostname)
+	url := reqURL.String()
+	return c.doRequest(ctx, "PUT", url, "0.1", requestBody, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+type CephAPIDashboardSetting struct {
+	Name    string `json:"name"`
+	Default any    `json:"default"`
+	Type    string `json:"type"`
+	Value   any    `json:"value"`
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#get--api-settings>
+
+func (c *CephAPIClient) DashboardGetSettings(ctx context.Context, names []string) ([]CephAPIDashboardSetting, error) {
+	endpoint := c.endpoint.JoinPath("/api/settings")
+	query := url.Values{}
+	query.Add("names", strings.Join(names, ","))
+	endpoint.RawQuery = query.Encode()
+
+	return do[[]CephAPIDashboardSetting](ctx, c, "GET", endpoint.String(), "1.0", nil)
+}
+
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#put--api-settings>
+
+func (c *CephAPIClient) DashboardSetSettings(ctx context.Context, settings map[string]any) error {
+	url := c.endpoint.JoinPath("/api/settings").String()
+	return c.doRequest(ctx, "PUT", url, "1.0", settings, http.StatusOK, http.StatusAccepted)
+}
+
+// DashboardResetSetting restores the default value of a dashboard setting.
+//
+// <https://docs.ceph.com/en/latest/mgr/ceph_api/#delete--api-settings-name>
+func (c *CephAPIClient) DashboardResetSetting(ctx context.Context, name string) error {
+	ctx, reqURL := c.apiURL(ctx, "/api/settings/{name}", name)
+	url := reqURL.String()
+
+	return c.doRequest(ctx, "DELETE", url, "1.0", nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
 }
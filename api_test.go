@@ -0,0 +1,603 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func newPrefixedDashboardServer(t *testing.T, prefix string) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch strings.TrimPrefix(r.URL.Path, prefix) {
+		case "", "/":
+			w.WriteHeader(http.StatusOK)
+		case "/api/auth":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(CephAPIAuthResponse{Token: "test-token"})
+		case "/api/rgw/bucket/test-bucket":
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(CephAPIRGWBucket{Bucket: "test-bucket"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &paths
+}
+
+func TestCephAPIClientURLPrefix(t *testing.T) {
+	for _, suffix := range []string{"/dashboard", "/dashboard/"} {
+		t.Run(suffix, func(t *testing.T) {
+			server, paths := newPrefixedDashboardServer(t, "/dashboard")
+
+			endpoint, err := parseEndpointURL(server.URL + suffix)
+			if err != nil {
+				t.Fatalf("parseEndpointURL() error = %v", err)
+			}
+
+			client := &CephAPIClient{}
+			if err := client.Configure(t.Context(), []*url.URL{endpoint}, "admin", "password", ""); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+
+			bucket, err := client.RGWGetBucket(t.Context(), "test-bucket")
+			if err != nil {
+				t.Fatalf("RGWGetBucket() error = %v", err)
+			}
+			if bucket.Bucket != "test-bucket" {
+				t.Errorf("RGWGetBucket() bucket = %q, want %q", bucket.Bucket, "test-bucket")
+			}
+
+			expected := []string{
+				"GET /dashboard",
+				"POST /dashboard/api/auth",
+				"GET /dashboard/api/rgw/bucket/test-bucket",
+			}
+			if strings.Join(*paths, "\n") != strings.Join(expected, "\n") {
+				t.Errorf("requested paths = %v, want %v", *paths, expected)
+			}
+		})
+	}
+}
+
+func TestCephAPIClientStandbyRedirect(t *testing.T) {
+	active, paths := newPrefixedDashboardServer(t, "/dashboard")
+
+	var standbyPaths []string
+	standby := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		standbyPaths = append(standbyPaths, r.Method+" "+r.URL.Path)
+		http.Redirect(w, r, active.URL+"/dashboard/", http.StatusSeeOther)
+	}))
+	defer standby.Close()
+
+	standbyEndpoint, err := parseEndpointURL(standby.URL + "/dashboard")
+	if err != nil {
+		t.Fatalf("parseEndpointURL() error = %v", err)
+	}
+
+	client := &CephAPIClient{
+		client: &http.Client{Transport: &standbyRedirectTransport{base: http.DefaultTransport}},
+	}
+	if err := client.Configure(t.Context(), []*url.URL{standbyEndpoint}, "admin", "password", ""); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if expected := active.URL + "/dashboard"; client.endpoint.String() != expected {
+		t.Errorf("endpoint = %q, want the active mgr %q", client.endpoint, expected)
+	}
+
+	// A failover after Configure leaves the client pointing at a standby.
+	client.endpoint = standbyEndpoint
+	if _, err := client.RGWGetBucket(t.Context(), "test-bucket"); err != nil {
+		t.Fatalf("RGWGetBucket() through the standby error = %v", err)
+	}
+
+	expected := []string{
+		"GET /dashboard/",
+		"POST /dashboard/api/auth",
+		"GET /dashboard/api/rgw/bucket/test-bucket",
+	}
+	if strings.Join(*paths, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("active mgr paths = %v, want %v", *paths, expected)
+	}
+	expected = []string{
+		"GET /dashboard",
+		"GET /dashboard/api/rgw/bucket/test-bucket",
+	}
+	if strings.Join(standbyPaths, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("standby mgr paths = %v, want %v", standbyPaths, expected)
+	}
+}
+
+func TestStandbyRedirectTarget(t *testing.T) {
+	tests := map[string]struct {
+		request  string
+		location string
+		expected string
+	}{
+		"api path": {
+			request:  "https://mgr-b:8443/api/pool?stats=true",
+			location: "https://mgr-a:8443/",
+			expected: "https://mgr-a:8443/api/pool?stats=true",
+		},
+		"url prefix": {
+			request:  "https://mgr-b:8443/dashboard/api/pool",
+			location: "https://mgr-a:8443/dashboard/",
+			expected: "https://mgr-a:8443/dashboard/api/pool",
+		},
+		"dashboard root": {
+			request:  "https://mgr-b:8443",
+			location: "http://mgr-a:8080/",
+			expected: "http://mgr-a:8080/",
+		},
+		"same host": {
+			request:  "https://mgr-a:8443/api/pool",
+			location: "/login",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			requestURL, err := url.Parse(tt.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			target, ok := standbyRedirectTarget(requestURL, tt.location)
+			if tt.expected == "" {
+				if ok {
+					t.Errorf("standbyRedirectTarget() = %q, want no redirect", target)
+				}
+				return
+			}
+			if !ok || target.String() != tt.expected {
+				t.Errorf("standbyRedirectTarget() = %v, %v, want %q", target, ok, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJoinPathSegment(t *testing.T) {
+	base, err := url.Parse("https://ceph.example.com/dashboard/api/rgw/bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"test-bucket":      "https://ceph.example.com/dashboard/api/rgw/bucket/test-bucket",
+		"acme/test-bucket": "https://ceph.example.com/dashboard/api/rgw/bucket/acme%2Ftest-bucket",
+		"acme$test-user":   "https://ceph.example.com/dashboard/api/rgw/bucket/acme$test-user",
+	}
+	for segment, want := range tests {
+		if got := joinPathSegment(base, segment).String(); got != want {
+			t.Errorf("joinPathSegment(%q) = %q, want %q", segment, got, want)
+		}
+	}
+}
+
+func TestRGWUserID(t *testing.T) {
+	if got := rgwUserID("", "test-user"); got != "test-user" {
+		t.Errorf("rgwUserID() = %q, want %q", got, "test-user")
+	}
+	if got := rgwUserID("acme", "test-user"); got != "acme$test-user" {
+		t.Errorf("rgwUserID() = %q, want %q", got, "acme$test-user")
+	}
+	if tenant, uid := splitRGWUserID("acme$test-user:swift"); tenant != "acme" || uid != "test-user:swift" {
+		t.Errorf("splitRGWUserID() = %q, %q", tenant, uid)
+	}
+	if tenant, uid := splitRGWUserID("test-user"); tenant != "" || uid != "test-user" {
+		t.Errorf("splitRGWUserID() = %q, %q", tenant, uid)
+	}
+}
+
+type countingRoundTripper struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.inFlight++
+	rt.peak = max(rt.peak, rt.inFlight)
+	rt.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	rt.mu.Lock()
+	rt.inFlight--
+	rt.mu.Unlock()
+
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestConcurrencyLimitedTransport(t *testing.T) {
+	base := &countingRoundTripper{}
+	client := &http.Client{Transport: newConcurrencyLimitedTransport(base, 2)}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get("http://ceph.example.com/api/health/minimal")
+			if err != nil {
+				t.Errorf("Get() error = %v", err)
+				return
+			}
+			resp.Body.Close() //nolint:errcheck
+		}()
+	}
+	wg.Wait()
+
+	if base.peak > 2 {
+		t.Errorf("peak concurrent requests = %d, want at most 2", base.peak)
+	}
+}
+
+func TestHeaderTransport(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &headerTransport{
+		base: http.DefaultTransport,
+		headers: map[string]string{
+			requestIDHeader:   "run-1234",
+			"X-Terraform-Env": "production",
+		},
+	}}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/health/minimal", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "application/vnd.ceph.api.v1.0+json")
+
+	logRequest := logAPIRequest(ctx, req)
+	resp, err := client.Do(req)
+	logRequest(resp, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close() //nolint:errcheck
+
+	if got := received.Get(requestIDHeader); got != "run-1234" {
+		t.Errorf("%s = %q, want %q", requestIDHeader, got, "run-1234")
+	}
+	if got := received.Get("X-Terraform-Env"); got != "production" {
+		t.Errorf("X-Terraform-Env = %q, want %q", got, "production")
+	}
+	if got := received.Get("Accept"); got != "application/vnd.ceph.api.v1.0+json" {
+		t.Errorf("Accept = %q, want the header set by the caller", got)
+	}
+	if req.Header.Get(requestIDHeader) != "" {
+		t.Error("headerTransport modified the caller's request")
+	}
+	if !strings.Contains(output.String(), `"request_id":"run-1234"`) {
+		t.Errorf("request log does not include the request ID: %s", output.String())
+	}
+}
+
+func TestCephAPIClientDoRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q, want the client token", got)
+		}
+
+		switch r.URL.Path {
+		case "/api/pool":
+			if got := r.Header.Get("Accept"); got != "application/vnd.ceph.api.v2.0+json" {
+				t.Errorf("Accept = %q, want version 2.0", got)
+			}
+			var req map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req["pool"] != "rbd" {
+				t.Errorf("request body = %v, %v, want pool rbd", req, err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"name": "rbd"}`))
+		case "/api/cephfs/subvolume/cephfs/info":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"detail": "[errno -2] subvolume 'test' does not exist"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"detail": "bad request"}`))
+		}
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &CephAPIClient{client: server.Client(), endpoint: endpoint, token: "test-token"}
+
+	type poolResult struct {
+		Name string `json:"name"`
+	}
+	result, err := do[poolResult](t.Context(), client, "POST", server.URL+"/api/pool", "2.0", map[string]string{"pool": "rbd"}, http.StatusOK, http.StatusAccepted)
+	if err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+	if result.Name != "rbd" {
+		t.Errorf("do() decoded name = %q, want %q", result.Name, "rbd")
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	if _, err := do[poolResult](withoutResponseTrace(ctx), client, "POST", server.URL+"/api/pool", "2.0", map[string]string{"pool": "rbd"}, http.StatusAccepted); err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+	if strings.Contains(output.String(), "Ceph API response body") {
+		t.Errorf("do() traced the response body with withoutResponseTrace: %s", output.String())
+	}
+
+	err = client.doRequest(t.Context(), "POST", server.URL+"/api/pool", "2.0", map[string]string{"pool": "rbd"})
+	var apiErr *CephAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusAccepted {
+		t.Errorf("doRequest() with only 200 expected error = %v, want a 202 CephAPIError", err)
+	}
+
+	_, err = do[json.RawMessage](t.Context(), client, "GET", server.URL+"/api/unknown", "2.0", nil)
+	if !errors.As(err, &apiErr) || apiErr.Detail != "bad request" {
+		t.Errorf("do() error = %v, want the dashboard detail", err)
+	}
+
+	err = client.doCephFSRequest(t.Context(), "GET", server.URL+"/api/cephfs/subvolume/cephfs/info", nil)
+	if !isCephAPINotFound(err) {
+		t.Errorf("doCephFSRequest() error = %v, want not found", err)
+	}
+}
+
+func TestCephAPIClientRGWDaemon(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.RequestURI())
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL + "/dashboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &CephAPIClient{client: server.Client(), endpoint: endpoint, token: "test-token", rgwDaemon: "rgw.zone-a"}
+
+	for _, tc := range []struct {
+		ctx  context.Context
+		path string
+		want string
+	}{
+		{t.Context(), "/dashboard/api/rgw/bucket/b", "/dashboard/api/rgw/bucket/b?daemon_name=rgw.zone-a"},
+		{withRGWDaemon(t.Context(), "rgw.zone-b"), "/dashboard/api/rgw/user/u?stats=true", "/dashboard/api/rgw/user/u?daemon_name=rgw.zone-b&stats=true"},
+		{withRGWDaemon(t.Context(), ""), "/dashboard/api/rgw/user", "/dashboard/api/rgw/user?daemon_name=rgw.zone-a"},
+		{t.Context(), "/dashboard/api/rgw/user?daemon_name=rgw.zone-c", "/dashboard/api/rgw/user?daemon_name=rgw.zone-c"},
+		{withRGWDaemon(t.Context(), "rgw.zone-b"), "/dashboard/api/pool", "/dashboard/api/pool"},
+	} {
+		mu.Lock()
+		requests = nil
+		mu.Unlock()
+
+		if err := client.doRequest(tc.ctx, "GET", server.URL+tc.path, "1.0", nil); err != nil {
+			t.Fatalf("doRequest(%s) error = %v", tc.path, err)
+		}
+		if len(requests) != 1 || requests[0] != tc.want {
+			t.Errorf("doRequest(%s) sent %v, want %s", tc.path, requests, tc.want)
+		}
+	}
+}
+
+func TestNewCephAPIError(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		wantComponent string
+		wantDetail    string
+		wantNotFound  bool
+		wantRetryable bool
+		wantMessage   string
+	}{
+		{
+			name:          "dashboard error",
+			statusCode:    http.StatusNotFound,
+			body:          `{"detail": "User does not exist", "code": "NoSuchUser", "component": "rgw"}`,
+			wantComponent: "rgw",
+			wantDetail:    "User does not exist",
+			wantNotFound:  true,
+			wantMessage:   "ceph API returned status 404: User does not exist",
+		},
+		{
+			name:          "plain text body",
+			statusCode:    http.StatusServiceUnavailable,
+			body:          "service unavailable\n",
+			wantDetail:    "service unavailable",
+			wantRetryable: true,
+			wantMessage:   "ceph API returned status 503: service unavailable",
+		},
+		{
+			name:        "empty body",
+			statusCode:  http.StatusBadRequest,
+			wantMessage: "ceph API returned status 400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := newCephAPIError(tt.statusCode, []byte(tt.body))
+			if apiErr.Component != tt.wantComponent {
+				t.Errorf("Component = %q, want %q", apiErr.Component, tt.wantComponent)
+			}
+			if apiErr.Detail != tt.wantDetail {
+				t.Errorf("Detail = %q, want %q", apiErr.Detail, tt.wantDetail)
+			}
+			if apiErr.NotFound() != tt.wantNotFound {
+				t.Errorf("NotFound() = %v, want %v", apiErr.NotFound(), tt.wantNotFound)
+			}
+			if apiErr.Retryable() != tt.wantRetryable {
+				t.Errorf("Retryable() = %v, want %v", apiErr.Retryable(), tt.wantRetryable)
+			}
+			if apiErr.Error() != tt.wantMessage {
+				t.Errorf("Error() = %q, want %q", apiErr.Error(), tt.wantMessage)
+			}
+
+			wrapped := fmt.Errorf("unable to read: %w", apiErr)
+			if isCephAPINotFound(wrapped) != tt.wantNotFound {
+				t.Errorf("isCephAPINotFound() = %v, want %v", isCephAPINotFound(wrapped), tt.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts[r.Method]++
+		attempt := attempts[r.Method]
+		mu.Unlock()
+
+		if attempt < 3 {
+			if r.URL.Path == "/throttled" {
+				w.WriteHeader(http.StatusTooManyRequests)
+			} else {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &retryTransport{
+			base:       http.DefaultTransport,
+			maxRetries: defaultMaxRetries,
+			backoff:    time.Millisecond,
+		},
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK || attempts["GET"] != 3 {
+		t.Errorf("GET status = %d after %d attempts, want 200 after 3", resp.StatusCode, attempts["GET"])
+	}
+
+	resp, err = client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusServiceUnavailable || attempts["POST"] != 1 {
+		t.Errorf("POST status = %d after %d attempts, want 503 after 1", resp.StatusCode, attempts["POST"])
+	}
+
+	req, err := http.NewRequestWithContext(t.Context(), "DELETE", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusServiceUnavailable || attempts["DELETE"] != 1 {
+		t.Errorf("DELETE status = %d after %d attempts, want 503 after 1", resp.StatusCode, attempts["DELETE"])
+	}
+
+	req, err = http.NewRequestWithContext(t.Context(), "PUT", server.URL+"/throttled", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK || attempts["PUT"] != 3 {
+		t.Errorf("throttled PUT status = %d after %d attempts, want 200 after 3", resp.StatusCode, attempts["PUT"])
+	}
+
+	req, err = http.NewRequestWithContext(withoutRetries(t.Context()), "GET", server.URL+"/throttled", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	attempts["GET"] = 0
+	mu.Unlock()
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusTooManyRequests || attempts["GET"] != 1 {
+		t.Errorf("GET without retries status = %d after %d attempts, want 429 after 1", resp.StatusCode, attempts["GET"])
+	}
+}
+
+func TestCephAPIErasureCodeProfileJSON(t *testing.T) {
+	var profile CephAPIErasureCodeProfile
+	err := json.Unmarshal([]byte(`{"name": "lrc", "k": 4, "m": 2, "l": "3", "plugin": "lrc", "crush-failure-domain": "host", "crush-locality": "rack"}`), &profile)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if profile.K != 4 || profile.L != "3" || profile.CrushFailureDomain != "host" {
+		t.Errorf("Unmarshal() = %+v", profile)
+	}
+	if len(profile.Params) != 6 || profile.Params["k"] != "4" || profile.Params["crush-locality"] != "rack" {
+		t.Errorf("Unmarshal() params = %v, want every parameter but the name", profile.Params)
+	}
+
+	d := "3"
+	data, err := json.Marshal(CephAPIErasureCodeProfileCreateRequest{
+		Name:   "clay",
+		D:      &d,
+		Params: map[string]string{"w": "16", "d": "5"},
+	})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got, want := string(data), `{"d":"3","name":"clay","w":"16"}`; got != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+}
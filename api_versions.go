@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	cephAPIMediaTypePattern = regexp.MustCompile(`^application/vnd\.ceph\.api\.v(\d+)\.(\d+)\+json$`)
+
+	// e.g. "Incorrect version: endpoint is '2.0', client requested '1.0'"
+	cephAPIVersionMismatchPattern = regexp.MustCompile(`endpoint is '(\d+)\.(\d+)'`)
+
+	cephAPIVersionPattern = regexp.MustCompile(`^\d+\.\d+$`)
+)
+
+// apiVersionTransport chooses the dashboard API version each request asks
+// for. Every client method requests the version it was written against;
+// provider overrides replace it for matching paths, and when the dashboard
+// rejects a minor version it does not serve, the request is resent with the
+// version the dashboard reports and that version is remembered for the path.
+// A different major version changes the request or response schema, so it
+// is reported rather than negotiated.
+type apiVersionTransport struct {
+	base http.RoundTripper
+
+	// overrides maps API path prefixes such as "/api/crush_rule" to versions
+	// such as "2.0". The longest matching prefix wins.
+	overrides map[string]string
+
+	// negotiated maps host and API path to the version the dashboard serves.
+	negotiated sync.Map
+}
+
+func newAPIVersionTransport(base http.RoundTripper, overrides map[string]string) *apiVersionTransport {
+	return &apiVersionTransport{
+		base:      base,
+		overrides: overrides,
+	}
+}
+
+func (t *apiVersionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	match := cephAPIMediaTypePattern.FindStringSubmatch(req.Header.Get("Accept"))
+	if match == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	apiPath := cephAPIPath(req.URL.Path)
+	negotiatedKey := req.URL.Host + apiPath
+	requested := match[1] + "." + match[2]
+	if version, ok := t.override(apiPath); ok {
+		requested = version
+	}
+	if version, ok := t.negotiated.Load(negotiatedKey); ok {
+		requested = version.(string)
+	}
+	if requested != match[1]+"."+match[2] {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept", cephAPIMediaType(requested))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnsupportedMediaType {
+		return resp, err
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close() //nolint:errcheck
+	served, ok := parseCephAPIVersionMismatch(body)
+	if !ok {
+		resp.Body = io.NopCloser(strings.NewReader(string(body)))
+		return resp, nil
+	}
+
+	if strings.Split(served, ".")[0] != strings.Split(requested, ".")[0] {
+		return nil, fmt.Errorf("the Ceph API serves %s as version %s but the provider requested %s, which this Ceph release does not support; if version %s is compatible, set api_versions = { %q = %q } in the provider configuration", apiPath, served, requested, served, apiPath, served)
+	}
+
+	if req.Body != nil && req.GetBody == nil {
+		resp.Body = io.NopCloser(strings.NewReader(string(body)))
+		return resp, nil
+	}
+
+	tflog.Debug(req.Context(), "Negotiated Ceph API version", map[string]any{
+		"path":      apiPath,
+		"requested": requested,
+		"served":    served,
+	})
+	t.negotiated.Store(negotiatedKey, served)
+
+	retry := req.Clone(req.Context())
+	retry.Header.Set("Accept", cephAPIMediaType(served))
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(retry)
+}
+
+// override returns the configured version for the longest prefix of apiPath.
+func (t *apiVersionTransport) override(apiPath string) (string, bool) {
+	var version string
+	longest := -1
+	for prefix, v := range t.overrides {
+		if len(prefix) > longest && (apiPath == prefix || strings.HasPrefix(apiPath, strings.TrimSuffix(prefix, "/")+"/")) {
+			version = v
+			longest = len(prefix)
+		}
+	}
+	return version, longest >= 0
+}
+
+func cephAPIMediaType(version string) string {
+	return "application/vnd.ceph.api.v" + version + "+json"
+}
+
+// cephAPIPath strips any URL prefix the dashboard is served under, e.g.
+// "/dashboard/api/pool" becomes "/api/pool".
+func cephAPIPath(urlPath string) string {
+	if i := strings.Index(urlPath, "/api/"); i >= 0 {
+		return urlPath[i:]
+	}
+	return urlPath
+}
+
+// parseCephAPIVersionMismatch returns the version the dashboard serves from
+// the body of a 415 response to a request for a version it does not serve.
+func parseCephAPIVersionMismatch(body []byte) (string, bool) {
+	detail := string(body)
+	var errBody struct {
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &errBody); err == nil && errBody.Detail != "" {
+		detail = errBody.Detail
+	}
+
+	match := cephAPIVersionMismatchPattern.FindStringSubmatch(detail)
+	if match == nil {
+		return "", false
+	}
+	return match[1] + "." + match[2], true
+}
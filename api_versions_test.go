@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newVersionedDashboardServer serves each path at one API version and
+// rejects other versions the way the dashboard does.
+func newVersionedDashboardServer(t *testing.T, versions map[string]string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var rejected atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served := versions[cephAPIPath(r.URL.Path)]
+		requested := strings.TrimSuffix(strings.TrimPrefix(r.Header.Get("Accept"), "application/vnd.ceph.api.v"), "+json")
+		servedMajor, servedMinor, _ := strings.Cut(served, ".")
+		requestedMajor, requestedMinor, _ := strings.Cut(requested, ".")
+		if servedMajor != requestedMajor || requestedMinor > servedMinor {
+			rejected.Add(1)
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"status": "415 Unsupported Media Type",
+				"detail": fmt.Sprintf("Incorrect version: endpoint is '%s', client requested '%s'", served, requested),
+			})
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &rejected
+}
+
+func TestAPIVersionTransport(t *testing.T) {
+	server, rejected := newVersionedDashboardServer(t, map[string]string{
+		"/api/old": "1.0",
+		"/api/new": "2.0",
+	})
+
+	do := func(t *testing.T, client *http.Client, method, path, version string) (*http.Response, error) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(t.Context(), method, server.URL+"/dashboard"+path, bytes.NewBufferString(`{"a":1}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept", cephAPIMediaType(version))
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close() //nolint:errcheck
+		}
+		return resp, err
+	}
+
+	t.Run("negotiates an older minor version", func(t *testing.T) {
+		rejected.Store(0)
+		client := &http.Client{Transport: newAPIVersionTransport(http.DefaultTransport, nil)}
+
+		for range 2 {
+			resp, err := do(t, client, http.MethodPost, "/api/old", "1.1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+		if got := rejected.Load(); got != 1 {
+			t.Errorf("dashboard rejected %d requests, want 1", got)
+		}
+	})
+
+	t.Run("reports another major version", func(t *testing.T) {
+		client := &http.Client{Transport: newAPIVersionTransport(http.DefaultTransport, nil)}
+
+		_, err := do(t, client, http.MethodGet, "/api/new", "1.0")
+		if err == nil || !strings.Contains(err.Error(), `set api_versions = { "/api/new" = "2.0" }`) {
+			t.Errorf("error = %v, want an api_versions hint", err)
+		}
+	})
+
+	t.Run("applies overrides", func(t *testing.T) {
+		client := &http.Client{Transport: newAPIVersionTransport(http.DefaultTransport, map[string]string{
+			"/api/":    "1.0",
+			"/api/new": "2.0",
+		})}
+
+		for _, path := range []string{"/api/new", "/api/old"} {
+			resp, err := do(t, client, http.MethodGet, path, "1.0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("%s status = %d, want %d", path, resp.StatusCode, http.StatusOK)
+			}
+		}
+	})
+}
+
+func TestParseCephAPIVersionMismatch(t *testing.T) {
+	tests := map[string]string{
+		`{"detail": "Incorrect version: endpoint is '2.0', client requested '1.0'", "code": "415"}`: "2.0",
+		`Incorrect version: endpoint is '1.3', client requested '1.4'`:                              "1.3",
+		`{"detail": "Unsupported Media Type"}`:                                                      "",
+	}
+	for body, expected := range tests {
+		got, ok := parseCephAPIVersionMismatch([]byte(body))
+		if got != expected || ok != (expected != "") {
+			t.Errorf("parseCephAPIVersionMismatch(%q) = %q, %v, want %q", body, got, ok, expected)
+		}
+	}
+}
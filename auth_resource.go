@@ -3,19 +3,27 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
 	_ resource.Resource                = &AuthResource{}
 	_ resource.ResourceWithImportState = &AuthResource{}
+	_ resource.ResourceWithIdentity    = &AuthResource{}
+	_ resource.ResourceWithModifyPlan  = &AuthResource{}
 )
 
 func newAuthResource() resource.Resource {
@@ -27,10 +35,18 @@ type AuthResource struct {
 }
 
 type AuthResourceModel struct {
-	Entity  types.String `tfsdk:"entity"`
-	Caps    types.Map    `tfsdk:"caps"`
-	Key     types.String `tfsdk:"key"`
-	Keyring types.String `tfsdk:"keyring"`
+	Entity       types.String `tfsdk:"entity"`
+	Caps         types.Map    `tfsdk:"caps"`
+	Key          types.String `tfsdk:"key"`
+	KeyWO        types.String `tfsdk:"key_wo"`
+	KeyWOVersion types.Int64  `tfsdk:"key_wo_version"`
+	Keyring      types.String `tfsdk:"keyring"`
+
+	AllowDangerousEntities types.Bool `tfsdk:"allow_dangerous_entities"`
+}
+
+type AuthResourceIdentityModel struct {
+	Entity types.String `tfsdk:"entity"`
 }
 
 func (r *AuthResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,18 +68,56 @@ func (r *AuthResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				ElementType:         types.StringType,
 				MarkdownDescription: "The caps of the entity",
 				Required:            true,
+				Validators: []validator.Map{
+					CephCapsSyntax(),
+				},
 			},
 			"key": resourceSchema.StringAttribute{
-				MarkdownDescription: "The cephx key of the entity. If not specified, Ceph will generate a random key.",
+				MarkdownDescription: "The cephx key of the entity. If not specified, Ceph will generate a random key. The key is stored in state so it can be used in outputs; use `key_wo` to keep it out of state.",
 				Optional:            true,
 				Computed:            true,
 				Sensitive:           true,
 			},
+			"key_wo": resourceSchema.StringAttribute{
+				MarkdownDescription: "The cephx key of the entity, as a write-only attribute that is never stored in state. When set, `key` and `keyring` are left empty. Requires Terraform 1.11 or later and `key_wo_version`.",
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("key")),
+					stringvalidator.AlsoRequires(path.MatchRoot("key_wo_version")),
+				},
+			},
+			"key_wo_version": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The version of `key_wo`. Change it to rotate the entity to the new key.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("key_wo")),
+				},
+			},
 			"keyring": resourceSchema.StringAttribute{
 				MarkdownDescription: "The complete cephx keyring as JSON",
 				Computed:            true,
 				Sensitive:           true,
 			},
+			"allow_dangerous_entities": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether to allow creating, changing or deleting `client.admin` and the daemon entities (`mon.*`, `mgr.*`, `mds.*` and `osd.*`), which the cluster itself relies on. " +
+					"Without it, plans that would change such an entity fail, so that a mistake such as a bad `for_each` cannot lock out the cluster. Importing and reading them is always allowed. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *AuthResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"entity": identityschema.StringAttribute{
+				Description:       "The entity name (i.e.: client.admin)",
+				RequiredForImport: true,
+			},
 		},
 	}
 }
@@ -86,6 +140,48 @@ func (r *AuthResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+func (r *AuthResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan, state AuthResourceModel
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if req.Plan.Raw.IsNull() {
+		if !resp.Diagnostics.HasError() && isDangerousCephEntity(state.Entity.ValueString()) && !state.AllowDangerousEntities.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Dangerous Entity",
+				fmt.Sprintf("Refusing to delete %s, which the cluster relies on. Set allow_dangerous_entities to true and apply before destroying it, or remove it from the state with terraform state rm.", state.Entity.ValueString()),
+			)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.AllowDangerousEntities.ValueBool() {
+		return
+	}
+
+	entity := plan.Entity.ValueString()
+	if !req.State.Raw.IsNull() {
+		// Update only changes the caps and the write-only key, so a plan that
+		// only flips allow_dangerous_entities changes nothing in Ceph.
+		if plan.Entity.Equal(state.Entity) && plan.Caps.Equal(state.Caps) && plan.KeyWOVersion.Equal(state.KeyWOVersion) {
+			return
+		}
+		if isDangerousCephEntity(state.Entity.ValueString()) {
+			entity = state.Entity.ValueString()
+		}
+	}
+
+	if isDangerousCephEntity(entity) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("entity"),
+			"Dangerous Entity",
+			fmt.Sprintf("Refusing to change %s, which the cluster relies on. Set allow_dangerous_entities to true to manage it.", entity),
+		)
+	}
+}
+
 func (r *AuthResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data AuthResourceModel
 
@@ -103,6 +199,16 @@ func (r *AuthResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	key := data.Key.ValueString()
+
+	var keyWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("key_wo"), &keyWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !keyWO.IsNull() && !keyWO.IsUnknown() {
+		key = keyWO.ValueString()
+	}
+
 	var err error
 	if key != "" {
 		users := []CephUser{
@@ -131,6 +237,7 @@ func (r *AuthResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, AuthResourceIdentityModel{Entity: data.Entity})...)
 }
 
 func (r *AuthResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -143,12 +250,26 @@ func (r *AuthResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	entity := data.Entity.ValueString()
-	updateAuthModelFromCephExport(ctx, r.client, entity, &data, &resp.Diagnostics)
+	keyringRaw, err := r.client.ClusterExportUser(ctx, entity)
+	if isCephAPINotFound(err) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Entity %s", entity))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to export user from Ceph API: %s", err),
+		)
+		return
+	}
+
+	updateAuthModelFromKeyring(ctx, entity, keyringRaw, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, AuthResourceIdentityModel{Entity: data.Entity})...)
 }
 
 func (r *AuthResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -167,7 +288,29 @@ func (r *AuthResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	err := r.client.ClusterUpdateUser(ctx, entity, caps)
+	var stateKeyWOVersion types.Int64
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("key_wo_version"), &stateKeyWOVersion)...)
+
+	var keyWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("key_wo"), &keyWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var err error
+	if !data.KeyWOVersion.Equal(stateKeyWOVersion) && !keyWO.IsNull() && !keyWO.IsUnknown() {
+		// Importing an existing entity replaces both its key and its caps.
+		importData := formatCephKeyring([]CephUser{
+			{
+				Entity: entity,
+				Key:    keyWO.ValueString(),
+				Caps:   caps,
+			},
+		})
+		err = r.client.ClusterImportUser(ctx, importData)
+	} else {
+		err = r.client.ClusterUpdateUser(ctx, entity, caps)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"API Request Error",
@@ -182,6 +325,7 @@ func (r *AuthResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, AuthResourceIdentityModel{Entity: data.Entity})...)
 }
 
 func (r *AuthResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -195,7 +339,7 @@ func (r *AuthResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 	entity := data.Entity.ValueString()
 	err := r.client.ClusterDeleteUser(ctx, entity)
-	if err != nil {
+	if err != nil && !isCephAPINotFound(err) {
 		resp.Diagnostics.AddError(
 			"API Request Error",
 			fmt.Sprintf("Unable to delete user from Ceph API: %s", err),
@@ -205,7 +349,59 @@ func (r *AuthResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *AuthResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("entity"), req, resp)
+	entity := req.ID
+	if req.ID == "" && req.Identity != nil {
+		var identity AuthResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		entity = identity.Entity.ValueString()
+	}
+
+	// Accept "foo" as well as "client.foo", since most entities are clients.
+	entity = normalizeCephEntity(entity)
+
+	// Read removes missing entities from state, which Terraform would only
+	// report as a missing object, so check here that the entity exists.
+	if _, err := r.client.ClusterExportUser(ctx, entity); err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to export user from Ceph API: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entity"), entity)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("allow_dangerous_entities"), false)...)
+	resp.Diagnostics.Append(resp.Identity.SetAttribute(ctx, path.Root("entity"), entity)...)
+}
+
+// normalizeCephEntity adds the "client." prefix to an entity name without a
+// known entity type, e.g. "foo" or "rbd.mirror".
+func normalizeCephEntity(entity string) string {
+	if entityType, _, ok := strings.Cut(entity, "."); ok {
+		switch entityType {
+		case "client", "mds", "mgr", "mon", "osd":
+			return entity
+		}
+	}
+	return "client." + entity
+}
+
+// isDangerousCephEntity reports whether the cluster relies on entity, so that
+// changing or deleting it can lock out the cluster or its daemons.
+func isDangerousCephEntity(entity string) bool {
+	if entity == "client.admin" {
+		return true
+	}
+
+	entityType, _, _ := strings.Cut(entity, ".")
+	switch entityType {
+	case "mds", "mgr", "mon", "osd":
+		return true
+	}
+	return false
 }
 
 func updateAuthModelFromCephExport(ctx context.Context, client *CephAPIClient, entity string, data *AuthResourceModel, diagnostics *diag.Diagnostics) {
@@ -218,6 +414,13 @@ func updateAuthModelFromCephExport(ctx context.Context, client *CephAPIClient, e
 		return
 	}
 
+	updateAuthModelFromKeyring(ctx, entity, keyringRaw, data, diagnostics)
+}
+
+// updateAuthModelFromKeyring sets the caps and key of data from an exported
+// keyring. Caps changed outside Terraform, e.g. with `ceph auth caps`, show
+// up as a difference to the configuration that the next apply reverts.
+func updateAuthModelFromKeyring(ctx context.Context, entity, keyringRaw string, data *AuthResourceModel, diagnostics *diag.Diagnostics) {
 	keyringUsers, err := parseCephKeyring(keyringRaw)
 	if err != nil {
 		diagnostics.AddError(
@@ -242,6 +445,11 @@ func updateAuthModelFromCephExport(ctx context.Context, client *CephAPIClient, e
 	data.Caps = cephCapsToMapValue(ctx, keyringUser.Caps, diagnostics)
 	data.Key = types.StringValue(keyringUser.Key)
 	data.Keyring = types.StringValue(keyringRaw)
+	if !data.KeyWOVersion.IsNull() {
+		data.Key = types.StringNull()
+		data.Keyring = types.StringNull()
+	}
+	data.KeyWO = types.StringNull()
 }
 
 func mapAttrToCephCaps(ctx context.Context, caps types.Map, diags *diag.Diagnostics) (CephCaps, bool) {
@@ -3,15 +3,18 @@ package main
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func testAccProviderConfig() config.Variables {
@@ -182,6 +185,33 @@ func TestAccCephAuthResource_invalidCapType(t *testing.T) {
 	})
 }
 
+func TestAccCephAuthResource_invalidCapSyntax(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testEntity := acctest.RandomWithPrefix("client.test-invalid")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_auth" "invalid" {
+					  entity = %q
+					  caps = {
+					    mon = "allow r"
+					    osd = "alow rw pool=foo"
+					  }
+					}
+				`, testEntity),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?i)invalid capabilities`),
+			},
+		},
+	})
+}
+
 func TestAccCephAuthResource_invalidCapTypeOnUpdate(t *testing.T) {
 	detachLogs := cephDaemonLogs.AttachTestFunction(t)
 	defer detachLogs()
@@ -263,6 +293,41 @@ func TestAccCephAuthResourceImport(t *testing.T) {
 	})
 }
 
+func TestAccCephAuthResourceImport_withoutClientPrefix(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testEntity := acctest.RandomWithPrefix("client.test-import-prefix")
+	config := testAccProviderConfigBlock + fmt.Sprintf(`
+		resource "ceph_auth" "bar" {
+		  entity = %q
+		  caps = {
+		    mon = "allow r"
+		  }
+		}
+	`, testEntity)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephAuthDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          config,
+			},
+			{
+				ConfigVariables:                      testAccProviderConfig(),
+				Config:                               config,
+				ResourceName:                         "ceph_auth.bar",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "entity",
+				ImportStateId:                        strings.TrimPrefix(testEntity, "client."),
+			},
+		},
+	})
+}
+
 func TestAccCephAuthResourceImport_nonExistent(t *testing.T) {
 	detachLogs := cephDaemonLogs.AttachTestFunction(t)
 	defer detachLogs()
@@ -346,6 +411,21 @@ func checkCephAuthHasCaps(t *testing.T, entity string, expectedCaps map[string]s
 	}
 }
 
+func checkCephAuthLacksCap(t *testing.T, entity string, capType string) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		authInfo, err := cephTestClusterCLI.AuthGet(t.Context(), entity)
+		if err != nil {
+			return fmt.Errorf("auth entity %s does not exist: %w", entity, err)
+		}
+
+		if actualCap, ok := authInfo.Caps[capType]; ok {
+			return fmt.Errorf("unexpected cap %s for entity %s: %q", capType, entity, actualCap)
+		}
+		return nil
+	}
+}
+
 func checkCephAuthHasKey(t *testing.T, entity string, expectedKey string) resource.TestCheckFunc {
 	t.Helper()
 	return func(s *terraform.State) error {
@@ -461,12 +541,236 @@ func TestAccCephAuthResource_capsDriftDetection(t *testing.T) {
 					  }
 					}
 				`, testEntity),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_auth.test", plancheck.ResourceActionUpdate),
+					},
+				},
 				Check: resource.ComposeAggregateTestCheckFunc(
 					checkCephAuthExists(t, testEntity),
 					checkCephAuthHasCaps(t, testEntity, map[string]string{
 						"mon": "allow r",
 						"osd": "allow rw pool=original",
 					}),
+					checkCephAuthLacksCap(t, testEntity, "mgr"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCephAuthResource_deletedOutOfBand(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testEntity := acctest.RandomWithPrefix("client.test-deleted")
+	config := testAccProviderConfigBlock + fmt.Sprintf(`
+		resource "ceph_auth" "test" {
+		  entity = %q
+		  caps = {
+		    mon = "allow r"
+		  }
+		}
+	`, testEntity)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephAuthDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          config,
+				Check:           checkCephAuthExists(t, testEntity),
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.AuthDelete(t.Context(), testEntity); err != nil {
+						t.Fatalf("Failed to delete entity out of band: %v", err)
+					}
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config:          config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_auth.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkCephAuthExists(t, testEntity),
+			},
+		},
+	})
+}
+
+func TestNormalizeCephEntity(t *testing.T) {
+	tests := map[string]string{
+		"foo":                 "client.foo",
+		"client.foo":          "client.foo",
+		"rbd.mirror":          "client.rbd.mirror",
+		"client.rbd-mirror.a": "client.rbd-mirror.a",
+		"osd.0":               "osd.0",
+		"mgr.ceph-1.abcdef":   "mgr.ceph-1.abcdef",
+		"mon.":                "mon.",
+	}
+	for entity, want := range tests {
+		if got := normalizeCephEntity(entity); got != want {
+			t.Errorf("normalizeCephEntity(%q) = %q, want %q", entity, got, want)
+		}
+	}
+}
+
+func TestIsDangerousCephEntity(t *testing.T) {
+	tests := map[string]bool{
+		"client.admin":      true,
+		"client.admins":     false,
+		"client.foo":        false,
+		"osd.0":             true,
+		"mgr.ceph-1.abcdef": true,
+		"mds.a":             true,
+		"mon.":              true,
+	}
+	for entity, want := range tests {
+		if got := isDangerousCephEntity(entity); got != want {
+			t.Errorf("isDangerousCephEntity(%q) = %v, want %v", entity, got, want)
+		}
+	}
+}
+
+func TestAccCephAuthResource_dangerousEntity(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	adminCaps := map[string]string{
+		"mon": "allow *",
+		"mds": "allow *",
+		"osd": "allow *",
+		"mgr": "allow *",
+	}
+
+	adminConfig := func(monCap string) string {
+		return testAccProviderConfigBlock + fmt.Sprintf(`
+			resource "ceph_auth" "admin" {
+			  entity = "client.admin"
+			  caps = {
+			    mon = %q
+			    mds = "allow *"
+			    osd = "allow *"
+			    mgr = "allow *"
+			  }
+			}
+		`, monCap)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          adminConfig("allow *"),
+				ExpectError:     regexp.MustCompile(`Refusing to change client\.admin`),
+			},
+			{
+				ConfigVariables:    testAccProviderConfig(),
+				Config:             adminConfig("allow *"),
+				ResourceName:       "ceph_auth.admin",
+				ImportState:        true,
+				ImportStateId:      "client.admin",
+				ImportStatePersist: true,
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          adminConfig("allow *"),
+				PlanOnly:        true,
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          adminConfig("allow r"),
+				ExpectError:     regexp.MustCompile(`Refusing to change client\.admin`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock,
+				ExpectError:     regexp.MustCompile(`Refusing to delete client\.admin`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					removed {
+					  from = ceph_auth.admin
+
+					  lifecycle {
+					    destroy = false
+					  }
+					}
+				`,
+				Check: checkCephAuthHasCaps(t, "client.admin", adminCaps),
+			},
+		},
+	})
+}
+
+func TestAccCephAuthResource_writeOnlyKey(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testEntity := acctest.RandomWithPrefix("client.test-wo-key")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephAuthDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_auth" "foo" {
+					  entity         = %q
+					  key_wo         = "AQBvaBVesCMcKRAAoKhLdz8Qh/qPNqF9UGKYfg=="
+					  key_wo_version = 1
+					  caps = {
+					    mon = "allow r"
+					  }
+					}
+				`, testEntity),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_auth.foo",
+						tfjsonpath.New("key"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"ceph_auth.foo",
+						tfjsonpath.New("key_wo"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"ceph_auth.foo",
+						tfjsonpath.New("keyring"),
+						knownvalue.Null(),
+					),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephAuthHasKey(t, testEntity, "AQBvaBVesCMcKRAAoKhLdz8Qh/qPNqF9UGKYfg=="),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_auth" "foo" {
+					  entity         = %q
+					  key_wo         = "AQCiaBVeqgKkJhAAvYdhxpkFQ1QWH2o2Xl2ykg=="
+					  key_wo_version = 2
+					  caps = {
+					    mon = "allow r"
+					  }
+					}
+				`, testEntity),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephAuthHasKey(t, testEntity, "AQCiaBVeqgKkJhAAvYdhxpkFQ1QWH2o2Xl2ykg=="),
+					checkCephAuthHasCaps(t, testEntity, map[string]string{
+						"mon": "allow r",
+					}),
 				),
 			},
 		},
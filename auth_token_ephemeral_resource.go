@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource          = &AuthTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose = &AuthTokenEphemeralResource{}
+)
+
+func newAuthTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AuthTokenEphemeralResource{}
+}
+
+type AuthTokenEphemeralResource struct {
+	client *CephAPIClient
+}
+
+type AuthTokenEphemeralResourceModel struct {
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	Endpoint types.String `tfsdk:"endpoint"`
+	Token    types.String `tfsdk:"token"`
+}
+
+func (r *AuthTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_token"
+}
+
+func (r *AuthTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This ephemeral resource logs in to the Ceph Dashboard API and returns a short-lived token, for example to call the dashboard with the http provider. The token is logged out again when Terraform closes the ephemeral resource.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The dashboard username to log in as",
+				Required:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The dashboard password",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The Ceph API endpoint URL the token was issued by",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The dashboard API token, to be sent as `Authorization: Bearer <token>`",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *AuthTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AuthTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data AuthTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := r.client.Auth(ctx, data.Username.ValueString(), data.Password.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to authenticate with Ceph API: %s", err),
+		)
+		return
+	}
+
+	tokenJSON, err := json.Marshal(token)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Private State Error",
+			fmt.Sprintf("Unable to marshal token to JSON: %s", err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, "token", tokenJSON)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Endpoint = types.StringValue(r.client.endpoint.String())
+	data.Token = types.StringValue(token)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *AuthTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	tokenBytes, diags := req.Private.GetKey(ctx, "token")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var token string
+	if err := json.Unmarshal(tokenBytes, &token); err != nil {
+		resp.Diagnostics.AddError(
+			"Private State Error",
+			fmt.Sprintf("Unable to unmarshal token from JSON: %s", err),
+		)
+		return
+	}
+
+	err := r.client.Logout(ctx, token)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to log out of Ceph API: %s", err),
+		)
+		return
+	}
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	balancerResourceID          = "balancer"
+	balancerMaxMisplacedOption  = "target_max_misplaced_ratio"
+	balancerMaxMisplacedSection = "mgr"
+)
+
+var (
+	_ resource.Resource                = &BalancerResource{}
+	_ resource.ResourceWithImportState = &BalancerResource{}
+	_ resource.ResourceWithModifyPlan  = &BalancerResource{}
+)
+
+func newBalancerResource() resource.Resource {
+	return &BalancerResource{}
+}
+
+// BalancerResource manages the cluster-wide balancer module. "ceph balancer
+// on" and "ceph balancer mode" only set the module's active and mode options,
+// so it goes through the same module config API as ceph_mgr_module_config.
+type BalancerResource struct {
+	client *CephAPIClient
+}
+
+type BalancerResourceModel struct {
+	ID           types.String  `tfsdk:"id"`
+	Active       types.Bool    `tfsdk:"active"`
+	Mode         types.String  `tfsdk:"mode"`
+	MaxMisplaced types.Float64 `tfsdk:"max_misplaced"`
+}
+
+func (r *BalancerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_balancer"
+}
+
+func (r *BalancerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the Ceph balancer module, which moves PGs between OSDs to even out data placement. " +
+			"There is one balancer per cluster, so declare this resource at most once. Destroying it restores the Ceph defaults.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `balancer`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"active": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether the balancer runs automatically, as with `ceph balancer on`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"mode": resourceSchema.StringAttribute{
+				MarkdownDescription: "The balancer mode: `upmap`, `crush-compat`, `upmap-read`, `read` or `none`. `upmap` requires `require_min_compat_client` luminous or later, and the read modes require reef.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("none", "crush-compat", "upmap", "upmap-read", "read"),
+				},
+			},
+			"max_misplaced": resourceSchema.Float64Attribute{
+				MarkdownDescription: "The maximum fraction of PGs the balancer may have misplaced at once, stored as the mgr `target_max_misplaced_ratio` option. Left unmanaged if not set.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
+			},
+		},
+	}
+}
+
+func (r *BalancerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan rejects the read balancer modes on releases that lack them.
+func (r *BalancerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var mode types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("mode"), &mode)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if mode.ValueString() != "upmap-read" && mode.ValueString() != "read" {
+		return
+	}
+
+	if err := r.client.requireRelease(fmt.Sprintf("The %s balancer mode", mode.ValueString()), cephReleaseReef); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("mode"), "Unsupported Ceph Release", err.Error())
+	}
+}
+
+func (r *BalancerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BalancerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, types.Float64Null())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BalancerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BalancerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BalancerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state BalancerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, state.MaxMisplaced)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BalancerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BalancerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, option := range []string{"active", "mode"} {
+		configName := "mgr/balancer/" + option
+		err := r.client.ClusterDeleteConf(ctx, configName, "mgr")
+		if err != nil && !isCephAPINotFound(err) {
+			resp.Diagnostics.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to reset balancer option '%s': %s", configName, err),
+			)
+			return
+		}
+	}
+
+	if !data.MaxMisplaced.IsNull() {
+		err := r.client.ClusterDeleteConf(ctx, balancerMaxMisplacedOption, balancerMaxMisplacedSection)
+		if err != nil && !isCephAPINotFound(err) {
+			resp.Diagnostics.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to reset '%s': %s", balancerMaxMisplacedOption, err),
+			)
+			return
+		}
+	}
+}
+
+func (r *BalancerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != balancerResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", balancerResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), balancerResourceID)...)
+}
+
+// apply writes the configured settings and reads the result back into data.
+// priorMaxMisplaced is the previously managed max_misplaced, which is reset
+// when it is removed from the configuration.
+func (r *BalancerResource) apply(ctx context.Context, data *BalancerResourceModel, priorMaxMisplaced types.Float64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	moduleConfig := CephAPIMgrModuleConfig{}
+	if !data.Active.IsNull() && !data.Active.IsUnknown() {
+		moduleConfig["active"] = strconv.FormatBool(data.Active.ValueBool())
+	}
+	if !data.Mode.IsNull() && !data.Mode.IsUnknown() {
+		moduleConfig["mode"] = data.Mode.ValueString()
+	}
+
+	if len(moduleConfig) > 0 {
+		if err := r.client.MgrSetModuleConfig(ctx, "balancer", moduleConfig); err != nil {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to configure the balancer module: %s", err),
+			)
+			return diags
+		}
+	}
+
+	switch {
+	case !data.MaxMisplaced.IsNull():
+		value := strconv.FormatFloat(data.MaxMisplaced.ValueFloat64(), 'g', -1, 64)
+		if err := r.client.ClusterUpdateConf(ctx, balancerMaxMisplacedOption, balancerMaxMisplacedSection, value); err != nil {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to set '%s': %s", balancerMaxMisplacedOption, err),
+			)
+			return diags
+		}
+	case !priorMaxMisplaced.IsNull():
+		err := r.client.ClusterDeleteConf(ctx, balancerMaxMisplacedOption, balancerMaxMisplacedSection)
+		if err != nil && !isCephAPINotFound(err) {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to reset '%s': %s", balancerMaxMisplacedOption, err),
+			)
+			return diags
+		}
+	}
+
+	data.ID = types.StringValue(balancerResourceID)
+	diags.Append(r.read(ctx, data)...)
+	return diags
+}
+
+func (r *BalancerResource) read(ctx context.Context, data *BalancerResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	moduleConfig, err := r.client.MgrGetModuleConfig(ctx, "balancer")
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read the balancer module config: %s", err),
+		)
+		return diags
+	}
+
+	active, err := formatMgrModuleConfigValue(moduleConfig["active"])
+	if err == nil {
+		var activeValue bool
+		activeValue, err = strconv.ParseBool(active)
+		data.Active = types.BoolValue(activeValue)
+	}
+	if err != nil {
+		diags.AddError(
+			"Configuration Value Formatting Error",
+			fmt.Sprintf("Unable to parse balancer option 'active': %s", err),
+		)
+		return diags
+	}
+
+	mode, err := formatMgrModuleConfigValue(moduleConfig["mode"])
+	if err != nil {
+		diags.AddError(
+			"Configuration Value Formatting Error",
+			fmt.Sprintf("Unable to parse balancer option 'mode': %s", err),
+		)
+		return diags
+	}
+	data.Mode = types.StringValue(mode)
+
+	if data.MaxMisplaced.IsNull() {
+		return diags
+	}
+
+	conf, err := r.client.ClusterGetConf(ctx, balancerMaxMisplacedOption)
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read '%s': %s", balancerMaxMisplacedOption, err),
+		)
+		return diags
+	}
+
+	value, ok := conf.SectionValue(balancerMaxMisplacedSection)
+	if !ok {
+		data.MaxMisplaced = types.Float64Null()
+		return diags
+	}
+
+	maxMisplaced, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		diags.AddError(
+			"Configuration Value Formatting Error",
+			fmt.Sprintf("Unable to parse '%s' value %q: %s", balancerMaxMisplacedOption, value, err),
+		)
+		return diags
+	}
+	data.MaxMisplaced = types.Float64Value(maxMisplaced)
+
+	return diags
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephBalancerResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephBalancerDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_balancer" "test" {
+					  active        = false
+					  mode          = "crush-compat"
+					  max_misplaced = 0.1
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_balancer.test", tfjsonpath.New("id"), knownvalue.StringExact("balancer")),
+					statecheck.ExpectKnownValue("ceph_balancer.test", tfjsonpath.New("active"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue("ceph_balancer.test", tfjsonpath.New("mode"), knownvalue.StringExact("crush-compat")),
+					statecheck.ExpectKnownValue("ceph_balancer.test", tfjsonpath.New("max_misplaced"), knownvalue.Float64Exact(0.1)),
+				},
+				Check: checkCephConfigValue(t, "mgr", "mgr/balancer/mode", "crush-compat"),
+			},
+			{
+				ConfigVariables:   testAccProviderConfig(),
+				ResourceName:      "ceph_balancer.test",
+				ImportState:       true,
+				ImportStateId:     "balancer",
+				ImportStateVerify: true,
+				// max_misplaced is only read back once it is managed.
+				ImportStateVerifyIgnore: []string{"max_misplaced"},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_balancer" "test" {
+					  mode = "upmap"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_balancer.test", tfjsonpath.New("active"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue("ceph_balancer.test", tfjsonpath.New("mode"), knownvalue.StringExact("upmap")),
+					statecheck.ExpectKnownValue("ceph_balancer.test", tfjsonpath.New("max_misplaced"), knownvalue.Null()),
+				},
+				Check: checkCephConfigAbsent(t, "mgr", balancerMaxMisplacedOption),
+			},
+		},
+	})
+}
+
+func checkCephConfigValue(t *testing.T, section, name, expected string) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		configDump, err := cephTestClusterCLI.ConfigDump(t.Context())
+		if err != nil {
+			return fmt.Errorf("failed to get config dump: %w", err)
+		}
+
+		for _, entry := range configDump {
+			if entry.Section == section && entry.Name == name {
+				if entry.Value != expected {
+					return fmt.Errorf("config %s/%s = %q, want %q", section, name, entry.Value, expected)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("config %s/%s not found in config dump", section, name)
+	}
+}
+
+func checkCephConfigAbsent(t *testing.T, section, name string) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		configDump, err := cephTestClusterCLI.ConfigDump(t.Context())
+		if err != nil {
+			return fmt.Errorf("failed to get config dump: %w", err)
+		}
+
+		for _, entry := range configDump {
+			if entry.Section == section && entry.Name == name {
+				return fmt.Errorf("config %s/%s still set to %q", section, name, entry.Value)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckCephBalancerDestroy(t *testing.T) resource.TestCheckFunc {
+	t.Helper()
+	return resource.ComposeAggregateTestCheckFunc(
+		checkCephConfigAbsent(t, "mgr", "mgr/balancer/active"),
+		checkCephConfigAbsent(t, "mgr", "mgr/balancer/mode"),
+		checkCephConfigAbsent(t, "mgr", balancerMaxMisplacedOption),
+	)
+}
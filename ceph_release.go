@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	cephReleaseReef  = 18
+	cephReleaseSquid = 19
+)
+
+var cephReleaseNames = map[int]string{
+	14: "nautilus",
+	15: "octopus",
+	16: "pacific",
+	17: "quincy",
+	18: "reef",
+	19: "squid",
+	20: "tentacle",
+}
+
+// cephReleaseOrder lists the Ceph release names from oldest to newest.
+var cephReleaseOrder = []string{
+	"argonaut", "bobtail", "cuttlefish", "dumpling", "emperor", "firefly", "giant", "hammer", "infernalis", "jewel",
+	"kraken", "luminous", "mimic", "nautilus", "octopus", "pacific", "quincy", "reef", "squid", "tentacle",
+}
+
+// cephRelease is the version of Ceph the cluster runs. The zero value means
+// the version is unknown.
+type cephRelease struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// parseCephRelease parses a version string such as "ceph version 19.2.1
+// (58a7fab8be0a062d730ad7da874972fd3fba59fb) squid (stable)".
+func parseCephRelease(version string) (cephRelease, bool) {
+	parts := strings.Split(parseCephVersion(version), ".")
+	if len(parts) != 3 {
+		return cephRelease{}, false
+	}
+
+	var release cephRelease
+	for i, field := range []*int{&release.Major, &release.Minor, &release.Patch} {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return cephRelease{}, false
+		}
+		*field = n
+	}
+	return release, true
+}
+
+func (r cephRelease) String() string {
+	version := fmt.Sprintf("%d.%d.%d", r.Major, r.Minor, r.Patch)
+	if name, ok := cephReleaseNames[r.Major]; ok {
+		version += " (" + name + ")"
+	}
+	return version
+}
+
+// cephReleaseName returns the code name of a major release, such as "reef"
+// for 18.
+func cephReleaseName(major int) string {
+	if name, ok := cephReleaseNames[major]; ok {
+		return fmt.Sprintf("%s (%d)", name, major)
+	}
+	return strconv.Itoa(major)
+}
+
+// detectRelease records the release the cluster runs so resources can reject
+// features it lacks before the mgr does. Failing to detect it only disables
+// those checks, so errors are logged rather than returned.
+func (c *CephAPIClient) detectRelease(ctx context.Context) {
+	summary, err := c.GetSummary(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to detect Ceph release", map[string]any{"error": err.Error()})
+		return
+	}
+
+	release, ok := parseCephRelease(summary.Version)
+	if !ok {
+		tflog.Warn(ctx, "Unable to parse Ceph version", map[string]any{"version": summary.Version})
+		return
+	}
+
+	c.release = release
+	tflog.Info(ctx, "Detected Ceph release", map[string]any{"version": release.String()})
+}
+
+// requireRelease returns an error if the cluster runs a release older than
+// major. feature completes the sentence "<feature> requires Ceph ...". When
+// the release is unknown the request is left for the cluster to reject.
+func (c *CephAPIClient) requireRelease(feature string, major int) error {
+	if c.release.Major == 0 || c.release.Major >= major {
+		return nil
+	}
+	return fmt.Errorf("%s requires Ceph %s or later, but the cluster runs Ceph %s", feature, cephReleaseName(major), c.release)
+}
+
+// cephReleaseAtLeast reports whether the named release is the same as or
+// newer than oldest. Unknown names are treated as older than every release.
+func cephReleaseAtLeast(release, oldest string) bool {
+	i := slices.Index(cephReleaseOrder, release)
+	return i >= 0 && i >= slices.Index(cephReleaseOrder, oldest)
+}
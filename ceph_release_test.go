@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestParseCephRelease(t *testing.T) {
+	release, ok := parseCephRelease("ceph version 18.2.4 (e7ad5345525c7aa95470c26863873b581076945d) reef (stable)")
+	if !ok || release != (cephRelease{Major: 18, Minor: 2, Patch: 4}) {
+		t.Errorf("parseCephRelease() = %v, %v, want 18.2.4, true", release, ok)
+	}
+	if got := release.String(); got != "18.2.4 (reef)" {
+		t.Errorf("String() = %q, want %q", got, "18.2.4 (reef)")
+	}
+
+	if _, ok := parseCephRelease("ceph version Development (no_version) squid (dev)"); ok {
+		t.Error("parseCephRelease() of a development build succeeded")
+	}
+}
+
+func TestRequireRelease(t *testing.T) {
+	client := &CephAPIClient{}
+	if err := client.requireRelease("RGW accounts", cephReleaseSquid); err != nil {
+		t.Errorf("requireRelease() with an unknown release = %v, want nil", err)
+	}
+
+	client.release = cephRelease{Major: 19, Minor: 2, Patch: 1}
+	if err := client.requireRelease("RGW accounts", cephReleaseSquid); err != nil {
+		t.Errorf("requireRelease() on squid = %v, want nil", err)
+	}
+
+	client.release = cephRelease{Major: 17, Minor: 2, Patch: 7}
+	err := client.requireRelease("RGW accounts", cephReleaseSquid)
+	expected := "RGW accounts requires Ceph squid (19) or later, but the cluster runs Ceph 17.2.7 (quincy)"
+	if err == nil || err.Error() != expected {
+		t.Errorf("requireRelease() on quincy = %v, want %q", err, expected)
+	}
+}
+
+func TestCephReleaseAtLeast(t *testing.T) {
+	tests := []struct {
+		release string
+		oldest  string
+		want    bool
+	}{
+		{"luminous", "luminous", true},
+		{"squid", "luminous", true},
+		{"jewel", "luminous", false},
+		{"", "luminous", false},
+		{"unknown", "firefly", false},
+	}
+
+	for _, tt := range tests {
+		if got := cephReleaseAtLeast(tt.release, tt.oldest); got != tt.want {
+			t.Errorf("cephReleaseAtLeast(%q, %q) = %v, want %v", tt.release, tt.oldest, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const cephadmContainerImageResourceID = "cephadm_container_image"
+
+var (
+	_ resource.Resource                = &CephadmContainerImageResource{}
+	_ resource.ResourceWithImportState = &CephadmContainerImageResource{}
+)
+
+func newCephadmContainerImageResource() resource.Resource {
+	return &CephadmContainerImageResource{}
+}
+
+// CephadmContainerImageResource manages the global container_image option
+// cephadm deploys new daemons from.
+type CephadmContainerImageResource struct {
+	client *CephAPIClient
+}
+
+type CephadmContainerImageResourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Image types.String `tfsdk:"image"`
+}
+
+func (r *CephadmContainerImageResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cephadm_container_image"
+}
+
+func (r *CephadmContainerImageResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the container image cephadm deploys new Ceph daemons from, the global `container_image` option. " +
+			"Daemons that are already running keep their image; use `ceph_orch_upgrade` to move them to another release. " +
+			"There is one container image per cluster, so declare this resource at most once. Destroying it restores the default image.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `cephadm_container_image`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"image": resourceSchema.StringAttribute{
+				MarkdownDescription: "The container image, e.g. `quay.io/ceph/ceph:v19.2.1`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *CephadmContainerImageResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CephadmContainerImageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CephadmContainerImageResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setImage(ctx, data.Image.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(cephadmContainerImageResourceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CephadmContainerImageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CephadmContainerImageResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conf, err := r.client.ClusterGetConf(ctx, "container_image")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read container image: %s", err),
+		)
+		return
+	}
+
+	image, ok := conf.SectionValue("global")
+	if !ok {
+		removeMissingResource(ctx, resp, "The global container_image setting")
+		return
+	}
+
+	data.ID = types.StringValue(cephadmContainerImageResourceID)
+	data.Image = types.StringValue(image)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CephadmContainerImageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CephadmContainerImageResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setImage(ctx, data.Image.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(cephadmContainerImageResourceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CephadmContainerImageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if err := r.client.ClusterDeleteConf(ctx, "container_image", "global"); err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to reset container image: %s", err),
+		)
+		return
+	}
+}
+
+func (r *CephadmContainerImageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != cephadmContainerImageResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", cephadmContainerImageResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), cephadmContainerImageResourceID)...)
+}
+
+func (r *CephadmContainerImageResource) setImage(ctx context.Context, image string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := r.client.ClusterUpdateConf(ctx, "container_image", "global", image); err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to set container image: %s", err),
+		)
+	}
+	return diags
+}
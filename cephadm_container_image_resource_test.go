@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephCephadmContainerImageResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		CheckDestroy: func(s *terraform.State) error {
+			if _, err := cephTestClusterCLI.ConfigGetFromDump(t.Context(), "global", "container_image"); err == nil {
+				return fmt.Errorf("global/container_image still exists")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_cephadm_container_image" "test" {
+					  image = "quay.io/ceph/ceph:v19.2.1"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_cephadm_container_image.test", tfjsonpath.New("image"), knownvalue.StringExact("quay.io/ceph/ceph:v19.2.1")),
+				},
+				Check: checkCephContainerImage(t, "quay.io/ceph/ceph:v19.2.1"),
+			},
+			{
+				ConfigVariables:   testAccProviderConfig(),
+				ResourceName:      "ceph_cephadm_container_image.test",
+				ImportState:       true,
+				ImportStateId:     "cephadm_container_image",
+				ImportStateVerify: true,
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_cephadm_container_image" "test" {
+					  image = "registry.example.com/ceph/ceph:v19.2.2"
+					}
+				`,
+				Check: checkCephContainerImage(t, "registry.example.com/ceph/ceph:v19.2.2"),
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.ConfigRemove(t.Context(), "global", "container_image"); err != nil {
+						t.Fatalf("Failed to remove container_image out of band: %v", err)
+					}
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_cephadm_container_image" "test" {
+					  image = "registry.example.com/ceph/ceph:v19.2.2"
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_cephadm_container_image.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkCephContainerImage(t, "registry.example.com/ceph/ceph:v19.2.2"),
+			},
+		},
+	})
+}
+
+func checkCephContainerImage(t *testing.T, expected string) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		value, err := cephTestClusterCLI.ConfigGetFromDump(t.Context(), "global", "container_image")
+		if err != nil {
+			return err
+		}
+		if value != expected {
+			return fmt.Errorf("container_image = %q, want %q", value, expected)
+		}
+		return nil
+	}
+}
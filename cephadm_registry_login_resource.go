@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const cephadmRegistryLoginResourceID = "cephadm_registry_login"
+
+// cephadmRegistryOptions are the cephadm module options registry-login
+// stores the credentials in.
+var cephadmRegistryOptions = []string{
+	"mgr/cephadm/registry_url",
+	"mgr/cephadm/registry_username",
+	"mgr/cephadm/registry_password",
+}
+
+var (
+	_ resource.Resource                = &CephadmRegistryLoginResource{}
+	_ resource.ResourceWithImportState = &CephadmRegistryLoginResource{}
+)
+
+func newCephadmRegistryLoginResource() resource.Resource {
+	return &CephadmRegistryLoginResource{}
+}
+
+// CephadmRegistryLoginResource manages the credentials cephadm pulls
+// container images with. The dashboard API does not expose them, so it
+// always goes through the ceph CLI.
+type CephadmRegistryLoginResource struct {
+	client *CephAPIClient
+}
+
+type CephadmRegistryLoginResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	URL               types.String `tfsdk:"url"`
+	Username          types.String `tfsdk:"username"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+}
+
+func (r *CephadmRegistryLoginResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cephadm_registry_login"
+}
+
+func (r *CephadmRegistryLoginResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Logs every cephadm host in to a private container registry, as with `ceph cephadm registry-login`, and keeps the credentials for hosts added later. " +
+			"The registry login is only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled. " +
+			"cephadm keeps one set of registry credentials, so declare this resource at most once. Destroying it removes the stored credentials but does not log the hosts out.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `cephadm_registry_login`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": resourceSchema.StringAttribute{
+				MarkdownDescription: "The registry host, e.g. `registry.example.com:5000`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"username": resourceSchema.StringAttribute{
+				MarkdownDescription: "The registry username",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"password_wo": resourceSchema.StringAttribute{
+				MarkdownDescription: "The registry password, as a write-only attribute that is never stored in state. Requires Terraform 1.11 or later.",
+				Required:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+			},
+			"password_wo_version": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The version of `password_wo`. Change it to log in again with a new password.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *CephadmRegistryLoginResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CephadmRegistryLoginResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CephadmRegistryLoginResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	var password types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &password)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.login(ctx, &data, password.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CephadmRegistryLoginResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CephadmRegistryLoginResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read cephadm registry login: %s", err),
+		)
+		return
+	}
+
+	registryURL, err := cli.ConfigGet(ctx, "mgr", "mgr/cephadm/registry_url")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read cephadm registry login: %s", err),
+		)
+		return
+	}
+
+	if registryURL == "" {
+		removeMissingResource(ctx, resp, "The cephadm registry login")
+		return
+	}
+
+	username, err := cli.ConfigGet(ctx, "mgr", "mgr/cephadm/registry_username")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read cephadm registry login: %s", err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(cephadmRegistryLoginResourceID)
+	data.URL = types.StringValue(registryURL)
+	data.Username = types.StringValue(username)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CephadmRegistryLoginResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CephadmRegistryLoginResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	var password types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &password)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.login(ctx, &data, password.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CephadmRegistryLoginResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to remove cephadm registry login: %s", err),
+		)
+		return
+	}
+
+	for _, option := range cephadmRegistryOptions {
+		if err := cli.ConfigRemove(ctx, "mgr", option); err != nil {
+			resp.Diagnostics.AddError(
+				"CLI Request Error",
+				fmt.Sprintf("Unable to remove cephadm registry login: %s", err),
+			)
+			return
+		}
+	}
+}
+
+func (r *CephadmRegistryLoginResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != cephadmRegistryLoginResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", cephadmRegistryLoginResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), cephadmRegistryLoginResourceID)...)
+}
+
+func (r *CephadmRegistryLoginResource) login(ctx context.Context, data *CephadmRegistryLoginResourceModel, password string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		diags.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to log in to registry %s: %s", data.URL.ValueString(), err),
+		)
+		return diags
+	}
+
+	if err := cli.CephadmRegistryLogin(ctx, data.URL.ValueString(), data.Username.ValueString(), password); err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to log in to registry %s: %s", data.URL.ValueString(), err),
+		)
+		return diags
+	}
+
+	data.ID = types.StringValue(cephadmRegistryLoginResourceID)
+	data.PasswordWO = types.StringNull()
+	return diags
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// The test cluster is not deployed with cephadm, so this covers the CLI
+// requirement and the error path, and there is no login to remove out of
+// band.
+func TestAccCephCephadmRegistryLoginResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	loginConfig := `
+		resource "ceph_cephadm_registry_login" "test" {
+		  url                 = "registry.example.com"
+		  username            = "ceph"
+		  password_wo         = "hunter2"
+		  password_wo_version = 1
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + loginConfig,
+				ExpectError:     regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint":  config.StringVariable(testDashboardURL),
+					"ceph_conf": config.StringVariable(testConfPath),
+				},
+				Config:      testAccCLIProviderConfigBlock + loginConfig,
+				ExpectError: regexp.MustCompile(`Unable to log in to registry registry.example.com`),
+			},
+		},
+	})
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const cephadmSSHResourceID = "cephadm_ssh"
+
+var (
+	_ resource.Resource                = &CephadmSSHResource{}
+	_ resource.ResourceWithImportState = &CephadmSSHResource{}
+)
+
+func newCephadmSSHResource() resource.Resource {
+	return &CephadmSSHResource{}
+}
+
+// CephadmSSHResource manages how cephadm connects to hosts. The dashboard
+// API does not expose it, so it always goes through the ceph CLI.
+type CephadmSSHResource struct {
+	client *CephAPIClient
+}
+
+type CephadmSSHResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	User      types.String `tfsdk:"user"`
+	SSHConfig types.String `tfsdk:"ssh_config"`
+}
+
+func (r *CephadmSSHResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cephadm_ssh"
+}
+
+func (r *CephadmSSHResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the SSH user and `ssh_config` cephadm uses to reach hosts, as with `ceph cephadm set-user` and `ceph cephadm set-ssh-config`. " +
+			"These settings are only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled. " +
+			"There is one SSH configuration per cluster, so declare this resource at most once. Destroying it restores the `root` user and the built-in `ssh_config`.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `cephadm_ssh`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user": resourceSchema.StringAttribute{
+				MarkdownDescription: "The user cephadm logs in as. Users other than `root` need passwordless sudo on every host. Defaults to `root`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("root"),
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"ssh_config": resourceSchema.StringAttribute{
+				MarkdownDescription: "A custom `ssh_config` used instead of the built-in one",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *CephadmSSHResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CephadmSSHResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CephadmSSHResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data.User.ValueString(), data.SSHConfig.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(cephadmSSHResourceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CephadmSSHResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CephadmSSHResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read cephadm ssh configuration: %s", err),
+		)
+		return
+	}
+
+	user, err := cli.CephadmGetUser(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read cephadm ssh configuration: %s", err),
+		)
+		return
+	}
+
+	sshConfig, err := cli.CephadmGetSSHConfig(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read cephadm ssh configuration: %s", err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(cephadmSSHResourceID)
+	data.User = types.StringValue(user)
+	if sshConfig == "" {
+		data.SSHConfig = types.StringNull()
+	} else {
+		data.SSHConfig = types.StringValue(sshConfig)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CephadmSSHResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CephadmSSHResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data.User.ValueString(), data.SSHConfig.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(cephadmSSHResourceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CephadmSSHResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.apply(ctx, "root", "")...)
+}
+
+func (r *CephadmSSHResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != cephadmSSHResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", cephadmSSHResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), cephadmSSHResourceID)...)
+}
+
+// apply sets the ssh config before the user, since cephadm checks that it
+// can still reach every host when the user changes. An empty sshConfig
+// restores the built-in one.
+func (r *CephadmSSHResource) apply(ctx context.Context, user, sshConfig string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		diags.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to set cephadm ssh configuration: %s", err),
+		)
+		return diags
+	}
+
+	if sshConfig == "" {
+		err = cli.CephadmClearSSHConfig(ctx)
+	} else {
+		err = cli.CephadmSetSSHConfig(ctx, sshConfig)
+	}
+	if err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to set cephadm ssh configuration: %s", err),
+		)
+		return diags
+	}
+
+	if err := cli.CephadmSetUser(ctx, user); err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to set cephadm ssh configuration: %s", err),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The test cluster is not deployed with cephadm, so this covers the CLI
+// requirement and the error path.
+func TestAccCephCephadmSSHResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	sshConfig := `
+		resource "ceph_cephadm_ssh" "test" {
+		  user       = "cephadm"
+		  ssh_config = "Host *\n  StrictHostKeyChecking no\n"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + sshConfig,
+				ExpectError:     regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint":  config.StringVariable(testDashboardURL),
+					"ceph_conf": config.StringVariable(testConfPath),
+				},
+				Config:      testAccCLIProviderConfigBlock + sshConfig,
+				ExpectError: regexp.MustCompile(`Unable to set cephadm ssh configuration`),
+			},
+		},
+	})
+}
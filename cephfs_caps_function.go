@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &CephFSCapsFunction{}
+
+func newCephFSCapsFunction() function.Function {
+	return &CephFSCapsFunction{}
+}
+
+// CephFSCapsFunction builds the same caps as `ceph fs authorize`.
+type CephFSCapsFunction struct{}
+
+func (f *CephFSCapsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cephfs_caps"
+}
+
+func (f *CephFSCapsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build cephx caps for a CephFS client",
+		MarkdownDescription: "Returns the `mon`, `mds` and `osd` caps `ceph fs authorize` would grant a client on a filesystem path, for the `caps` of `ceph_auth`. " +
+			"For example `cephfs_caps(\"cephfs\", \"/volumes\", true)` returns `{ mon = \"allow r fsname=cephfs\", mds = \"allow rw fsname=cephfs path=/volumes\", osd = \"allow rw tag cephfs data=cephfs\" }`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "fs",
+				MarkdownDescription: "The filesystem name",
+			},
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "The absolute path the client may access, `/` for the whole filesystem",
+			},
+			function.BoolParameter{
+				Name:                "rw",
+				MarkdownDescription: "Whether the client may write, otherwise it is read-only",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *CephFSCapsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fs, path string
+	var rw bool
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &fs, &path, &rw))
+	if resp.Error != nil {
+		return
+	}
+
+	if fs == "" || strings.ContainsAny(fs, " ,") {
+		resp.Error = function.NewArgumentFuncError(0, "fs must be a non-empty filesystem name")
+		return
+	}
+	if !strings.HasPrefix(path, "/") || strings.ContainsAny(path, " ,") {
+		resp.Error = function.NewArgumentFuncError(1, "path must be an absolute path without spaces or commas")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, cephFSCaps(fs, path, rw)))
+}
+
+// cephFSCaps leaves out the path for the root and a trailing slash otherwise,
+// as `ceph fs authorize` does.
+func cephFSCaps(fs, path string, rw bool) map[string]string {
+	access := "r"
+	if rw {
+		access = "rw"
+	}
+
+	mds := "allow " + access + " fsname=" + fs
+	if path = strings.TrimRight(path, "/"); path != "" {
+		mds += " path=" + path
+	}
+
+	return map[string]string{
+		"mon": "allow r fsname=" + fs,
+		"mds": mds,
+		"osd": "allow " + access + " tag cephfs data=" + fs,
+	}
+}
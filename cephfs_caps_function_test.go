@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCephCephFSCapsFunction(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testEntity := acctest.RandomWithPrefix("client.test-cephfs-caps")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		CheckDestroy: testAccCheckCephAuthDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_auth" "test" {
+					  entity = %q
+					  caps   = provider::ceph::cephfs_caps("cephfs", "/volumes/", true)
+					}
+
+					output "root_read_only" {
+					  value = provider::ceph::cephfs_caps("cephfs", "/", false)
+					}
+				`, testEntity),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_auth.test",
+						tfjsonpath.New("caps"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"mon": knownvalue.StringExact("allow r fsname=cephfs"),
+							"mds": knownvalue.StringExact("allow rw fsname=cephfs path=/volumes"),
+							"osd": knownvalue.StringExact("allow rw tag cephfs data=cephfs"),
+						}),
+					),
+					statecheck.ExpectKnownOutputValue(
+						"root_read_only",
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"mon": knownvalue.StringExact("allow r fsname=cephfs"),
+							"mds": knownvalue.StringExact("allow r fsname=cephfs"),
+							"osd": knownvalue.StringExact("allow r tag cephfs data=cephfs"),
+						}),
+					),
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					output "invalid" {
+					  value = provider::ceph::cephfs_caps("cephfs", "volumes", true)
+					}
+				`,
+				ExpectError: regexp.MustCompile(`path must be an absolute path`),
+			},
+		},
+	})
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &CephFSFilesystemsDataSource{}
+
+func newCephFSFilesystemsDataSource() datasource.DataSource {
+	return &CephFSFilesystemsDataSource{}
+}
+
+type CephFSFilesystemsDataSource struct {
+	client *CephAPIClient
+}
+
+type CephFSFilesystemsDataSourceModel struct {
+	Filesystems []CephFSFilesystemItem `tfsdk:"filesystems"`
+}
+
+type CephFSFilesystemItem struct {
+	ID                 types.Int64           `tfsdk:"id"`
+	Name               types.String          `tfsdk:"name"`
+	MetadataPool       types.String          `tfsdk:"metadata_pool"`
+	DataPools          []types.String        `tfsdk:"data_pools"`
+	MaxMDS             types.Int64           `tfsdk:"max_mds"`
+	Ranks              []CephFSFilesystemMDS `tfsdk:"ranks"`
+	Joinable           types.Bool            `tfsdk:"joinable"`
+	AllowSnaps         types.Bool            `tfsdk:"allow_snaps"`
+	AllowMultiMDSSnaps types.Bool            `tfsdk:"allow_multimds_snaps"`
+	AllowStandbyReplay types.Bool            `tfsdk:"allow_standby_replay"`
+}
+
+type CephFSFilesystemMDS struct {
+	Rank  types.Int64  `tfsdk:"rank"`
+	Name  types.String `tfsdk:"name"`
+	State types.String `tfsdk:"state"`
+}
+
+func (d *CephFSFilesystemsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cephfs_filesystems"
+}
+
+func (d *CephFSFilesystemsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source lists the CephFS filesystems of the cluster (equivalent to `ceph fs ls`), with their pools, MDS ranks and flags.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"filesystems": dataSourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The filesystems, ordered by ID.",
+				Computed:            true,
+				NestedObject: dataSourceSchema.NestedAttributeObject{
+					Attributes: map[string]dataSourceSchema.Attribute{
+						"id": dataSourceSchema.Int64Attribute{
+							MarkdownDescription: "The filesystem ID.",
+							Computed:            true,
+						},
+						"name": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The filesystem name.",
+							Computed:            true,
+						},
+						"metadata_pool": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The name of the metadata pool.",
+							Computed:            true,
+						},
+						"data_pools": dataSourceSchema.ListAttribute{
+							MarkdownDescription: "The names of the data pools, the default data pool first.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"max_mds": dataSourceSchema.Int64Attribute{
+							MarkdownDescription: "The maximum number of active MDS ranks.",
+							Computed:            true,
+						},
+						"ranks": dataSourceSchema.ListNestedAttribute{
+							MarkdownDescription: "The MDS daemons holding a rank, ordered by rank.",
+							Computed:            true,
+							NestedObject: dataSourceSchema.NestedAttributeObject{
+								Attributes: map[string]dataSourceSchema.Attribute{
+									"rank": dataSourceSchema.Int64Attribute{
+										MarkdownDescription: "The MDS rank.",
+										Computed:            true,
+									},
+									"name": dataSourceSchema.StringAttribute{
+										MarkdownDescription: "The name of the MDS daemon.",
+										Computed:            true,
+									},
+									"state": dataSourceSchema.StringAttribute{
+										MarkdownDescription: "The MDS state, e.g. 'up:active'.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"joinable": dataSourceSchema.BoolAttribute{
+							MarkdownDescription: "Whether MDS daemons may join the filesystem. It is false after `ceph fs fail`.",
+							Computed:            true,
+						},
+						"allow_snaps": dataSourceSchema.BoolAttribute{
+							MarkdownDescription: "Whether snapshots are allowed.",
+							Computed:            true,
+						},
+						"allow_multimds_snaps": dataSourceSchema.BoolAttribute{
+							MarkdownDescription: "Whether snapshots are allowed with multiple active MDS ranks.",
+							Computed:            true,
+						},
+						"allow_standby_replay": dataSourceSchema.BoolAttribute{
+							MarkdownDescription: "Whether standby-replay daemons are allowed.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CephFSFilesystemsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *CephFSFilesystemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withReadCache(ctx)
+
+	var data CephFSFilesystemsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filesystems, err := d.client.ListCephFS(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to list CephFS filesystems from Ceph API: %s", err),
+		)
+		return
+	}
+
+	pools, err := d.client.ListPools(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to list pools from Ceph API: %s", err),
+		)
+		return
+	}
+
+	// The fs map refers to pools by ID.
+	poolNames := make(map[int]string, len(pools))
+	for _, pool := range pools {
+		poolNames[pool.PoolID] = pool.PoolName
+	}
+	poolName := func(id int) types.String {
+		if name, ok := poolNames[id]; ok {
+			return types.StringValue(name)
+		}
+		return types.StringValue(strconv.Itoa(id))
+	}
+
+	slices.SortFunc(filesystems, func(a, b CephAPICephFS) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	data.Filesystems = make([]CephFSFilesystemItem, 0, len(filesystems))
+	for _, fs := range filesystems {
+		item := CephFSFilesystemItem{
+			ID:                 types.Int64Value(int64(fs.ID)),
+			Name:               types.StringValue(fs.MDSMap.FsName),
+			MetadataPool:       poolName(fs.MDSMap.MetadataPool),
+			DataPools:          make([]types.String, 0, len(fs.MDSMap.DataPools)),
+			MaxMDS:             types.Int64Value(int64(fs.MDSMap.MaxMDS)),
+			Ranks:              []CephFSFilesystemMDS{},
+			Joinable:           types.BoolValue(fs.MDSMap.FlagsState.Joinable),
+			AllowSnaps:         types.BoolValue(fs.MDSMap.FlagsState.AllowSnaps),
+			AllowMultiMDSSnaps: types.BoolValue(fs.MDSMap.FlagsState.AllowMultiMDSSnaps),
+			AllowStandbyReplay: types.BoolValue(fs.MDSMap.FlagsState.AllowStandbyReplay),
+		}
+
+		for _, id := range fs.MDSMap.DataPools {
+			item.DataPools = append(item.DataPools, poolName(id))
+		}
+
+		// Standby-replay daemons are listed in info too, with rank -1 in older
+		// releases; only the daemons holding a rank are reported.
+		infos := make([]CephAPIMDSInfo, 0, len(fs.MDSMap.Info))
+		for _, info := range fs.MDSMap.Info {
+			if info.Rank >= 0 && info.State != "up:standby-replay" {
+				infos = append(infos, info)
+			}
+		}
+		slices.SortFunc(infos, func(a, b CephAPIMDSInfo) int {
+			return cmp.Compare(a.Rank, b.Rank)
+		})
+		for _, info := range infos {
+			item.Ranks = append(item.Ranks, CephFSFilesystemMDS{
+				Rank:  types.Int64Value(int64(info.Rank)),
+				Name:  types.StringValue(info.Name),
+				State: types.StringValue(info.State),
+			})
+		}
+
+		data.Filesystems = append(data.Filesystems, item)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCephCephFSFilesystemsDataSource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	fsName := acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+			testAccCreateCephFs(t, fsName)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					data "ceph_cephfs_filesystems" "test" {}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"data.ceph_cephfs_filesystems.test",
+						"filesystems.*",
+						map[string]string{
+							"name":          fsName,
+							"metadata_pool": fsName + "_metadata",
+							"data_pools.#":  "1",
+							"data_pools.0":  fsName + "_data",
+							"max_mds":       "1",
+							"ranks.#":       "0",
+							"allow_snaps":   "true",
+						},
+					),
+				),
+			},
+		},
+	})
+}
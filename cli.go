@@ -1,11 +1,14 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
+	"os"
 	"os/exec"
 	"reflect"
 	"slices"
@@ -17,14 +20,39 @@ import (
 
 var ErrRGWUserNotFound = errors.New("rgw user not found")
 
+var ErrRGWAccountNotFound = errors.New("rgw account not found")
+
+var ErrRGWBucketNotFound = errors.New("rgw bucket not found")
+
 type CephCLI struct {
-	confPath string
+	confPath    string
+	keyringPath string
+	clientName  string
 }
 
 func NewCephCLI(confPath string) *CephCLI {
 	return &CephCLI{confPath: confPath}
 }
 
+// command builds a ceph or radosgw-admin invocation. The keyring and client
+// name are passed through CEPH_ARGS so every subcommand picks them up.
+func (c *CephCLI) command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+
+	var cephArgs []string
+	if c.keyringPath != "" {
+		cephArgs = append(cephArgs, "--keyring="+c.keyringPath)
+	}
+	if c.clientName != "" {
+		cephArgs = append(cephArgs, "--name="+c.clientName)
+	}
+	if len(cephArgs) > 0 {
+		cmd.Env = append(os.Environ(), "CEPH_ARGS="+strings.Join(cephArgs, " "))
+	}
+
+	return cmd
+}
+
 type CephAuthInfo struct {
 	Key  string            `json:"key"`
 	Caps map[string]string `json:"caps"`
@@ -45,7 +73,7 @@ type ConfigDumpEntry struct {
 const floatComparisonEpsilon = 1e-9
 
 func (c *CephCLI) AuthGet(ctx context.Context, entity string) (*CephAuthInfo, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "auth", "get", entity, "--format", "json")
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "auth", "get", entity, "--format", "json")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get auth for %s: %w", entity, err)
@@ -76,7 +104,7 @@ func (c *CephCLI) AuthSetCaps(ctx context.Context, entity string, caps map[strin
 		args = append(args, capType, caps[capType])
 	}
 
-	cmd := exec.CommandContext(ctx, "ceph", args...)
+	cmd := c.command(ctx, "ceph", args...)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to set caps for %s: %w", entity, err)
 	}
@@ -91,8 +119,16 @@ func (c *CephCLI) AuthSetCaps(ctx context.Context, entity string, caps map[strin
 	return nil
 }
 
+func (c *CephCLI) AuthDelete(ctx context.Context, entity string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "auth", "del", entity)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to delete auth for %s: %w", entity, err)
+	}
+	return nil
+}
+
 func (c *CephCLI) ConfigSet(ctx context.Context, scope, key, value string) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "config", "set", scope, key, value)
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "config", "set", scope, key, value)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to set config %s=%s for scope %s: %w", key, value, scope, err)
 	}
@@ -116,7 +152,7 @@ func (c *CephCLI) ConfigSet(ctx context.Context, scope, key, value string) error
 }
 
 func (c *CephCLI) ConfigGet(ctx context.Context, scope, key string) (string, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "config", "get", scope, key)
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "config", "get", scope, key)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get config %s for scope %s: %w", key, scope, err)
@@ -126,7 +162,7 @@ func (c *CephCLI) ConfigGet(ctx context.Context, scope, key string) (string, err
 }
 
 func (c *CephCLI) ConfigGetFromDump(ctx context.Context, scope, key string) (string, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "config", "dump", "--format", "json")
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "config", "dump", "--format", "json")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to dump config: %w", err)
@@ -154,7 +190,7 @@ func (c *CephCLI) ConfigGetFromDump(ctx context.Context, scope, key string) (str
 }
 
 func (c *CephCLI) ConfigRemove(ctx context.Context, scope, key string) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "config", "rm", scope, key)
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "config", "rm", scope, key)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to remove config %s for scope %s: %w", key, scope, err)
 	}
@@ -171,7 +207,7 @@ type CephCrushRule struct {
 }
 
 func (c *CephCLI) CrushRuleCreateReplicated(ctx context.Context, name, root, failureDomain string) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "create-replicated", name, root, failureDomain)
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "create-replicated", name, root, failureDomain)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to create replicated crush rule %s: %w", name, err)
 	}
@@ -187,7 +223,7 @@ func (c *CephCLI) CrushRuleCreateReplicated(ctx context.Context, name, root, fai
 }
 
 func (c *CephCLI) CrushRuleCreateSimple(ctx context.Context, name, root, failureDomain string) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "create-simple", name, root, failureDomain)
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "create-simple", name, root, failureDomain)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to create simple crush rule %s: %w", name, err)
 	}
@@ -203,7 +239,7 @@ func (c *CephCLI) CrushRuleCreateSimple(ctx context.Context, name, root, failure
 }
 
 func (c *CephCLI) CrushRuleCreateErasure(ctx context.Context, name, profile string) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "create-erasure", name, profile)
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "create-erasure", name, profile)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to create erasure crush rule %s: %w", name, err)
 	}
@@ -219,7 +255,7 @@ func (c *CephCLI) CrushRuleCreateErasure(ctx context.Context, name, profile stri
 }
 
 func (c *CephCLI) CrushRuleDump(ctx context.Context, name string) (*CephCrushRule, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "dump", name, "--format", "json")
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "dump", name, "--format", "json")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to dump crush rule %s: %w", name, err)
@@ -234,7 +270,7 @@ func (c *CephCLI) CrushRuleDump(ctx context.Context, name string) (*CephCrushRul
 }
 
 func (c *CephCLI) CrushRuleList(ctx context.Context) ([]string, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "ls", "--format", "json")
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "ls", "--format", "json")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list crush rules: %w", err)
@@ -249,7 +285,7 @@ func (c *CephCLI) CrushRuleList(ctx context.Context) ([]string, error) {
 }
 
 func (c *CephCLI) CrushRuleRemove(ctx context.Context, name string) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "rm", name)
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "rule", "rm", name)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to remove crush rule %s: %w", name, err)
 	}
@@ -274,7 +310,7 @@ func (c *CephCLI) ErasureCodeProfileSet(ctx context.Context, name string, params
 		args = append(args, fmt.Sprintf("%s=%s", key, params[key]))
 	}
 
-	cmd := exec.CommandContext(ctx, "ceph", args...)
+	cmd := c.command(ctx, "ceph", args...)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to set erasure code profile %s: %w", name, err)
 	}
@@ -296,7 +332,7 @@ func (c *CephCLI) ErasureCodeProfileSet(ctx context.Context, name string, params
 }
 
 func (c *CephCLI) ErasureCodeProfileGet(ctx context.Context, name string) (map[string]string, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "erasure-code-profile", "get", name, "--format", "json")
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "erasure-code-profile", "get", name, "--format", "json")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get erasure code profile %s: %w", name, err)
@@ -311,7 +347,7 @@ func (c *CephCLI) ErasureCodeProfileGet(ctx context.Context, name string) (map[s
 }
 
 func (c *CephCLI) ErasureCodeProfileList(ctx context.Context) ([]string, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "erasure-code-profile", "ls", "--format", "json")
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "erasure-code-profile", "ls", "--format", "json")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list erasure code profiles: %w", err)
@@ -326,7 +362,7 @@ func (c *CephCLI) ErasureCodeProfileList(ctx context.Context) ([]string, error)
 }
 
 func (c *CephCLI) ErasureCodeProfileRemove(ctx context.Context, name string) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "erasure-code-profile", "rm", name)
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "erasure-code-profile", "rm", name)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to remove erasure code profile %s: %w", name, err)
 	}
@@ -386,7 +422,7 @@ func (c *CephCLI) RgwUserCreate(ctx context.Context, uid, displayName string, op
 		}
 	}
 
-	cmd := exec.CommandContext(ctx, "radosgw-admin", args...)
+	cmd := c.command(ctx, "radosgw-admin", args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to create rgw user %s: %w", uid, err)
@@ -406,7 +442,7 @@ func (c *CephCLI) RgwUserCreate(ctx context.Context, uid, displayName string, op
 }
 
 func (c *CephCLI) RgwUserInfo(ctx context.Context, uid string) (*RgwUserInfo, error) {
-	cmd := exec.CommandContext(ctx, "radosgw-admin", "--conf", c.confPath, "--format=json", "user", "info", "--uid="+uid)
+	cmd := c.command(ctx, "radosgw-admin", "--conf", c.confPath, "--format=json", "user", "info", "--uid="+uid)
 	output, err := cmd.Output()
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -445,7 +481,7 @@ func (c *CephCLI) RgwUserModify(ctx context.Context, uid string, opts *RgwUserMo
 		}
 	}
 
-	cmd := exec.CommandContext(ctx, "radosgw-admin", args...)
+	cmd := c.command(ctx, "radosgw-admin", args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to modify rgw user %s: %w", uid, err)
@@ -481,7 +517,7 @@ func (c *CephCLI) RgwUserRemove(ctx context.Context, uid string, purgeData bool)
 		args = append(args, "--purge-data")
 	}
 
-	cmd := exec.CommandContext(ctx, "radosgw-admin", args...)
+	cmd := c.command(ctx, "radosgw-admin", args...)
 	_, err := cmd.Output()
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -512,7 +548,7 @@ func (c *CephCLI) RgwUserSuspend(ctx context.Context, uid string, suspend bool)
 	}
 
 	args := []string{"--conf", c.confPath, "user", subcommand, "--uid=" + uid}
-	cmd := exec.CommandContext(ctx, "radosgw-admin", args...)
+	cmd := c.command(ctx, "radosgw-admin", args...)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to %s rgw user %s: %w", subcommand, uid, err)
 	}
@@ -540,7 +576,7 @@ func (c *CephCLI) RgwSubuserCreate(ctx context.Context, uid, subuser string, opt
 		}
 	}
 
-	cmd := exec.CommandContext(ctx, "radosgw-admin", args...)
+	cmd := c.command(ctx, "radosgw-admin", args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to create rgw subuser %s for %s: %w", subuser, uid, err)
@@ -579,7 +615,7 @@ func (c *CephCLI) RgwKeyCreate(ctx context.Context, uid string, opts *RgwKeyCrea
 		}
 	}
 
-	cmd := exec.CommandContext(ctx, "radosgw-admin", args...)
+	cmd := c.command(ctx, "radosgw-admin", args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to create rgw key for %s: %w", uid, err)
@@ -621,7 +657,7 @@ func (c *CephCLI) RgwKeyCreate(ctx context.Context, uid string, opts *RgwKeyCrea
 func (c *CephCLI) RgwKeyRemove(ctx context.Context, uid, accessKey string) error {
 	args := []string{"--conf", c.confPath, "key", "rm", "--uid=" + uid, "--access-key=" + accessKey}
 
-	cmd := exec.CommandContext(ctx, "radosgw-admin", args...)
+	cmd := c.command(ctx, "radosgw-admin", args...)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to remove rgw key %s for %s: %w", accessKey, uid, err)
 	}
@@ -638,227 +674,1661 @@ func (c *CephCLI) RgwKeyRemove(ctx context.Context, uid, accessKey string) error
 	return nil
 }
 
-func (c *CephCLI) PoolCreate(ctx context.Context, poolName string, pgNum int, poolType string) error {
-	args := []string{"--conf", c.confPath, "osd", "pool", "create", poolName, fmt.Sprintf("%d", pgNum)}
-	if poolType != "" {
-		args = append(args, poolType)
+// RgwMfaEntry is a TOTP token as reported by `radosgw-admin mfa list`.
+type RgwMfaEntry struct {
+	ID       string `json:"id"`
+	SeedType string `json:"seed_type"`
+	StepSize int    `json:"step_size"`
+	Window   int    `json:"window"`
+}
+
+type RgwMfaCreateOptions struct {
+	SeedType string
+	Seconds  int
+	Window   int
+}
+
+func (c *CephCLI) RgwMfaCreate(ctx context.Context, uid, serial, seed string, opts *RgwMfaCreateOptions) error {
+	args := []string{"--conf", c.confPath, "mfa", "create", "--uid=" + uid, "--totp-serial=" + serial, "--totp-seed=" + seed}
+
+	if opts != nil {
+		if opts.SeedType != "" {
+			args = append(args, "--totp-seed-type="+opts.SeedType)
+		}
+		if opts.Seconds != 0 {
+			args = append(args, fmt.Sprintf("--totp-seconds=%d", opts.Seconds))
+		}
+		if opts.Window != 0 {
+			args = append(args, fmt.Sprintf("--totp-window=%d", opts.Window))
+		}
 	}
 
-	cmd := exec.CommandContext(ctx, "ceph", args...)
+	cmd := c.command(ctx, "radosgw-admin", args...)
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to create pool %s: %w", poolName, err)
+		return fmt.Errorf("failed to create mfa token %s for %s: %w", serial, uid, err)
 	}
+	return nil
+}
 
-	_, err := c.PoolGet(ctx, poolName, "size")
+func (c *CephCLI) RgwMfaList(ctx context.Context, uid string) ([]RgwMfaEntry, error) {
+	cmd := c.command(ctx, "radosgw-admin", "--conf", c.confPath, "--format=json", "mfa", "list", "--uid="+uid)
+	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to verify pool creation: %w", err)
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			stderr := string(exitErr.Stderr)
+			if strings.Contains(stderr, "could not fetch user info") {
+				return nil, fmt.Errorf("failed to list mfa tokens for %s: %w", uid, ErrRGWUserNotFound)
+			}
+		}
+		return nil, fmt.Errorf("failed to list mfa tokens for %s: %w", uid, err)
 	}
-	return nil
+
+	var result struct {
+		Entries []RgwMfaEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse mfa list output: %w", err)
+	}
+
+	return result.Entries, nil
 }
 
-func (c *CephCLI) PoolDelete(ctx context.Context, poolName string) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "delete", poolName, poolName, "--yes-i-really-really-mean-it")
+func (c *CephCLI) RgwMfaRemove(ctx context.Context, uid, serial string) error {
+	cmd := c.command(ctx, "radosgw-admin", "--conf", c.confPath, "mfa", "remove", "--uid="+uid, "--totp-serial="+serial)
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to delete pool %s: %w", poolName, err)
+		return fmt.Errorf("failed to remove mfa token %s for %s: %w", serial, uid, err)
 	}
+	return nil
+}
 
-	_, err := c.PoolGet(ctx, poolName, "size")
-	if err == nil {
-		return fmt.Errorf("pool still exists after deletion: %s", poolName)
+type RgwAccountInfo struct {
+	ID            string `json:"id"`
+	Tenant        string `json:"tenant"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	MaxUsers      int    `json:"max_users"`
+	MaxRoles      int    `json:"max_roles"`
+	MaxGroups     int    `json:"max_groups"`
+	MaxBuckets    int    `json:"max_buckets"`
+	MaxAccessKeys int    `json:"max_access_keys"`
+}
+
+type RgwAccountOptions struct {
+	Name          string
+	Email         *string
+	MaxUsers      *int
+	MaxRoles      *int
+	MaxGroups     *int
+	MaxBuckets    *int
+	MaxAccessKeys *int
+}
+
+func (opts *RgwAccountOptions) args() []string {
+	var args []string
+	if opts.Name != "" {
+		args = append(args, "--account-name="+opts.Name)
 	}
-	return nil
+	if opts.Email != nil {
+		args = append(args, "--email="+*opts.Email)
+	}
+	if opts.MaxUsers != nil {
+		args = append(args, fmt.Sprintf("--max-users=%d", *opts.MaxUsers))
+	}
+	if opts.MaxRoles != nil {
+		args = append(args, fmt.Sprintf("--max-roles=%d", *opts.MaxRoles))
+	}
+	if opts.MaxGroups != nil {
+		args = append(args, fmt.Sprintf("--max-groups=%d", *opts.MaxGroups))
+	}
+	if opts.MaxBuckets != nil {
+		args = append(args, fmt.Sprintf("--max-buckets=%d", *opts.MaxBuckets))
+	}
+	if opts.MaxAccessKeys != nil {
+		args = append(args, fmt.Sprintf("--max-access-keys=%d", *opts.MaxAccessKeys))
+	}
+	return args
 }
 
-func (c *CephCLI) PoolGet(ctx context.Context, poolName, key string) (string, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "get", poolName, key)
+// RgwAccountCreate creates an RGW account. Accounts were added in Squid; an
+// empty accountID lets RGW generate one.
+func (c *CephCLI) RgwAccountCreate(ctx context.Context, accountID, tenant string, opts *RgwAccountOptions) (*RgwAccountInfo, error) {
+	args := []string{"--conf", c.confPath, "--format=json", "account", "create"}
+	if accountID != "" {
+		args = append(args, "--account-id="+accountID)
+	}
+	if tenant != "" {
+		args = append(args, "--tenant="+tenant)
+	}
+	if opts != nil {
+		args = append(args, opts.args()...)
+	}
+
+	cmd := c.command(ctx, "radosgw-admin", args...)
 	output, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("failed to get pool %s property %s: %w", poolName, key, err)
+		return nil, fmt.Errorf("failed to create rgw account: %w", err)
 	}
 
-	text := strings.TrimSpace(string(output))
-	prefix := key + ": "
-	if !strings.HasPrefix(text, prefix) {
-		return "", fmt.Errorf("unexpected output format: %s", text)
+	var account RgwAccountInfo
+	if err := json.Unmarshal(output, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse rgw account create output: %w", err)
 	}
 
-	value := strings.TrimPrefix(text, prefix)
-	return strings.TrimSpace(value), nil
+	return &account, nil
 }
 
-func (c *CephCLI) PoolSet(ctx context.Context, poolName, key, value string) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "set", poolName, key, value)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to set pool %s property %s=%s: %w", poolName, key, value, err)
-	}
-
-	actualValue, err := c.PoolGet(ctx, poolName, key)
+func (c *CephCLI) RgwAccountGet(ctx context.Context, accountID string) (*RgwAccountInfo, error) {
+	cmd := c.command(ctx, "radosgw-admin", "--conf", c.confPath, "--format=json", "account", "get", "--account-id="+accountID)
+	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to verify pool property: %w", err)
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			stderr := string(exitErr.Stderr)
+			if strings.Contains(stderr, "No such file or directory") {
+				return nil, fmt.Errorf("failed to get rgw account %s: %w", accountID, ErrRGWAccountNotFound)
+			}
+		}
+		return nil, fmt.Errorf("failed to get rgw account %s: %w", accountID, err)
 	}
-	if actualValue != value {
-		return fmt.Errorf("pool property %s not updated: expected %q, got %q", key, value, actualValue)
+
+	var account RgwAccountInfo
+	if err := json.Unmarshal(output, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse rgw account get output: %w", err)
 	}
-	return nil
+
+	return &account, nil
 }
 
-func (c *CephCLI) PoolSetWait(ctx context.Context, poolName, key, value string) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "set", poolName, key, value)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to set pool %s property %s=%s: %w", poolName, key, value, err)
+func (c *CephCLI) RgwAccountModify(ctx context.Context, accountID string, opts *RgwAccountOptions) (*RgwAccountInfo, error) {
+	args := []string{"--conf", c.confPath, "--format=json", "account", "modify", "--account-id=" + accountID}
+	if opts != nil {
+		args = append(args, opts.args()...)
 	}
 
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
+	cmd := c.command(ctx, "radosgw-admin", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to modify rgw account %s: %w", accountID, err)
+	}
 
-	var lastValue string
-	for {
-		select {
-		case <-ticker.C:
-			actualValue, err := c.PoolGet(ctx, poolName, key)
-			if err != nil {
-				continue
-			}
-			lastValue = actualValue
-			if actualValue == value {
-				return nil
+	var account RgwAccountInfo
+	if err := json.Unmarshal(output, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse rgw account modify output: %w", err)
+	}
+
+	return &account, nil
+}
+
+func (c *CephCLI) RgwAccountRemove(ctx context.Context, accountID string) error {
+	cmd := c.command(ctx, "radosgw-admin", "--conf", c.confPath, "account", "rm", "--account-id="+accountID)
+	_, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			stderr := string(exitErr.Stderr)
+			if strings.Contains(stderr, "No such file or directory") {
+				return fmt.Errorf("failed to remove rgw account %s: %w", accountID, ErrRGWAccountNotFound)
 			}
-		case <-ctx.Done():
-			return fmt.Errorf("pool property %s not updated: expected %q, got %q: %w", key, value, lastValue, ctx.Err())
 		}
+		return fmt.Errorf("failed to remove rgw account %s: %w", accountID, err)
 	}
+	return nil
 }
 
-func (c *CephCLI) PoolSetQuota(ctx context.Context, poolName, field string, value int64) error {
-	valueStr := strconv.FormatInt(value, 10)
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "set-quota", poolName, field, valueStr)
+type CrushTunables struct {
+	Profile string `json:"profile"`
+}
+
+func (c *CephCLI) OsdCrushShowTunables(ctx context.Context) (*CrushTunables, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "show-tunables", "--format", "json")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to show crush tunables: %w", err)
+	}
+
+	var tunables CrushTunables
+	if err := json.Unmarshal(output, &tunables); err != nil {
+		return nil, fmt.Errorf("failed to parse crush tunables: %w", err)
+	}
+
+	return &tunables, nil
+}
+
+func (c *CephCLI) OsdCrushTunables(ctx context.Context, profile string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "tunables", profile)
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to set pool %s quota %s=%v: %w", poolName, field, value, err)
+		return fmt.Errorf("failed to set crush tunables profile %s: %w", profile, err)
 	}
+	return nil
+}
 
-	actualValue, err := c.PoolGetQuota(ctx, poolName, field)
+// OsdReleaseRequirements are the release requirements recorded in the OSD
+// map. MinCompatClient is the oldest client release that supports the
+// features the map uses, which RequireMinCompatClient cannot be lowered past.
+type OsdReleaseRequirements struct {
+	RequireMinCompatClient string `json:"require_min_compat_client"`
+	MinCompatClient        string `json:"min_compat_client"`
+	RequireOSDRelease      string `json:"require_osd_release"`
+}
+
+func (c *CephCLI) OsdGetReleaseRequirements(ctx context.Context) (*OsdReleaseRequirements, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "dump", "--format", "json")
+	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to verify pool quota: %w", err)
+		return nil, fmt.Errorf("failed to dump osd map: %w", err)
 	}
 
-	if value != actualValue {
-		return fmt.Errorf("pool quota %s not updated: expected %v, got %v", field, value, actualValue)
+	var requirements OsdReleaseRequirements
+	if err := json.Unmarshal(output, &requirements); err != nil {
+		return nil, fmt.Errorf("failed to parse osd dump output: %w", err)
+	}
+
+	return &requirements, nil
+}
+
+func (c *CephCLI) OsdGetRequireMinCompatClient(ctx context.Context) (string, error) {
+	requirements, err := c.OsdGetReleaseRequirements(ctx)
+	if err != nil {
+		return "", err
+	}
+	return requirements.RequireMinCompatClient, nil
+}
+
+// OsdSetRequireMinCompatClient sets the oldest client release allowed to
+// connect. Ceph refuses while older clients are connected or below the
+// release the features in use require, and explains why in its output.
+func (c *CephCLI) OsdSetRequireMinCompatClient(ctx context.Context, release string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "set-require-min-compat-client", release)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set require-min-compat-client %s: %w: %s", release, err, strings.TrimSpace(string(output)))
 	}
 	return nil
 }
 
-func (c *CephCLI) PoolGetQuota(ctx context.Context, poolName, field string) (int64, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "get-quota", poolName, "--format", "json")
+// OsdRequireOSDRelease sets the oldest release OSDs must run. Ceph refuses to
+// lower it or to raise it past the release of any OSD.
+func (c *CephCLI) OsdRequireOSDRelease(ctx context.Context, release string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "require-osd-release", release)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set require-osd-release %s: %w: %s", release, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) OsdCrushClassList(ctx context.Context) ([]string, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "class", "ls", "--format", "json")
 	output, err := cmd.Output()
 	if err != nil {
-		return 0, fmt.Errorf("failed to get pool %s quota: %w", poolName, err)
+		return nil, fmt.Errorf("failed to list device classes: %w", err)
 	}
 
-	var quotaInfo PoolQuotaInfo
-	if err := json.Unmarshal(output, &quotaInfo); err != nil {
-		return 0, fmt.Errorf("failed to parse quota JSON: %w", err)
+	var classes []string
+	if err := json.Unmarshal(output, &classes); err != nil {
+		return nil, fmt.Errorf("failed to parse device class list: %w", err)
 	}
 
-	switch field {
-	case "max_objects":
-		return quotaInfo.QuotaMaxObjects, nil
-	case "max_bytes":
-		return quotaInfo.QuotaMaxBytes, nil
-	default:
-		return 0, fmt.Errorf("unknown quota field: %s", field)
-	}
+	return classes, nil
 }
 
-func (c *CephCLI) PoolApplicationGet(ctx context.Context, poolName string) ([]string, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "application", "get", poolName, "--format", "json")
+func (c *CephCLI) OsdCrushClassListOSDs(ctx context.Context, class string) ([]int64, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "class", "ls-osd", class, "--format", "json")
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pool %s applications: %w", poolName, err)
+		return nil, fmt.Errorf("failed to list OSDs of device class %s: %w", class, err)
 	}
 
-	var apps map[string]any
-	if err := json.Unmarshal(output, &apps); err != nil {
-		return nil, fmt.Errorf("failed to parse pool applications: %w", err)
+	var osds []int64
+	if err := json.Unmarshal(output, &osds); err != nil {
+		return nil, fmt.Errorf("failed to parse OSDs of device class %s: %w", class, err)
 	}
 
-	result := make([]string, 0, len(apps))
-	for app := range apps {
-		result = append(result, app)
+	return osds, nil
+}
+
+func (c *CephCLI) OsdCrushClassCreate(ctx context.Context, class string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "class", "create", class)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create device class %s: %w: %s", class, err, strings.TrimSpace(string(output)))
 	}
-	return result, nil
+	return nil
 }
 
-func (c *CephCLI) PoolApplicationEnable(ctx context.Context, poolName, application string) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "application", "enable", poolName, application)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to enable application %s on pool %s: %w", application, poolName, err)
+// OsdCrushClassRemove removes a device class. Ceph refuses while a CRUSH
+// rule uses it.
+func (c *CephCLI) OsdCrushClassRemove(ctx context.Context, class string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "class", "rm", class)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove device class %s: %w: %s", class, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// OsdCrushSetDeviceClass moves OSDs to a device class. Ceph does not change
+// the class of an OSD that has one, so their current class is removed first.
+func (c *CephCLI) OsdCrushSetDeviceClass(ctx context.Context, class string, osds []int64) error {
+	if len(osds) == 0 {
+		return nil
 	}
 
-	apps, err := c.PoolApplicationGet(ctx, poolName)
-	if err != nil {
-		return fmt.Errorf("failed to verify application was enabled: %w", err)
+	if err := c.OsdCrushRemoveDeviceClass(ctx, osds); err != nil {
+		return err
 	}
 
-	if slices.Contains(apps, application) {
+	args := []string{"--conf", c.confPath, "osd", "crush", "set-device-class", class}
+	for _, osd := range osds {
+		args = append(args, fmt.Sprintf("osd.%d", osd))
+	}
+
+	cmd := c.command(ctx, "ceph", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set device class %s: %w: %s", class, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) OsdCrushRemoveDeviceClass(ctx context.Context, osds []int64) error {
+	if len(osds) == 0 {
 		return nil
 	}
-	return fmt.Errorf("application %s not found in pool %s applications after enabling", application, poolName)
+
+	args := []string{"--conf", c.confPath, "osd", "crush", "rm-device-class"}
+	for _, osd := range osds {
+		args = append(args, fmt.Sprintf("osd.%d", osd))
+	}
+
+	cmd := c.command(ctx, "ceph", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove device class: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
 }
 
-func (c *CephCLI) PoolExists(ctx context.Context, poolName string) (bool, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "get", poolName, "size")
-	output, err := cmd.CombinedOutput()
+func (c *CephCLI) OsdCrushGetDeviceClass(ctx context.Context, osd int64) (string, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "crush", "get-device-class", fmt.Sprintf("osd.%d", osd))
+	output, err := cmd.Output()
 	if err != nil {
-		if _, ok := err.(*exec.ExitError); ok {
-			stderr := strings.ToLower(string(output))
-			if strings.Contains(stderr, "error enoent") || strings.Contains(stderr, "pool does not exist") || strings.Contains(stderr, "unrecognized pool") {
-				return false, nil
-			}
-		}
-		return false, fmt.Errorf("failed to check pool existence: %w", err)
+		return "", fmt.Errorf("failed to get device class of osd.%d: %w", osd, err)
 	}
-	return true, nil
+	return strings.TrimSpace(string(output)), nil
 }
 
-type RgwBucketInfo struct {
-	Owner string `json:"owner"`
+type CrushTreeNode struct {
+	ID          int64   `json:"id"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	TypeID      int64   `json:"type_id"`
+	DeviceClass string  `json:"device_class"`
+	CrushWeight float64 `json:"crush_weight"`
+	Children    []int64 `json:"children"`
 }
 
-func (c *CephCLI) RgwBucketInfo(ctx context.Context, bucket string) (*RgwBucketInfo, error) {
-	cmd := exec.CommandContext(ctx, "radosgw-admin", "--conf", c.confPath, "--format=json", "bucket", "stats", "--bucket="+bucket)
+type CrushTree struct {
+	Nodes []CrushTreeNode `json:"nodes"`
+	Stray []CrushTreeNode `json:"stray"`
+}
+
+// OsdCrushTree dumps the CRUSH hierarchy, including the per device class
+// shadow trees (e.g. "default~ssd") if showShadow is set.
+func (c *CephCLI) OsdCrushTree(ctx context.Context, showShadow bool) (*CrushTree, error) {
+	args := []string{"--conf", c.confPath, "osd", "crush", "tree", "--format", "json"}
+	if showShadow {
+		args = append(args, "--show-shadow")
+	}
+
+	cmd := c.command(ctx, "ceph", args...)
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get rgw bucket info for %s: %w", bucket, err)
+		return nil, fmt.Errorf("failed to dump crush tree: %w", err)
 	}
 
-	var bucketInfo RgwBucketInfo
-	if err := json.Unmarshal(output, &bucketInfo); err != nil {
-		return nil, fmt.Errorf("failed to parse rgw bucket info output: %w", err)
+	var tree CrushTree
+	if err := json.Unmarshal(output, &tree); err != nil {
+		return nil, fmt.Errorf("failed to parse crush tree: %w", err)
 	}
 
-	return &bucketInfo, nil
+	return &tree, nil
 }
 
-type CephHealthStatus struct {
-	Mgrmap CephHealthStatusMgrmap `json:"mgrmap"`
-	Monmap CephHealthStatusMonmap `json:"monmap"`
-	Osdmap CephHealthStatusOsdmap `json:"osdmap"`
+type MonDumpMon struct {
+	Name          string `json:"name"`
+	CrushLocation string `json:"crush_location"`
 }
 
-type CephHealthStatusMonmap struct {
-	NumMons int `json:"num_mons"`
+type MonDump struct {
+	ElectionStrategy int          `json:"election_strategy"`
+	StretchMode      bool         `json:"stretch_mode"`
+	TiebreakerMon    string       `json:"tiebreaker_mon"`
+	Mons             []MonDumpMon `json:"mons"`
 }
 
-type CephHealthStatusMgrmap struct {
-	Available bool `json:"available"`
+func (c *CephCLI) MonDump(ctx context.Context) (*MonDump, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "mon", "dump", "--format", "json")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump mon map: %w", err)
+	}
+
+	var dump MonDump
+	if err := json.Unmarshal(output, &dump); err != nil {
+		return nil, fmt.Errorf("failed to parse mon dump output: %w", err)
+	}
+
+	return &dump, nil
 }
 
-type CephHealthStatusOsdmap struct {
-	NumUpOsds int `json:"num_up_osds"`
+func (c *CephCLI) MonSetLocation(ctx context.Context, name string, location map[string]string) error {
+	args := []string{"--conf", c.confPath, "mon", "set_location", name}
+	for _, key := range slices.Sorted(maps.Keys(location)) {
+		args = append(args, key+"="+location[key])
+	}
+
+	cmd := c.command(ctx, "ceph", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set location of mon %s: %w: %s", name, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) MonSetElectionStrategy(ctx context.Context, strategy string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "mon", "set", "election_strategy", strategy)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set mon election strategy %s: %w: %s", strategy, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) MonEnableStretchMode(ctx context.Context, tiebreakerMon, crushRule, dividingBucket string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "mon", "enable_stretch_mode", tiebreakerMon, crushRule, dividingBucket)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to enable stretch mode: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// MonDisableStretchMode leaves stretch mode, moving stretched pools to
+// crushRule or to the default replicated rule when it is empty. It requires
+// Squid or later.
+func (c *CephCLI) MonDisableStretchMode(ctx context.Context, crushRule string) error {
+	args := []string{"--conf", c.confPath, "mon", "disable_stretch_mode"}
+	if crushRule != "" {
+		args = append(args, crushRule)
+	}
+	args = append(args, "--yes-i-really-mean-it")
+
+	cmd := c.command(ctx, "ceph", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to disable stretch mode: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+type DashboardSAML2Options struct {
+	BaseURL              string
+	IdPMetadata          string
+	IdPUsernameAttribute string
+	IdPEntityID          string
+	SPX509CertPath       string
+	SPPrivateKeyPath     string
+}
+
+// args passes the optional parameters by name, since any of them may be
+// omitted.
+func (o DashboardSAML2Options) args() []string {
+	args := []string{o.BaseURL, o.IdPMetadata}
+	if o.IdPUsernameAttribute != "" {
+		args = append(args, "--idp_username_attribute="+o.IdPUsernameAttribute)
+	}
+	if o.IdPEntityID != "" {
+		args = append(args, "--idp_entity_id="+o.IdPEntityID)
+	}
+	if o.SPX509CertPath != "" {
+		args = append(args, "--sp_x_509_cert="+o.SPX509CertPath)
+	}
+	if o.SPPrivateKeyPath != "" {
+		args = append(args, "--sp_private_key="+o.SPPrivateKeyPath)
+	}
+	return args
+}
+
+// DashboardSAML2Settings is the subset of "ceph dashboard sso show saml2"
+// the provider reads back.
+type DashboardSAML2Settings struct {
+	OneloginSettings struct {
+		SP struct {
+			EntityID                  string `json:"entityId"`
+			AttributeConsumingService struct {
+				RequestedAttributes []struct {
+					Name string `json:"name"`
+				} `json:"requestedAttributes"`
+			} `json:"attributeConsumingService"`
+		} `json:"sp"`
+		IdP struct {
+			EntityID string `json:"entityId"`
+		} `json:"idp"`
+	} `json:"onelogin_settings"`
+}
+
+func (c *CephCLI) DashboardSSOSetupSAML2(ctx context.Context, opts DashboardSAML2Options) error {
+	args := append([]string{"--conf", c.confPath, "dashboard", "sso", "setup", "saml2"}, opts.args()...)
+	cmd := c.command(ctx, "ceph", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set up dashboard SAML2 SSO: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// DashboardSSOEnabled reports whether dashboard SSO is enabled.
+func (c *CephCLI) DashboardSSOEnabled(ctx context.Context) (bool, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "dashboard", "sso", "status")
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to get dashboard SSO status: %w", err)
+	}
+
+	// e.g. `SSO is "enabled" with "SAML2" protocol.`
+	return strings.Contains(string(output), `"enabled"`), nil
+}
+
+func (c *CephCLI) DashboardSSOShowSAML2(ctx context.Context) (*DashboardSAML2Settings, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "dashboard", "sso", "show", "saml2")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to show dashboard SAML2 settings: %w", err)
+	}
+
+	var settings DashboardSAML2Settings
+	if err := json.Unmarshal(output, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard SAML2 settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
+func (c *CephCLI) DashboardSSODisable(ctx context.Context) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "dashboard", "sso", "disable")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to disable dashboard SSO: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) CrashArchiveAll(ctx context.Context) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "crash", "archive-all")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to archive crash reports: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// MgrFail fails over the active mgr to a standby.
+func (c *CephCLI) MgrFail(ctx context.Context) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "mgr", "fail")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to fail over mgr: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// OrchRestart restarts every daemon of an orchestrator service, e.g. rgw.foo.
+func (c *CephCLI) OrchRestart(ctx context.Context, service string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "orch", "restart", service)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to restart service %s: %w: %s", service, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+type OrchServiceStatus struct {
+	Running            int    `json:"running"`
+	Size               int    `json:"size"`
+	ContainerImageName string `json:"container_image_name"`
+	LastRefresh        string `json:"last_refresh"`
+}
+
+// OrchService is a service as listed by "ceph orch ls". Spec is the applied
+// service spec, i.e. the entry without its status and events.
+type OrchService struct {
+	ServiceType string            `json:"service_type"`
+	ServiceID   string            `json:"service_id"`
+	ServiceName string            `json:"service_name"`
+	Unmanaged   bool              `json:"unmanaged"`
+	Status      OrchServiceStatus `json:"status"`
+	Spec        map[string]any    `json:"-"`
+}
+
+// OrchLs lists the services the orchestrator manages, optionally only those
+// of one type.
+func (c *CephCLI) OrchLs(ctx context.Context, serviceType string) ([]OrchService, error) {
+	args := []string{"--conf", c.confPath, "orch", "ls", "--format", "json"}
+	if serviceType != "" {
+		args = append(args, "--service_type", serviceType)
+	}
+
+	cmd := c.command(ctx, "ceph", args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orchestrator services: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// e.g. "No services reported"
+	if !strings.HasPrefix(strings.TrimSpace(string(output)), "[") {
+		return []OrchService{}, nil
+	}
+
+	var services []OrchService
+	if err := json.Unmarshal(output, &services); err != nil {
+		return nil, fmt.Errorf("failed to parse orchestrator services: %w", err)
+	}
+
+	var specs []map[string]any
+	if err := json.Unmarshal(output, &specs); err != nil {
+		return nil, fmt.Errorf("failed to parse orchestrator services: %w", err)
+	}
+	for i := range services {
+		delete(specs[i], "status")
+		delete(specs[i], "events")
+		services[i].Spec = specs[i]
+	}
+
+	return services, nil
+}
+
+// OrchDaemon is a daemon as listed by "ceph orch ps".
+type OrchDaemon struct {
+	DaemonType  string `json:"daemon_type"`
+	DaemonID    string `json:"daemon_id"`
+	DaemonName  string `json:"daemon_name"`
+	ServiceName string `json:"service_name"`
+	Hostname    string `json:"hostname"`
+	StatusDesc  string `json:"status_desc"`
+	Version     string `json:"version"`
+}
+
+type OrchPsOptions struct {
+	ServiceName string
+	DaemonType  string
+	Hostname    string
+}
+
+// OrchPs lists the daemons the orchestrator manages, filtered by any
+// non-empty option.
+func (c *CephCLI) OrchPs(ctx context.Context, opts OrchPsOptions) ([]OrchDaemon, error) {
+	args := []string{"--conf", c.confPath, "orch", "ps", "--format", "json"}
+	if opts.ServiceName != "" {
+		args = append(args, "--service_name", opts.ServiceName)
+	}
+	if opts.DaemonType != "" {
+		args = append(args, "--daemon_type", opts.DaemonType)
+	}
+	if opts.Hostname != "" {
+		args = append(args, "--hostname", opts.Hostname)
+	}
+
+	cmd := c.command(ctx, "ceph", args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orchestrator daemons: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// e.g. "No daemons reported"
+	if !strings.HasPrefix(strings.TrimSpace(string(output)), "[") {
+		return []OrchDaemon{}, nil
+	}
+
+	var daemons []OrchDaemon
+	if err := json.Unmarshal(output, &daemons); err != nil {
+		return nil, fmt.Errorf("failed to parse orchestrator daemons: %w", err)
+	}
+
+	return daemons, nil
+}
+
+// OrchDeviceSysAPI is the subset of the ceph-volume device properties the
+// provider reads. rotational is "1" or "0".
+type OrchDeviceSysAPI struct {
+	Rotational string  `json:"rotational"`
+	Size       float64 `json:"size"`
+	Model      string  `json:"model"`
+	Vendor     string  `json:"vendor"`
+}
+
+type OrchDevice struct {
+	Path            string           `json:"path"`
+	DeviceID        string           `json:"device_id"`
+	Available       bool             `json:"available"`
+	RejectedReasons []string         `json:"rejected_reasons"`
+	SysAPI          OrchDeviceSysAPI `json:"sys_api"`
+}
+
+type OrchDeviceHost struct {
+	Name    string       `json:"name"`
+	Devices []OrchDevice `json:"devices"`
+}
+
+// OrchDeviceLs lists the storage devices the orchestrator found on each
+// host, optionally only on the given hosts.
+func (c *CephCLI) OrchDeviceLs(ctx context.Context, hostnames ...string) ([]OrchDeviceHost, error) {
+	args := append([]string{"--conf", c.confPath, "orch", "device", "ls", "--format", "json"}, hostnames...)
+
+	cmd := c.command(ctx, "ceph", args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orchestrator devices: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var hosts []OrchDeviceHost
+	if err := json.Unmarshal(output, &hosts); err != nil {
+		return nil, fmt.Errorf("failed to parse orchestrator devices: %w", err)
+	}
+
+	return hosts, nil
+}
+
+// CephadmGetUser returns the user cephadm logs in as over SSH.
+func (c *CephCLI) CephadmGetUser(ctx context.Context) (string, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "cephadm", "get-user")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cephadm ssh user: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (c *CephCLI) CephadmSetUser(ctx context.Context, user string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "cephadm", "set-user", user)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set cephadm ssh user: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// CephadmGetSSHConfig returns the custom ssh_config set for cephadm, or "" if
+// it uses the built-in one. "ceph cephadm get-ssh-config" would return the
+// built-in config instead, so this reads the mgr store directly.
+func (c *CephCLI) CephadmGetSSHConfig(ctx context.Context) (string, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "config-key", "get", "mgr/cephadm/ssh_config")
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if strings.Contains(stderr.String(), "ENOENT") {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get cephadm ssh config: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(output), nil
+}
+
+func (c *CephCLI) CephadmSetSSHConfig(ctx context.Context, sshConfig string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "cephadm", "set-ssh-config", "-i", "-")
+	cmd.Stdin = strings.NewReader(sshConfig)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set cephadm ssh config: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) CephadmClearSSHConfig(ctx context.Context) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "cephadm", "clear-ssh-config")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to clear cephadm ssh config: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// CephadmRegistryLogin logs every host in to a container registry and stores
+// the credentials for hosts added later. The credentials are passed on stdin
+// so the password does not show up in the process list.
+func (c *CephCLI) CephadmRegistryLogin(ctx context.Context, url, username, password string) error {
+	input, err := json.Marshal(map[string]string{
+		"url":      url,
+		"username": username,
+		"password": password,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode registry credentials: %w", err)
+	}
+
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "cephadm", "registry-login", "-i", "-")
+	cmd.Stdin = bytes.NewReader(input)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to log in to registry %s: %w: %s", url, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+type FsMirrorPeerRemote struct {
+	ClientName  string `json:"client_name"`
+	ClusterName string `json:"cluster_name"`
+	FsName      string `json:"fs_name"`
+}
+
+type FsMirrorPeer struct {
+	UUID   string             `json:"uuid"`
+	Remote FsMirrorPeerRemote `json:"remote"`
+}
+
+type FsMirrorInfo struct {
+	Peers []FsMirrorPeer `json:"peers"`
+}
+
+type FsDumpMDSInfo struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+type FsDumpMDSMap struct {
+	FsName string                   `json:"fs_name"`
+	Info   map[string]FsDumpMDSInfo `json:"info"`
+}
+
+// FsDumpFilesystem is a filesystem in the FSMap. MirrorInfo is nil unless
+// snapshot mirroring is enabled.
+type FsDumpFilesystem struct {
+	ID         int           `json:"id"`
+	MDSMap     FsDumpMDSMap  `json:"mdsmap"`
+	MirrorInfo *FsMirrorInfo `json:"mirror_info"`
+}
+
+type FsDump struct {
+	Filesystems []FsDumpFilesystem `json:"filesystems"`
+}
+
+// Filesystem returns the filesystem with the given name, or nil.
+func (d *FsDump) Filesystem(name string) *FsDumpFilesystem {
+	for i := range d.Filesystems {
+		if d.Filesystems[i].MDSMap.FsName == name {
+			return &d.Filesystems[i]
+		}
+	}
+	return nil
+}
+
+func (c *CephCLI) FsDump(ctx context.Context) (*FsDump, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "fs", "dump", "--format", "json")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump fs map: %w", err)
+	}
+
+	var dump FsDump
+	if err := json.Unmarshal(output, &dump); err != nil {
+		return nil, fmt.Errorf("failed to parse fs dump output: %w", err)
+	}
+
+	return &dump, nil
+}
+
+// FsSnapshotMirrorEnable enables snapshot mirroring on a filesystem. The
+// command is served by the mirroring mgr module, so it is retried for a while
+// when the module was only just enabled and has not loaded yet.
+func (c *CephCLI) FsSnapshotMirrorEnable(ctx context.Context, fsName string) error {
+	deadline := time.After(30 * time.Second)
+	for {
+		cmd := c.command(ctx, "ceph", "--conf", c.confPath, "fs", "snapshot", "mirror", "enable", fsName)
+		output, err := cmd.CombinedOutput()
+		if err == nil {
+			return nil
+		}
+		if !strings.Contains(string(output), "Module 'mirroring'") {
+			return fmt.Errorf("failed to enable snapshot mirroring on %s: %w: %s", fsName, err, strings.TrimSpace(string(output)))
+		}
+
+		select {
+		case <-time.After(time.Second):
+		case <-deadline:
+			return fmt.Errorf("failed to enable snapshot mirroring on %s: %w: %s", fsName, err, strings.TrimSpace(string(output)))
+		case <-ctx.Done():
+			return fmt.Errorf("failed to enable snapshot mirroring on %s: %w", fsName, ctx.Err())
+		}
+	}
+}
+
+func (c *CephCLI) FsSnapshotMirrorDisable(ctx context.Context, fsName string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "fs", "snapshot", "mirror", "disable", fsName)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to disable snapshot mirroring on %s: %w: %s", fsName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// FsSnapshotMirrorPeerAdd adds a peer given as a cluster spec such as
+// client.mirror_remote@remote. monHost and key may both be empty, in which
+// case the remote cluster config must already be present on the mgr host.
+func (c *CephCLI) FsSnapshotMirrorPeerAdd(ctx context.Context, fsName, remoteClusterSpec, remoteFsName, monHost, key string) error {
+	args := []string{"--conf", c.confPath, "fs", "snapshot", "mirror", "peer_add", fsName, remoteClusterSpec, remoteFsName}
+	if monHost != "" {
+		args = append(args, monHost, key)
+	}
+
+	cmd := c.command(ctx, "ceph", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to add snapshot mirror peer to %s: %w: %s", fsName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) FsSnapshotMirrorPeerBootstrapImport(ctx context.Context, fsName, token string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "fs", "snapshot", "mirror", "peer_bootstrap", "import", fsName, token)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to import snapshot mirror peer bootstrap token to %s: %w: %s", fsName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) FsSnapshotMirrorPeerRemove(ctx context.Context, fsName, uuid string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "fs", "snapshot", "mirror", "peer_remove", fsName, uuid)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove snapshot mirror peer %s from %s: %w: %s", uuid, fsName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RBDTrashPurgeSchedule is one schedule of an RBD trash purge schedule level.
+// StartTime is nil when the schedule has no start time.
+type RBDTrashPurgeSchedule struct {
+	Interval  string  `json:"interval"`
+	StartTime *string `json:"start_time"`
+}
+
+type RBDTrashPurgeScheduleLevel struct {
+	Name     string                  `json:"name"`
+	Schedule []RBDTrashPurgeSchedule `json:"schedule"`
+}
+
+// RBDTrashPurgeScheduleAdd adds a schedule to a level spec such as "rbd/" or
+// "rbd/namespace/". startTime may be empty.
+func (c *CephCLI) RBDTrashPurgeScheduleAdd(ctx context.Context, levelSpec, interval, startTime string) error {
+	args := []string{"--conf", c.confPath, "rbd", "trash", "purge", "schedule", "add", levelSpec, interval}
+	if startTime != "" {
+		args = append(args, startTime)
+	}
+
+	cmd := c.command(ctx, "ceph", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to add trash purge schedule to %s: %w: %s", levelSpec, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) RBDTrashPurgeScheduleRemove(ctx context.Context, levelSpec, interval, startTime string) error {
+	args := []string{"--conf", c.confPath, "rbd", "trash", "purge", "schedule", "remove", levelSpec, interval}
+	if startTime != "" {
+		args = append(args, startTime)
+	}
+
+	cmd := c.command(ctx, "ceph", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove trash purge schedule from %s: %w: %s", levelSpec, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RBDTrashPurgeScheduleList returns the schedules of a level spec and its
+// children, keyed by level spec ID.
+func (c *CephCLI) RBDTrashPurgeScheduleList(ctx context.Context, levelSpec string) (map[string]RBDTrashPurgeScheduleLevel, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "rbd", "trash", "purge", "schedule", "list", levelSpec)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("failed to list trash purge schedules of %s: %w: %s", levelSpec, err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to list trash purge schedules of %s: %w", levelSpec, err)
+	}
+
+	levels := map[string]RBDTrashPurgeScheduleLevel{}
+	if err := json.Unmarshal(output, &levels); err != nil {
+		return nil, fmt.Errorf("failed to parse trash purge schedules: %w", err)
+	}
+
+	return levels, nil
+}
+
+// MonCommand runs a mon command given in its JSON form, e.g.
+// {"prefix": "osd pool set", "pool": "rbd", "var": "size", "val": "3"}, and
+// returns its output.
+func (c *CephCLI) MonCommand(ctx context.Context, command map[string]any) (string, error) {
+	commandArgs, err := monCommandArgs(command)
+	if err != nil {
+		return "", err
+	}
+
+	args := append([]string{"--conf", c.confPath}, commandArgs...)
+	cmd := c.command(ctx, "ceph", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("failed to run %q: %w: %s", command["prefix"], err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("failed to run %q: %w", command["prefix"], err)
+	}
+	return string(output), nil
+}
+
+// monCommandArgs converts a JSON mon command to ceph CLI arguments: the
+// prefix words followed by every other field as a named argument, repeated
+// for lists.
+func monCommandArgs(command map[string]any) ([]string, error) {
+	prefix, ok := command["prefix"].(string)
+	if !ok || strings.TrimSpace(prefix) == "" {
+		return nil, fmt.Errorf("command must have a string \"prefix\"")
+	}
+
+	args := strings.Fields(prefix)
+	for _, key := range slices.Sorted(maps.Keys(command)) {
+		if key == "prefix" {
+			continue
+		}
+
+		values, ok := command[key].([]any)
+		if !ok {
+			values = []any{command[key]}
+		}
+		for _, value := range values {
+			switch v := value.(type) {
+			case string:
+				args = append(args, "--"+key+"="+v)
+			case float64:
+				args = append(args, "--"+key+"="+strconv.FormatFloat(v, 'f', -1, 64))
+			case bool:
+				args = append(args, "--"+key+"="+strconv.FormatBool(v))
+			default:
+				return nil, fmt.Errorf("unsupported value for %q: %v", key, value)
+			}
+		}
+	}
+	return args, nil
+}
+
+func (c *CephCLI) PoolCreate(ctx context.Context, poolName string, pgNum int, poolType string) error {
+	args := []string{"--conf", c.confPath, "osd", "pool", "create", poolName, fmt.Sprintf("%d", pgNum)}
+	if poolType != "" {
+		args = append(args, poolType)
+	}
+
+	cmd := c.command(ctx, "ceph", args...)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to create pool %s: %w", poolName, err)
+	}
+
+	_, err := c.PoolGet(ctx, poolName, "size")
+	if err != nil {
+		return fmt.Errorf("failed to verify pool creation: %w", err)
+	}
+	return nil
+}
+
+func (c *CephCLI) PoolDelete(ctx context.Context, poolName string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "delete", poolName, poolName, "--yes-i-really-really-mean-it")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to delete pool %s: %w", poolName, err)
+	}
+
+	_, err := c.PoolGet(ctx, poolName, "size")
+	if err == nil {
+		return fmt.Errorf("pool still exists after deletion: %s", poolName)
+	}
+	return nil
+}
+
+func (c *CephCLI) PoolGet(ctx context.Context, poolName, key string) (string, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "get", poolName, key)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get pool %s property %s: %w", poolName, key, err)
+	}
+
+	text := strings.TrimSpace(string(output))
+	prefix := key + ": "
+	if !strings.HasPrefix(text, prefix) {
+		return "", fmt.Errorf("unexpected output format: %s", text)
+	}
+
+	value := strings.TrimPrefix(text, prefix)
+	return strings.TrimSpace(value), nil
+}
+
+func (c *CephCLI) PoolSet(ctx context.Context, poolName, key, value string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "set", poolName, key, value)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to set pool %s property %s=%s: %w", poolName, key, value, err)
+	}
+
+	actualValue, err := c.PoolGet(ctx, poolName, key)
+	if err != nil {
+		return fmt.Errorf("failed to verify pool property: %w", err)
+	}
+	if actualValue != value {
+		return fmt.Errorf("pool property %s not updated: expected %q, got %q", key, value, actualValue)
+	}
+	return nil
+}
+
+func (c *CephCLI) PoolSetWait(ctx context.Context, poolName, key, value string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "set", poolName, key, value)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to set pool %s property %s=%s: %w", poolName, key, value, err)
+	}
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	var lastValue string
+	for {
+		select {
+		case <-ticker.C:
+			actualValue, err := c.PoolGet(ctx, poolName, key)
+			if err != nil {
+				continue
+			}
+			lastValue = actualValue
+			if actualValue == value {
+				return nil
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("pool property %s not updated: expected %q, got %q: %w", key, value, lastValue, ctx.Err())
+		}
+	}
+}
+
+func (c *CephCLI) PoolSetQuota(ctx context.Context, poolName, field string, value int64) error {
+	valueStr := strconv.FormatInt(value, 10)
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "set-quota", poolName, field, valueStr)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to set pool %s quota %s=%v: %w", poolName, field, value, err)
+	}
+
+	actualValue, err := c.PoolGetQuota(ctx, poolName, field)
+	if err != nil {
+		return fmt.Errorf("failed to verify pool quota: %w", err)
+	}
+
+	if value != actualValue {
+		return fmt.Errorf("pool quota %s not updated: expected %v, got %v", field, value, actualValue)
+	}
+	return nil
+}
+
+func (c *CephCLI) PoolGetQuota(ctx context.Context, poolName, field string) (int64, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "get-quota", poolName, "--format", "json")
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pool %s quota: %w", poolName, err)
+	}
+
+	var quotaInfo PoolQuotaInfo
+	if err := json.Unmarshal(output, &quotaInfo); err != nil {
+		return 0, fmt.Errorf("failed to parse quota JSON: %w", err)
+	}
+
+	switch field {
+	case "max_objects":
+		return quotaInfo.QuotaMaxObjects, nil
+	case "max_bytes":
+		return quotaInfo.QuotaMaxBytes, nil
+	default:
+		return 0, fmt.Errorf("unknown quota field: %s", field)
+	}
+}
+
+func (c *CephCLI) PoolApplicationGet(ctx context.Context, poolName string) ([]string, error) {
+	apps, err := c.PoolApplicationMetadata(ctx, poolName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(apps))
+	for app := range apps {
+		result = append(result, app)
+	}
+	return result, nil
+}
+
+// PoolApplicationMetadata returns the applications enabled on a pool with the
+// key/value pairs set on each by "osd pool application set".
+func (c *CephCLI) PoolApplicationMetadata(ctx context.Context, poolName string) (map[string]map[string]string, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "application", "get", poolName, "--format", "json")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool %s applications: %w", poolName, err)
+	}
+
+	var apps map[string]map[string]string
+	if err := json.Unmarshal(output, &apps); err != nil {
+		return nil, fmt.Errorf("failed to parse pool applications: %w", err)
+	}
+	return apps, nil
+}
+
+func (c *CephCLI) PoolApplicationEnable(ctx context.Context, poolName, application string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "application", "enable", poolName, application)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to enable application %s on pool %s: %w", application, poolName, err)
+	}
+
+	apps, err := c.PoolApplicationGet(ctx, poolName)
+	if err != nil {
+		return fmt.Errorf("failed to verify application was enabled: %w", err)
+	}
+
+	if slices.Contains(apps, application) {
+		return nil
+	}
+	return fmt.Errorf("application %s not found in pool %s applications after enabling", application, poolName)
+}
+
+func (c *CephCLI) FsNew(ctx context.Context, fsName, metadataPool, dataPool string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "fs", "new", fsName, metadataPool, dataPool)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create filesystem %s: %w: %s", fsName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) FsSubvolumeCreate(ctx context.Context, fsName, subvolumeName string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "fs", "subvolume", "create", fsName, subvolumeName)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create subvolume %s: %w: %s", subvolumeName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) FsSubvolumeRemove(ctx context.Context, fsName, subvolumeName string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "fs", "subvolume", "rm", fsName, subvolumeName, "--force")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove subvolume %s: %w: %s", subvolumeName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) FsRemove(ctx context.Context, fsName string) error {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "fs", "fail", fsName)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to fail filesystem %s: %w: %s", fsName, err, strings.TrimSpace(string(output)))
+	}
+
+	cmd = c.command(ctx, "ceph", "--conf", c.confPath, "fs", "rm", fsName, "--yes-i-really-mean-it")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove filesystem %s: %w: %s", fsName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) RBDMirrorPoolEnable(ctx context.Context, poolName, mode string) error {
+	cmd := c.command(ctx, "rbd", "--conf", c.confPath, "mirror", "pool", "enable", poolName, mode)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to enable mirroring on pool %s: %w: %s", poolName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (c *CephCLI) PoolExists(ctx context.Context, poolName string) (bool, error) {
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "osd", "pool", "get", poolName, "size")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			stderr := strings.ToLower(string(output))
+			if strings.Contains(stderr, "error enoent") || strings.Contains(stderr, "pool does not exist") || strings.Contains(stderr, "unrecognized pool") {
+				return false, nil
+			}
+		}
+		return false, fmt.Errorf("failed to check pool existence: %w", err)
+	}
+	return true, nil
+}
+
+type RgwBucketInfo struct {
+	ID    string `json:"id"`
+	Owner string `json:"owner"`
+}
+
+func (c *CephCLI) RgwBucketInfo(ctx context.Context, bucket string) (*RgwBucketInfo, error) {
+	cmd := c.command(ctx, "radosgw-admin", "--conf", c.confPath, "--format=json", "bucket", "stats", "--bucket="+bucket)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			stderr := string(exitErr.Stderr)
+			if strings.Contains(stderr, "No such file or directory") {
+				return nil, fmt.Errorf("failed to get rgw bucket info for %s: %w", bucket, ErrRGWBucketNotFound)
+			}
+		}
+		return nil, fmt.Errorf("failed to get rgw bucket info for %s: %w", bucket, err)
+	}
+
+	var bucketInfo RgwBucketInfo
+	if err := json.Unmarshal(output, &bucketInfo); err != nil {
+		return nil, fmt.Errorf("failed to parse rgw bucket info output: %w", err)
+	}
+
+	return &bucketInfo, nil
+}
+
+// rgwBucketDataSyncDisabled is BUCKET_DATASYNC_DISABLED from the bucket
+// instance flags.
+const rgwBucketDataSyncDisabled = 0x8
+
+// RgwBucketSyncEnabled reports whether multisite data sync is enabled for a
+// bucket. bucket stats does not include the bucket flags, so they are read
+// from the bucket instance metadata.
+func (c *CephCLI) RgwBucketSyncEnabled(ctx context.Context, bucket string) (bool, error) {
+	bucketInfo, err := c.RgwBucketInfo(ctx, bucket)
+	if err != nil {
+		return false, err
+	}
+
+	cmd := c.command(ctx, "radosgw-admin", "--conf", c.confPath, "--format=json", "metadata", "get", "bucket.instance:"+bucket+":"+bucketInfo.ID)
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rgw bucket instance for %s: %w", bucket, err)
+	}
+
+	var instance struct {
+		Data struct {
+			BucketInfo struct {
+				Flags int `json:"flags"`
+			} `json:"bucket_info"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(output, &instance); err != nil {
+		return false, fmt.Errorf("failed to parse rgw bucket instance output: %w", err)
+	}
+
+	return instance.Data.BucketInfo.Flags&rgwBucketDataSyncDisabled == 0, nil
+}
+
+func (c *CephCLI) RgwBucketSyncSet(ctx context.Context, bucket string, enabled bool) error {
+	action := "disable"
+	if enabled {
+		action = "enable"
+	}
+
+	cmd := c.command(ctx, "radosgw-admin", "--conf", c.confPath, "bucket", "sync", action, "--bucket="+bucket)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to %s sync for rgw bucket %s: %w: %s", action, bucket, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RgwSyncPolicy is the multisite sync policy of the zonegroup or of a bucket
+// as reported by `radosgw-admin sync policy get`.
+type RgwSyncPolicy struct {
+	Groups []RgwSyncGroup `json:"groups"`
+}
+
+type RgwSyncGroup struct {
+	ID       string             `json:"id"`
+	DataFlow RgwSyncDataFlow    `json:"data_flow"`
+	Pipes    []RgwSyncGroupPipe `json:"pipes"`
+	Status   string             `json:"status"`
+}
+
+type RgwSyncDataFlow struct {
+	Symmetrical []RgwSyncSymmetricalFlow `json:"symmetrical"`
+	Directional []RgwSyncDirectionalFlow `json:"directional"`
+}
+
+type RgwSyncSymmetricalFlow struct {
+	ID    string   `json:"id"`
+	Zones []string `json:"zones"`
+}
+
+type RgwSyncDirectionalFlow struct {
+	SourceZone string `json:"source_zone"`
+	DestZone   string `json:"dest_zone"`
+}
+
+type RgwSyncGroupPipe struct {
+	ID     string              `json:"id"`
+	Source RgwSyncGroupPipeEnd `json:"source"`
+	Dest   RgwSyncGroupPipeEnd `json:"dest"`
+}
+
+type RgwSyncGroupPipeEnd struct {
+	Bucket string   `json:"bucket"`
+	Zones  []string `json:"zones"`
+}
+
+// Group returns the sync group with the given ID, or nil if there is none.
+func (p *RgwSyncPolicy) Group(groupID string) *RgwSyncGroup {
+	for i := range p.Groups {
+		if p.Groups[i].ID == groupID {
+			return &p.Groups[i]
+		}
+	}
+	return nil
+}
+
+// rgwSyncCommand runs a radosgw-admin sync subcommand against the zonegroup
+// policy, or against the policy of bucket if it is not empty.
+func (c *CephCLI) rgwSyncCommand(ctx context.Context, bucket string, args ...string) ([]byte, error) {
+	args = append([]string{"--conf", c.confPath, "--format=json"}, args...)
+	if bucket != "" {
+		args = append(args, "--bucket="+bucket)
+	}
+
+	cmd := c.command(ctx, "radosgw-admin", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			stderr := strings.TrimSpace(string(exitErr.Stderr))
+			if bucket != "" && strings.Contains(stderr, "No such file or directory") {
+				return nil, fmt.Errorf("%w: %s", ErrRGWBucketNotFound, stderr)
+			}
+			return nil, fmt.Errorf("%w: %s", err, stderr)
+		}
+		return nil, err
+	}
+	return output, nil
+}
+
+func (c *CephCLI) RgwSyncPolicyGet(ctx context.Context, bucket string) (*RgwSyncPolicy, error) {
+	output, err := c.rgwSyncCommand(ctx, bucket, "sync", "policy", "get")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync policy: %w", err)
+	}
+
+	var policy RgwSyncPolicy
+	if err := json.Unmarshal(output, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse sync policy output: %w", err)
+	}
+
+	return &policy, nil
+}
+
+func (c *CephCLI) RgwSyncGroupCreate(ctx context.Context, bucket, groupID, status string) error {
+	if _, err := c.rgwSyncCommand(ctx, bucket, "sync", "group", "create", "--group-id="+groupID, "--status="+status); err != nil {
+		return fmt.Errorf("failed to create sync group %s: %w", groupID, err)
+	}
+	return nil
+}
+
+func (c *CephCLI) RgwSyncGroupModify(ctx context.Context, bucket, groupID, status string) error {
+	if _, err := c.rgwSyncCommand(ctx, bucket, "sync", "group", "modify", "--group-id="+groupID, "--status="+status); err != nil {
+		return fmt.Errorf("failed to modify sync group %s: %w", groupID, err)
+	}
+	return nil
+}
+
+func (c *CephCLI) RgwSyncGroupRemove(ctx context.Context, bucket, groupID string) error {
+	if _, err := c.rgwSyncCommand(ctx, bucket, "sync", "group", "remove", "--group-id="+groupID); err != nil {
+		return fmt.Errorf("failed to remove sync group %s: %w", groupID, err)
+	}
+	return nil
+}
+
+func (c *CephCLI) RgwSyncSymmetricalFlowCreate(ctx context.Context, bucket, groupID string, flow RgwSyncSymmetricalFlow) error {
+	if _, err := c.rgwSyncCommand(ctx, bucket, "sync", "group", "flow", "create", "--group-id="+groupID, "--flow-id="+flow.ID, "--flow-type=symmetrical", "--zones="+strings.Join(flow.Zones, ",")); err != nil {
+		return fmt.Errorf("failed to create sync flow %s: %w", flow.ID, err)
+	}
+	return nil
+}
+
+func (c *CephCLI) RgwSyncSymmetricalFlowRemove(ctx context.Context, bucket, groupID, flowID string) error {
+	if _, err := c.rgwSyncCommand(ctx, bucket, "sync", "group", "flow", "remove", "--group-id="+groupID, "--flow-id="+flowID, "--flow-type=symmetrical"); err != nil {
+		return fmt.Errorf("failed to remove sync flow %s: %w", flowID, err)
+	}
+	return nil
+}
+
+// radosgw-admin requires a flow ID for directional flows, but they are only
+// identified by their zones, so one is derived from them.
+func rgwSyncDirectionalFlowID(flow RgwSyncDirectionalFlow) string {
+	return flow.SourceZone + "-" + flow.DestZone
+}
+
+func (c *CephCLI) RgwSyncDirectionalFlowCreate(ctx context.Context, bucket, groupID string, flow RgwSyncDirectionalFlow) error {
+	if _, err := c.rgwSyncCommand(ctx, bucket, "sync", "group", "flow", "create", "--group-id="+groupID, "--flow-id="+rgwSyncDirectionalFlowID(flow), "--flow-type=directional", "--source-zone="+flow.SourceZone, "--dest-zone="+flow.DestZone); err != nil {
+		return fmt.Errorf("failed to create sync flow from %s to %s: %w", flow.SourceZone, flow.DestZone, err)
+	}
+	return nil
+}
+
+func (c *CephCLI) RgwSyncDirectionalFlowRemove(ctx context.Context, bucket, groupID string, flow RgwSyncDirectionalFlow) error {
+	if _, err := c.rgwSyncCommand(ctx, bucket, "sync", "group", "flow", "remove", "--group-id="+groupID, "--flow-id="+rgwSyncDirectionalFlowID(flow), "--flow-type=directional", "--source-zone="+flow.SourceZone, "--dest-zone="+flow.DestZone); err != nil {
+		return fmt.Errorf("failed to remove sync flow from %s to %s: %w", flow.SourceZone, flow.DestZone, err)
+	}
+	return nil
+}
+
+func (c *CephCLI) RgwSyncPipeCreate(ctx context.Context, bucket, groupID string, pipe RgwSyncGroupPipe) error {
+	args := []string{"sync", "group", "pipe", "create", "--group-id=" + groupID, "--pipe-id=" + pipe.ID,
+		"--source-zones=" + strings.Join(pipe.Source.Zones, ","), "--dest-zones=" + strings.Join(pipe.Dest.Zones, ",")}
+	if pipe.Source.Bucket != "" {
+		args = append(args, "--source-bucket="+pipe.Source.Bucket)
+	}
+	if pipe.Dest.Bucket != "" {
+		args = append(args, "--dest-bucket="+pipe.Dest.Bucket)
+	}
+
+	if _, err := c.rgwSyncCommand(ctx, bucket, args...); err != nil {
+		return fmt.Errorf("failed to create sync pipe %s: %w", pipe.ID, err)
+	}
+	return nil
+}
+
+func (c *CephCLI) RgwSyncPipeRemove(ctx context.Context, bucket, groupID, pipeID string) error {
+	if _, err := c.rgwSyncCommand(ctx, bucket, "sync", "group", "pipe", "remove", "--group-id="+groupID, "--pipe-id="+pipeID); err != nil {
+		return fmt.Errorf("failed to remove sync pipe %s: %w", pipeID, err)
+	}
+	return nil
+}
+
+// RgwPeriodCommit commits the staged period so zonegroup changes such as
+// sync policy take effect across a realm.
+func (c *CephCLI) RgwPeriodCommit(ctx context.Context) error {
+	cmd := c.command(ctx, "radosgw-admin", "--conf", c.confPath, "period", "update", "--commit")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to commit period: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RgwUsage is the usage log reported by `radosgw-admin usage show`, with
+// per-bucket entries for each logged hour and a per-user summary.
+type RgwUsage struct {
+	Entries []RgwUsageEntry   `json:"entries"`
+	Summary []RgwUsageSummary `json:"summary"`
+}
+
+type RgwUsageEntry struct {
+	User    string           `json:"user"`
+	Buckets []RgwUsageBucket `json:"buckets"`
+}
+
+type RgwUsageBucket struct {
+	Bucket     string             `json:"bucket"`
+	Time       string             `json:"time"`
+	Owner      string             `json:"owner"`
+	Categories []RgwUsageCategory `json:"categories"`
+}
+
+type RgwUsageSummary struct {
+	User       string             `json:"user"`
+	Categories []RgwUsageCategory `json:"categories"`
+	Total      RgwUsageCategory   `json:"total"`
+}
+
+type RgwUsageCategory struct {
+	Category      string `json:"category"`
+	BytesSent     int64  `json:"bytes_sent"`
+	BytesReceived int64  `json:"bytes_received"`
+	Ops           int64  `json:"ops"`
+	SuccessfulOps int64  `json:"successful_ops"`
+}
+
+// RgwUsageShow reads the usage log, optionally filtered by user and bucket
+// and limited to [start, end). Zero times leave the window open.
+func (c *CephCLI) RgwUsageShow(ctx context.Context, uid, bucket string, start, end time.Time) (*RgwUsage, error) {
+	args := []string{"--conf", c.confPath, "--format=json", "usage", "show", "--show-log-entries=true", "--show-log-sum=true"}
+	if uid != "" {
+		args = append(args, "--uid="+uid)
+	}
+	if bucket != "" {
+		args = append(args, "--bucket="+bucket)
+	}
+	if !start.IsZero() {
+		args = append(args, "--start-date="+start.UTC().Format(time.DateTime))
+	}
+	if !end.IsZero() {
+		args = append(args, "--end-date="+end.UTC().Format(time.DateTime))
+	}
+
+	cmd := c.command(ctx, "radosgw-admin", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("failed to show rgw usage: %w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to show rgw usage: %w", err)
+	}
+
+	var usage RgwUsage
+	if err := json.Unmarshal(output, &usage); err != nil {
+		return nil, fmt.Errorf("failed to parse rgw usage output: %w", err)
+	}
+
+	return &usage, nil
+}
+
+type CephHealthStatus struct {
+	Mgrmap CephHealthStatusMgrmap `json:"mgrmap"`
+	Monmap CephHealthStatusMonmap `json:"monmap"`
+	Osdmap CephHealthStatusOsdmap `json:"osdmap"`
+}
+
+type CephHealthStatusMonmap struct {
+	NumMons int `json:"num_mons"`
+}
+
+type CephHealthStatusMgrmap struct {
+	Available bool `json:"available"`
+}
+
+type CephHealthStatusOsdmap struct {
+	NumUpOsds int `json:"num_up_osds"`
+}
+
+// RgwBucketRemove deletes a bucket and its objects, optionally bypassing
+// garbage collection so large buckets are removed immediately.
+func (c *CephCLI) RgwBucketRemove(ctx context.Context, bucket string, bypassGC bool) error {
+	args := []string{"--conf", c.confPath, "bucket", "rm", "--bucket=" + bucket, "--purge-objects"}
+	if bypassGC {
+		args = append(args, "--bypass-gc")
+	}
+
+	cmd := c.command(ctx, "radosgw-admin", args...)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to remove rgw bucket %s: %w", bucket, err)
+	}
+	return nil
 }
 
 func (c *CephCLI) CheckHealth(ctx context.Context) error {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "status", "--format", "json")
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "status", "--format", "json")
 	output, err := cmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to check cluster status: %w", err)
@@ -883,7 +2353,7 @@ func (c *CephCLI) CheckHealth(ctx context.Context) error {
 }
 
 func (c *CephCLI) ConfigDump(ctx context.Context) ([]ConfigDumpEntry, error) {
-	cmd := exec.CommandContext(ctx, "ceph", "--conf", c.confPath, "config", "dump", "--format", "json")
+	cmd := c.command(ctx, "ceph", "--conf", c.confPath, "config", "dump", "--format", "json")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to dump config: %w", err)
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ClientConfDataSource{}
+
+func newClientConfDataSource() datasource.DataSource {
+	return &ClientConfDataSource{}
+}
+
+type ClientConfDataSource struct {
+	client *CephAPIClient
+}
+
+type ClientConfDataSourceModel struct {
+	Overrides types.Map    `tfsdk:"overrides"`
+	FSID      types.String `tfsdk:"fsid"`
+	MonHost   types.String `tfsdk:"mon_host"`
+	Content   types.String `tfsdk:"content"`
+}
+
+func (d *ClientConfDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client_conf"
+}
+
+func (d *ClientConfDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source renders a minimal client `ceph.conf` (equivalent to `ceph config generate-minimal-conf`), for provisioning hosts and containers that connect to the cluster.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"overrides": dataSourceSchema.MapAttribute{
+				MarkdownDescription: "Additional options for the `[global]` section, keyed by option name",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.NoneOf("fsid", "mon_host")),
+				},
+			},
+			"fsid": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The cluster fsid",
+				Computed:            true,
+			},
+			"mon_host": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The `mon_host` value listing every monitor's address vector",
+				Computed:            true,
+			},
+			"content": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The rendered `ceph.conf`",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ClientConfDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ClientConfDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withReadCache(ctx)
+
+	var data ClientConfDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	overrides := map[string]string{}
+	if !data.Overrides.IsNull() {
+		resp.Diagnostics.Append(data.Overrides.ElementsAs(ctx, &overrides, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	monitor, err := d.client.GetMonitor(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to get monitor map from Ceph API: %s", err),
+		)
+		return
+	}
+	monMap := monitor.MonStatus.MonMap
+	monHost := formatMonHost(monMap.Mons)
+
+	data.FSID = types.StringValue(monMap.FSID)
+	data.MonHost = types.StringValue(monHost)
+	data.Content = types.StringValue(renderClientConf(monMap.FSID, monHost, overrides))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// formatMonHost formats the address vectors of the monitors in rank order the
+// way Ceph prints them, e.g. "[v2:10.0.0.1:3300/0,v1:10.0.0.1:6789/0]".
+func formatMonHost(mons []CephAPIMonMapMon) string {
+	mons = slices.Clone(mons)
+	slices.SortFunc(mons, func(a, b CephAPIMonMapMon) int {
+		return cmp.Compare(a.Rank, b.Rank)
+	})
+
+	vecs := make([]string, 0, len(mons))
+	for _, mon := range mons {
+		addrs := make([]string, 0, len(mon.PublicAddrs.AddrVec))
+		for _, addr := range mon.PublicAddrs.AddrVec {
+			addrs = append(addrs, fmt.Sprintf("%s:%s/%d", addr.Type, addr.Addr, addr.Nonce))
+		}
+		vecs = append(vecs, "["+strings.Join(addrs, ",")+"]")
+	}
+	return strings.Join(vecs, " ")
+}
+
+func renderClientConf(fsid, monHost string, overrides map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# minimal ceph.conf for %s\n", fsid)
+	b.WriteString("[global]\n")
+	fmt.Fprintf(&b, "\tfsid = %s\n", fsid)
+	fmt.Fprintf(&b, "\tmon_host = %s\n", monHost)
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		fmt.Fprintf(&b, "\t%s = %s\n", name, overrides[name])
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephClientConfDataSource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					data "ceph_client_conf" "test" {
+					  overrides = {
+					    rbd_cache      = "true"
+					    ms_client_mode   = "secure"
+					  }
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.ceph_client_conf.test",
+						tfjsonpath.New("mon_host"),
+						knownvalue.StringExact("[v1:127.0.0.1:6789/0]"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.ceph_client_conf.test",
+						tfjsonpath.New("content"),
+						knownvalue.StringExact("# minimal ceph.conf for 6bb5784d-86b1-4b48-aff7-04d5dd22ef07\n"+
+							"[global]\n"+
+							"\tfsid = 6bb5784d-86b1-4b48-aff7-04d5dd22ef07\n"+
+							"\tmon_host = [v1:127.0.0.1:6789/0]\n"+
+							"\tms_client_mode = secure\n"+
+							"\trbd_cache = true\n"),
+					),
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					data "ceph_client_conf" "test" {
+					  overrides = {
+					    fsid = "00000000-0000-0000-0000-000000000000"
+					  }
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const clusterFeaturesResourceID = "cluster_features"
+
+var (
+	_ resource.Resource                = &ClusterFeaturesResource{}
+	_ resource.ResourceWithImportState = &ClusterFeaturesResource{}
+)
+
+func newClusterFeaturesResource() resource.Resource {
+	return &ClusterFeaturesResource{}
+}
+
+// ClusterFeaturesResource manages the client and OSD releases the OSD map
+// requires, which gate features such as upmap. The dashboard API exposes
+// neither, so it always goes through the ceph CLI.
+type ClusterFeaturesResource struct {
+	client *CephAPIClient
+}
+
+type ClusterFeaturesResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	RequireMinCompatClient types.String `tfsdk:"require_min_compat_client"`
+	RequireOSDRelease      types.String `tfsdk:"require_osd_release"`
+	MinCompatClient        types.String `tfsdk:"min_compat_client"`
+	UpmapEnabled           types.Bool   `tfsdk:"upmap_enabled"`
+}
+
+func (r *ClusterFeaturesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_features"
+}
+
+func (r *ClusterFeaturesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the oldest client and OSD releases the cluster requires, as with `ceph osd set-require-min-compat-client` and `ceph osd require-osd-release`. " +
+			"Features such as `upmap` balancing and `pg-upmap-items` only work once `require_min_compat_client` is `luminous` or later; when Ceph refuses a change, for example because older clients are connected, its reason is reported. " +
+			"These settings are only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled. " +
+			"There is one set per cluster, so declare this resource at most once. It replaces the deprecated `require_min_compat_client` attribute of `ceph_osd_crush_tunables`, which must not be set as well. Destroying it leaves the cluster settings unchanged.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `cluster_features`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"require_min_compat_client": resourceSchema.StringAttribute{
+				MarkdownDescription: "The oldest client release allowed to connect. Ceph refuses to raise it while older clients are connected, or to lower it below `min_compat_client`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(cephReleaseOrder[slices.Index(cephReleaseOrder, "firefly"):]...),
+				},
+			},
+			"require_osd_release": resourceSchema.StringAttribute{
+				MarkdownDescription: "The oldest release OSDs must run to join the cluster, usually raised to the new release after an upgrade completes. Ceph refuses to lower it or to raise it past the release any OSD runs.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(cephReleaseOrder[slices.Index(cephReleaseOrder, "luminous"):]...),
+				},
+			},
+			"min_compat_client": resourceSchema.StringAttribute{
+				MarkdownDescription: "The oldest client release that supports the features the OSD map currently uses",
+				Computed:            true,
+			},
+			"upmap_enabled": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether `require_min_compat_client` allows `upmap` balancing and `pg-upmap-items`",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ClusterFeaturesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterFeaturesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ClusterFeaturesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, ClusterFeaturesResourceModel{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterFeaturesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ClusterFeaturesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read cluster features: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, cli, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterFeaturesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ClusterFeaturesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Neither setting can be unset, so Delete only forgets the resource.
+func (r *ClusterFeaturesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *ClusterFeaturesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != clusterFeaturesResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", clusterFeaturesResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), clusterFeaturesResourceID)...)
+}
+
+// apply changes the settings in data that differ from prior and reads the
+// result back into data.
+func (r *ClusterFeaturesResource) apply(ctx context.Context, data *ClusterFeaturesResourceModel, prior ClusterFeaturesResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		diags.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to manage cluster features: %s", err),
+		)
+		return diags
+	}
+
+	if !data.RequireOSDRelease.IsUnknown() && !data.RequireOSDRelease.Equal(prior.RequireOSDRelease) {
+		if err := cli.OsdRequireOSDRelease(ctx, data.RequireOSDRelease.ValueString()); err != nil {
+			diags.AddError(
+				"CLI Request Error",
+				fmt.Sprintf("Unable to set require-osd-release: %s", err),
+			)
+			return diags
+		}
+	}
+
+	if !data.RequireMinCompatClient.IsUnknown() && !data.RequireMinCompatClient.Equal(prior.RequireMinCompatClient) {
+		if err := cli.OsdSetRequireMinCompatClient(ctx, data.RequireMinCompatClient.ValueString()); err != nil {
+			diags.AddError(
+				"CLI Request Error",
+				fmt.Sprintf("Unable to set require-min-compat-client: %s", err),
+			)
+			return diags
+		}
+	}
+
+	data.ID = types.StringValue(clusterFeaturesResourceID)
+	diags.Append(r.read(ctx, cli, data)...)
+	return diags
+}
+
+func (r *ClusterFeaturesResource) read(ctx context.Context, cli *CephCLI, data *ClusterFeaturesResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	requirements, err := cli.OsdGetReleaseRequirements(ctx)
+	if err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read cluster features: %s", err),
+		)
+		return diags
+	}
+
+	data.RequireMinCompatClient = types.StringValue(requirements.RequireMinCompatClient)
+	data.RequireOSDRelease = types.StringValue(requirements.RequireOSDRelease)
+	data.MinCompatClient = types.StringValue(requirements.MinCompatClient)
+	data.UpmapEnabled = types.BoolValue(cephReleaseAtLeast(requirements.RequireMinCompatClient, "luminous"))
+
+	return diags
+}
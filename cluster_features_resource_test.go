@@ -0,0 +1,82 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephClusterFeaturesResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_cluster_features" "test" {
+					  require_min_compat_client = "luminous"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					resource "ceph_cluster_features" "test" {
+					  require_min_compat_client = "luminous"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_cluster_features.test", tfjsonpath.New("require_min_compat_client"), knownvalue.StringExact("luminous")),
+					statecheck.ExpectKnownValue("ceph_cluster_features.test", tfjsonpath.New("require_osd_release"), knownvalue.StringRegexp(regexp.MustCompile(`^[a-z]+$`))),
+					statecheck.ExpectKnownValue("ceph_cluster_features.test", tfjsonpath.New("min_compat_client"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("ceph_cluster_features.test", tfjsonpath.New("upmap_enabled"), knownvalue.Bool(true)),
+				},
+				Check: checkCephRequireMinCompatClient(t, "luminous"),
+			},
+			{
+				ConfigVariables:   configVariables,
+				Config:            testAccCLIProviderConfigBlock,
+				ResourceName:      "ceph_cluster_features.test",
+				ImportState:       true,
+				ImportStateId:     "cluster_features",
+				ImportStateVerify: true,
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					resource "ceph_cluster_features" "test" {
+					  require_min_compat_client = "mimic"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_cluster_features.test", tfjsonpath.New("upmap_enabled"), knownvalue.Bool(true)),
+				},
+				Check: checkCephRequireMinCompatClient(t, "mimic"),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					resource "ceph_cluster_features" "test" {
+					  require_min_compat_client = "mimic"
+					  require_osd_release       = "luminous"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Unable to set require-osd-release`),
+			},
+		},
+	})
+}
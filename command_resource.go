@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource               = &CommandResource{}
+	_ resource.ResourceWithModifyPlan = &CommandResource{}
+)
+
+func newCommandResource() resource.Resource {
+	return &CommandResource{}
+}
+
+// CommandResource runs an arbitrary mon command for cluster features the
+// provider does not model. An optional check command makes it idempotent:
+// the command only runs while the check output differs from the expected
+// output, and Read treats a differing check as drift.
+type CommandResource struct {
+	client *CephAPIClient
+}
+
+type CommandResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Command        types.String `tfsdk:"command"`
+	CheckCommand   types.String `tfsdk:"check_command"`
+	ExpectedOutput types.String `tfsdk:"expected_output"`
+	DestroyCommand types.String `tfsdk:"destroy_command"`
+	Output         types.String `tfsdk:"output"`
+}
+
+func (r *CommandResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_command"
+}
+
+func (r *CommandResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Runs a mon command for cluster features this provider does not manage yet. " +
+			"Commands are given in their JSON form, e.g. `jsonencode({ prefix = \"osd pool set\", pool = \"rbd\", var = \"size\", val = \"3\" })`, and run through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled. " +
+			"The dashboard API cannot be used instead: it only exposes its own REST endpoints and has no way to send an arbitrary mon command. " +
+			"Without `check_command` the command runs once when the resource is created. " +
+			"With it, the command only runs while the check output differs from `expected_output`, and a differing check on refresh plans to run the command again.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "A hash of `command`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"command": resourceSchema.StringAttribute{
+				MarkdownDescription: "The JSON mon command to run",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					MonCommand(),
+				},
+			},
+			"check_command": resourceSchema.StringAttribute{
+				MarkdownDescription: "A read-only JSON mon command whose output tells whether `command` has taken effect",
+				Optional:            true,
+				Validators: []validator.String{
+					MonCommand(),
+					stringvalidator.AlsoRequires(path.MatchRoot("expected_output")),
+				},
+			},
+			"expected_output": resourceSchema.StringAttribute{
+				MarkdownDescription: "The output of `check_command` once `command` has taken effect. Leading and trailing whitespace is ignored.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("check_command")),
+				},
+			},
+			"destroy_command": resourceSchema.StringAttribute{
+				MarkdownDescription: "A JSON mon command to run when the resource is destroyed",
+				Optional:            true,
+				Validators: []validator.String{
+					MonCommand(),
+				},
+			},
+			"output": resourceSchema.StringAttribute{
+				MarkdownDescription: "The output of `command`, or empty if it was skipped because the check already matched",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CommandResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan fails the plan when the CLI backend the commands run through is
+// not configured, rather than leaving it to fail on apply.
+func (r *CommandResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		var destroyCommand types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("destroy_command"), &destroyCommand)...)
+		if resp.Diagnostics.HasError() || destroyCommand.IsNull() {
+			return
+		}
+	}
+
+	if _, err := r.client.CLI(); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to run mon commands: %s", err),
+		)
+	}
+}
+
+func (r *CommandResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CommandResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to run command: %s", err),
+		)
+		return
+	}
+
+	data.Output = types.StringValue("")
+
+	matched := false
+	if !data.CheckCommand.IsNull() {
+		var diags diag.Diagnostics
+		_, matched, diags = r.check(ctx, cli, data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !matched {
+		output, diags := runMonCommand(ctx, cli, data.Command.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Output = types.StringValue(output)
+
+		if !data.CheckCommand.IsNull() {
+			_, matched, diags = r.check(ctx, cli, data)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			if !matched {
+				resp.Diagnostics.AddError(
+					"Command Check Failed",
+					"The command ran successfully, but check_command still does not return expected_output.",
+				)
+				return
+			}
+		}
+	}
+
+	sum := sha256.Sum256([]byte(data.Command.ValueString()))
+	data.ID = types.StringValue(hex.EncodeToString(sum[:]))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CommandResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CommandResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.CheckCommand.IsNull() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to run check command: %s", err),
+		)
+		return
+	}
+
+	output, matched, diags := r.check(ctx, cli, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !matched {
+		removeMissingResource(ctx, resp, fmt.Sprintf("The effect of command %s (check_command returned %q, expected %q)", data.Command.ValueString(), strings.TrimSpace(output), strings.TrimSpace(data.ExpectedOutput.ValueString())))
+		return
+	}
+}
+
+// Only command forces replacement; the other attributes take effect on the
+// next Read or Delete.
+func (r *CommandResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CommandResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CommandResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CommandResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.DestroyCommand.IsNull() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to run destroy command: %s", err),
+		)
+		return
+	}
+
+	_, diags := runMonCommand(ctx, cli, data.DestroyCommand.ValueString())
+	resp.Diagnostics.Append(diags...)
+}
+
+// check runs check_command and reports whether it returns expected_output.
+func (r *CommandResource) check(ctx context.Context, cli *CephCLI, data CommandResourceModel) (string, bool, diag.Diagnostics) {
+	output, diags := runMonCommand(ctx, cli, data.CheckCommand.ValueString())
+	if diags.HasError() {
+		return "", false, diags
+	}
+	return output, strings.TrimSpace(output) == strings.TrimSpace(data.ExpectedOutput.ValueString()), diags
+}
+
+func runMonCommand(ctx context.Context, cli *CephCLI, commandJSON string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var command map[string]any
+	if err := json.Unmarshal([]byte(commandJSON), &command); err != nil {
+		diags.AddError(
+			"Invalid Mon Command",
+			fmt.Sprintf("Unable to parse command %s: %s", commandJSON, err),
+		)
+		return "", diags
+	}
+
+	output, err := cli.MonCommand(ctx, command)
+	if err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to run command: %s", err),
+		)
+		return "", diags
+	}
+	return output, diags
+}
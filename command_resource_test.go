@@ -0,0 +1,108 @@
+package main
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestMonCommandArgs(t *testing.T) {
+	args, err := monCommandArgs(map[string]any{
+		"prefix": "osd pool set",
+		"pool":   "rbd",
+		"var":    "size",
+		"val":    float64(3),
+		"force":  true,
+		"ids":    []any{"1", "2"},
+	})
+	if err != nil {
+		t.Fatalf("monCommandArgs() error = %v", err)
+	}
+
+	want := []string{"osd", "pool", "set", "--force=true", "--ids=1", "--ids=2", "--pool=rbd", "--val=3", "--var=size"}
+	if !slices.Equal(args, want) {
+		t.Errorf("monCommandArgs() = %q, want %q", args, want)
+	}
+
+	for _, command := range []map[string]any{
+		{},
+		{"prefix": ""},
+		{"prefix": "status", "nested": map[string]any{"a": "b"}},
+	} {
+		if _, err := monCommandArgs(command); err == nil {
+			t.Errorf("monCommandArgs(%v) error = nil, want error", command)
+		}
+	}
+}
+
+func TestAccCephCommandResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resourceConfig := `
+		resource "ceph_command" "test" {
+		  command         = jsonencode({ prefix = "config set", who = "global", name = "osd_max_scrubs", value = "2" })
+		  check_command   = jsonencode({ prefix = "config get", who = "global", key = "osd_max_scrubs" })
+		  expected_output = "2"
+		  destroy_command = jsonencode({ prefix = "config rm", who = "global", name = "osd_max_scrubs" })
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             checkCephConfigAbsent(t, "global", "osd_max_scrubs"),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_command" "test" {
+					  command = jsonencode({ pool = "rbd" })
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Mon Command`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + resourceConfig,
+				PlanOnly:        true,
+				ExpectError:     regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config:          testAccCLIProviderConfigBlock + resourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_command.test", tfjsonpath.New("id"), knownvalue.NotNull()),
+				},
+				Check: checkCephConfigValue(t, "global", "osd_max_scrubs", "2"),
+			},
+			{
+				// Drift in the checked value runs the command again.
+				PreConfig: func() {
+					if err := cephTestClusterCLI.ConfigSet(t.Context(), "global", "osd_max_scrubs", "5"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				ConfigVariables: configVariables,
+				Config:          testAccCLIProviderConfigBlock + resourceConfig,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_command.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkCephConfigValue(t, "global", "osd_max_scrubs", "2"),
+			},
+		},
+	})
+}
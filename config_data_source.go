@@ -96,6 +96,8 @@ func (d *ConfigDataSource) Configure(ctx context.Context, req datasource.Configu
 }
 
 func (d *ConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withReadCache(ctx)
+
 	var data ConfigDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
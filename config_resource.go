@@ -3,19 +3,28 @@ package main
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
 	_ resource.Resource                = &ConfigResource{}
 	_ resource.ResourceWithImportState = &ConfigResource{}
+	_ resource.ResourceWithIdentity    = &ConfigResource{}
+	_ resource.ResourceWithModifyPlan  = &ConfigResource{}
 )
 
 func newConfigResource() resource.Resource {
@@ -27,8 +36,27 @@ type ConfigResource struct {
 }
 
 type ConfigResourceModel struct {
+	Section   types.String `tfsdk:"section"`
+	Mask      types.String `tfsdk:"mask"`
+	Config    types.Map    `tfsdk:"config"`
+	Exclusive types.Bool   `tfsdk:"exclusive"`
+}
+
+type ConfigResourceIdentityModel struct {
 	Section types.String `tfsdk:"section"`
-	Config  types.Map    `tfsdk:"config"`
+	Mask    types.String `tfsdk:"mask"`
+}
+
+// who returns the target for config set/rm, e.g. "osd" or "osd/class:ssd".
+func (m ConfigResourceModel) who() string {
+	if m.Mask.ValueString() == "" {
+		return m.Section.ValueString()
+	}
+	return m.Section.ValueString() + "/" + m.Mask.ValueString()
+}
+
+func (m ConfigResourceModel) identity() ConfigResourceIdentityModel {
+	return ConfigResourceIdentityModel{Section: m.Section, Mask: m.Mask}
 }
 
 func (r *ConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -45,15 +73,46 @@ func (r *ConfigResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^/]*$`), "must not contain a mask, use the mask attribute instead"),
+				},
+			},
+			"mask": resourceSchema.StringAttribute{
+				MarkdownDescription: "Restricts the configuration to matching daemons within the section (e.g., 'class:ssd', 'host:web01'). Reading masked configuration requires the provider `cli_backend`, as the dashboard API does not report masks.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"config": resourceSchema.MapAttribute{
-				MarkdownDescription: "Map of configuration names to values for the specified section.",
+				MarkdownDescription: "Map of configuration names to values for the specified section. Values are compared according to the option type, so formatting differences such as `2000` vs `2000.000000`, `1` vs `true` or `1h` vs `3600` do not cause diffs.",
 				Required:            true,
 				ElementType:         types.StringType,
 				Validators: []validator.Map{
 					NoMgrPrefixKeys(),
 				},
 			},
+			"exclusive": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether this resource is the authority for the entire section (and mask). When `true`, configuration set in the cluster for the section but missing from `config` is reported as drift and removed. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *ConfigResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"section": identityschema.StringAttribute{
+				Description:       "The configuration section (e.g. global, mon, osd.0)",
+				RequiredForImport: true,
+			},
+			"mask": identityschema.StringAttribute{
+				Description:       "The configuration mask (e.g. class:ssd, host:web01)",
+				OptionalForImport: true,
+			},
 		},
 	}
 }
@@ -76,6 +135,75 @@ func (r *ConfigResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+// ModifyPlan checks the configured option names against the cluster's option
+// metadata, and warns about changes that only take effect after a restart.
+func (r *ConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan ConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Config.IsUnknown() || plan.Section.IsUnknown() {
+		return
+	}
+
+	var planConfigs map[string]types.String
+	resp.Diagnostics.Append(plan.Config.ElementsAs(ctx, &planConfigs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateConfigs := make(map[string]types.String)
+	if !req.State.Raw.IsNull() {
+		var state ConfigResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(state.Config.ElementsAs(ctx, &stateConfigs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	options, err := r.client.ClusterListConf(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"API Request Warning",
+			fmt.Sprintf("Unable to list cluster configuration options, skipping plan-time validation: %s", err),
+		)
+		return
+	}
+
+	optionsByName := make(map[string]CephAPIClusterConf, len(options))
+	for _, option := range options {
+		optionsByName[option.Name] = option
+	}
+
+	for name, value := range planConfigs {
+		option, ok := optionsByName[name]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("config").AtMapKey(name),
+				"Unknown Configuration Option",
+				fmt.Sprintf("Unknown configuration option '%s' for section '%s'.", name, plan.Section.ValueString()),
+			)
+			continue
+		}
+
+		if option.CanUpdateAtRuntime || value.IsUnknown() || value.Equal(stateConfigs[name]) {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("config").AtMapKey(name),
+			"Restart Required",
+			fmt.Sprintf("Configuration option '%s' cannot be updated at runtime. The new value only takes effect after the affected daemons in '%s' are restarted.", name, plan.who()),
+		)
+	}
+}
+
 func (r *ConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ConfigResourceModel
 
@@ -85,13 +213,19 @@ func (r *ConfigResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	section := data.Section.ValueString()
+	section := data.who()
 
-	if strings.Contains(section, "/") {
-		resp.Diagnostics.AddWarning(
-			"Config Mask Limitation",
-			fmt.Sprintf("Section '%s' uses mask syntax which may cause drift detection issues.", section),
-		)
+	// Masked entries can only be read back through the CLI, so fail before
+	// writing anything if it is unavailable.
+	if data.Mask.ValueString() != "" {
+		if _, err := r.client.CLI(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("mask"),
+				"CLI Backend Required",
+				fmt.Sprintf("Unable to manage masked configuration: %s", err),
+			)
+			return
+		}
 	}
 
 	var configs map[string]string
@@ -100,33 +234,43 @@ func (r *ConfigResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	var createdConfigs []string
+	if !r.bulkSetConfigs(ctx, section, configs) {
+		var createdConfigs []string
 
-	for name, value := range configs {
-		err := r.client.ClusterUpdateConf(ctx, name, section, value)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"API Request Error",
-				fmt.Sprintf("Unable to create cluster configuration %s/%s: %s", section, name, err),
-			)
+		for name, value := range configs {
+			err := r.client.ClusterUpdateConf(ctx, name, section, value)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"API Request Error",
+					fmt.Sprintf("Unable to create cluster configuration %s/%s: %s", section, name, err),
+				)
 
-			for _, createdName := range createdConfigs {
-				rollbackErr := r.client.ClusterDeleteConf(ctx, createdName, section)
-				if rollbackErr != nil {
-					resp.Diagnostics.AddError(
-						"Rollback Failed",
-						fmt.Sprintf("Failed to rollback configuration %s/%s: %s. Cluster may be in an inconsistent state. Manual intervention may be required.", section, createdName, rollbackErr),
-					)
-					return
+				for _, createdName := range createdConfigs {
+					rollbackErr := r.client.ClusterDeleteConf(ctx, createdName, section)
+					if rollbackErr != nil {
+						resp.Diagnostics.AddError(
+							"Rollback Failed",
+							fmt.Sprintf("Failed to rollback configuration %s/%s: %s. Cluster may be in an inconsistent state. Manual intervention may be required.", section, createdName, rollbackErr),
+						)
+						return
+					}
 				}
+				return
 			}
-			return
+
+			createdConfigs = append(createdConfigs, name)
 		}
+	}
 
-		createdConfigs = append(createdConfigs, name)
+	if data.Exclusive.ValueBool() {
+		r.removeUnmanagedConfigs(ctx, &data, configs, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -139,6 +283,7 @@ func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	section := data.Section.ValueString()
+	mask := data.Mask.ValueString()
 
 	var configs map[string]string
 	resp.Diagnostics.Append(data.Config.ElementsAs(ctx, &configs, false)...)
@@ -146,6 +291,21 @@ func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	// The dashboard API folds masked entries into their section, so prefer
+	// the CLI config dump whenever it is available to tell them apart.
+	var dumpValues map[string]string
+	if _, cliErr := r.client.CLI(); mask != "" || cliErr == nil {
+		var err error
+		dumpValues, err = readConfigFromDump(ctx, r.client, section, mask)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"CLI Request Error",
+				fmt.Sprintf("Unable to read cluster configuration %s: %s", data.who(), err),
+			)
+			return
+		}
+	}
+
 	updatedConfigs := make(map[string]string)
 
 	for name := range configs {
@@ -158,10 +318,24 @@ func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, res
 			return
 		}
 
+		values := apiConfig.Value
+		if dumpValues != nil {
+			values = nil
+			if value, ok := dumpValues[name]; ok {
+				values = []CephAPIClusterConfValue{{Section: section, Value: value}}
+			}
+		}
+
 		found := false
-		for _, v := range apiConfig.Value {
+		for _, v := range values {
 			if v.Section == section {
-				updatedConfigs[name] = v.Value
+				// Keep the configured spelling when Ceph only formats it
+				// differently, e.g. "2000" vs "2000.000000".
+				if cephConfigValuesEqual(apiConfig.Type, configs[name], v.Value) {
+					updatedConfigs[name] = configs[name]
+				} else {
+					updatedConfigs[name] = v.Value
+				}
 				found = true
 				break
 			}
@@ -170,8 +344,24 @@ func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, res
 		if !found {
 			resp.Diagnostics.AddWarning(
 				"Configuration Drift Detected",
-				fmt.Sprintf("Configuration %s/%s no longer exists in cluster. Removing from state.", section, name),
+				fmt.Sprintf("Configuration %s/%s no longer exists in cluster. Removing from state.", data.who(), name),
+			)
+		}
+	}
+
+	if data.Exclusive.ValueBool() {
+		sectionConfigs, err := r.sectionConfigs(ctx, section, mask)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to list cluster configuration for %s: %s", data.who(), err),
 			)
+			return
+		}
+		for name, value := range sectionConfigs {
+			if _, managed := configs[name]; !managed {
+				updatedConfigs[name] = value
+			}
 		}
 	}
 
@@ -188,6 +378,7 @@ func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	data.Config = configValue
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *ConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -200,7 +391,7 @@ func (r *ConfigResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	section := newData.Section.ValueString()
+	section := newData.who()
 
 	var oldConfigs, newConfigs map[string]string
 	resp.Diagnostics.Append(oldData.Config.ElementsAs(ctx, &oldConfigs, false)...)
@@ -209,24 +400,25 @@ func (r *ConfigResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	changedConfigs := make(map[string]string)
 	for name, newValue := range newConfigs {
-		oldValue, exists := oldConfigs[name]
+		if oldValue, exists := oldConfigs[name]; !exists || oldValue != newValue {
+			changedConfigs[name] = newValue
+		}
+	}
 
-		if !exists {
-			err := r.client.ClusterUpdateConf(ctx, name, section, newValue)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"API Request Error",
-					fmt.Sprintf("Unable to create cluster configuration %s/%s: %s", section, name, err),
-				)
-				return
+	if !r.bulkSetConfigs(ctx, section, changedConfigs) {
+		for name, newValue := range changedConfigs {
+			action := "update"
+			if _, exists := oldConfigs[name]; !exists {
+				action = "create"
 			}
-		} else if oldValue != newValue {
+
 			err := r.client.ClusterUpdateConf(ctx, name, section, newValue)
 			if err != nil {
 				resp.Diagnostics.AddError(
 					"API Request Error",
-					fmt.Sprintf("Unable to update cluster configuration %s/%s: %s", section, name, err),
+					fmt.Sprintf("Unable to %s cluster configuration %s/%s: %s", action, section, name, err),
 				)
 				return
 			}
@@ -246,7 +438,15 @@ func (r *ConfigResource) Update(ctx context.Context, req resource.UpdateRequest,
 		}
 	}
 
+	if newData.Exclusive.ValueBool() {
+		r.removeUnmanagedConfigs(ctx, &newData, newConfigs, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newData)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newData.identity())...)
 }
 
 func (r *ConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -258,7 +458,7 @@ func (r *ConfigResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	section := data.Section.ValueString()
+	section := data.who()
 
 	var configs map[string]string
 	resp.Diagnostics.Append(data.Config.ElementsAs(ctx, &configs, false)...)
@@ -278,16 +478,30 @@ func (r *ConfigResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *ConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	section := strings.TrimSpace(req.ID)
+	section, mask, _ := strings.Cut(strings.TrimSpace(req.ID), "/")
+	if req.ID == "" && req.Identity != nil {
+		var identity ConfigResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		section = identity.Section.ValueString()
+		mask = identity.Mask.ValueString()
+	}
 
 	if section == "" {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
-			"Import ID cannot be empty. Expected format: section name (e.g., 'global', 'mon', 'osd', 'osd.0')",
+			"Import ID cannot be empty. Expected format: section name with optional mask (e.g., 'global', 'osd.0', 'osd/class:ssd')",
 		)
 		return
 	}
 
+	if mask != "" {
+		r.importMaskedConfig(ctx, section, mask, resp)
+		return
+	}
+
 	allConfigs, err := r.client.ClusterListConf(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -331,9 +545,162 @@ func (r *ConfigResource) ImportState(ctx context.Context, req resource.ImportSta
 	}
 
 	data := ConfigResourceModel{
-		Section: types.StringValue(section),
-		Config:  configValue,
+		Section:   types.StringValue(section),
+		Mask:      types.StringNull(),
+		Config:    configValue,
+		Exclusive: types.BoolValue(false),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+func (r *ConfigResource) importMaskedConfig(ctx context.Context, section, mask string, resp *resource.ImportStateResponse) {
+	importedConfigs, err := readConfigFromDump(ctx, r.client, section, mask)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read masked cluster configuration during import: %s", err),
+		)
+		return
+	}
+
+	for name := range importedConfigs {
+		if strings.HasPrefix(name, "mgr/") {
+			delete(importedConfigs, name)
+		}
+	}
+
+	if len(importedConfigs) == 0 {
+		resp.Diagnostics.AddError(
+			"No Configurations Found",
+			fmt.Sprintf("No configurations found for section '%s' with mask '%s'.", section, mask),
+		)
+		return
+	}
+
+	configValue, diags := types.MapValueFrom(ctx, types.StringType, importedConfigs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := ConfigResourceModel{
+		Section:   types.StringValue(section),
+		Mask:      types.StringValue(mask),
+		Config:    configValue,
+		Exclusive: types.BoolValue(false),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// bulkSetConfigs sets all configs of a section in one request and reports
+// whether it succeeded. The dashboard rejects the batch if any option cannot
+// be updated at runtime, and older releases lack it, so on failure the caller
+// sets options one at a time, which also reports the option at fault.
+func (r *ConfigResource) bulkSetConfigs(ctx context.Context, section string, configs map[string]string) bool {
+	if len(configs) < 2 {
+		return false
+	}
+
+	if err := r.client.ClusterBulkUpdateConf(ctx, section, configs); err != nil {
+		tflog.Debug(ctx, "Unable to set configuration options in one request, setting them individually", map[string]any{
+			"section": section,
+			"error":   err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// sectionConfigs returns every value set for section with exactly the given
+// mask, excluding mgr module options, which ceph_config does not manage.
+func (r *ConfigResource) sectionConfigs(ctx context.Context, section, mask string) (map[string]string, error) {
+	values, err := listSectionConfig(ctx, r.client, section, mask)
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range values {
+		if strings.HasPrefix(name, "mgr/") {
+			delete(values, name)
+		}
+	}
+
+	return values, nil
+}
+
+// removeUnmanagedConfigs deletes configuration in the resource's section that
+// is not part of configs.
+func (r *ConfigResource) removeUnmanagedConfigs(ctx context.Context, data *ConfigResourceModel, configs map[string]string, diags *diag.Diagnostics) {
+	sectionConfigs, err := r.sectionConfigs(ctx, data.Section.ValueString(), data.Mask.ValueString())
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to list cluster configuration for %s: %s", data.who(), err),
+		)
+		return
+	}
+
+	for name := range sectionConfigs {
+		if _, managed := configs[name]; managed {
+			continue
+		}
+		if err := r.client.ClusterDeleteConf(ctx, name, data.who()); err != nil {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to delete unmanaged cluster configuration %s/%s: %s", data.who(), name, err),
+			)
+			return
+		}
+	}
+}
+
+// listSectionConfig returns every value set for section with exactly the
+// given mask. The dashboard API folds masked entries into their section, so
+// the CLI config dump is used whenever it is available or a mask is given.
+func listSectionConfig(ctx context.Context, client *CephAPIClient, section, mask string) (map[string]string, error) {
+	if _, cliErr := client.CLI(); mask != "" || cliErr == nil {
+		return readConfigFromDump(ctx, client, section, mask)
+	}
+
+	options, err := client.ClusterListConf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	for _, option := range options {
+		for _, v := range option.Value {
+			if v.Section == section {
+				values[option.Name] = v.Value
+				break
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// readConfigFromDump returns the values set for section with exactly the
+// given mask, which may be empty, using the CLI config dump.
+func readConfigFromDump(ctx context.Context, client *CephAPIClient, section, mask string) (map[string]string, error) {
+	cli, err := client.CLI()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := cli.ConfigDump(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	for _, entry := range entries {
+		if entry.Section == section && entry.Mask == mask {
+			values[entry.Name] = entry.Value
+		}
+	}
+
+	return values, nil
+}
@@ -1,14 +1,17 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
@@ -276,6 +279,74 @@ func TestAccCephConfigResource_multipleConfigs(t *testing.T) {
 	})
 }
 
+// Several changed options are set in one bulk request.
+func TestAccCephConfigResource_batch(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	value1 := acctest.RandIntRange(2, 9)
+	value2 := acctest.RandIntRange(10, 99)
+
+	checkConfig := func(values map[string]string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			for name, expected := range values {
+				value, err := cephTestClusterCLI.ConfigGetFromDump(t.Context(), "osd", name)
+				if err != nil {
+					return err
+				}
+				if value != expected {
+					return fmt.Errorf("osd/%s = %q, want %q", name, value, expected)
+				}
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephConfigDestroy(t),
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_config" "osd" {
+						section = "osd"
+						config = {
+							"osd_max_backfills"       = "%d"
+							"osd_recovery_max_active" = "%d"
+						}
+					}
+				`, value1, value1),
+				Check: checkConfig(map[string]string{
+					"osd_max_backfills":       fmt.Sprintf("%d", value1),
+					"osd_recovery_max_active": fmt.Sprintf("%d", value1),
+				}),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_config" "osd" {
+						section = "osd"
+						config = {
+							"osd_max_backfills"       = "%d"
+							"osd_recovery_max_active" = "%d"
+							"osd_scrub_chunk_max"     = "%d"
+						}
+					}
+				`, value2, value1, value2),
+				Check: checkConfig(map[string]string{
+					"osd_max_backfills":       fmt.Sprintf("%d", value2),
+					"osd_recovery_max_active": fmt.Sprintf("%d", value1),
+					"osd_scrub_chunk_max":     fmt.Sprintf("%d", value2),
+				}),
+			},
+		},
+	})
+}
+
 func TestAccCephConfigResource_removeConfig(t *testing.T) {
 	detachLogs := cephDaemonLogs.AttachTestFunction(t)
 	defer detachLogs()
@@ -960,6 +1031,9 @@ func testAccCheckCephConfigDestroy(t *testing.T) resource.TestCheckFunc {
 			}
 
 			section := rs.Primary.Attributes["section"]
+			if mask := rs.Primary.Attributes["mask"]; mask != "" {
+				section += "/" + mask
+			}
 
 			for key := range rs.Primary.Attributes {
 				if !strings.HasPrefix(key, "config.") || key == "config.%" {
@@ -977,3 +1051,260 @@ func testAccCheckCephConfigDestroy(t *testing.T) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func TestAccCephConfigResource_normalizedValues(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	sleepValue := acctest.RandIntRange(1000, 9999)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephConfigDestroy(t),
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_config" "osd" {
+						section = "osd"
+						config = {
+							"osd_recovery_sleep"    = "%d"
+							"osd_scrub_auto_repair" = "1"
+							"osd_memory_target"     = "4GiB"
+						}
+					}
+				`, sleepValue),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_config.osd",
+						tfjsonpath.New("config"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"osd_recovery_sleep":    knownvalue.StringExact(fmt.Sprintf("%d", sleepValue)),
+							"osd_scrub_auto_repair": knownvalue.StringExact("1"),
+							"osd_memory_target":     knownvalue.StringExact("4GiB"),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccCephConfigResource_mask(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	sectionValue := acctest.RandIntRange(1, 4)
+	maskedValue := acctest.RandIntRange(5, 9)
+	configName := "osd_max_backfills"
+
+	configBlock := `
+		variable "endpoint" {
+		  type = string
+		}
+
+		variable "ceph_conf" {
+		  type = string
+		}
+
+		provider "ceph" {
+		  endpoint    = var.endpoint
+		  username    = "admin"
+		  password    = "password"
+		  cli_backend = true
+		  ceph_conf   = var.ceph_conf
+		}
+	`
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephConfigDestroy(t),
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_config" "ssd" {
+						section = "osd"
+						mask    = "class:ssd"
+						config = {
+							%q = "%d"
+						}
+					}
+				`, configName, maskedValue),
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: configBlock + fmt.Sprintf(`
+					resource "ceph_config" "osd" {
+						section = "osd"
+						config = {
+							%q = "%d"
+						}
+					}
+
+					resource "ceph_config" "ssd" {
+						section = "osd"
+						mask    = "class:ssd"
+						config = {
+							%q = "%d"
+						}
+					}
+				`, configName, sectionValue, configName, maskedValue),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_config.ssd",
+						tfjsonpath.New("config"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							configName: knownvalue.StringExact(fmt.Sprintf("%d", maskedValue)),
+						}),
+					),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(s *terraform.State) error {
+						value, err := cephTestClusterCLI.ConfigGetFromDump(t.Context(), "osd/class:ssd", configName)
+						if err != nil {
+							return err
+						}
+						if value != fmt.Sprintf("%d", maskedValue) {
+							return fmt.Errorf("expected masked %s to be %d, got %s", configName, maskedValue, value)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ConfigVariables:                      configVariables,
+				ResourceName:                         "ceph_config.ssd",
+				ImportState:                          true,
+				ImportStateId:                        "osd/class:ssd",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "section",
+				Config: configBlock + fmt.Sprintf(`
+					resource "ceph_config" "osd" {
+						section = "osd"
+						config = {
+							%q = "%d"
+						}
+					}
+
+					resource "ceph_config" "ssd" {
+						section = "osd"
+						mask    = "class:ssd"
+						config = {
+							%q = "%d"
+						}
+					}
+				`, configName, sectionValue, configName, maskedValue),
+			},
+		},
+	})
+}
+
+func TestAccCephConfigResource_unknownOptionRejection(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephConfigDestroy(t),
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_config" "test" {
+						section = "osd"
+						config = {
+							"osd_max_backfills"        = "2"
+							"osd_max_backfills_typo_x" = "2"
+						}
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Unknown configuration option 'osd_max_backfills_typo_x'`),
+			},
+		},
+	})
+}
+
+func TestAccCephConfigResource_exclusive(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	managedValue := acctest.RandIntRange(100, 999)
+
+	checkUnmanagedRemoved := func(s *terraform.State) error {
+		if _, err := cephTestClusterCLI.ConfigGetFromDump(t.Context(), "mds", "mds_beacon_grace"); err == nil {
+			return fmt.Errorf("unmanaged config mds/mds_beacon_grace still exists")
+		}
+		return nil
+	}
+
+	setUnmanaged := func() {
+		if err := cephTestClusterCLI.ConfigSet(t.Context(), "mds", "mds_beacon_grace", "20"); err != nil {
+			t.Fatalf("Failed to set unmanaged config via CLI: %v", err)
+		}
+	}
+
+	resourceConfig := testAccProviderConfigBlock + fmt.Sprintf(`
+		resource "ceph_config" "mds" {
+			section   = "mds"
+			exclusive = true
+			config = {
+				"mds_cache_trim_threshold" = "%d"
+			}
+		}
+	`, managedValue)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephConfigDestroy(t),
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+			setUnmanaged()
+			testCleanup(t, func(ctx context.Context) {
+				_ = cephTestClusterCLI.ConfigRemove(ctx, "mds", "mds_beacon_grace")
+			})
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          resourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_config.mds",
+						tfjsonpath.New("config"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"mds_cache_trim_threshold": knownvalue.StringExact(fmt.Sprintf("%d", managedValue)),
+						}),
+					),
+				},
+				Check: checkUnmanagedRemoved,
+			},
+			{
+				PreConfig:       setUnmanaged,
+				ConfigVariables: testAccProviderConfig(),
+				Config:          resourceConfig,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_config.mds", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkUnmanagedRemoved,
+			},
+		},
+	})
+}
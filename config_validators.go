@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type noMgrPrefixKeysValidator struct{}
@@ -16,7 +20,7 @@ func (v noMgrPrefixKeysValidator) Description(ctx context.Context) string {
 }
 
 func (v noMgrPrefixKeysValidator) MarkdownDescription(ctx context.Context) string {
-	return "Ensures no map keys start with `mgr/` prefix. Use `ceph_mgr_module_config` instead."
+	return "Ensures no map keys start with `mgr/` prefix. Use `ceph_mgr_module_config` or `ceph_mgr_config` instead."
 }
 
 func (v noMgrPrefixKeysValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
@@ -29,7 +33,7 @@ func (v noMgrPrefixKeysValidator) ValidateMap(ctx context.Context, req validator
 			resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
 				req.Path,
 				"Invalid Configuration Name",
-				fmt.Sprintf("Configuration '%s' cannot be managed via ceph_config. Use ceph_mgr_module_config instead.", key),
+				fmt.Sprintf("Configuration '%s' cannot be managed via ceph_config. Use ceph_mgr_module_config or ceph_mgr_config instead.", key),
 			))
 		}
 	}
@@ -38,3 +42,137 @@ func (v noMgrPrefixKeysValidator) ValidateMap(ctx context.Context, req validator
 func NoMgrPrefixKeys() validator.Map {
 	return noMgrPrefixKeysValidator{}
 }
+
+type positiveDurationValidator struct{}
+
+func (v positiveDurationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as 30m or 2h"
+}
+
+func (v positiveDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return "Value must be a positive duration such as `30m` or `2h`."
+}
+
+func (v positiveDurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("duration must be positive")
+	}
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Value %q is not a valid duration: %s", req.ConfigValue.ValueString(), err),
+		))
+	}
+}
+
+func PositiveDuration() validator.String {
+	return positiveDurationValidator{}
+}
+
+type rfc3339TimestampValidator struct{}
+
+func (v rfc3339TimestampValidator) Description(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp such as 2025-01-01T00:00:00Z"
+}
+
+func (v rfc3339TimestampValidator) MarkdownDescription(ctx context.Context) string {
+	return "Value must be an RFC 3339 timestamp such as `2025-01-01T00:00:00Z`."
+}
+
+func (v rfc3339TimestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("Value %q is not a valid RFC 3339 timestamp: %s", req.ConfigValue.ValueString(), err),
+		))
+	}
+}
+
+func RFC3339Timestamp() validator.String {
+	return rfc3339TimestampValidator{}
+}
+
+type monCommandValidator struct{}
+
+func (v monCommandValidator) Description(ctx context.Context) string {
+	return "value must be a JSON mon command with a prefix"
+}
+
+func (v monCommandValidator) MarkdownDescription(ctx context.Context) string {
+	return "Value must be a JSON mon command with a `prefix`, e.g. `{\"prefix\": \"osd pool set\", \"pool\": \"rbd\", \"var\": \"size\", \"val\": \"3\"}`."
+}
+
+func (v monCommandValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	var command map[string]any
+	err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &command)
+	if err == nil {
+		_, err = monCommandArgs(command)
+	}
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"Invalid Mon Command",
+			fmt.Sprintf("Value is not a valid JSON mon command: %s", err),
+		))
+	}
+}
+
+func MonCommand() validator.String {
+	return monCommandValidator{}
+}
+
+type cephCapsValidator struct{}
+
+func (v cephCapsValidator) Description(ctx context.Context) string {
+	return "caps must be cephx capabilities such as allow rw pool=foo"
+}
+
+func (v cephCapsValidator) MarkdownDescription(ctx context.Context) string {
+	return "Caps must be cephx capabilities, e.g. `allow rw pool=foo` or `profile rbd`, separated by commas."
+}
+
+func (v cephCapsValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	for key, value := range req.ConfigValue.Elements() {
+		capValue, ok := value.(types.String)
+		if !ok || capValue.IsUnknown() || capValue.IsNull() {
+			continue
+		}
+
+		// Unsupported cap types are reported when the caps are applied.
+		capType := strings.ToLower(key)
+		if !slices.Contains([]string{"mon", "mgr", "osd", "mds"}, capType) {
+			continue
+		}
+
+		if err := validateCephCap(capType, capValue.ValueString()); err != nil {
+			resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+				req.Path.AtMapKey(key),
+				"Invalid Capabilities",
+				fmt.Sprintf("The %s caps %q are invalid: %s", key, capValue.ValueString(), err),
+			))
+		}
+	}
+}
+
+func CephCapsSyntax() validator.Map {
+	return cephCapsValidator{}
+}
@@ -69,6 +69,8 @@ func (d *ConfigValueDataSource) Configure(ctx context.Context, req datasource.Co
 }
 
 func (d *ConfigValueDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withReadCache(ctx)
+
 	var data ConfigValueDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// cephConfigValuesEqual reports whether two values of a config option of the
+// given type (as reported by /api/cluster_conf) mean the same thing to Ceph.
+// Values that fail to parse are compared verbatim.
+func cephConfigValuesEqual(optType, a, b string) bool {
+	if a == b {
+		return true
+	}
+	normA, okA := normalizeCephConfigValue(optType, a)
+	normB, okB := normalizeCephConfigValue(optType, b)
+	return okA && okB && normA == normB
+}
+
+func normalizeCephConfigValue(optType, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+
+	switch optType {
+	case "bool":
+		switch strings.ToLower(value) {
+		case "true", "1", "yes", "on":
+			return "true", true
+		case "false", "0", "no", "off":
+			return "false", true
+		}
+	case "int", "uint":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return strconv.FormatInt(n, 10), true
+		}
+	case "float":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return strconv.FormatFloat(f, 'f', -1, 64), true
+		}
+	case "size":
+		if n, ok := parseCephSize(value); ok {
+			return strconv.FormatInt(n, 10), true
+		}
+	case "secs":
+		if n, ok := parseCephTimespan(value, 1); ok {
+			return strconv.FormatInt(n, 10), true
+		}
+	case "millisecs":
+		if n, ok := parseCephTimespan(value, 1000); ok {
+			return strconv.FormatInt(n, 10), true
+		}
+	}
+
+	return value, false
+}
+
+// parseCephSize parses sizes such as "512", "4K", "1GiB" or "2MB". Like Ceph,
+// all suffixes are treated as powers of 1024.
+func parseCephSize(value string) (int64, bool) {
+	number, unit := splitNumberUnit(value)
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	unit = strings.TrimSuffix(strings.TrimSuffix(unit, "B"), "i")
+	shift := strings.Index("KMGTPE", unit) + 1
+	if unit == "" {
+		shift = 0
+	} else if len(unit) != 1 || shift == 0 {
+		return 0, false
+	}
+
+	if n > math.MaxInt64>>(10*shift) {
+		return 0, false
+	}
+	return n << (10 * shift), true
+}
+
+var cephTimespanUnits = map[string]int64{
+	"s": 1, "sec": 1, "secs": 1, "second": 1, "seconds": 1,
+	"m": 60, "min": 60, "mins": 60, "minute": 60, "minutes": 60,
+	"h": 3600, "hr": 3600, "hrs": 3600, "hour": 3600, "hours": 3600,
+	"d": 86400, "day": 86400, "days": 86400,
+	"w": 604800, "wk": 604800, "wks": 604800, "week": 604800, "weeks": 604800,
+}
+
+// parseCephTimespan parses durations such as "3600", "1h" or "1h 30m" into
+// units of 1/perSecond seconds. A bare number is already in those units.
+func parseCephTimespan(value string, perSecond int64) (int64, bool) {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, true
+	}
+
+	var total int64
+	rest := value
+	for rest != "" {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		number, tail := splitNumberUnit(rest)
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+
+		end := strings.IndexFunc(tail, func(r rune) bool { return !unicode.IsLetter(r) })
+		if end == -1 {
+			end = len(tail)
+		}
+		unit := strings.ToLower(tail[:end])
+		rest = tail[end:]
+
+		if unit == "ms" && perSecond == 1000 {
+			total += n
+			continue
+		}
+		seconds, ok := cephTimespanUnits[unit]
+		if !ok {
+			return 0, false
+		}
+		total += n * seconds * perSecond
+	}
+
+	return total, true
+}
+
+func splitNumberUnit(value string) (string, string) {
+	i := strings.IndexFunc(value, func(r rune) bool { return !unicode.IsDigit(r) })
+	if i == -1 {
+		return value, ""
+	}
+	return value[:i], strings.TrimSpace(value[i:])
+}
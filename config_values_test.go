@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestCephConfigValuesEqual(t *testing.T) {
+	tests := []struct {
+		optType string
+		a, b    string
+		want    bool
+	}{
+		{"float", "2000", "2000.000000", true},
+		{"float", "2000.0", "2000.000000", true},
+		{"float", "0.5", "0.500000", true},
+		{"float", "2000", "2001.000000", false},
+		{"bool", "true", "1", true},
+		{"bool", "false", "off", true},
+		{"bool", "true", "false", false},
+		{"int", "007", "7", true},
+		{"uint", "10", "11", false},
+		{"secs", "1h", "3600", true},
+		{"secs", "1h 30m", "5400", true},
+		{"secs", "2d", "172800", true},
+		{"secs", "1x", "1", false},
+		{"millisecs", "5s", "5000", true},
+		{"millisecs", "250ms", "250", true},
+		{"size", "1GiB", "1073741824", true},
+		{"size", "512M", "536870912", true},
+		{"size", "4K", "4096", true},
+		{"size", "4KB", "4Ki", true},
+		{"size", "1Q", "1", false},
+		{"str", "foo", "foo", true},
+		{"str", "1", "true", false},
+		{"", "2000", "2000.000000", false},
+	}
+
+	for _, tt := range tests {
+		if got := cephConfigValuesEqual(tt.optType, tt.a, tt.b); got != tt.want {
+			t.Errorf("cephConfigValuesEqual(%q, %q, %q) = %v, want %v", tt.optType, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const crashArchiveResourceID = "crash_archive"
+
+var _ resource.Resource = &CrashArchiveResource{}
+
+func newCrashArchiveResource() resource.Resource {
+	return &CrashArchiveResource{}
+}
+
+// CrashArchiveResource archives all crash reports when it is created, which
+// clears the RECENT_CRASH health warning. Changing triggers replaces it and
+// archives again. The dashboard API has no crash endpoints, so it goes
+// through the ceph CLI.
+type CrashArchiveResource struct {
+	client *CephAPIClient
+}
+
+type CrashArchiveResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Triggers types.Map    `tfsdk:"triggers"`
+}
+
+func (r *CrashArchiveResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_crash_archive"
+}
+
+func (r *CrashArchiveResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Archives all crash reports, as with `ceph crash archive-all`, so they no longer raise the `RECENT_CRASH` health warning. " +
+			"Archiving happens when the resource is created or replaced; destroying it does nothing. " +
+			"Crash reports are only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `crash_archive`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": resourceSchema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that archive the crash reports again whenever they change, e.g. a maintenance ticket number.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CrashArchiveResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CrashArchiveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CrashArchiveResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to archive crash reports: %s", err),
+		)
+		return
+	}
+
+	if err := cli.CrashArchiveAll(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to archive crash reports: %s", err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(crashArchiveResourceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Archiving is a one-off action, so there is nothing to read back.
+func (r *CrashArchiveResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// triggers requires replacement, so there is nothing to update.
+func (r *CrashArchiveResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CrashArchiveResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CrashArchiveResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephCrashArchiveResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_crash_archive" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					resource "ceph_crash_archive" "test" {
+					  triggers = { ticket = "OPS-1" }
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_crash_archive.test", tfjsonpath.New("id"), knownvalue.StringExact("crash_archive")),
+				},
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					resource "ceph_crash_archive" "test" {
+					  triggers = { ticket = "OPS-2" }
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_crash_archive.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	crashResourceID = "crash"
+	crashModule     = "crash"
+)
+
+var (
+	_ resource.Resource                = &CrashResource{}
+	_ resource.ResourceWithImportState = &CrashResource{}
+)
+
+func newCrashResource() resource.Resource {
+	return &CrashResource{}
+}
+
+// CrashResource manages the options of the crash mgr module. Options left out
+// of the configuration are not touched.
+type CrashResource struct {
+	client *CephAPIClient
+}
+
+type CrashResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	RetainInterval     types.Int64  `tfsdk:"retain_interval"`
+	WarnRecentInterval types.Int64  `tfsdk:"warn_recent_interval"`
+}
+
+func (m *CrashResourceModel) options() []mgrModuleOption {
+	return []mgrModuleOption{
+		{"retain_interval", &m.RetainInterval},
+		{"warn_recent_interval", &m.WarnRecentInterval},
+	}
+}
+
+func (r *CrashResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_crash"
+}
+
+func (r *CrashResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the retention settings of the `crash` mgr module, which collects daemon crash reports. " +
+			"There is one module per cluster, so declare this resource at most once. " +
+			"Attributes that are not set are left unmanaged, and destroying the resource restores the Ceph defaults of the managed ones. " +
+			"Use `ceph_crash_archive` to acknowledge existing crash reports.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `crash`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"retain_interval": resourceSchema.Int64Attribute{
+				MarkdownDescription: "How long crash reports are kept before they are pruned, in seconds.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"warn_recent_interval": resourceSchema.Int64Attribute{
+				MarkdownDescription: "How long an unarchived crash raises the `RECENT_CRASH` health warning, in seconds. `0` disables the warning.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+func (r *CrashResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CrashResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CrashResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, CrashResourceModel{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(crashResourceID)
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CrashResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CrashResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CrashResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state CrashResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(crashResourceID)
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CrashResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CrashResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, CrashResourceModel{}, data)...)
+}
+
+func (r *CrashResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != crashResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", crashResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), crashResourceID)...)
+}
+
+// apply sets every option configured in data and resets the ones that were
+// managed in prior but are no longer configured.
+func (r *CrashResource) apply(ctx context.Context, data CrashResourceModel, prior CrashResourceModel) diag.Diagnostics {
+	return applyMgrModuleOptions(ctx, r.client, crashModule, data.options(), prior.options())
+}
+
+func (r *CrashResource) read(ctx context.Context, data *CrashResourceModel) diag.Diagnostics {
+	return readMgrModuleOptions(ctx, r.client, crashModule, data.options())
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephCrashResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			checkCephConfigAbsent(t, "mgr", "mgr/crash/retain_interval"),
+			checkCephConfigAbsent(t, "mgr", "mgr/crash/warn_recent_interval"),
+		),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_crash" "test" {
+					  retain_interval      = 2592000
+					  warn_recent_interval = 86400
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_crash.test", tfjsonpath.New("id"), knownvalue.StringExact("crash")),
+					statecheck.ExpectKnownValue("ceph_crash.test", tfjsonpath.New("retain_interval"), knownvalue.Int64Exact(2592000)),
+					statecheck.ExpectKnownValue("ceph_crash.test", tfjsonpath.New("warn_recent_interval"), knownvalue.Int64Exact(86400)),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigValue(t, "mgr", "mgr/crash/retain_interval", "2592000"),
+					checkCephConfigValue(t, "mgr", "mgr/crash/warn_recent_interval", "86400"),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_crash" "test" {
+					  warn_recent_interval = 0
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_crash.test", tfjsonpath.New("retain_interval"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("ceph_crash.test", tfjsonpath.New("warn_recent_interval"), knownvalue.Int64Exact(0)),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigAbsent(t, "mgr", "mgr/crash/retain_interval"),
+					checkCephConfigValue(t, "mgr", "mgr/crash/warn_recent_interval", "0"),
+				),
+			},
+		},
+	})
+}
@@ -83,6 +83,10 @@ func (d *CrushRuleDataSource) Schema(ctx context.Context, req datasource.SchemaR
 							MarkdownDescription: "CRUSH bucket or ID targeted by the step, when applicable.",
 							Computed:            true,
 						},
+						"item_name": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "Name of the CRUSH bucket targeted by the step, with the device class appended after a `~` for class-specific shadow trees (e.g., 'default~ssd').",
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -109,6 +113,8 @@ func (d *CrushRuleDataSource) Configure(ctx context.Context, req datasource.Conf
 }
 
 func (d *CrushRuleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withReadCache(ctx)
+
 	var data CrushRuleDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -153,12 +159,19 @@ func (d *CrushRuleDataSource) Read(ctx context.Context, req datasource.ReadReque
 			stepAttrs["item"] = types.Int64Null()
 		}
 
+		if step.ItemName != "" {
+			stepAttrs["item_name"] = types.StringValue(step.ItemName)
+		} else {
+			stepAttrs["item_name"] = types.StringNull()
+		}
+
 		stepObj, stepDiags := types.ObjectValue(
 			map[string]attr.Type{
-				"op":   types.StringType,
-				"num":  types.Int64Type,
-				"type": types.StringType,
-				"item": types.Int64Type,
+				"op":        types.StringType,
+				"num":       types.Int64Type,
+				"type":      types.StringType,
+				"item":      types.Int64Type,
+				"item_name": types.StringType,
 			},
 			stepAttrs,
 		)
@@ -173,10 +186,11 @@ func (d *CrushRuleDataSource) Read(ctx context.Context, req datasource.ReadReque
 	stepsValue, stepDiags := types.ListValue(
 		types.ObjectType{
 			AttrTypes: map[string]attr.Type{
-				"op":   types.StringType,
-				"num":  types.Int64Type,
-				"type": types.StringType,
-				"item": types.Int64Type,
+				"op":        types.StringType,
+				"num":       types.Int64Type,
+				"type":      types.StringType,
+				"item":      types.Int64Type,
+				"item_name": types.StringType,
 			},
 		},
 		stepsObjects,
@@ -3,12 +3,14 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -21,6 +23,7 @@ import (
 var (
 	_ resource.Resource                = &CrushRuleResource{}
 	_ resource.ResourceWithImportState = &CrushRuleResource{}
+	_ resource.ResourceWithIdentity    = &CrushRuleResource{}
 )
 
 func newCrushRuleResource() resource.Resource {
@@ -46,6 +49,10 @@ type CrushRuleResourceModel struct {
 	Steps         types.List   `tfsdk:"steps"`
 }
 
+type CrushRuleResourceIdentityModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
 func (r *CrushRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_crush_rule"
 }
@@ -88,10 +95,16 @@ func (r *CrushRuleResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 			},
 			"profile": resourceSchema.StringAttribute{
-				MarkdownDescription: "The erasure code profile name. Required when pool_type is 'erasure', ignored for replicated pools.",
+				MarkdownDescription: "The erasure code profile name. Required when pool_type is 'erasure', ignored for replicated pools. Ceph does not record the profile in the rule, so setting it on an imported rule does not replace the rule.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"Changing the profile replaces the rule, unless the rule was imported without one.",
+						"Changing the profile replaces the rule, unless the rule was imported without one.",
+					),
 				},
 			},
 			"root": resourceSchema.StringAttribute{
@@ -159,6 +172,10 @@ func (r *CrushRuleResource) Schema(ctx context.Context, req resource.SchemaReque
 							MarkdownDescription: "CRUSH bucket or ID targeted by the step, when applicable.",
 							Computed:            true,
 						},
+						"item_name": resourceSchema.StringAttribute{
+							MarkdownDescription: "Name of the CRUSH bucket targeted by the step, with the device class appended after a `~` for class-specific shadow trees (e.g., 'default~ssd').",
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -166,6 +183,17 @@ func (r *CrushRuleResource) Schema(ctx context.Context, req resource.SchemaReque
 	}
 }
 
+func (r *CrushRuleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				Description:       "The name of the CRUSH rule",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
 func (r *CrushRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -238,6 +266,7 @@ func (r *CrushRuleResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, CrushRuleResourceIdentityModel{Name: data.Name})...)
 }
 
 func (r *CrushRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -250,6 +279,10 @@ func (r *CrushRuleResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	rule, err := r.client.GetCrushRule(ctx, data.Name.ValueString())
+	if isCephAPINotFound(err) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("CRUSH rule '%s'", data.Name.ValueString()))
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"API Request Error",
@@ -264,13 +297,21 @@ func (r *CrushRuleResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, CrushRuleResourceIdentityModel{Name: data.Name})...)
 }
 
 func (r *CrushRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"CRUSH rules are immutable in Ceph and cannot be updated. Any changes require replacing the resource.",
-	)
+	var data CrushRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every other change replaces the rule, so this only records the profile
+	// of an imported rule.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *CrushRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -283,7 +324,7 @@ func (r *CrushRuleResource) Delete(ctx context.Context, req resource.DeleteReque
 	}
 
 	err := r.client.DeleteCrushRule(ctx, data.Name.ValueString())
-	if err != nil {
+	if err != nil && !isCephAPINotFound(err) {
 		resp.Diagnostics.AddError(
 			"API Request Error",
 			fmt.Sprintf("Unable to delete CRUSH rule '%s': %s. Note that CRUSH rules cannot be deleted if they are in use by any pools.", data.Name.ValueString(), err),
@@ -293,12 +334,34 @@ func (r *CrushRuleResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *CrushRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("name"), path.Root("name"), req, resp)
 }
 
 func (r *CrushRuleResource) updateModelFromAPI(data *CrushRuleResourceModel, rule *CephAPICrushRule) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	// Imported rules only have a name, so take the settings the rule was
+	// created with from its steps. Rules created by Terraform keep their
+	// configured values, since erasure rules take them from the profile.
+	root, deviceClass, failureDomain := crushRuleSettings(rule)
+	if data.PoolType.IsNull() {
+		switch rule.Type {
+		case crushRuleTypeErasure:
+			data.PoolType = types.StringValue("erasure")
+		default:
+			data.PoolType = types.StringValue("replicated")
+		}
+	}
+	if data.Root.IsNull() && root != "" {
+		data.Root = types.StringValue(root)
+	}
+	if data.FailureDomain.IsNull() && failureDomain != "" {
+		data.FailureDomain = types.StringValue(failureDomain)
+	}
+	if data.DeviceClass.IsNull() && data.RuleID.IsNull() && deviceClass != "" {
+		data.DeviceClass = types.StringValue(deviceClass)
+	}
+
 	data.RuleID = types.Int64Value(int64(rule.RuleID))
 	data.Ruleset = types.Int64Value(int64(rule.Ruleset))
 	data.Type = types.Int64Value(int64(rule.Type))
@@ -324,12 +387,19 @@ func (r *CrushRuleResource) updateModelFromAPI(data *CrushRuleResourceModel, rul
 			stepAttrs["item"] = types.Int64Null()
 		}
 
+		if step.ItemName != "" {
+			stepAttrs["item_name"] = types.StringValue(step.ItemName)
+		} else {
+			stepAttrs["item_name"] = types.StringNull()
+		}
+
 		stepObj, stepDiags := types.ObjectValue(
 			map[string]attr.Type{
-				"op":   types.StringType,
-				"num":  types.Int64Type,
-				"type": types.StringType,
-				"item": types.Int64Type,
+				"op":        types.StringType,
+				"num":       types.Int64Type,
+				"type":      types.StringType,
+				"item":      types.Int64Type,
+				"item_name": types.StringType,
 			},
 			stepAttrs,
 		)
@@ -344,10 +414,11 @@ func (r *CrushRuleResource) updateModelFromAPI(data *CrushRuleResourceModel, rul
 	stepsValue, stepDiags := types.ListValue(
 		types.ObjectType{
 			AttrTypes: map[string]attr.Type{
-				"op":   types.StringType,
-				"num":  types.Int64Type,
-				"type": types.StringType,
-				"item": types.Int64Type,
+				"op":        types.StringType,
+				"num":       types.Int64Type,
+				"type":      types.StringType,
+				"item":      types.Int64Type,
+				"item_name": types.StringType,
 			},
 		},
 		stepsObjects,
@@ -360,3 +431,23 @@ func (r *CrushRuleResource) updateModelFromAPI(data *CrushRuleResourceModel, rul
 
 	return diags
 }
+
+// crushRuleTypeErasure is the type of rules for erasure coded pools.
+const crushRuleTypeErasure = 3
+
+// crushRuleSettings returns the root, device class and failure domain of a
+// rule created by "ceph osd crush rule create-replicated" or
+// "create-erasure", taken from its take and first choose step.
+func crushRuleSettings(rule *CephAPICrushRule) (root, deviceClass, failureDomain string) {
+	for _, step := range rule.Steps {
+		switch {
+		case step.Op == "take" && root == "":
+			// Device classes are stored as shadow trees named e.g.
+			// "default~ssd".
+			root, deviceClass, _ = strings.Cut(step.ItemName, "~")
+		case strings.HasPrefix(step.Op, "choose") && failureDomain == "":
+			failureDomain = step.Type
+		}
+	}
+	return root, deviceClass, failureDomain
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"slices"
@@ -9,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
@@ -104,7 +106,27 @@ func TestAccCephCrushRuleResource_replicated(t *testing.T) {
 				ImportStateVerify:                    true,
 				ImportStateId:                        ruleName,
 				ImportStateVerifyIdentifierAttribute: "name",
-				ImportStateVerifyIgnore:              []string{"pool_type", "failure_domain", "device_class", "profile", "root"},
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.CrushRuleRemove(t.Context(), ruleName); err != nil {
+						t.Fatalf("Failed to delete CRUSH rule out of band: %v", err)
+					}
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_crush_rule" "test" {
+					  name           = %q
+					  pool_type      = "replicated"
+					  failure_domain = "host"
+					}
+				`, ruleName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_crush_rule.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkCephCrushRuleExists(t, ruleName),
 			},
 		},
 	})
@@ -232,7 +254,7 @@ func TestAccCephCrushRuleResource_erasure(t *testing.T) {
 				ImportStateVerify:                    true,
 				ImportStateId:                        ruleName,
 				ImportStateVerifyIdentifierAttribute: "name",
-				ImportStateVerifyIgnore:              []string{"pool_type", "failure_domain", "device_class", "profile", "root"},
+				ImportStateVerifyIgnore:              []string{"profile"},
 			},
 		},
 	})
@@ -297,6 +319,121 @@ func TestAccCephCrushRuleResource_withDeviceClass(t *testing.T) {
 	})
 }
 
+func TestAccCephCrushRuleResource_importExisting(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	ruleName := fmt.Sprintf("test-import-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	config := testAccProviderConfigBlock + fmt.Sprintf(`
+		resource "ceph_crush_rule" "test" {
+		  name           = %q
+		  failure_domain = "osd"
+		}
+	`, ruleName)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephCrushRuleDestroy(t),
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+
+			if err := cephTestClusterCLI.CrushRuleCreateReplicated(t.Context(), ruleName, "default", "osd"); err != nil {
+				t.Fatalf("Failed to create replicated crush rule: %v", err)
+			}
+
+			testCleanup(t, func(ctx context.Context) {
+				if err := cephTestClusterCLI.CrushRuleRemove(ctx, ruleName); err != nil {
+					t.Errorf("Failed to cleanup crush rule %s: %v", ruleName, err)
+				}
+			})
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables:    testAccProviderConfig(),
+				Config:             config,
+				ResourceName:       "ceph_crush_rule.test",
+				ImportState:        true,
+				ImportStateId:      ruleName,
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(states))
+					}
+					for key, want := range map[string]string{
+						"pool_type":         "replicated",
+						"root":              "default",
+						"failure_domain":    "osd",
+						"steps.0.item_name": "default",
+					} {
+						if got := states[0].Attributes[key]; got != want {
+							return fmt.Errorf("%s = %q, want %q", key, got, want)
+						}
+					}
+					return nil
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestCrushRuleSettings(t *testing.T) {
+	tests := map[string]struct {
+		steps             []CephAPICrushRuleStep
+		wantRoot          string
+		wantDeviceClass   string
+		wantFailureDomain string
+	}{
+		"replicated": {
+			steps: []CephAPICrushRuleStep{
+				{Op: "take", Item: -1, ItemName: "default"},
+				{Op: "chooseleaf_firstn", Type: "host"},
+				{Op: "emit"},
+			},
+			wantRoot:          "default",
+			wantFailureDomain: "host",
+		},
+		"device class": {
+			steps: []CephAPICrushRuleStep{
+				{Op: "take", Item: -2, ItemName: "default~ssd"},
+				{Op: "choose_firstn", Type: "osd"},
+				{Op: "emit"},
+			},
+			wantRoot:          "default",
+			wantDeviceClass:   "ssd",
+			wantFailureDomain: "osd",
+		},
+		"erasure": {
+			steps: []CephAPICrushRuleStep{
+				{Op: "set_chooseleaf_tries", Num: 5},
+				{Op: "set_choose_tries", Num: 100},
+				{Op: "take", Item: -1, ItemName: "dc1"},
+				{Op: "chooseleaf_indep", Type: "rack"},
+				{Op: "emit"},
+			},
+			wantRoot:          "dc1",
+			wantFailureDomain: "rack",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			root, deviceClass, failureDomain := crushRuleSettings(&CephAPICrushRule{Steps: tt.steps})
+			if root != tt.wantRoot || deviceClass != tt.wantDeviceClass || failureDomain != tt.wantFailureDomain {
+				t.Errorf("crushRuleSettings() = %q, %q, %q, want %q, %q, %q", root, deviceClass, failureDomain, tt.wantRoot, tt.wantDeviceClass, tt.wantFailureDomain)
+			}
+		})
+	}
+}
+
 func TestAccCephCrushRuleResource_InvalidPoolType(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
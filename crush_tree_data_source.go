@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &CrushTreeDataSource{}
+
+func newCrushTreeDataSource() datasource.DataSource {
+	return &CrushTreeDataSource{}
+}
+
+type CrushTreeDataSource struct {
+	client *CephAPIClient
+}
+
+type CrushTreeDataSourceModel struct {
+	ShowShadow types.Bool          `tfsdk:"show_shadow"`
+	Nodes      []CrushTreeNodeItem `tfsdk:"nodes"`
+	Stray      []CrushTreeNodeItem `tfsdk:"stray"`
+}
+
+type CrushTreeNodeItem struct {
+	ID          types.Int64   `tfsdk:"id"`
+	Name        types.String  `tfsdk:"name"`
+	Type        types.String  `tfsdk:"type"`
+	TypeID      types.Int64   `tfsdk:"type_id"`
+	DeviceClass types.String  `tfsdk:"device_class"`
+	Weight      types.Float64 `tfsdk:"weight"`
+	Children    []types.Int64 `tfsdk:"children"`
+}
+
+func (d *CrushTreeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_crush_tree"
+}
+
+func (d *CrushTreeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	nodeAttributes := map[string]dataSourceSchema.Attribute{
+		"id": dataSourceSchema.Int64Attribute{
+			MarkdownDescription: "The CRUSH ID: negative for buckets, the OSD ID for devices.",
+			Computed:            true,
+		},
+		"name": dataSourceSchema.StringAttribute{
+			MarkdownDescription: "The name, e.g. `default`, `node1` or `osd.3`. Shadow buckets have the device class appended, e.g. `default~ssd`.",
+			Computed:            true,
+		},
+		"type": dataSourceSchema.StringAttribute{
+			MarkdownDescription: "The bucket type, e.g. `root`, `host` or `rack`, or `osd` for devices.",
+			Computed:            true,
+		},
+		"type_id": dataSourceSchema.Int64Attribute{
+			MarkdownDescription: "The numeric bucket type, `0` for devices.",
+			Computed:            true,
+		},
+		"device_class": dataSourceSchema.StringAttribute{
+			MarkdownDescription: "The device class of an OSD or shadow bucket. Empty for regular buckets and OSDs without a class.",
+			Computed:            true,
+		},
+		"weight": dataSourceSchema.Float64Attribute{
+			MarkdownDescription: "The CRUSH weight, by convention the capacity in TiB. Bucket weights are the sum of their items.",
+			Computed:            true,
+		},
+		"children": dataSourceSchema.ListAttribute{
+			MarkdownDescription: "The IDs of the bucket's items. Empty for OSDs.",
+			ElementType:         types.Int64Type,
+			Computed:            true,
+		},
+	}
+
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source returns the CRUSH hierarchy of buckets and OSDs with their weights and device classes (equivalent to `ceph osd crush tree`). " +
+			"The CRUSH tree is only available through the ceph CLI, so this data source requires the provider `cli_backend` to be enabled.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"show_shadow": dataSourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether to include the shadow trees Ceph maintains for each device class, e.g. `default~ssd`. Defaults to `false`.",
+				Optional:            true,
+			},
+			"nodes": dataSourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The buckets and OSDs, depth first from each root.",
+				Computed:            true,
+				NestedObject: dataSourceSchema.NestedAttributeObject{
+					Attributes: nodeAttributes,
+				},
+			},
+			"stray": dataSourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The OSDs that exist but are not in the CRUSH hierarchy.",
+				Computed:            true,
+				NestedObject: dataSourceSchema.NestedAttributeObject{
+					Attributes: nodeAttributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *CrushTreeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *CrushTreeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CrushTreeDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := d.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read the CRUSH tree: %s", err),
+		)
+		return
+	}
+
+	tree, err := cli.OsdCrushTree(ctx, data.ShowShadow.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read the CRUSH tree: %s", err),
+		)
+		return
+	}
+
+	data.Nodes = crushTreeNodeItems(tree.Nodes)
+	data.Stray = crushTreeNodeItems(tree.Stray)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func crushTreeNodeItems(nodes []CrushTreeNode) []CrushTreeNodeItem {
+	items := []CrushTreeNodeItem{}
+	for _, node := range nodes {
+		children := []types.Int64{}
+		for _, child := range node.Children {
+			children = append(children, types.Int64Value(child))
+		}
+
+		items = append(items, CrushTreeNodeItem{
+			ID:          types.Int64Value(node.ID),
+			Name:        types.StringValue(node.Name),
+			Type:        types.StringValue(node.Type),
+			TypeID:      types.Int64Value(node.TypeID),
+			DeviceClass: types.StringValue(node.DeviceClass),
+			Weight:      types.Float64Value(node.CrushWeight),
+			Children:    children,
+		})
+	}
+	return items
+}
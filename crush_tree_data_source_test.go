@@ -0,0 +1,65 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCephCrushTreeDataSource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					data "ceph_crush_tree" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					data "ceph_crush_tree" "test" {}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_crush_tree.test", "nodes.0.name", "default"),
+					resource.TestCheckResourceAttr("data.ceph_crush_tree.test", "nodes.0.type", "root"),
+					resource.TestCheckResourceAttrSet("data.ceph_crush_tree.test", "nodes.0.children.0"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ceph_crush_tree.test", "nodes.*", map[string]string{
+						"id":   "0",
+						"name": "osd.0",
+						"type": "osd",
+					}),
+				),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					data "ceph_crush_tree" "test" {
+					  show_shadow = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchTypeSetElemNestedAttrs("data.ceph_crush_tree.test", "nodes.*", map[string]*regexp.Regexp{
+						"name": regexp.MustCompile(`^default~`),
+						"type": regexp.MustCompile(`^root$`),
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &CSIConfigDataSource{}
+
+func newCSIConfigDataSource() datasource.DataSource {
+	return &CSIConfigDataSource{}
+}
+
+type CSIConfigDataSource struct {
+	client *CephAPIClient
+}
+
+type CSIConfigDataSourceModel struct {
+	Entity               types.String `tfsdk:"entity"`
+	ClusterID            types.String `tfsdk:"cluster_id"`
+	CephFSSubvolumeGroup types.String `tfsdk:"cephfs_subvolume_group"`
+	RBDRadosNamespace    types.String `tfsdk:"rbd_rados_namespace"`
+	FSID                 types.String `tfsdk:"fsid"`
+	Monitors             types.List   `tfsdk:"monitors"`
+	UserID               types.String `tfsdk:"user_id"`
+	UserKey              types.String `tfsdk:"user_key"`
+	ConfigJSON           types.String `tfsdk:"config_json"`
+}
+
+// csiClusterConfig is one entry of the config.json key of the ceph-csi
+// ConfigMap.
+type csiClusterConfig struct {
+	ClusterID string           `json:"clusterID"`
+	Monitors  []string         `json:"monitors"`
+	CephFS    *csiCephFSConfig `json:"cephFS,omitempty"`
+	RBD       *csiRBDConfig    `json:"rbd,omitempty"`
+}
+
+type csiCephFSConfig struct {
+	SubvolumeGroup string `json:"subvolumeGroup"`
+}
+
+type csiRBDConfig struct {
+	RadosNamespace string `json:"radosNamespace"`
+}
+
+func (d *CSIConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_csi_config"
+}
+
+func (d *CSIConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source collects what ceph-csi needs to connect to the cluster: the `config.json` of its `ceph-csi-config` ConfigMap, and the user ID and key for its Secret.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"entity": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The cephx user ceph-csi authenticates as, e.g. 'client.csi-rbd-node'",
+				Required:            true,
+			},
+			"cluster_id": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The `clusterID` StorageClasses refer to. Defaults to the cluster fsid.",
+				Optional:            true,
+			},
+			"cephfs_subvolume_group": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The CephFS subvolume group ceph-csi creates volumes in",
+				Optional:            true,
+			},
+			"rbd_rados_namespace": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The RADOS namespace ceph-csi creates RBD images in",
+				Optional:            true,
+			},
+			"fsid": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The cluster fsid",
+				Computed:            true,
+			},
+			"monitors": dataSourceSchema.ListAttribute{
+				MarkdownDescription: "The monitor addresses in rank order, using the msgr v1 address when a monitor has one",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"user_id": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The user ID without the `client.` prefix, for the `userID` key of the Secret",
+				Computed:            true,
+			},
+			"user_key": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The cephx key of the user, for the `userKey` key of the Secret",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"config_json": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The `config.json` value of the `ceph-csi-config` ConfigMap",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CSIConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *CSIConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CSIConfigDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monitor, err := d.client.GetMonitor(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to get monitor map from Ceph API: %s", err),
+		)
+		return
+	}
+	monMap := monitor.MonStatus.MonMap
+
+	entity := data.Entity.ValueString()
+	keyringRaw, err := d.client.ClusterExportUser(ctx, entity)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to export user from Ceph API: %s", err),
+		)
+		return
+	}
+
+	keyringUsers, err := parseCephKeyring(keyringRaw)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse keyring data",
+			fmt.Sprintf("Unable to parse keyring data: %s", err),
+		)
+		return
+	} else if len(keyringUsers) == 0 {
+		resp.Diagnostics.AddError(
+			"Empty keyring data",
+			fmt.Sprintf("Ceph export returned no users for entity %s", entity),
+		)
+		return
+	}
+
+	config := csiClusterConfig{
+		ClusterID: monMap.FSID,
+		Monitors:  csiMonitorAddrs(monMap.Mons),
+	}
+	if !data.ClusterID.IsNull() {
+		config.ClusterID = data.ClusterID.ValueString()
+	}
+	if !data.CephFSSubvolumeGroup.IsNull() {
+		config.CephFS = &csiCephFSConfig{SubvolumeGroup: data.CephFSSubvolumeGroup.ValueString()}
+	}
+	if !data.RBDRadosNamespace.IsNull() {
+		config.RBD = &csiRBDConfig{RadosNamespace: data.RBDRadosNamespace.ValueString()}
+	}
+
+	configJSON, err := json.Marshal([]csiClusterConfig{config})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"JSON Encoding Error",
+			fmt.Sprintf("Unable to encode ceph-csi config: %s", err),
+		)
+		return
+	}
+
+	monitors, diags := types.ListValueFrom(ctx, types.StringType, config.Monitors)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.FSID = types.StringValue(monMap.FSID)
+	data.Monitors = monitors
+	data.UserID = types.StringValue(strings.TrimPrefix(entity, "client."))
+	data.UserKey = types.StringValue(keyringUsers[0].Key)
+	data.ConfigJSON = types.StringValue(string(configJSON))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// csiMonitorAddrs returns one address per monitor in rank order. ceph-csi
+// connects with msgr v1 unless configured otherwise, so the v1 address is
+// preferred.
+func csiMonitorAddrs(mons []CephAPIMonMapMon) []string {
+	mons = slices.Clone(mons)
+	slices.SortFunc(mons, func(a, b CephAPIMonMapMon) int {
+		return cmp.Compare(a.Rank, b.Rank)
+	})
+
+	addrs := []string{}
+	for _, mon := range mons {
+		addrVec := mon.PublicAddrs.AddrVec
+		i := slices.IndexFunc(addrVec, func(addr CephAPIMonAddr) bool {
+			return addr.Type == "v1"
+		})
+		if i < 0 {
+			i = 0
+		}
+		if i < len(addrVec) {
+			addrs = append(addrs, strings.TrimSuffix(addrVec[i].Addr, "/0"))
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephCSIConfigDataSource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					data "ceph_csi_config" "default" {
+					  entity = "client.admin"
+					}
+
+					data "ceph_csi_config" "custom" {
+					  entity                 = "client.admin"
+					  cluster_id             = "ceph"
+					  cephfs_subvolume_group = "csi"
+					  rbd_rados_namespace    = "k8s"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.ceph_csi_config.default",
+						tfjsonpath.New("fsid"),
+						knownvalue.StringExact("6bb5784d-86b1-4b48-aff7-04d5dd22ef07"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.ceph_csi_config.default",
+						tfjsonpath.New("monitors"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("127.0.0.1:6789"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.ceph_csi_config.default",
+						tfjsonpath.New("user_id"),
+						knownvalue.StringExact("admin"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.ceph_csi_config.default",
+						tfjsonpath.New("user_key"),
+						knownvalue.StringExact("AQB5m89objcKIxAAda2ULz/l3NH+mv9XzKePHQ=="),
+					),
+					statecheck.ExpectKnownValue(
+						"data.ceph_csi_config.default",
+						tfjsonpath.New("config_json"),
+						knownvalue.StringExact(`[{"clusterID":"6bb5784d-86b1-4b48-aff7-04d5dd22ef07","monitors":["127.0.0.1:6789"]}]`),
+					),
+					statecheck.ExpectKnownValue(
+						"data.ceph_csi_config.custom",
+						tfjsonpath.New("config_json"),
+						knownvalue.StringExact(`[{"clusterID":"ceph","monitors":["127.0.0.1:6789"],"cephFS":{"subvolumeGroup":"csi"},"rbd":{"radosNamespace":"k8s"}}]`),
+					),
+				},
+			},
+		},
+	})
+}
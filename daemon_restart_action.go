@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	actionSchema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ action.Action              = &DaemonRestartAction{}
+	_ action.ActionWithConfigure = &DaemonRestartAction{}
+)
+
+func newDaemonRestartAction() action.Action {
+	return &DaemonRestartAction{}
+}
+
+// DaemonRestartAction restarts the daemons of a service. The mgr is failed
+// over instead, since restarting the active mgr in place would also restart
+// the dashboard serving this provider. Neither is exposed by the dashboard
+// API, so it goes through the ceph CLI.
+type DaemonRestartAction struct {
+	client *CephAPIClient
+}
+
+type DaemonRestartActionModel struct {
+	Service types.String `tfsdk:"service"`
+}
+
+func (a *DaemonRestartAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_daemon_restart"
+}
+
+func (a *DaemonRestartAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = actionSchema.Schema{
+		MarkdownDescription: "Restarts the daemons of a service, e.g. after a config change that only takes effect on restart. " +
+			"`mgr` fails over to a standby mgr with `ceph mgr fail`; any other service is restarted with `ceph orch restart`, which requires an orchestrator such as cephadm. " +
+			"This action requires the provider `cli_backend` to be enabled.",
+		Attributes: map[string]actionSchema.Attribute{
+			"service": actionSchema.StringAttribute{
+				MarkdownDescription: "The service to restart as listed by `ceph orch ls`, e.g. `rgw.foo`, or `mgr`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (a *DaemonRestartAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
+
+func (a *DaemonRestartAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data DaemonRestartActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := data.Service.ValueString()
+
+	cli, err := a.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to restart %s: %s", service, err),
+		)
+		return
+	}
+
+	if service == "mgr" {
+		resp.SendProgress(action.InvokeProgressEvent{Message: "Failing over the active mgr"})
+		err = cli.MgrFail(ctx)
+	} else {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Restarting %s", service)})
+		err = cli.OrchRestart(ctx, service)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to restart %s: %s", service, err),
+		)
+		return
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// The test cluster has no orchestrator, and failing over its only mgr would
+// take the dashboard down, so this covers the CLI requirement and the error
+// path.
+func TestAccCephDaemonRestartAction(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	actionConfig := `
+		action "ceph_daemon_restart" "test" {
+		  config {
+		    service = "rgw.missing"
+		  }
+		}
+
+		resource "terraform_data" "trigger" {
+		  input = "restart"
+
+		  lifecycle {
+		    action_trigger {
+		      events  = [after_create]
+		      actions = [action.ceph_daemon_restart.test]
+		    }
+		  }
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(goversion.Must(goversion.NewVersion("1.14.0"))),
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + actionConfig,
+				ExpectError:     regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint":  config.StringVariable(testDashboardURL),
+					"ceph_conf": config.StringVariable(testConfPath),
+				},
+				Config:      testAccCLIProviderConfigBlock + actionConfig,
+				ExpectError: regexp.MustCompile(`Unable to restart rgw.missing`),
+			},
+		},
+	})
+}
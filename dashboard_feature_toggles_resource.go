@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const dashboardFeatureTogglesResourceID = "dashboard_feature_toggles"
+
+var (
+	_ resource.Resource                = &DashboardFeatureTogglesResource{}
+	_ resource.ResourceWithImportState = &DashboardFeatureTogglesResource{}
+)
+
+func newDashboardFeatureTogglesResource() resource.Resource {
+	return &DashboardFeatureTogglesResource{}
+}
+
+// DashboardFeatureTogglesResource manages the dashboard feature toggles, as
+// with `ceph dashboard feature enable|disable`. Each toggle is a
+// FEATURE_TOGGLE_<FEATURE> option of the dashboard module. Toggles left out
+// of the configuration are not touched.
+type DashboardFeatureTogglesResource struct {
+	client *CephAPIClient
+}
+
+type DashboardFeatureTogglesResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	RBD       types.Bool   `tfsdk:"rbd"`
+	Mirroring types.Bool   `tfsdk:"mirroring"`
+	ISCSI     types.Bool   `tfsdk:"iscsi"`
+	CephFS    types.Bool   `tfsdk:"cephfs"`
+	RGW       types.Bool   `tfsdk:"rgw"`
+	NFS       types.Bool   `tfsdk:"nfs"`
+}
+
+func (m *DashboardFeatureTogglesResourceModel) options() []mgrModuleOption {
+	return []mgrModuleOption{
+		{"FEATURE_TOGGLE_RBD", &m.RBD},
+		{"FEATURE_TOGGLE_MIRRORING", &m.Mirroring},
+		{"FEATURE_TOGGLE_ISCSI", &m.ISCSI},
+		{"FEATURE_TOGGLE_CEPHFS", &m.CephFS},
+		{"FEATURE_TOGGLE_RGW", &m.RGW},
+		{"FEATURE_TOGGLE_NFS", &m.NFS},
+	}
+}
+
+func (r *DashboardFeatureTogglesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard_feature_toggles"
+}
+
+func (r *DashboardFeatureTogglesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Enables or disables dashboard features, as with `ceph dashboard feature enable` and `ceph dashboard feature disable`. " +
+			"A disabled feature is hidden from the dashboard UI and its API endpoints are rejected, so that hardened clusters expose only the pages they use. " +
+			"Disabling `rbd`, `rgw`, `cephfs` or `nfs` also breaks the resources of this provider that manage them through the dashboard API. " +
+			"There is one set of toggles per cluster, so declare this resource at most once. " +
+			"Attributes that are not set are left unmanaged. Destroying the resource restores the defaults of the managed toggles, which enable every feature.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `dashboard_feature_toggles`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rbd": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether the block (RBD) images pages are enabled",
+				Optional:            true,
+			},
+			"mirroring": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether the RBD mirroring pages are enabled",
+				Optional:            true,
+			},
+			"iscsi": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether the iSCSI pages are enabled. Only releases whose dashboard still supports iSCSI have this toggle.",
+				Optional:            true,
+			},
+			"cephfs": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether the CephFS pages are enabled",
+				Optional:            true,
+			},
+			"rgw": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether the object gateway (RGW) pages are enabled",
+				Optional:            true,
+			},
+			"nfs": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether the NFS pages are enabled",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *DashboardFeatureTogglesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DashboardFeatureTogglesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data, prior DashboardFeatureTogglesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(applyMgrModuleOptions(ctx, r.client, "dashboard", data.options(), prior.options())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(dashboardFeatureTogglesResourceID)
+	resp.Diagnostics.Append(readMgrModuleOptions(ctx, r.client, "dashboard", data.options())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DashboardFeatureTogglesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DashboardFeatureTogglesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(readMgrModuleOptions(ctx, r.client, "dashboard", data.options())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DashboardFeatureTogglesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DashboardFeatureTogglesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(applyMgrModuleOptions(ctx, r.client, "dashboard", data.options(), state.options())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(dashboardFeatureTogglesResourceID)
+	resp.Diagnostics.Append(readMgrModuleOptions(ctx, r.client, "dashboard", data.options())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DashboardFeatureTogglesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data, empty DashboardFeatureTogglesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(applyMgrModuleOptions(ctx, r.client, "dashboard", empty.options(), data.options())...)
+}
+
+func (r *DashboardFeatureTogglesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != dashboardFeatureTogglesResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", dashboardFeatureTogglesResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dashboardFeatureTogglesResourceID)...)
+}
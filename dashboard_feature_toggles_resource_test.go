@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephDashboardFeatureTogglesResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			checkCephConfigAbsent(t, "mgr", "mgr/dashboard/FEATURE_TOGGLE_MIRRORING"),
+			checkCephConfigAbsent(t, "mgr", "mgr/dashboard/FEATURE_TOGGLE_NFS"),
+		),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_dashboard_feature_toggles" "test" {
+					  mirroring = false
+					  nfs       = false
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_dashboard_feature_toggles.test", tfjsonpath.New("id"), knownvalue.StringExact("dashboard_feature_toggles")),
+					statecheck.ExpectKnownValue("ceph_dashboard_feature_toggles.test", tfjsonpath.New("mirroring"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue("ceph_dashboard_feature_toggles.test", tfjsonpath.New("nfs"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue("ceph_dashboard_feature_toggles.test", tfjsonpath.New("rbd"), knownvalue.Null()),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigValue(t, "mgr", "mgr/dashboard/FEATURE_TOGGLE_MIRRORING", "false"),
+					checkCephConfigValue(t, "mgr", "mgr/dashboard/FEATURE_TOGGLE_NFS", "false"),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_dashboard_feature_toggles" "test" {
+					  mirroring = true
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_dashboard_feature_toggles.test", tfjsonpath.New("mirroring"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("ceph_dashboard_feature_toggles.test", tfjsonpath.New("nfs"), knownvalue.Null()),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigValue(t, "mgr", "mgr/dashboard/FEATURE_TOGGLE_MIRRORING", "true"),
+					checkCephConfigAbsent(t, "mgr", "mgr/dashboard/FEATURE_TOGGLE_NFS"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const dashboardMonitoringResourceID = "dashboard_monitoring"
+
+var (
+	_ resource.Resource                = &DashboardMonitoringResource{}
+	_ resource.ResourceWithImportState = &DashboardMonitoringResource{}
+)
+
+func newDashboardMonitoringResource() resource.Resource {
+	return &DashboardMonitoringResource{}
+}
+
+// DashboardMonitoringResource manages the dashboard settings that point it
+// at Grafana, Prometheus and Alertmanager.
+type DashboardMonitoringResource struct {
+	client *CephAPIClient
+}
+
+type DashboardMonitoringResourceModel struct {
+	ID                       types.String `tfsdk:"id"`
+	GrafanaAPIURL            types.String `tfsdk:"grafana_api_url"`
+	GrafanaFrontendAPIURL    types.String `tfsdk:"grafana_frontend_api_url"`
+	GrafanaAPISSLVerify      types.Bool   `tfsdk:"grafana_api_ssl_verify"`
+	PrometheusAPIHost        types.String `tfsdk:"prometheus_api_host"`
+	PrometheusAPISSLVerify   types.Bool   `tfsdk:"prometheus_api_ssl_verify"`
+	AlertmanagerAPIHost      types.String `tfsdk:"alertmanager_api_host"`
+	AlertmanagerAPISSLVerify types.Bool   `tfsdk:"alertmanager_api_ssl_verify"`
+}
+
+// settings maps each dashboard setting to its attribute value.
+func (m *DashboardMonitoringResourceModel) settings() map[string]any {
+	return map[string]any{
+		"GRAFANA_API_URL":             m.GrafanaAPIURL,
+		"GRAFANA_FRONTEND_API_URL":    m.GrafanaFrontendAPIURL,
+		"GRAFANA_API_SSL_VERIFY":      m.GrafanaAPISSLVerify,
+		"PROMETHEUS_API_HOST":         m.PrometheusAPIHost,
+		"PROMETHEUS_API_SSL_VERIFY":   m.PrometheusAPISSLVerify,
+		"ALERTMANAGER_API_HOST":       m.AlertmanagerAPIHost,
+		"ALERTMANAGER_API_SSL_VERIFY": m.AlertmanagerAPISSLVerify,
+	}
+}
+
+func (r *DashboardMonitoringResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard_monitoring"
+}
+
+func (r *DashboardMonitoringResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	urlValidators := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages how the dashboard reaches the monitoring stack: the Grafana, Prometheus and Alertmanager URLs and whether their TLS certificates are verified. " +
+			"Unset URLs are reset, so the dashboard falls back to what the orchestrator deployed, if anything. " +
+			"There is one set of dashboard settings per cluster, so declare this resource at most once. Destroying it resets every setting it manages.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `dashboard_monitoring`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"grafana_api_url": resourceSchema.StringAttribute{
+				MarkdownDescription: "The Grafana URL the dashboard queries, e.g. `https://grafana.example.com:3000`",
+				Optional:            true,
+				Validators:          urlValidators,
+			},
+			"grafana_frontend_api_url": resourceSchema.StringAttribute{
+				MarkdownDescription: "The Grafana URL browsers load embedded panels from, if it differs from `grafana_api_url`",
+				Optional:            true,
+				Validators:          urlValidators,
+			},
+			"grafana_api_ssl_verify": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether to verify the Grafana TLS certificate. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"prometheus_api_host": resourceSchema.StringAttribute{
+				MarkdownDescription: "The Prometheus URL, e.g. `http://prometheus.example.com:9095`",
+				Optional:            true,
+				Validators:          urlValidators,
+			},
+			"prometheus_api_ssl_verify": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether to verify the Prometheus TLS certificate. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"alertmanager_api_host": resourceSchema.StringAttribute{
+				MarkdownDescription: "The Alertmanager URL, e.g. `http://alertmanager.example.com:9093`",
+				Optional:            true,
+				Validators:          urlValidators,
+			},
+			"alertmanager_api_ssl_verify": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether to verify the Alertmanager TLS certificate. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *DashboardMonitoringResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DashboardMonitoringResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DashboardMonitoringResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(dashboardMonitoringResourceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DashboardMonitoringResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DashboardMonitoringResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names := make([]string, 0, len(data.settings()))
+	for name := range data.settings() {
+		names = append(names, name)
+	}
+
+	settings, err := r.client.DashboardGetSettings(ctx, names)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read dashboard monitoring settings: %s", err),
+		)
+		return
+	}
+
+	values := make(map[string]any, len(settings))
+	for _, setting := range settings {
+		values[setting.Name] = setting.Value
+	}
+
+	data.ID = types.StringValue(dashboardMonitoringResourceID)
+	data.GrafanaAPIURL = dashboardSettingString(values["GRAFANA_API_URL"])
+	data.GrafanaFrontendAPIURL = dashboardSettingString(values["GRAFANA_FRONTEND_API_URL"])
+	data.GrafanaAPISSLVerify = dashboardSettingBool(values["GRAFANA_API_SSL_VERIFY"])
+	data.PrometheusAPIHost = dashboardSettingString(values["PROMETHEUS_API_HOST"])
+	data.PrometheusAPISSLVerify = dashboardSettingBool(values["PROMETHEUS_API_SSL_VERIFY"])
+	data.AlertmanagerAPIHost = dashboardSettingString(values["ALERTMANAGER_API_HOST"])
+	data.AlertmanagerAPISSLVerify = dashboardSettingBool(values["ALERTMANAGER_API_SSL_VERIFY"])
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DashboardMonitoringResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DashboardMonitoringResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(dashboardMonitoringResourceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DashboardMonitoringResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DashboardMonitoringResourceModel
+
+	for name := range data.settings() {
+		if err := r.client.DashboardResetSetting(ctx, name); err != nil {
+			resp.Diagnostics.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to reset dashboard setting %s: %s", name, err),
+			)
+			return
+		}
+	}
+}
+
+func (r *DashboardMonitoringResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != dashboardMonitoringResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", dashboardMonitoringResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dashboardMonitoringResourceID)...)
+}
+
+// apply sets every configured setting in one request and resets the URLs
+// that are not configured.
+func (r *DashboardMonitoringResource) apply(ctx context.Context, data *DashboardMonitoringResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	values := map[string]any{}
+	for name, value := range data.settings() {
+		switch value := value.(type) {
+		case types.String:
+			if value.IsNull() {
+				if err := r.client.DashboardResetSetting(ctx, name); err != nil {
+					diags.AddError(
+						"API Request Error",
+						fmt.Sprintf("Unable to reset dashboard setting %s: %s", name, err),
+					)
+					return diags
+				}
+				continue
+			}
+			values[name] = value.ValueString()
+		case types.Bool:
+			values[name] = value.ValueBool()
+		}
+	}
+
+	if err := r.client.DashboardSetSettings(ctx, values); err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to set dashboard monitoring settings: %s", err),
+		)
+	}
+	return diags
+}
+
+// dashboardSettingString maps an unset (empty) URL setting to null.
+func dashboardSettingString(value any) types.String {
+	s, _ := value.(string)
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+func dashboardSettingBool(value any) types.Bool {
+	b, ok := value.(bool)
+	if !ok {
+		return types.BoolValue(true)
+	}
+	return types.BoolValue(b)
+}
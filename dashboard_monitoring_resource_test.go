@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephDashboardMonitoringResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		CheckDestroy: func(s *terraform.State) error {
+			for _, name := range []string{"GRAFANA_API_URL", "PROMETHEUS_API_HOST", "PROMETHEUS_API_SSL_VERIFY"} {
+				if value, err := cephTestClusterCLI.ConfigGetFromDump(t.Context(), "mgr", "mgr/dashboard/"+name); err == nil {
+					return fmt.Errorf("dashboard setting %s is still set to %q", name, value)
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_dashboard_monitoring" "test" {
+					  grafana_api_url           = "https://grafana.example.com:3000"
+					  prometheus_api_host       = "https://prometheus.example.com:9095"
+					  prometheus_api_ssl_verify = false
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_dashboard_monitoring.test", tfjsonpath.New("grafana_api_url"), knownvalue.StringExact("https://grafana.example.com:3000")),
+					statecheck.ExpectKnownValue("ceph_dashboard_monitoring.test", tfjsonpath.New("grafana_api_ssl_verify"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("ceph_dashboard_monitoring.test", tfjsonpath.New("prometheus_api_ssl_verify"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue("ceph_dashboard_monitoring.test", tfjsonpath.New("alertmanager_api_host"), knownvalue.Null()),
+				},
+				Check: checkCephDashboardSetting(t, "PROMETHEUS_API_HOST", "https://prometheus.example.com:9095"),
+			},
+			{
+				ConfigVariables:   testAccProviderConfig(),
+				ResourceName:      "ceph_dashboard_monitoring.test",
+				ImportState:       true,
+				ImportStateId:     "dashboard_monitoring",
+				ImportStateVerify: true,
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_dashboard_monitoring" "test" {
+					  prometheus_api_host   = "https://prometheus.example.com:9095"
+					  alertmanager_api_host = "https://alertmanager.example.com:9093"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_dashboard_monitoring.test", tfjsonpath.New("grafana_api_url"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("ceph_dashboard_monitoring.test", tfjsonpath.New("prometheus_api_ssl_verify"), knownvalue.Bool(true)),
+				},
+				Check: resource.ComposeTestCheckFunc(
+					checkCephDashboardSetting(t, "ALERTMANAGER_API_HOST", "https://alertmanager.example.com:9093"),
+					func(s *terraform.State) error {
+						if value, err := cephTestClusterCLI.ConfigGetFromDump(t.Context(), "mgr", "mgr/dashboard/GRAFANA_API_URL"); err == nil {
+							return fmt.Errorf("GRAFANA_API_URL is still set to %q", value)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func checkCephDashboardSetting(t *testing.T, name, expected string) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		value, err := cephTestClusterCLI.ConfigGetFromDump(t.Context(), "mgr", "mgr/dashboard/"+name)
+		if err != nil {
+			return err
+		}
+		if value != expected {
+			return fmt.Errorf("dashboard setting %s = %q, want %q", name, value, expected)
+		}
+		return nil
+	}
+}
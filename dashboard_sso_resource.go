@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	dashboardSSOResourceID     = "dashboard_sso"
+	dashboardSAML2EntitySuffix = "/auth/saml2/metadata"
+)
+
+var _ resource.Resource = &DashboardSSOResource{}
+
+func newDashboardSSOResource() resource.Resource {
+	return &DashboardSSOResource{}
+}
+
+// DashboardSSOResource configures SAML2 single sign-on for the dashboard. The
+// dashboard API does not expose SSO, so it always goes through the ceph CLI.
+type DashboardSSOResource struct {
+	client *CephAPIClient
+}
+
+type DashboardSSOResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	BaseURL              types.String `tfsdk:"base_url"`
+	IdPMetadata          types.String `tfsdk:"idp_metadata"`
+	IdPUsernameAttribute types.String `tfsdk:"idp_username_attribute"`
+	IdPEntityID          types.String `tfsdk:"idp_entity_id"`
+	SPX509CertPath       types.String `tfsdk:"sp_x509_cert_path"`
+	SPPrivateKeyPath     types.String `tfsdk:"sp_private_key_path"`
+}
+
+func (r *DashboardSSOResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard_sso"
+}
+
+func (r *DashboardSSOResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Configures and enables SAML2 single sign-on for the Ceph dashboard, as with `ceph dashboard sso setup saml2`. " +
+			"SSO is only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled. " +
+			"There is one SSO configuration per cluster, so declare this resource at most once. Destroying it disables SSO.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `dashboard_sso`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"base_url": resourceSchema.StringAttribute{
+				MarkdownDescription: "The URL users reach the dashboard at, e.g. `https://ceph.example.com:8443`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"idp_metadata": resourceSchema.StringAttribute{
+				MarkdownDescription: "The identity provider metadata, either as a URL or as the metadata XML itself. Ceph does not report it back, so changes made outside Terraform are not detected.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"idp_username_attribute": resourceSchema.StringAttribute{
+				MarkdownDescription: "The SAML attribute holding the dashboard username. Defaults to `uid`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("uid"),
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"idp_entity_id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The identity provider entity ID, when the metadata describes more than one.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"sp_x509_cert_path": resourceSchema.StringAttribute{
+				MarkdownDescription: "Path to the service provider certificate used to sign requests. Ceph reads it from the filesystem of the active mgr, so the certificate never passes through Terraform.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("sp_private_key_path")),
+				},
+			},
+			"sp_private_key_path": resourceSchema.StringAttribute{
+				MarkdownDescription: "Path to the private key of `sp_x509_cert_path`, also read from the filesystem of the active mgr.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("sp_x509_cert_path")),
+				},
+			},
+		},
+	}
+}
+
+func (r *DashboardSSOResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DashboardSSOResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DashboardSSOResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setup(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(dashboardSSOResourceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DashboardSSOResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DashboardSSOResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read dashboard SSO: %s", err),
+		)
+		return
+	}
+
+	enabled, err := cli.DashboardSSOEnabled(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read dashboard SSO: %s", err),
+		)
+		return
+	}
+	if !enabled {
+		removeMissingResource(ctx, resp, "Dashboard SSO")
+		return
+	}
+
+	settings, err := cli.DashboardSSOShowSAML2(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read dashboard SSO: %s", err),
+		)
+		return
+	}
+
+	sp := settings.OneloginSettings.SP
+	if baseURL, ok := strings.CutSuffix(sp.EntityID, dashboardSAML2EntitySuffix); ok {
+		data.BaseURL = types.StringValue(baseURL)
+	}
+	if attributes := sp.AttributeConsumingService.RequestedAttributes; len(attributes) > 0 {
+		data.IdPUsernameAttribute = types.StringValue(attributes[0].Name)
+	}
+	// Without an explicit entity ID Ceph takes the one from the metadata,
+	// which must not show up as drift.
+	if !data.IdPEntityID.IsNull() {
+		data.IdPEntityID = types.StringValue(settings.OneloginSettings.IdP.EntityID)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DashboardSSOResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DashboardSSOResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setup(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(dashboardSSOResourceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DashboardSSOResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to disable dashboard SSO: %s", err),
+		)
+		return
+	}
+
+	if err := cli.DashboardSSODisable(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to disable dashboard SSO: %s", err),
+		)
+		return
+	}
+}
+
+// setup (re)applies the whole SAML2 configuration, which also enables SSO.
+func (r *DashboardSSOResource) setup(ctx context.Context, data DashboardSSOResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		diags.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to set up dashboard SSO: %s", err),
+		)
+		return diags
+	}
+
+	err = cli.DashboardSSOSetupSAML2(ctx, DashboardSAML2Options{
+		BaseURL:              data.BaseURL.ValueString(),
+		IdPMetadata:          data.IdPMetadata.ValueString(),
+		IdPUsernameAttribute: data.IdPUsernameAttribute.ValueString(),
+		IdPEntityID:          data.IdPEntityID.ValueString(),
+		SPX509CertPath:       data.SPX509CertPath.ValueString(),
+		SPPrivateKeyPath:     data.SPPrivateKeyPath.ValueString(),
+	})
+	if err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to set up dashboard SSO: %s", err),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDashboardSAML2OptionsArgs(t *testing.T) {
+	opts := DashboardSAML2Options{
+		BaseURL:          "https://ceph.example.com:8443",
+		IdPMetadata:      "https://idp.example.com/metadata",
+		IdPEntityID:      "https://idp.example.com",
+		SPX509CertPath:   "/etc/ceph/sp.crt",
+		SPPrivateKeyPath: "/etc/ceph/sp.key",
+	}
+
+	want := []string{
+		"https://ceph.example.com:8443",
+		"https://idp.example.com/metadata",
+		"--idp_entity_id=https://idp.example.com",
+		"--sp_x_509_cert=/etc/ceph/sp.crt",
+		"--sp_private_key=/etc/ceph/sp.key",
+	}
+	if got := opts.args(); !slices.Equal(got, want) {
+		t.Errorf("args() = %q, want %q", got, want)
+	}
+}
+
+// The test cluster has no identity provider, so this covers the CLI
+// requirement and the error path, and SSO is never enabled to be disabled out
+// of band.
+func TestAccCephDashboardSSOResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resourceConfig := `
+		resource "ceph_dashboard_sso" "test" {
+		  base_url     = "https://ceph.example.com:8443"
+		  idp_metadata = "<invalid"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_dashboard_sso" "test" {
+					  base_url          = "https://ceph.example.com:8443"
+					  idp_metadata      = "https://idp.example.com/metadata"
+					  sp_x509_cert_path = "/etc/ceph/sp.crt"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`sp_private_key_path`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + resourceConfig,
+				ExpectError:     regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint":  config.StringVariable(testDashboardURL),
+					"ceph_conf": config.StringVariable(testConfPath),
+				},
+				Config:      testAccCLIProviderConfigBlock + resourceConfig,
+				ExpectError: regexp.MustCompile(`Unable to set up dashboard SSO`),
+			},
+		},
+	})
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	deviceHealthResourceID = "device_health"
+	deviceHealthModule     = "devicehealth"
+)
+
+var (
+	_ resource.Resource                = &DeviceHealthResource{}
+	_ resource.ResourceWithImportState = &DeviceHealthResource{}
+)
+
+func newDeviceHealthResource() resource.Resource {
+	return &DeviceHealthResource{}
+}
+
+// DeviceHealthResource manages the options of the devicehealth mgr module.
+// Like ceph_pg_autoscaler, options left out of the configuration are not
+// touched.
+type DeviceHealthResource struct {
+	client *CephAPIClient
+}
+
+type DeviceHealthResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	EnableMonitoring types.Bool   `tfsdk:"enable_monitoring"`
+	ScrapeFrequency  types.Int64  `tfsdk:"scrape_frequency"`
+	RetentionPeriod  types.Int64  `tfsdk:"retention_period"`
+	PoolName         types.String `tfsdk:"pool_name"`
+	SelfHeal         types.Bool   `tfsdk:"self_heal"`
+	MarkOutThreshold types.Int64  `tfsdk:"mark_out_threshold"`
+	WarnThreshold    types.Int64  `tfsdk:"warn_threshold"`
+}
+
+func (m *DeviceHealthResourceModel) options() []mgrModuleOption {
+	return []mgrModuleOption{
+		{"enable_monitoring", &m.EnableMonitoring},
+		{"scrape_frequency", &m.ScrapeFrequency},
+		{"retention_period", &m.RetentionPeriod},
+		{"pool_name", &m.PoolName},
+		{"self_heal", &m.SelfHeal},
+		{"mark_out_threshold", &m.MarkOutThreshold},
+		{"warn_threshold", &m.WarnThreshold},
+	}
+}
+
+func (r *DeviceHealthResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_health"
+}
+
+func (r *DeviceHealthResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	durationValidators := []validator.Int64{
+		int64validator.AtLeast(1),
+	}
+
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the `devicehealth` mgr module, which scrapes SMART data from OSD devices and acts on predicted failures. " +
+			"There is one module per cluster, so declare this resource at most once. " +
+			"Attributes that are not set are left unmanaged, and destroying the resource restores the Ceph defaults of the managed ones.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `device_health`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enable_monitoring": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether device health metrics are scraped, as with `ceph device monitoring on`.",
+				Optional:            true,
+			},
+			"scrape_frequency": resourceSchema.Int64Attribute{
+				MarkdownDescription: "How often to scrape device health metrics, in seconds.",
+				Optional:            true,
+				Validators:          durationValidators,
+			},
+			"retention_period": resourceSchema.Int64Attribute{
+				MarkdownDescription: "How long to keep device health metrics, in seconds.",
+				Optional:            true,
+				Validators:          durationValidators,
+			},
+			"pool_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The pool device health metrics are stored in.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"self_heal": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether OSDs on devices predicted to fail are marked out automatically.",
+				Optional:            true,
+			},
+			"mark_out_threshold": resourceSchema.Int64Attribute{
+				MarkdownDescription: "Mark an OSD out when its device is predicted to fail within this many seconds. Requires `self_heal`.",
+				Optional:            true,
+				Validators:          durationValidators,
+			},
+			"warn_threshold": resourceSchema.Int64Attribute{
+				MarkdownDescription: "Raise a health warning when a device is predicted to fail within this many seconds.",
+				Optional:            true,
+				Validators:          durationValidators,
+			},
+		},
+	}
+}
+
+func (r *DeviceHealthResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DeviceHealthResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DeviceHealthResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, DeviceHealthResourceModel{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(deviceHealthResourceID)
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceHealthResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DeviceHealthResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceHealthResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DeviceHealthResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(deviceHealthResourceID)
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceHealthResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DeviceHealthResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, DeviceHealthResourceModel{}, data)...)
+}
+
+func (r *DeviceHealthResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != deviceHealthResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", deviceHealthResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), deviceHealthResourceID)...)
+}
+
+// apply sets every option configured in data and resets the ones that were
+// managed in prior but are no longer configured.
+func (r *DeviceHealthResource) apply(ctx context.Context, data DeviceHealthResourceModel, prior DeviceHealthResourceModel) diag.Diagnostics {
+	return applyMgrModuleOptions(ctx, r.client, deviceHealthModule, data.options(), prior.options())
+}
+
+func (r *DeviceHealthResource) read(ctx context.Context, data *DeviceHealthResourceModel) diag.Diagnostics {
+	return readMgrModuleOptions(ctx, r.client, deviceHealthModule, data.options())
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephDeviceHealthResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			checkCephConfigAbsent(t, "mgr", "mgr/devicehealth/enable_monitoring"),
+			checkCephConfigAbsent(t, "mgr", "mgr/devicehealth/scrape_frequency"),
+			checkCephConfigAbsent(t, "mgr", "mgr/devicehealth/warn_threshold"),
+		),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_device_health" "test" {
+					  enable_monitoring = false
+					  scrape_frequency  = 3600
+					  warn_threshold    = 1209600
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_device_health.test", tfjsonpath.New("id"), knownvalue.StringExact("device_health")),
+					statecheck.ExpectKnownValue("ceph_device_health.test", tfjsonpath.New("enable_monitoring"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue("ceph_device_health.test", tfjsonpath.New("scrape_frequency"), knownvalue.Int64Exact(3600)),
+					statecheck.ExpectKnownValue("ceph_device_health.test", tfjsonpath.New("warn_threshold"), knownvalue.Int64Exact(1209600)),
+					statecheck.ExpectKnownValue("ceph_device_health.test", tfjsonpath.New("self_heal"), knownvalue.Null()),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigValue(t, "mgr", "mgr/devicehealth/enable_monitoring", "false"),
+					checkCephConfigValue(t, "mgr", "mgr/devicehealth/scrape_frequency", "3600"),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_device_health" "test" {
+					  scrape_frequency = 7200
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_device_health.test", tfjsonpath.New("scrape_frequency"), knownvalue.Int64Exact(7200)),
+					statecheck.ExpectKnownValue("ceph_device_health.test", tfjsonpath.New("enable_monitoring"), knownvalue.Null()),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigValue(t, "mgr", "mgr/devicehealth/scrape_frequency", "7200"),
+					checkCephConfigAbsent(t, "mgr", "mgr/devicehealth/enable_monitoring"),
+					checkCephConfigAbsent(t, "mgr", "mgr/devicehealth/warn_threshold"),
+				),
+			},
+		},
+	})
+}
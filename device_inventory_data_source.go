@@ -0,0 +1,184 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &DeviceInventoryDataSource{}
+
+func newDeviceInventoryDataSource() datasource.DataSource {
+	return &DeviceInventoryDataSource{}
+}
+
+type DeviceInventoryDataSource struct {
+	client *CephAPIClient
+}
+
+type DeviceInventoryDataSourceModel struct {
+	Hostnames []types.String        `tfsdk:"hostnames"`
+	Devices   []DeviceInventoryItem `tfsdk:"devices"`
+}
+
+type DeviceInventoryItem struct {
+	Hostname        types.String   `tfsdk:"hostname"`
+	Path            types.String   `tfsdk:"path"`
+	DeviceID        types.String   `tfsdk:"device_id"`
+	Size            types.Int64    `tfsdk:"size"`
+	Rotational      types.Bool     `tfsdk:"rotational"`
+	Model           types.String   `tfsdk:"model"`
+	Available       types.Bool     `tfsdk:"available"`
+	RejectedReasons []types.String `tfsdk:"rejected_reasons"`
+}
+
+func (d *DeviceInventoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_inventory"
+}
+
+func (d *DeviceInventoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source lists the storage devices the orchestrator found on each host (equivalent to `ceph orch device ls`), e.g. to build OSD specs from the actual hardware. " +
+			"The orchestrator is only available through the ceph CLI, so this data source requires the provider `cli_backend` to be enabled. " +
+			"The inventory is as of the last orchestrator refresh.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"hostnames": dataSourceSchema.ListAttribute{
+				MarkdownDescription: "Optional filter to only return the devices of these hosts",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"devices": dataSourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The devices, ordered by host and path.",
+				Computed:            true,
+				NestedObject: dataSourceSchema.NestedAttributeObject{
+					Attributes: map[string]dataSourceSchema.Attribute{
+						"hostname": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The host the device is attached to.",
+							Computed:            true,
+						},
+						"path": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The device path, e.g. `/dev/sdb`.",
+							Computed:            true,
+						},
+						"device_id": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The stable device ID, usually vendor, model and serial number.",
+							Computed:            true,
+						},
+						"size": dataSourceSchema.Int64Attribute{
+							MarkdownDescription: "The size in bytes.",
+							Computed:            true,
+						},
+						"rotational": dataSourceSchema.BoolAttribute{
+							MarkdownDescription: "Whether the device is a spinning disk.",
+							Computed:            true,
+						},
+						"model": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The device model.",
+							Computed:            true,
+						},
+						"available": dataSourceSchema.BoolAttribute{
+							MarkdownDescription: "Whether an OSD can be created on the device.",
+							Computed:            true,
+						},
+						"rejected_reasons": dataSourceSchema.ListAttribute{
+							MarkdownDescription: "Why the device is not available, e.g. `Has a FileSystem`.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DeviceInventoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DeviceInventoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeviceInventoryDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := d.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to list devices: %s", err),
+		)
+		return
+	}
+
+	var hostnames []string
+	for _, hostname := range data.Hostnames {
+		hostnames = append(hostnames, hostname.ValueString())
+	}
+
+	hosts, err := cli.OrchDeviceLs(ctx, hostnames...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to list devices: %s", err),
+		)
+		return
+	}
+
+	data.Devices = []DeviceInventoryItem{}
+	for _, host := range hosts {
+		for _, device := range host.Devices {
+			item := DeviceInventoryItem{
+				Hostname:        types.StringValue(host.Name),
+				Path:            types.StringValue(device.Path),
+				DeviceID:        types.StringValue(device.DeviceID),
+				Size:            types.Int64Value(int64(device.SysAPI.Size)),
+				Rotational:      types.BoolValue(device.SysAPI.Rotational == "1"),
+				Model:           types.StringValue(device.SysAPI.Model),
+				Available:       types.BoolValue(device.Available),
+				RejectedReasons: []types.String{},
+			}
+			for _, reason := range device.RejectedReasons {
+				item.RejectedReasons = append(item.RejectedReasons, types.StringValue(reason))
+			}
+			data.Devices = append(data.Devices, item)
+		}
+	}
+
+	slices.SortFunc(data.Devices, func(a, b DeviceInventoryItem) int {
+		return cmp.Or(
+			cmp.Compare(a.Hostname.ValueString(), b.Hostname.ValueString()),
+			cmp.Compare(a.Path.ValueString(), b.Path.ValueString()),
+		)
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
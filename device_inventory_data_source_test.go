@@ -0,0 +1,42 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The test cluster has no orchestrator, so this covers the CLI requirement
+// and the error path.
+func TestAccCephDeviceInventoryDataSource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	dataSourceConfig := `
+		data "ceph_device_inventory" "test" {}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + dataSourceConfig,
+				ExpectError:     regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint":  config.StringVariable(testDashboardURL),
+					"ceph_conf": config.StringVariable(testConfPath),
+				},
+				Config:      testAccCLIProviderConfigBlock + dataSourceConfig,
+				ExpectError: regexp.MustCompile(`Unable to list devices`),
+			},
+		},
+	})
+}
@@ -28,6 +28,10 @@ type ErasureCodeProfileDataSourceModel struct {
 	Technique          types.String `tfsdk:"technique"`
 	CrushRoot          types.String `tfsdk:"crush_root"`
 	CrushDeviceClass   types.String `tfsdk:"crush_device_class"`
+	ScalarMDS          types.String `tfsdk:"scalar_mds"`
+	D                  types.Int64  `tfsdk:"d"`
+	L                  types.Int64  `tfsdk:"l"`
+	Params             types.Map    `tfsdk:"params"`
 	Directory          types.String `tfsdk:"directory"`
 }
 
@@ -71,6 +75,23 @@ func (d *ErasureCodeProfileDataSource) Schema(ctx context.Context, req datasourc
 				MarkdownDescription: "The device class for placement",
 				Computed:            true,
 			},
+			"scalar_mds": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The scalar MDS code of a clay profile",
+				Computed:            true,
+			},
+			"d": dataSourceSchema.Int64Attribute{
+				MarkdownDescription: "Number of OSDs a clay profile reads from to recover a chunk",
+				Computed:            true,
+			},
+			"l": dataSourceSchema.Int64Attribute{
+				MarkdownDescription: "Locality of an lrc profile",
+				Computed:            true,
+			},
+			"params": dataSourceSchema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "All parameters of the profile as Ceph stores them, including the defaults the plugin filled in",
+				Computed:            true,
+			},
 			"directory": dataSourceSchema.StringAttribute{
 				MarkdownDescription: "The directory where the plugin is loaded from",
 				Computed:            true,
@@ -98,6 +119,8 @@ func (d *ErasureCodeProfileDataSource) Configure(ctx context.Context, req dataso
 }
 
 func (d *ErasureCodeProfileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withReadCache(ctx)
+
 	var data ErasureCodeProfileDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -136,7 +159,18 @@ func (d *ErasureCodeProfileDataSource) Read(ctx context.Context, req datasource.
 	} else {
 		data.CrushDeviceClass = types.StringNull()
 	}
+	if profile.ScalarMDS != "" {
+		data.ScalarMDS = types.StringValue(profile.ScalarMDS)
+	} else {
+		data.ScalarMDS = types.StringNull()
+	}
+	data.D = erasureCodeProfileInt64(profile.D)
+	data.L = erasureCodeProfileInt64(profile.L)
 	data.Directory = types.StringValue(profile.Directory)
 
+	params, diags := types.MapValueFrom(ctx, types.StringType, profile.Params)
+	resp.Diagnostics.Append(diags...)
+	data.Params = params
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
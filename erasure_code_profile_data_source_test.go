@@ -69,6 +69,11 @@ func TestAccCephErasureCodeProfileDataSource_k2m1(t *testing.T) {
 						tfjsonpath.New("plugin"),
 						knownvalue.NotNull(),
 					),
+					statecheck.ExpectKnownValue(
+						"data.ceph_erasure_code_profile.test",
+						tfjsonpath.New("params").AtMapKey("crush-failure-domain"),
+						knownvalue.StringExact("osd"),
+					),
 				},
 			},
 		},
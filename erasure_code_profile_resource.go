@@ -3,16 +3,24 @@ package main
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -20,6 +28,7 @@ var (
 	_ resource.Resource                     = &ErasureCodeProfileResource{}
 	_ resource.ResourceWithImportState      = &ErasureCodeProfileResource{}
 	_ resource.ResourceWithConfigValidators = &ErasureCodeProfileResource{}
+	_ resource.ResourceWithModifyPlan       = &ErasureCodeProfileResource{}
 )
 
 func newErasureCodeProfileResource() resource.Resource {
@@ -39,7 +48,17 @@ type ErasureCodeProfileResourceModel struct {
 	Technique          types.String `tfsdk:"technique"`
 	CrushRoot          types.String `tfsdk:"crush_root"`
 	CrushDeviceClass   types.String `tfsdk:"crush_device_class"`
+	ScalarMDS          types.String `tfsdk:"scalar_mds"`
+	D                  types.Int64  `tfsdk:"d"`
+	L                  types.Int64  `tfsdk:"l"`
+	ExtraParams        types.Map    `tfsdk:"extra_params"`
+	Force              types.Bool   `tfsdk:"force"`
 	Directory          types.String `tfsdk:"directory"`
+	Params             types.Map    `tfsdk:"params"`
+}
+
+type ErasureCodeProfileResourceIdentityModel struct {
+	Name types.String `tfsdk:"name"`
 }
 
 type erasureCodeKMValidator struct{}
@@ -98,7 +117,7 @@ func (r *ErasureCodeProfileResource) ConfigValidators(ctx context.Context) []res
 
 func (r *ErasureCodeProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceSchema.Schema{
-		MarkdownDescription: "This resource manages a Ceph erasure code profile. Erasure code profiles are immutable in Ceph, so any changes to the profile's attributes will trigger resource replacement.",
+		MarkdownDescription: "This resource manages a Ceph erasure code profile. Erasure code profiles are immutable in Ceph, so any changes to the profile's attributes will trigger resource replacement unless `force` is set.",
 		Attributes: map[string]resourceSchema.Attribute{
 			"name": resourceSchema.StringAttribute{
 				MarkdownDescription: "The name of the erasure code profile. This is the unique identifier for the profile.",
@@ -115,7 +134,7 @@ func (r *ErasureCodeProfileResource) Schema(ctx context.Context, req resource.Sc
 					int64validator.AtLeast(2),
 				},
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+					int64planmodifier.RequiresReplaceIf(erasureCodeProfileRequiresReplaceInt64, erasureCodeProfileRequiresReplaceDescription, erasureCodeProfileRequiresReplaceDescription),
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
@@ -127,7 +146,7 @@ func (r *ErasureCodeProfileResource) Schema(ctx context.Context, req resource.Sc
 					int64validator.AtLeast(1),
 				},
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+					int64planmodifier.RequiresReplaceIf(erasureCodeProfileRequiresReplaceInt64, erasureCodeProfileRequiresReplaceDescription, erasureCodeProfileRequiresReplaceDescription),
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
@@ -136,7 +155,7 @@ func (r *ErasureCodeProfileResource) Schema(ctx context.Context, req resource.Sc
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(erasureCodeProfileRequiresReplaceString, erasureCodeProfileRequiresReplaceDescription, erasureCodeProfileRequiresReplaceDescription),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
@@ -145,7 +164,7 @@ func (r *ErasureCodeProfileResource) Schema(ctx context.Context, req resource.Sc
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(erasureCodeProfileRequiresReplaceString, erasureCodeProfileRequiresReplaceDescription, erasureCodeProfileRequiresReplaceDescription),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
@@ -154,7 +173,7 @@ func (r *ErasureCodeProfileResource) Schema(ctx context.Context, req resource.Sc
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(erasureCodeProfileRequiresReplaceString, erasureCodeProfileRequiresReplaceDescription, erasureCodeProfileRequiresReplaceDescription),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
@@ -163,7 +182,7 @@ func (r *ErasureCodeProfileResource) Schema(ctx context.Context, req resource.Sc
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(erasureCodeProfileRequiresReplaceString, erasureCodeProfileRequiresReplaceDescription, erasureCodeProfileRequiresReplaceDescription),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
@@ -172,10 +191,60 @@ func (r *ErasureCodeProfileResource) Schema(ctx context.Context, req resource.Sc
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(erasureCodeProfileRequiresReplaceString, erasureCodeProfileRequiresReplaceDescription, erasureCodeProfileRequiresReplaceDescription),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scalar_mds": resourceSchema.StringAttribute{
+				MarkdownDescription: "The plugin the clay plugin uses as its scalar MDS code (e.g., 'jerasure', 'isa', 'shec').",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(erasureCodeProfileRequiresReplaceString, erasureCodeProfileRequiresReplaceDescription, erasureCodeProfileRequiresReplaceDescription),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"d": resourceSchema.Int64Attribute{
+				MarkdownDescription: "Number of OSDs the clay plugin reads from to recover a chunk. Must be between k+1 and k+m-1. Defaults to k+m-1.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(3),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(erasureCodeProfileRequiresReplaceInt64, erasureCodeProfileRequiresReplaceDescription, erasureCodeProfileRequiresReplaceDescription),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"l": resourceSchema.Int64Attribute{
+				MarkdownDescription: "Locality of the lrc plugin: the chunks are grouped in sets of l, each with a local parity chunk. k+m must be a multiple of l.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(erasureCodeProfileRequiresReplaceInt64, erasureCodeProfileRequiresReplaceDescription, erasureCodeProfileRequiresReplaceDescription),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"extra_params": resourceSchema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Other parameters passed to the plugin, e.g. `crush-locality` for lrc or `w` for jerasure. Only the configured keys are tracked; the defaults the plugin fills in are ignored.",
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.NoneOf(erasureCodeProfileFields...)),
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIf(erasureCodeProfileRequiresReplaceMap, erasureCodeProfileRequiresReplaceDescription, erasureCodeProfileRequiresReplaceDescription),
+				},
+			},
+			"force": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether to change the profile's parameters by removing and recreating the profile in place instead of replacing the resource. Ceph refuses to remove a profile that a pool uses, and pools never pick up the new parameters. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"directory": resourceSchema.StringAttribute{
 				MarkdownDescription: "The directory where the erasure code plugin is loaded from (computed by Ceph).",
 				Computed:            true,
@@ -183,6 +252,47 @@ func (r *ErasureCodeProfileResource) Schema(ctx context.Context, req resource.Sc
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"params": resourceSchema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "All parameters of the profile as Ceph stores them, including the defaults the plugin filled in, e.g. `w` for jerasure or the generated `layers` of an lrc profile.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+const erasureCodeProfileRequiresReplaceDescription = "Erasure code profiles are immutable, so changing a parameter replaces the profile unless force is set."
+
+// erasureCodeProfileForced reports whether the plan recreates the profile in
+// place instead of replacing the resource.
+func erasureCodeProfileForced(ctx context.Context, plan tfsdk.Plan) bool {
+	var force types.Bool
+	plan.GetAttribute(ctx, path.Root("force"), &force)
+	return force.ValueBool()
+}
+
+func erasureCodeProfileRequiresReplaceString(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !erasureCodeProfileForced(ctx, req.Plan)
+}
+
+func erasureCodeProfileRequiresReplaceInt64(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !erasureCodeProfileForced(ctx, req.Plan)
+}
+
+func erasureCodeProfileRequiresReplaceMap(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !erasureCodeProfileForced(ctx, req.Plan)
+}
+
+func (r *ErasureCodeProfileResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				Description:       "The name of the erasure code profile",
+				RequiredForImport: true,
+			},
 		},
 	}
 }
@@ -205,52 +315,79 @@ func (r *ErasureCodeProfileResource) Configure(ctx context.Context, req resource
 	r.client = client
 }
 
-func (r *ErasureCodeProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data ErasureCodeProfileResourceModel
-
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-
-	if resp.Diagnostics.HasError() {
+func (r *ErasureCodeProfileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
 		return
 	}
 
-	createReq := CephAPIErasureCodeProfileCreateRequest{
-		Name: data.Name.ValueString(),
+	var config, plan, state ErasureCodeProfileResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !plan.Force.ValueBool() {
+		return
 	}
 
-	if !data.K.IsNull() && !data.K.IsUnknown() {
-		val := fmt.Sprintf("%d", data.K.ValueInt64())
-		createReq.K = &val
+	planReq, diags := r.createRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	stateReq, diags := r.createRequest(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || reflect.DeepEqual(planReq, stateReq) {
+		return
 	}
 
-	if !data.M.IsNull() && !data.M.IsUnknown() {
-		val := fmt.Sprintf("%d", data.M.ValueInt64())
-		createReq.M = &val
+	// Recreating the profile in place can change the values Ceph fills in,
+	// e.g. the technique of a different plugin, so the ones that are not
+	// configured are only known after the apply.
+	if config.K.IsNull() {
+		plan.K = types.Int64Unknown()
 	}
-
-	if !data.Plugin.IsNull() && !data.Plugin.IsUnknown() {
-		val := data.Plugin.ValueString()
-		createReq.Plugin = &val
+	if config.M.IsNull() {
+		plan.M = types.Int64Unknown()
 	}
-
-	if !data.CrushFailureDomain.IsNull() && !data.CrushFailureDomain.IsUnknown() {
-		val := data.CrushFailureDomain.ValueString()
-		createReq.CrushFailureDomain = &val
+	if config.Plugin.IsNull() {
+		plan.Plugin = types.StringUnknown()
 	}
-
-	if !data.Technique.IsNull() && !data.Technique.IsUnknown() {
-		val := data.Technique.ValueString()
-		createReq.Technique = &val
+	if config.CrushFailureDomain.IsNull() {
+		plan.CrushFailureDomain = types.StringUnknown()
+	}
+	if config.Technique.IsNull() {
+		plan.Technique = types.StringUnknown()
+	}
+	if config.CrushRoot.IsNull() {
+		plan.CrushRoot = types.StringUnknown()
 	}
+	if config.CrushDeviceClass.IsNull() {
+		plan.CrushDeviceClass = types.StringUnknown()
+	}
+	if config.ScalarMDS.IsNull() {
+		plan.ScalarMDS = types.StringUnknown()
+	}
+	if config.D.IsNull() {
+		plan.D = types.Int64Unknown()
+	}
+	if config.L.IsNull() {
+		plan.L = types.Int64Unknown()
+	}
+	plan.Directory = types.StringUnknown()
+	plan.Params = types.MapUnknown(types.StringType)
 
-	if !data.CrushRoot.IsNull() && !data.CrushRoot.IsUnknown() {
-		val := data.CrushRoot.ValueString()
-		createReq.CrushRoot = &val
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *ErasureCodeProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ErasureCodeProfileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if !data.CrushDeviceClass.IsNull() && !data.CrushDeviceClass.IsUnknown() {
-		val := data.CrushDeviceClass.ValueString()
-		createReq.CrushDeviceClass = &val
+	createReq, diags := r.createRequest(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	err := r.client.CreateErasureCodeProfile(ctx, createReq)
@@ -271,9 +408,10 @@ func (r *ErasureCodeProfileResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
-	r.updateModelFromAPI(&data, profile)
+	resp.Diagnostics.Append(r.updateModelFromAPI(ctx, &data, profile)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ErasureCodeProfileResourceIdentityModel{Name: data.Name})...)
 }
 
 func (r *ErasureCodeProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -286,6 +424,10 @@ func (r *ErasureCodeProfileResource) Read(ctx context.Context, req resource.Read
 	}
 
 	profile, err := r.client.GetErasureCodeProfile(ctx, data.Name.ValueString())
+	if isCephAPINotFound(err) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Erasure code profile '%s'", data.Name.ValueString()))
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"API Request Error",
@@ -294,16 +436,68 @@ func (r *ErasureCodeProfileResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
-	r.updateModelFromAPI(&data, profile)
+	resp.Diagnostics.Append(r.updateModelFromAPI(ctx, &data, profile)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ErasureCodeProfileResourceIdentityModel{Name: data.Name})...)
 }
 
 func (r *ErasureCodeProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Erasure code profiles are immutable in Ceph and cannot be updated. Any changes require replacing the resource.",
-	)
+	var data, state ErasureCodeProfileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createReq, diags := r.createRequest(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	stateReq, diags := r.createRequest(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without force every parameter change replaces the resource, so the
+	// profile is only recreated if force is set and a parameter changed.
+	if reflect.DeepEqual(createReq, stateReq) {
+		state.Force = data.Force
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	err := r.client.DeleteErasureCodeProfile(ctx, data.Name.ValueString())
+	if err != nil && !isCephAPINotFound(err) {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to remove erasure code profile '%s' to recreate it: %s. Note that erasure code profiles cannot be deleted if they are in use by any pools.", data.Name.ValueString(), err),
+		)
+		return
+	}
+
+	err = r.client.CreateErasureCodeProfile(ctx, createReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to recreate erasure code profile '%s': %s", data.Name.ValueString(), err),
+		)
+		return
+	}
+
+	profile, err := r.client.GetErasureCodeProfile(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read erasure code profile '%s' after recreating it: %s", data.Name.ValueString(), err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateModelFromAPI(ctx, &data, profile)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ErasureCodeProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -316,7 +510,7 @@ func (r *ErasureCodeProfileResource) Delete(ctx context.Context, req resource.De
 	}
 
 	err := r.client.DeleteErasureCodeProfile(ctx, data.Name.ValueString())
-	if err != nil {
+	if err != nil && !isCephAPINotFound(err) {
 		resp.Diagnostics.AddError(
 			"API Request Error",
 			fmt.Sprintf("Unable to delete erasure code profile '%s': %s. Note that erasure code profiles cannot be deleted if they are in use by any pools.", data.Name.ValueString(), err),
@@ -326,10 +520,73 @@ func (r *ErasureCodeProfileResource) Delete(ctx context.Context, req resource.De
 }
 
 func (r *ErasureCodeProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("name"), path.Root("name"), req, resp)
+}
+
+func (r *ErasureCodeProfileResource) createRequest(ctx context.Context, data ErasureCodeProfileResourceModel) (CephAPIErasureCodeProfileCreateRequest, diag.Diagnostics) {
+	createReq := CephAPIErasureCodeProfileCreateRequest{
+		Name: data.Name.ValueString(),
+	}
+
+	if !data.K.IsNull() && !data.K.IsUnknown() {
+		val := fmt.Sprintf("%d", data.K.ValueInt64())
+		createReq.K = &val
+	}
+
+	if !data.M.IsNull() && !data.M.IsUnknown() {
+		val := fmt.Sprintf("%d", data.M.ValueInt64())
+		createReq.M = &val
+	}
+
+	if !data.Plugin.IsNull() && !data.Plugin.IsUnknown() {
+		val := data.Plugin.ValueString()
+		createReq.Plugin = &val
+	}
+
+	if !data.CrushFailureDomain.IsNull() && !data.CrushFailureDomain.IsUnknown() {
+		val := data.CrushFailureDomain.ValueString()
+		createReq.CrushFailureDomain = &val
+	}
+
+	if !data.Technique.IsNull() && !data.Technique.IsUnknown() {
+		val := data.Technique.ValueString()
+		createReq.Technique = &val
+	}
+
+	if !data.CrushRoot.IsNull() && !data.CrushRoot.IsUnknown() {
+		val := data.CrushRoot.ValueString()
+		createReq.CrushRoot = &val
+	}
+
+	if !data.CrushDeviceClass.IsNull() && !data.CrushDeviceClass.IsUnknown() {
+		val := data.CrushDeviceClass.ValueString()
+		createReq.CrushDeviceClass = &val
+	}
+
+	if !data.ScalarMDS.IsNull() && !data.ScalarMDS.IsUnknown() {
+		val := data.ScalarMDS.ValueString()
+		createReq.ScalarMDS = &val
+	}
+
+	if !data.D.IsNull() && !data.D.IsUnknown() {
+		val := fmt.Sprintf("%d", data.D.ValueInt64())
+		createReq.D = &val
+	}
+
+	if !data.L.IsNull() && !data.L.IsUnknown() {
+		val := fmt.Sprintf("%d", data.L.ValueInt64())
+		createReq.L = &val
+	}
+
+	var diags diag.Diagnostics
+	if !data.ExtraParams.IsNull() && !data.ExtraParams.IsUnknown() {
+		diags.Append(data.ExtraParams.ElementsAs(ctx, &createReq.Params, false)...)
+	}
+
+	return createReq, diags
 }
 
-func (r *ErasureCodeProfileResource) updateModelFromAPI(data *ErasureCodeProfileResourceModel, profile *CephAPIErasureCodeProfile) {
+func (r *ErasureCodeProfileResource) updateModelFromAPI(ctx context.Context, data *ErasureCodeProfileResourceModel, profile *CephAPIErasureCodeProfile) diag.Diagnostics {
 	data.K = types.Int64Value(int64(profile.K))
 	data.M = types.Int64Value(int64(profile.M))
 	data.Plugin = types.StringValue(profile.Plugin)
@@ -349,5 +606,48 @@ func (r *ErasureCodeProfileResource) updateModelFromAPI(data *ErasureCodeProfile
 	} else {
 		data.CrushDeviceClass = types.StringNull()
 	}
+	if profile.ScalarMDS != "" {
+		data.ScalarMDS = types.StringValue(profile.ScalarMDS)
+	} else {
+		data.ScalarMDS = types.StringNull()
+	}
+	data.D = erasureCodeProfileInt64(profile.D)
+	data.L = erasureCodeProfileInt64(profile.L)
 	data.Directory = types.StringValue(profile.Directory)
+	if data.Force.IsNull() || data.Force.IsUnknown() {
+		data.Force = types.BoolValue(false)
+	}
+
+	params, diags := types.MapValueFrom(ctx, types.StringType, profile.Params)
+	data.Params = params
+	if diags.HasError() {
+		return diags
+	}
+
+	// Only the configured extra parameters are tracked, since plugins fill
+	// in defaults for the ones left out.
+	if data.ExtraParams.IsNull() || data.ExtraParams.IsUnknown() {
+		data.ExtraParams = types.MapNull(types.StringType)
+		return diags
+	}
+	extra := make(map[string]string)
+	for key := range data.ExtraParams.Elements() {
+		if value, ok := profile.Params[key]; ok {
+			extra[key] = value
+		}
+	}
+	extraParams, extraDiags := types.MapValueFrom(ctx, types.StringType, extra)
+	data.ExtraParams = extraParams
+	diags.Append(extraDiags...)
+	return diags
+}
+
+// erasureCodeProfileInt64 converts a numeric profile parameter, which Ceph
+// stores as a string, or returns null if it is not set.
+func erasureCodeProfileInt64(value string) types.Int64 {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(n)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
@@ -78,6 +79,28 @@ func TestAccCephErasureCodeProfileResource_k2m1(t *testing.T) {
 				ImportStateId:                        profileName,
 				ImportStateVerifyIdentifierAttribute: "name",
 			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.ErasureCodeProfileRemove(t.Context(), profileName); err != nil {
+						t.Fatalf("Failed to delete erasure code profile out of band: %v", err)
+					}
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_erasure_code_profile" "test" {
+					  name                 = %q
+					  k                    = 2
+					  m                    = 1
+					  crush_failure_domain = "osd"
+					}
+				`, profileName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_erasure_code_profile.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkCephErasureCodeProfileExists(t, profileName),
+			},
 		},
 	})
 }
@@ -236,12 +259,209 @@ func TestAccCephErasureCodeProfileResource_defaults(t *testing.T) {
 					resource.TestCheckResourceAttrSet("ceph_erasure_code_profile.test", "m"),
 					resource.TestCheckResourceAttrSet("ceph_erasure_code_profile.test", "plugin"),
 					resource.TestCheckResourceAttrSet("ceph_erasure_code_profile.test", "crush_failure_domain"),
+					resource.TestCheckResourceAttrPair("ceph_erasure_code_profile.test", "params.k", "ceph_erasure_code_profile.test", "k"),
+					resource.TestCheckResourceAttrPair("ceph_erasure_code_profile.test", "params.plugin", "ceph_erasure_code_profile.test", "plugin"),
+					resource.TestCheckResourceAttrSet("ceph_erasure_code_profile.test", "params.technique"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCephErasureCodeProfileResource_lrc(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	profileName := fmt.Sprintf("test-profile-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephErasureCodeProfileDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_erasure_code_profile" "test" {
+					  name                 = %q
+					  plugin               = "lrc"
+					  k                    = 4
+					  m                    = 2
+					  l                    = 3
+					  crush_failure_domain = "osd"
+					}
+				`, profileName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_erasure_code_profile.test",
+						tfjsonpath.New("plugin"),
+						knownvalue.StringExact("lrc"),
+					),
+					statecheck.ExpectKnownValue(
+						"ceph_erasure_code_profile.test",
+						tfjsonpath.New("l"),
+						knownvalue.Int64Exact(3),
+					),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephErasureCodeProfileParam(t, profileName, "l", "3"),
+					resource.TestCheckResourceAttr("ceph_erasure_code_profile.test", "params.l", "3"),
+					resource.TestCheckResourceAttrSet("ceph_erasure_code_profile.test", "params.layers"),
 				),
 			},
+			{
+				ConfigVariables:                      testAccProviderConfig(),
+				ResourceName:                         "ceph_erasure_code_profile.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        profileName,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
 		},
 	})
 }
 
+func TestAccCephErasureCodeProfileResource_clay(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	profileName := fmt.Sprintf("test-profile-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephErasureCodeProfileDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_erasure_code_profile" "test" {
+					  name                 = %q
+					  plugin               = "clay"
+					  k                    = 2
+					  m                    = 2
+					  d                    = 3
+					  scalar_mds           = "jerasure"
+					  crush_failure_domain = "osd"
+					}
+				`, profileName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_erasure_code_profile.test",
+						tfjsonpath.New("d"),
+						knownvalue.Int64Exact(3),
+					),
+					statecheck.ExpectKnownValue(
+						"ceph_erasure_code_profile.test",
+						tfjsonpath.New("scalar_mds"),
+						knownvalue.StringExact("jerasure"),
+					),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephErasureCodeProfileParam(t, profileName, "d", "3"),
+					checkCephErasureCodeProfileParam(t, profileName, "scalar_mds", "jerasure"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCephErasureCodeProfileResource_forceExtraParams(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	profileName := fmt.Sprintf("test-profile-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	config := func(w string) string {
+		return testAccProviderConfigBlock + fmt.Sprintf(`
+			resource "ceph_erasure_code_profile" "test" {
+			  name                 = %q
+			  k                    = 2
+			  m                    = 1
+			  plugin               = "jerasure"
+			  technique            = "reed_sol_van"
+			  crush_failure_domain = "osd"
+			  force                = true
+			  extra_params = {
+			    w = %q
+			  }
+			}
+		`, profileName, w)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephErasureCodeProfileDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          config("16"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_erasure_code_profile.test",
+						tfjsonpath.New("extra_params"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"w": knownvalue.StringExact("16"),
+						}),
+					),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephErasureCodeProfileParam(t, profileName, "w", "16"),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          config("32"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_erasure_code_profile.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephErasureCodeProfileParam(t, profileName, "w", "32"),
+					resource.TestCheckResourceAttr("ceph_erasure_code_profile.test", "technique", "reed_sol_van"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCephErasureCodeProfileResource_extraParamsWithOwnAttribute(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_erasure_code_profile" "test" {
+					  name = "test-extra-params"
+					  extra_params = {
+					    technique = "cauchy_good"
+					  }
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)technique`),
+			},
+		},
+	})
+}
+
+func checkCephErasureCodeProfileParam(t *testing.T, profileName, key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		profile, err := cephTestClusterCLI.ErasureCodeProfileGet(t.Context(), profileName)
+		if err != nil {
+			return fmt.Errorf("failed to get erasure code profile '%s': %w", profileName, err)
+		}
+
+		if profile[key] != expected {
+			return fmt.Errorf("erasure code profile '%s' has %s=%q, want %q", profileName, key, profile[key], expected)
+		}
+
+		return nil
+	}
+}
+
 func checkCephErasureCodeProfileExists(t *testing.T, profileName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		profile, err := cephTestClusterCLI.ErasureCodeProfileGet(t.Context(), profileName)
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &FsMirrorPeerResource{}
+	_ resource.ResourceWithImportState = &FsMirrorPeerResource{}
+)
+
+func newFsMirrorPeerResource() resource.Resource {
+	return &FsMirrorPeerResource{}
+}
+
+// FsMirrorPeerResource adds a CephFS snapshot mirroring peer, either from a
+// bootstrap token or from an explicit remote cluster spec. Neither command
+// returns the new peer UUID, so it is found by comparing the peers before and
+// after. Peers cannot be changed in place.
+type FsMirrorPeerResource struct {
+	client *CephAPIClient
+}
+
+type FsMirrorPeerResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	FsName            types.String `tfsdk:"fs_name"`
+	UUID              types.String `tfsdk:"uuid"`
+	Token             types.String `tfsdk:"token"`
+	RemoteClusterSpec types.String `tfsdk:"remote_cluster_spec"`
+	RemoteFsName      types.String `tfsdk:"remote_fs_name"`
+	RemoteMonHost     types.String `tfsdk:"remote_mon_host"`
+	CephxKey          types.String `tfsdk:"cephx_key"`
+}
+
+func (r *FsMirrorPeerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fs_mirror_peer"
+}
+
+func (r *FsMirrorPeerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Adds a CephFS snapshot mirroring peer to a filesystem with mirroring enabled (see `ceph_fs_mirror`). " +
+			"Set either `token`, created on the remote cluster with `ceph fs snapshot mirror peer_bootstrap create`, or `remote_cluster_spec`. " +
+			"Snapshot mirroring is only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The filesystem and peer UUID in the form `<fs_name>/<uuid>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fs_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the local filesystem",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"uuid": resourceSchema.StringAttribute{
+				MarkdownDescription: "The UUID Ceph assigned to the peer",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token": resourceSchema.StringAttribute{
+				MarkdownDescription: "A bootstrap token from the remote cluster. It is not read back, so changing it outside Terraform is not detected.",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("remote_cluster_spec")),
+				},
+			},
+			"remote_cluster_spec": resourceSchema.StringAttribute{
+				MarkdownDescription: "The remote client and cluster in the form `client.<id>@<cluster>`. Read back from the peer when `token` is used.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^client\.[^@]+@[^@]+$`), "must be in the form client.<id>@<cluster>"),
+				},
+			},
+			"remote_fs_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the remote filesystem. Defaults to `fs_name`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token")),
+				},
+			},
+			"remote_mon_host": resourceSchema.StringAttribute{
+				MarkdownDescription: "The monitor addresses of the remote cluster. Without it the remote cluster config must already be present on the mgr host.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("remote_cluster_spec"), path.MatchRoot("cephx_key")),
+				},
+			},
+			"cephx_key": resourceSchema.StringAttribute{
+				MarkdownDescription: "The cephx key of the remote client. It is not read back.",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("remote_mon_host")),
+				},
+			},
+		},
+	}
+}
+
+func (r *FsMirrorPeerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FsMirrorPeerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FsMirrorPeerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to add snapshot mirror peer: %s", err),
+		)
+		return
+	}
+
+	fsName := data.FsName.ValueString()
+	before, err := r.peers(ctx, cli, fsName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to add snapshot mirror peer: %s", err),
+		)
+		return
+	}
+
+	if !data.Token.IsNull() {
+		err = cli.FsSnapshotMirrorPeerBootstrapImport(ctx, fsName, data.Token.ValueString())
+	} else {
+		remoteFsName := fsName
+		if !data.RemoteFsName.IsUnknown() && !data.RemoteFsName.IsNull() {
+			remoteFsName = data.RemoteFsName.ValueString()
+		}
+		err = cli.FsSnapshotMirrorPeerAdd(ctx, fsName, data.RemoteClusterSpec.ValueString(), remoteFsName, data.RemoteMonHost.ValueString(), data.CephxKey.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to add snapshot mirror peer: %s", err),
+		)
+		return
+	}
+
+	after, err := r.peers(ctx, cli, fsName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read snapshot mirror peers: %s", err),
+		)
+		return
+	}
+
+	var peer *FsMirrorPeer
+	for i := range after {
+		if !fsMirrorPeersContain(before, after[i].UUID) {
+			peer = &after[i]
+			break
+		}
+	}
+	if peer == nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Snapshot mirror peer was added to %s but could not be found in the fs map", fsName),
+		)
+		return
+	}
+
+	setFsMirrorPeer(&data, *peer)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FsMirrorPeerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FsMirrorPeerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read snapshot mirror peer: %s", err),
+		)
+		return
+	}
+
+	peers, err := r.peers(ctx, cli, data.FsName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read snapshot mirror peer: %s", err),
+		)
+		return
+	}
+
+	for _, peer := range peers {
+		if peer.UUID == data.UUID.ValueString() {
+			setFsMirrorPeer(&data, peer)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	removeMissingResource(ctx, resp, fmt.Sprintf("Snapshot mirror peer %s of filesystem %s", data.UUID.ValueString(), data.FsName.ValueString()))
+}
+
+// Every configurable attribute requires replacement, so Update is never called.
+func (r *FsMirrorPeerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+func (r *FsMirrorPeerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FsMirrorPeerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to remove snapshot mirror peer: %s", err),
+		)
+		return
+	}
+
+	if err := cli.FsSnapshotMirrorPeerRemove(ctx, data.FsName.ValueString(), data.UUID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to remove snapshot mirror peer: %s", err),
+		)
+		return
+	}
+}
+
+func (r *FsMirrorPeerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	fsName, uuid, ok := strings.Cut(req.ID, "/")
+	if !ok || fsName == "" || uuid == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the form '<fs_name>/<uuid>', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fs_name"), fsName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), uuid)...)
+}
+
+// peers returns the peers of a filesystem, or an error if the filesystem does
+// not exist or does not have mirroring enabled.
+func (r *FsMirrorPeerResource) peers(ctx context.Context, cli *CephCLI, fsName string) ([]FsMirrorPeer, error) {
+	fsDump, err := cli.FsDump(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fs := fsDump.Filesystem(fsName)
+	if fs == nil {
+		return nil, fmt.Errorf("filesystem %s not found", fsName)
+	}
+	if fs.MirrorInfo == nil {
+		return nil, fmt.Errorf("snapshot mirroring is not enabled on filesystem %s", fsName)
+	}
+	return fs.MirrorInfo.Peers, nil
+}
+
+func fsMirrorPeersContain(peers []FsMirrorPeer, uuid string) bool {
+	for _, peer := range peers {
+		if peer.UUID == uuid {
+			return true
+		}
+	}
+	return false
+}
+
+func setFsMirrorPeer(data *FsMirrorPeerResourceModel, peer FsMirrorPeer) {
+	data.ID = types.StringValue(data.FsName.ValueString() + "/" + peer.UUID)
+	data.UUID = types.StringValue(peer.UUID)
+	data.RemoteClusterSpec = types.StringValue(peer.Remote.ClientName + "@" + peer.Remote.ClusterName)
+	data.RemoteFsName = types.StringValue(peer.Remote.FsName)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The test cluster has no second cluster to peer with, so only validation and
+// the failure paths are covered, and there is no peer to remove out of band.
+func TestAccCephFsMirrorPeerResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	fsName := acctest.RandString(8)
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+			testAccCreateCephFs(t, fsName)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_mirror_peer" "test" {
+					  fs_name             = %q
+					  token               = "dG9rZW4="
+					  remote_cluster_spec = "client.mirror@remote"
+					}
+				`, fsName),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_mirror_peer" "test" {
+					  fs_name             = %q
+					  remote_cluster_spec = "mirror@remote"
+					}
+				`, fsName),
+				ExpectError: regexp.MustCompile(`must be in the form client.<id>@<cluster>`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_mirror_peer" "test" {
+					  fs_name             = %q
+					  remote_cluster_spec = "client.mirror@remote"
+					  cephx_key           = "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=="
+					}
+				`, fsName),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_mirror_peer" "test" {
+					  fs_name = %q
+					  token   = "dG9rZW4="
+					}
+				`, fsName),
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_mirror_peer" "test" {
+					  fs_name = %q
+					  token   = "dG9rZW4="
+					}
+				`, fsName),
+				ExpectError: regexp.MustCompile(`snapshot mirroring is not enabled`),
+			},
+		},
+	})
+}
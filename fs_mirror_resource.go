@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const fsMirroringModule = "mirroring"
+
+var (
+	_ resource.Resource                = &FsMirrorResource{}
+	_ resource.ResourceWithImportState = &FsMirrorResource{}
+)
+
+func newFsMirrorResource() resource.Resource {
+	return &FsMirrorResource{}
+}
+
+// FsMirrorResource enables CephFS snapshot mirroring on a filesystem. The
+// dashboard API does not expose mirroring, so it always goes through the ceph
+// CLI.
+type FsMirrorResource struct {
+	client *CephAPIClient
+}
+
+type FsMirrorResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	FsName types.String `tfsdk:"fs_name"`
+}
+
+func (r *FsMirrorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fs_mirror"
+}
+
+func (r *FsMirrorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Enables CephFS snapshot mirroring on a filesystem, as with `ceph fs snapshot mirror enable`. The `mirroring` mgr module is enabled if needed. " +
+			"Add peers with `ceph_fs_mirror_peer`; a `cephfs-mirror` daemon must be running for snapshots to be replicated. " +
+			"Snapshot mirroring is only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled. " +
+			"Destroying the resource disables mirroring on the filesystem.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the filesystem",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fs_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the filesystem to mirror",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *FsMirrorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FsMirrorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FsMirrorResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to enable snapshot mirroring: %s", err),
+		)
+		return
+	}
+
+	if err := r.client.MgrEnableModule(ctx, fsMirroringModule); err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to enable the mirroring module: %s", err),
+		)
+		return
+	}
+
+	if err := cli.FsSnapshotMirrorEnable(ctx, data.FsName.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to enable snapshot mirroring: %s", err),
+		)
+		return
+	}
+
+	data.ID = data.FsName
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FsMirrorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FsMirrorResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read snapshot mirroring: %s", err),
+		)
+		return
+	}
+
+	fsDump, err := cli.FsDump(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read snapshot mirroring: %s", err),
+		)
+		return
+	}
+
+	fs := fsDump.Filesystem(data.FsName.ValueString())
+	if fs == nil || fs.MirrorInfo == nil {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Snapshot mirroring of filesystem %s", data.FsName.ValueString()))
+		return
+	}
+
+	data.ID = data.FsName
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// fs_name requires replacement, so Update is never called.
+func (r *FsMirrorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+func (r *FsMirrorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FsMirrorResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to disable snapshot mirroring: %s", err),
+		)
+		return
+	}
+
+	if err := cli.FsSnapshotMirrorDisable(ctx, data.FsName.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to disable snapshot mirroring: %s", err),
+		)
+		return
+	}
+}
+
+func (r *FsMirrorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("fs_name"), req, resp)
+}
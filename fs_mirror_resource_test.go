@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCephFsMirrorResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	fsName := acctest.RandString(8)
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+			testAccCreateCephFs(t, fsName)
+		},
+		CheckDestroy: checkCephFsMirrorEnabled(t, fsName, false),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_mirror" "test" {
+					  fs_name = %q
+					}
+				`, fsName),
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_mirror" "test" {
+					  fs_name = %q
+					}
+				`, fsName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_fs_mirror.test", "id", fsName),
+					checkCephFsMirrorEnabled(t, fsName, true),
+				),
+			},
+			{
+				ConfigVariables:   configVariables,
+				ResourceName:      "ceph_fs_mirror.test",
+				ImportState:       true,
+				ImportStateId:     fsName,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.FsSnapshotMirrorDisable(t.Context(), fsName); err != nil {
+						t.Fatalf("Failed to disable snapshot mirroring out of band: %v", err)
+					}
+				},
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_mirror" "test" {
+					  fs_name = %q
+					}
+				`, fsName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_fs_mirror.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkCephFsMirrorEnabled(t, fsName, true),
+			},
+		},
+	})
+}
+
+// testAccCreateCephFs creates a filesystem without an MDS, which is enough
+// for settings kept in the fs map, and removes it again after the test.
+func testAccCreateCephFs(t *testing.T, fsName string) {
+	t.Helper()
+
+	metadataPool := fsName + "_metadata"
+	dataPool := fsName + "_data"
+
+	for _, pool := range []string{metadataPool, dataPool} {
+		if err := cephTestClusterCLI.PoolCreate(t.Context(), pool, 8, ""); err != nil {
+			t.Fatalf("Failed to create pool: %v", err)
+		}
+
+		testCleanup(t, func(ctx context.Context) {
+			if err := cephTestClusterCLI.PoolDelete(ctx, pool); err != nil {
+				t.Errorf("Failed to cleanup pool %s: %v", pool, err)
+			}
+		})
+	}
+
+	if err := cephTestClusterCLI.FsNew(t.Context(), fsName, metadataPool, dataPool); err != nil {
+		t.Fatalf("Failed to create filesystem: %v", err)
+	}
+
+	testCleanup(t, func(ctx context.Context) {
+		if err := cephTestClusterCLI.FsRemove(ctx, fsName); err != nil {
+			t.Errorf("Failed to cleanup filesystem %s: %v", fsName, err)
+		}
+	})
+}
+
+func checkCephFsMirrorEnabled(t *testing.T, fsName string, expected bool) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		fsDump, err := cephTestClusterCLI.FsDump(t.Context())
+		if err != nil {
+			return err
+		}
+		fs := fsDump.Filesystem(fsName)
+		if fs == nil {
+			return fmt.Errorf("filesystem %s not found in fs map", fsName)
+		}
+		if enabled := fs.MirrorInfo != nil; enabled != expected {
+			return fmt.Errorf("filesystem %s mirroring enabled = %v, want %v", fsName, enabled, expected)
+		}
+		return nil
+	}
+}
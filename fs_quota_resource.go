@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &FsQuotaResource{}
+	_ resource.ResourceWithImportState = &FsQuotaResource{}
+)
+
+func newFsQuotaResource() resource.Resource {
+	return &FsQuotaResource{}
+}
+
+// FsQuotaResource manages the quota attributes of any CephFS directory, as
+// opposed to the size of a subvolume.
+type FsQuotaResource struct {
+	client *CephAPIClient
+}
+
+type FsQuotaResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	FsName   types.String `tfsdk:"fs_name"`
+	Path     types.String `tfsdk:"path"`
+	MaxBytes types.Int64  `tfsdk:"max_bytes"`
+	MaxFiles types.Int64  `tfsdk:"max_files"`
+}
+
+func (r *FsQuotaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fs_quota"
+}
+
+func (r *FsQuotaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the quota of a directory in a CephFS filesystem, equivalent to setting the `ceph.quota.max_bytes` and `ceph.quota.max_files` extended attributes. " +
+			"The directory must already exist. Destroying the resource removes both quotas.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The filesystem name followed by the path, e.g. `cephfs/projects/a`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fs_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the filesystem",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"path": resourceSchema.StringAttribute{
+				MarkdownDescription: "The absolute path of the directory within the filesystem, e.g. `/projects/a`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/.+`), "must be an absolute path below the filesystem root"),
+				},
+			},
+			"max_bytes": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The maximum number of bytes stored below the directory, or `0` for no limit. Defaults to `0`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_files": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The maximum number of files and directories below the directory, or `0` for no limit. Defaults to `0`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+func (r *FsQuotaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FsQuotaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FsQuotaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setQuotas(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FsQuotaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FsQuotaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fsID, err := r.client.CephFSID(ctx, data.FsName.ValueString())
+	if isCephAPINotFound(err) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Filesystem %s", data.FsName.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read CephFS quota: %s", err),
+		)
+		return
+	}
+
+	quotas, err := r.client.CephFSGetQuotas(ctx, fsID, data.Path.ValueString())
+	if isCephAPINotFound(err) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Path %s of filesystem %s", data.Path.ValueString(), data.FsName.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read CephFS quota of %s: %s", data.Path.ValueString(), err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.FsName.ValueString() + data.Path.ValueString())
+	data.MaxBytes = types.Int64Value(quotas.MaxBytes)
+	data.MaxFiles = types.Int64Value(quotas.MaxFiles)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FsQuotaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FsQuotaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setQuotas(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FsQuotaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FsQuotaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fsID, err := r.client.CephFSID(ctx, data.FsName.ValueString())
+	if isCephAPINotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to remove CephFS quota: %s", err),
+		)
+		return
+	}
+
+	err = r.client.CephFSSetQuotas(ctx, fsID, CephAPICephFSQuotaRequest{Path: data.Path.ValueString()})
+	if isCephAPINotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to remove CephFS quota of %s: %s", data.Path.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ImportState accepts "<fs_name>/<path>", the same form as the ID.
+// Filesystem names cannot contain "/".
+func (r *FsQuotaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	fsName, dirPath, ok := strings.Cut(req.ID, "/")
+	if !ok || fsName == "" || dirPath == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the form '<fs_name>/<path>', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fs_name"), fsName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), "/"+dirPath)...)
+}
+
+func (r *FsQuotaResource) setQuotas(ctx context.Context, data *FsQuotaResourceModel, diags *diag.Diagnostics) {
+	fsID, err := r.client.CephFSID(ctx, data.FsName.ValueString())
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to set CephFS quota: %s", err),
+		)
+		return
+	}
+
+	err = r.client.CephFSSetQuotas(ctx, fsID, CephAPICephFSQuotaRequest{
+		Path:     data.Path.ValueString(),
+		MaxBytes: data.MaxBytes.ValueInt64(),
+		MaxFiles: data.MaxFiles.ValueInt64(),
+	})
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to set CephFS quota of %s: %s", data.Path.ValueString(), err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.FsName.ValueString() + data.Path.ValueString())
+}
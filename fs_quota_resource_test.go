@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Quota paths can only be removed through a CephFS mount, which the test
+// harness does not have, so deleting the path out of band is not covered.
+func TestAccCephFsQuotaResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	fsName := acctest.RandString(8)
+	subvolumeName := acctest.RandomWithPrefix("test-subvolume")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+			testAccCreateCephFs(t, fsName)
+			testAccCreateCephFsSubvolume(t, fsName, subvolumeName)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_quota" "test" {
+					  fs_name   = %q
+					  path      = "/volumes/_nogroup"
+					  max_bytes = 1073741824
+					}
+				`, fsName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_fs_quota.test", "id", fsName+"/volumes/_nogroup"),
+					resource.TestCheckResourceAttr("ceph_fs_quota.test", "max_bytes", "1073741824"),
+					resource.TestCheckResourceAttr("ceph_fs_quota.test", "max_files", "0"),
+				),
+			},
+			{
+				ConfigVariables:   testAccProviderConfig(),
+				ResourceName:      "ceph_fs_quota.test",
+				ImportState:       true,
+				ImportStateId:     fsName + "/volumes/_nogroup",
+				ImportStateVerify: true,
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_quota" "test" {
+					  fs_name   = %q
+					  path      = "/volumes/_nogroup"
+					  max_files = 1000
+					}
+				`, fsName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_fs_quota.test", "max_bytes", "0"),
+					resource.TestCheckResourceAttr("ceph_fs_quota.test", "max_files", "1000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCephFsQuotaResource_relativePath(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_fs_quota" "test" {
+					  fs_name   = "cephfs"
+					  path      = "volumes"
+					  max_files = 10
+					}
+				`,
+				ExpectError: regexp.MustCompile(`must be an absolute path`),
+			},
+		},
+	})
+}
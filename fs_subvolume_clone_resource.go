@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &FsSubvolumeCloneResource{}
+
+func newFsSubvolumeCloneResource() resource.Resource {
+	return &FsSubvolumeCloneResource{}
+}
+
+// FsSubvolumeCloneResource creates a subvolume from a subvolume snapshot.
+// The clone is owned by the resource once complete, so destroying it removes
+// the cloned subvolume. It cannot be imported since subvolume info does not
+// record the snapshot a clone was made from.
+type FsSubvolumeCloneResource struct {
+	client *CephAPIClient
+}
+
+type FsSubvolumeCloneResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	FsName          types.String   `tfsdk:"fs_name"`
+	GroupName       types.String   `tfsdk:"group_name"`
+	SubvolumeName   types.String   `tfsdk:"subvolume_name"`
+	SnapshotName    types.String   `tfsdk:"snapshot_name"`
+	Name            types.String   `tfsdk:"name"`
+	TargetGroupName types.String   `tfsdk:"target_group_name"`
+	Path            types.String   `tfsdk:"path"`
+	DataPool        types.String   `tfsdk:"data_pool"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *FsSubvolumeCloneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fs_subvolume_clone"
+}
+
+func (r *FsSubvolumeCloneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replaceString := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+	nonEmpty := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Creates a new CephFS subvolume from a subvolume snapshot, as with `ceph fs subvolume snapshot clone`. " +
+			"Creation waits for the clone to complete. Destroying the resource removes the cloned subvolume. " +
+			"The clone cannot be imported, since Ceph does not record which snapshot a subvolume was cloned from.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The clone ID in the form `<fs_name>/<name>`, or `<fs_name>/<target_group_name>/<name>` for clones in a group",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fs_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the filesystem (volume)",
+				Required:            true,
+				PlanModifiers:       replaceString,
+				Validators:          nonEmpty,
+			},
+			"group_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The subvolume group of the source subvolume. Omit for the default group.",
+				Optional:            true,
+				PlanModifiers:       replaceString,
+				Validators:          nonEmpty,
+			},
+			"subvolume_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the source subvolume",
+				Required:            true,
+				PlanModifiers:       replaceString,
+				Validators:          nonEmpty,
+			},
+			"snapshot_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the snapshot to clone",
+				Required:            true,
+				PlanModifiers:       replaceString,
+				Validators:          nonEmpty,
+			},
+			"name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the new subvolume",
+				Required:            true,
+				PlanModifiers:       replaceString,
+				Validators:          nonEmpty,
+			},
+			"target_group_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The subvolume group to create the new subvolume in. Omit for the default group.",
+				Optional:            true,
+				PlanModifiers:       replaceString,
+				Validators:          nonEmpty,
+			},
+			"path": resourceSchema.StringAttribute{
+				MarkdownDescription: "The path of the new subvolume within the filesystem",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"data_pool": resourceSchema.StringAttribute{
+				MarkdownDescription: "The data pool of the new subvolume",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]resourceSchema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *FsSubvolumeCloneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FsSubvolumeCloneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FsSubvolumeCloneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.client.CephFSCloneSubvolumeSnapshot(ctx, CephAPICephFSSubvolumeSnapshotCloneRequest{
+		VolName:         data.FsName.ValueString(),
+		SubvolName:      data.SubvolumeName.ValueString(),
+		SnapName:        data.SnapshotName.ValueString(),
+		CloneName:       data.Name.ValueString(),
+		GroupName:       data.GroupName.ValueString(),
+		TargetGroupName: data.TargetGroupName.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to clone subvolume snapshot: %s", err),
+		)
+		return
+	}
+
+	info, err := r.waitForClone(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Clone %s did not complete: %s", data.Name.ValueString(), err),
+		)
+		return
+	}
+
+	r.updateModel(&data, info)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FsSubvolumeCloneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FsSubvolumeCloneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := r.client.CephFSGetSubvolume(ctx, data.FsName.ValueString(), data.Name.ValueString(), data.TargetGroupName.ValueString())
+	if isCephAPINotFound(err) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Subvolume %s of filesystem %s", data.Name.ValueString(), data.FsName.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read cloned subvolume: %s", err),
+		)
+		return
+	}
+
+	r.updateModel(&data, info)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Every attribute other than timeouts requires replacement, so Update only
+// has to store the new timeouts.
+func (r *FsSubvolumeCloneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FsSubvolumeCloneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FsSubvolumeCloneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FsSubvolumeCloneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CephFSDeleteSubvolume(ctx, data.FsName.ValueString(), data.Name.ValueString(), data.TargetGroupName.ValueString())
+	if isCephAPINotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to delete cloned subvolume: %s", err),
+		)
+		return
+	}
+}
+
+// waitForClone polls the new subvolume until the clone completes. The
+// volumes module refuses subvolume info with "not ready" while a clone is
+// pending or in progress, including clones that failed.
+func (r *FsSubvolumeCloneResource) waitForClone(ctx context.Context, data FsSubvolumeCloneResourceModel) (CephAPICephFSSubvolumeInfo, error) {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		info, err := r.client.CephFSGetSubvolume(ctx, data.FsName.ValueString(), data.Name.ValueString(), data.TargetGroupName.ValueString())
+		var apiErr *CephAPIError
+		if err == nil || !errors.As(err, &apiErr) || !strings.Contains(apiErr.Detail, "not ready") {
+			return info, err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return CephAPICephFSSubvolumeInfo{}, fmt.Errorf("%w: %s", ctx.Err(), err)
+		}
+	}
+}
+
+func (r *FsSubvolumeCloneResource) updateModel(data *FsSubvolumeCloneResourceModel, info CephAPICephFSSubvolumeInfo) {
+	data.ID = types.StringValue(fsSubvolumeID(data.FsName.ValueString(), data.TargetGroupName.ValueString(), data.Name.ValueString()))
+	data.Path = types.StringValue(info.Path)
+	data.DataPool = types.StringValue(info.DataPool)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccCephFsSubvolumeCloneResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	fsName := acctest.RandString(8)
+	subvolumeName := acctest.RandomWithPrefix("test-subvolume")
+	snapshotName := acctest.RandomWithPrefix("test-snapshot")
+	cloneName := acctest.RandomWithPrefix("test-clone")
+
+	resourceConfig := fmt.Sprintf(`
+		resource "ceph_fs_subvolume_snapshot" "test" {
+		  fs_name        = %q
+		  subvolume_name = %q
+		  name           = %q
+		}
+
+		resource "ceph_fs_subvolume_clone" "test" {
+		  fs_name        = ceph_fs_subvolume_snapshot.test.fs_name
+		  subvolume_name = ceph_fs_subvolume_snapshot.test.subvolume_name
+		  snapshot_name  = ceph_fs_subvolume_snapshot.test.name
+		  name           = %q
+		}
+	`, fsName, subvolumeName, snapshotName, cloneName)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+			testAccCreateCephFs(t, fsName)
+			testAccCreateCephFsSubvolume(t, fsName, subvolumeName)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + resourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_fs_subvolume_clone.test", "id", fsName+"/"+cloneName),
+					resource.TestMatchResourceAttr("ceph_fs_subvolume_clone.test", "path", regexp.MustCompile("^/volumes/_nogroup/"+regexp.QuoteMeta(cloneName)+"/")),
+					resource.TestCheckResourceAttr("ceph_fs_subvolume_clone.test", "data_pool", fsName+"_data"),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.FsSubvolumeRemove(t.Context(), fsName, cloneName); err != nil {
+						t.Fatalf("Failed to remove clone out of band: %v", err)
+					}
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + resourceConfig,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_fs_subvolume_clone.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &FsSubvolumeSnapshotResource{}
+	_ resource.ResourceWithImportState = &FsSubvolumeSnapshotResource{}
+)
+
+func newFsSubvolumeSnapshotResource() resource.Resource {
+	return &FsSubvolumeSnapshotResource{}
+}
+
+type FsSubvolumeSnapshotResource struct {
+	client *CephAPIClient
+}
+
+type FsSubvolumeSnapshotResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	FsName           types.String `tfsdk:"fs_name"`
+	GroupName        types.String `tfsdk:"group_name"`
+	SubvolumeName    types.String `tfsdk:"subvolume_name"`
+	Name             types.String `tfsdk:"name"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	DataPool         types.String `tfsdk:"data_pool"`
+	HasPendingClones types.Bool   `tfsdk:"has_pending_clones"`
+}
+
+func (r *FsSubvolumeSnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fs_subvolume_snapshot"
+}
+
+func (r *FsSubvolumeSnapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages a snapshot of a CephFS subvolume, as with `ceph fs subvolume snapshot create`. " +
+			"Use `ceph_fs_subvolume_clone` to create new subvolumes from the snapshot. A snapshot cannot be destroyed while clones of it are still in progress.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The snapshot ID in the form `<fs_name>/<subvolume_name>/<name>`, or `<fs_name>/<group_name>/<subvolume_name>/<name>` for subvolumes in a group",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fs_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the filesystem (volume)",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"group_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The subvolume group of the subvolume. Omit for the default group.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"subvolume_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the subvolume to snapshot",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the snapshot",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"created_at": resourceSchema.StringAttribute{
+				MarkdownDescription: "When the snapshot was taken",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"data_pool": resourceSchema.StringAttribute{
+				MarkdownDescription: "The data pool of the subvolume",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"has_pending_clones": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether clones of the snapshot are still in progress",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FsSubvolumeSnapshotResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FsSubvolumeSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FsSubvolumeSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CephFSCreateSubvolumeSnapshot(ctx, CephAPICephFSSubvolumeSnapshotCreateRequest{
+		VolName:    data.FsName.ValueString(),
+		SubvolName: data.SubvolumeName.ValueString(),
+		SnapName:   data.Name.ValueString(),
+		GroupName:  data.GroupName.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to create subvolume snapshot: %s", err),
+		)
+		return
+	}
+
+	found := r.read(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Subvolume snapshot %s was not found after creation", data.Name.ValueString()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FsSubvolumeSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FsSubvolumeSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Snapshot %s of subvolume %s", data.Name.ValueString(), data.SubvolumeName.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Every attribute requires replacement, so Update is never called.
+func (r *FsSubvolumeSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+func (r *FsSubvolumeSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FsSubvolumeSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CephFSDeleteSubvolumeSnapshot(ctx, data.FsName.ValueString(), data.SubvolumeName.ValueString(), data.Name.ValueString(), data.GroupName.ValueString())
+	if isCephAPINotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to delete subvolume snapshot: %s", err),
+		)
+		return
+	}
+}
+
+func (r *FsSubvolumeSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if (len(parts) != 3 && len(parts) != 4) || slices.Contains(parts, "") {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the form '<fs_name>/<subvolume_name>/<name>' or '<fs_name>/<group_name>/<subvolume_name>/<name>', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fs_name"), parts[0])...)
+	if len(parts) == 4 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_name"), parts[1])...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subvolume_name"), parts[len(parts)-2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[len(parts)-1])...)
+}
+
+// read refreshes data from the snapshot info and reports whether the
+// snapshot exists.
+func (r *FsSubvolumeSnapshotResource) read(ctx context.Context, data *FsSubvolumeSnapshotResourceModel, diags *diag.Diagnostics) bool {
+	info, err := r.client.CephFSGetSubvolumeSnapshot(ctx, data.FsName.ValueString(), data.SubvolumeName.ValueString(), data.Name.ValueString(), data.GroupName.ValueString())
+	if isCephAPINotFound(err) {
+		return false
+	}
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read subvolume snapshot: %s", err),
+		)
+		return false
+	}
+
+	data.ID = types.StringValue(fsSubvolumeID(data.FsName.ValueString(), data.GroupName.ValueString(), data.SubvolumeName.ValueString()) + "/" + data.Name.ValueString())
+	data.CreatedAt = types.StringValue(info.CreatedAt)
+	data.DataPool = types.StringValue(info.DataPool)
+	data.HasPendingClones = types.BoolValue(info.HasPendingClones == "yes")
+	return true
+}
+
+// fsSubvolumeID identifies a subvolume as "<fs_name>/<subvolume_name>", or
+// "<fs_name>/<group_name>/<subvolume_name>" outside the default group.
+func fsSubvolumeID(fsName, groupName, subvolumeName string) string {
+	if groupName == "" {
+		return fsName + "/" + subvolumeName
+	}
+	return fsName + "/" + groupName + "/" + subvolumeName
+}
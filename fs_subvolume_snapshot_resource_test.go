@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccCephFsSubvolumeSnapshotResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	fsName := acctest.RandString(8)
+	subvolumeName := acctest.RandomWithPrefix("test-subvolume")
+	snapshotName := acctest.RandomWithPrefix("test-snapshot")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+			testAccCreateCephFs(t, fsName)
+			testAccCreateCephFsSubvolume(t, fsName, subvolumeName)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_subvolume_snapshot" "test" {
+					  fs_name        = %q
+					  subvolume_name = %q
+					  name           = %q
+					}
+				`, fsName, subvolumeName, snapshotName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_fs_subvolume_snapshot.test", "id", fsName+"/"+subvolumeName+"/"+snapshotName),
+					resource.TestCheckResourceAttrSet("ceph_fs_subvolume_snapshot.test", "created_at"),
+					resource.TestCheckResourceAttr("ceph_fs_subvolume_snapshot.test", "data_pool", fsName+"_data"),
+					resource.TestCheckResourceAttr("ceph_fs_subvolume_snapshot.test", "has_pending_clones", "false"),
+				),
+			},
+			{
+				ConfigVariables:   testAccProviderConfig(),
+				ResourceName:      "ceph_fs_subvolume_snapshot.test",
+				ImportState:       true,
+				ImportStateId:     fsName + "/" + subvolumeName + "/" + snapshotName,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					_, err := cephTestClusterCLI.MonCommand(t.Context(), map[string]any{
+						"prefix":    "fs subvolume snapshot rm",
+						"vol_name":  fsName,
+						"sub_name":  subvolumeName,
+						"snap_name": snapshotName,
+					})
+					if err != nil {
+						t.Fatalf("Failed to remove snapshot out of band: %v", err)
+					}
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_fs_subvolume_snapshot" "test" {
+					  fs_name        = %q
+					  subvolume_name = %q
+					  name           = %q
+					}
+				`, fsName, subvolumeName, snapshotName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_fs_subvolume_snapshot.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+// testAccCreateCephFsSubvolume waits for the filesystem to have an active MDS
+// and creates a subvolume in its default group.
+func testAccCreateCephFsSubvolume(t *testing.T, fsName, subvolumeName string) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 2*time.Minute)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for active := false; !active; {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Filesystem %s has no active MDS: %v", fsName, ctx.Err())
+		case <-ticker.C:
+			fsDump, err := cephTestClusterCLI.FsDump(ctx)
+			if err != nil {
+				continue
+			}
+			if fs := fsDump.Filesystem(fsName); fs != nil {
+				for _, mds := range fs.MDSMap.Info {
+					active = active || mds.State == "up:active"
+				}
+			}
+		}
+	}
+
+	if err := cephTestClusterCLI.FsSubvolumeCreate(ctx, fsName, subvolumeName); err != nil {
+		t.Fatalf("Failed to create subvolume: %v", err)
+	}
+
+	testCleanup(t, func(ctx context.Context) {
+		if err := cephTestClusterCLI.FsSubvolumeRemove(ctx, fsName, subvolumeName); err != nil {
+			t.Errorf("Failed to cleanup subvolume %s: %v", subvolumeName, err)
+		}
+	})
+}
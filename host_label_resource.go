@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &HostLabelResource{}
+	_ resource.ResourceWithImportState = &HostLabelResource{}
+	_ resource.ResourceWithIdentity    = &HostLabelResource{}
+)
+
+func newHostLabelResource() resource.Resource {
+	return &HostLabelResource{}
+}
+
+// HostLabelResource manages labels on a host the orchestrator already
+// manages, leaving the host itself alone.
+type HostLabelResource struct {
+	client *CephAPIClient
+}
+
+type HostLabelResourceModel struct {
+	Hostname  types.String `tfsdk:"hostname"`
+	Labels    types.Set    `tfsdk:"labels"`
+	Exclusive types.Bool   `tfsdk:"exclusive"`
+}
+
+type HostLabelResourceIdentityModel struct {
+	Hostname types.String `tfsdk:"hostname"`
+}
+
+func (r *HostLabelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_label"
+}
+
+func (r *HostLabelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages labels on an existing orchestrator host, which service placement specs can select hosts by. " +
+			"By default the labels are added alongside any others on the host, and destroying the resource removes only the labels it manages.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"hostname": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the host",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"labels": resourceSchema.SetAttribute{
+				MarkdownDescription: "The labels to ensure on the host, e.g. `[\"mon\", \"rgw\"]`",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"exclusive": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether this resource is the authority for every label on the host. When `true`, labels on the host but missing from `labels` are reported as drift and removed. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *HostLabelResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"hostname": identityschema.StringAttribute{
+				Description:       "The name of the host",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *HostLabelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *HostLabelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data HostLabelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var labels []string
+	resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &labels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setLabels(ctx, data.Hostname.ValueString(), nil, labels, data.Exclusive.ValueBool())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, HostLabelResourceIdentityModel{Hostname: data.Hostname})...)
+}
+
+func (r *HostLabelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data HostLabelResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, err := r.client.HostGet(ctx, data.Hostname.ValueString())
+	if err != nil {
+		if isCephAPINotFound(err) {
+			removeMissingResource(ctx, resp, fmt.Sprintf("Host %s", data.Hostname.ValueString()))
+			return
+		}
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read labels of host %s: %s", data.Hostname.ValueString(), err),
+		)
+		return
+	}
+
+	if data.Exclusive.IsNull() {
+		data.Exclusive = types.BoolValue(false)
+	}
+
+	// Imported resources adopt every label on the host.
+	labels := host.Labels
+	if !data.Labels.IsNull() && !data.Exclusive.ValueBool() {
+		var managed []string
+		resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		labels = nil
+		for _, label := range host.Labels {
+			if slices.Contains(managed, label) {
+				labels = append(labels, label)
+			}
+		}
+	}
+
+	labelsValue, diags := types.SetValueFrom(ctx, types.StringType, labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Labels = labelsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, HostLabelResourceIdentityModel{Hostname: data.Hostname})...)
+}
+
+func (r *HostLabelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, prior HostLabelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var labels, priorLabels []string
+	resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &labels, false)...)
+	resp.Diagnostics.Append(prior.Labels.ElementsAs(ctx, &priorLabels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setLabels(ctx, data.Hostname.ValueString(), priorLabels, labels, data.Exclusive.ValueBool())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, HostLabelResourceIdentityModel{Hostname: data.Hostname})...)
+}
+
+func (r *HostLabelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data HostLabelResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var labels []string
+	resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &labels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setLabels(ctx, data.Hostname.ValueString(), labels, nil, false)...)
+}
+
+func (r *HostLabelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("hostname"), path.Root("hostname"), req, resp)
+}
+
+// setLabels replaces the prior managed labels on the host with the desired
+// ones. Unless exclusive, labels the resource never managed are kept.
+func (r *HostLabelResource) setLabels(ctx context.Context, hostname string, prior, desired []string, exclusive bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	host, err := r.client.HostGet(ctx, hostname)
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read labels of host %s: %s", hostname, err),
+		)
+		return diags
+	}
+
+	labels := mergeHostLabels(host.Labels, prior, desired, exclusive)
+	current := slices.Clone(host.Labels)
+	slices.Sort(current)
+	if slices.Equal(labels, current) {
+		return diags
+	}
+
+	if err := r.client.HostSetLabels(ctx, hostname, labels); err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to set labels of host %s: %s", hostname, err),
+		)
+	}
+	return diags
+}
+
+// mergeHostLabels returns the sorted labels the host should have.
+func mergeHostLabels(current, prior, desired []string, exclusive bool) []string {
+	labels := append([]string{}, desired...)
+	if !exclusive {
+		for _, label := range current {
+			if !slices.Contains(prior, label) {
+				labels = append(labels, label)
+			}
+		}
+	}
+	slices.Sort(labels)
+	return slices.Compact(labels)
+}
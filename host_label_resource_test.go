@@ -0,0 +1,82 @@
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMergeHostLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   []string
+		prior     []string
+		desired   []string
+		exclusive bool
+		expected  []string
+	}{
+		{
+			name:     "adds to unmanaged labels",
+			current:  []string{"mon", "_admin"},
+			desired:  []string{"rgw"},
+			expected: []string{"_admin", "mon", "rgw"},
+		},
+		{
+			name:     "removes labels dropped from the config",
+			current:  []string{"_admin", "mon", "rgw"},
+			prior:    []string{"mon", "rgw"},
+			desired:  []string{"rgw"},
+			expected: []string{"_admin", "rgw"},
+		},
+		{
+			name:      "exclusive drops unmanaged labels",
+			current:   []string{"_admin", "mon"},
+			desired:   []string{"rgw", "mon"},
+			exclusive: true,
+			expected:  []string{"mon", "rgw"},
+		},
+		{
+			name:     "removing every managed label",
+			current:  []string{"rgw"},
+			prior:    []string{"rgw"},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeHostLabels(tt.current, tt.prior, tt.desired, tt.exclusive)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("mergeHostLabels() = %#v, want %#v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// The test cluster has no orchestrator, so this only covers the error path,
+// and no host can be removed out of band.
+func TestAccCephHostLabelResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_host_label" "test" {
+					  hostname = "ceph-test"
+					  labels   = ["rgw"]
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Unable to read labels of host ceph-test`),
+			},
+		},
+	})
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// keyringSecretPattern matches the key lines of a keyring, e.g.
+// "key = AQBvaBFZAAAAABAA9VHgwCg3rWn8fMaX8KL01A==".
+var keyringSecretPattern = regexp.MustCompile(`(?m)^\s*key\s*=\s*(\S+)`)
+
+// debugHTTPTransport logs every request and response, including bodies, at
+// TRACE level. Credentials the provider was configured with and secrets found
+// in the exchange are masked before anything is logged.
+type debugHTTPTransport struct {
+	base http.RoundTripper
+
+	// secrets are masked in every message, e.g. the configured password.
+	secrets []string
+}
+
+func newDebugHTTPTransport(base http.RoundTripper, secrets ...string) *debugHTTPTransport {
+	return &debugHTTPTransport{
+		base:    base,
+		secrets: secrets,
+	}
+}
+
+func (t *debugHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(body)
+			body.Close() //nolint:errcheck
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+
+	var respBody []byte
+	if resp != nil && resp.Body != nil {
+		respBody, _ = io.ReadAll(resp.Body)
+		resp.Body.Close() //nolint:errcheck
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	}
+
+	secrets := append([]string{}, t.secrets...)
+	secrets = append(secrets, headerSecrets(req.Header)...)
+	secrets = append(secrets, querySecrets(req.URL.Query())...)
+	secrets = append(secrets, bodySecrets(reqBody)...)
+	secrets = append(secrets, bodySecrets(respBody)...)
+	ctx := tflog.MaskLogStrings(req.Context(), maskableSecrets(secrets)...)
+
+	fields := map[string]any{
+		"method":          req.Method,
+		"url":             req.URL.String(),
+		"request_headers": flattenHeader(req.Header),
+		"request_body":    string(reqBody),
+	}
+	if resp != nil {
+		fields["status_code"] = resp.StatusCode
+		fields["response_headers"] = flattenHeader(resp.Header)
+		fields["response_body"] = string(respBody)
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	tflog.Trace(ctx, "Ceph HTTP exchange", fields)
+
+	return resp, err
+}
+
+// maskableSecrets drops values that would mask unrelated text, such as the
+// empty value of RGW's "key" query parameter or a "generate-secret" flag.
+func maskableSecrets(secrets []string) []string {
+	var maskable []string
+	for _, secret := range secrets {
+		switch strings.ToLower(secret) {
+		case "", "true", "false":
+			continue
+		}
+		maskable = append(maskable, secret)
+	}
+	return maskable
+}
+
+// flattenHeader joins repeated headers and redacts credentials, keeping any
+// scheme such as "Bearer". Masking only applies to top-level string fields,
+// so the header map is redacted here.
+func flattenHeader(header http.Header) map[string]string {
+	flat := make(map[string]string, len(header))
+	for name, values := range header {
+		if sensitiveName(name) {
+			redacted := make([]string, len(values))
+			for i, value := range values {
+				redacted[i] = "***"
+				if scheme, _, ok := strings.Cut(value, " "); ok {
+					redacted[i] = scheme + " ***"
+				}
+			}
+			values = redacted
+		}
+		flat[name] = strings.Join(values, ", ")
+	}
+	return flat
+}
+
+// sensitiveName reports whether a JSON key, query parameter or header holds a
+// credential, e.g. "password", "secret_key", "secret-key" or "Authorization".
+func sensitiveName(name string) bool {
+	name = strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+	switch name {
+	case "key", "access_key", "authorization", "cookie", "set_cookie", "x_amz_security_token":
+		return true
+	}
+	for _, word := range []string{"password", "token", "secret", "keyring", "passphrase", "seed"} {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func headerSecrets(header http.Header) []string {
+	var secrets []string
+	for name, values := range header {
+		if !sensitiveName(name) {
+			continue
+		}
+		for _, value := range values {
+			secrets = append(secrets, value)
+			// Mask the credential on its own too, in case it appears
+			// elsewhere without its "Bearer " prefix.
+			if _, credential, ok := strings.Cut(value, " "); ok {
+				secrets = append(secrets, credential)
+			}
+		}
+	}
+	return secrets
+}
+
+func querySecrets(query url.Values) []string {
+	var secrets []string
+	for name, values := range query {
+		if sensitiveName(name) {
+			secrets = append(secrets, values...)
+		}
+	}
+	return secrets
+}
+
+// bodySecrets returns the values of sensitive JSON fields and the keys of any
+// keyrings in body, which may be JSON or plain text.
+func bodySecrets(body []byte) []string {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return keyringSecrets(string(body))
+	}
+
+	var secrets []string
+	var walk func(name string, value any)
+	walk = func(name string, value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			for k, child := range v {
+				walk(k, child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(name, child)
+			}
+		case string:
+			if v == "" {
+				return
+			}
+			if sensitiveName(name) {
+				secrets = append(secrets, v)
+			}
+			secrets = append(secrets, keyringSecrets(v)...)
+		}
+	}
+	walk("", value)
+	return secrets
+}
+
+func keyringSecrets(text string) []string {
+	var secrets []string
+	for _, match := range keyringSecretPattern.FindAllStringSubmatch(text, -1) {
+		secrets = append(secrets, match[1])
+	}
+	return secrets
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestDebugHTTPTransport(t *testing.T) {
+	const responseBody = `{"keys":[{"user":"test","access_key":"ACCESSKEY1","secret_key":"SECRETKEY1"}],` +
+		`"keyring":"[client.test]\n\tkey = AQBvaBFZAAAAABAA9VHgwCg3rWn8fMaX8KL01A==\n"}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, responseBody) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := &http.Client{Transport: newDebugHTTPTransport(http.DefaultTransport, "configured-password", "")}
+	req, err := http.NewRequestWithContext(ctx, "POST", server.URL+"/admin/user?uid=test&secret-key=QUERYSECRET", strings.NewReader(`{"username":"admin","password":"BODYPASSWORD"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer BEARERTOKEN")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != responseBody {
+		t.Errorf("response body = %q, want %q", body, responseBody)
+	}
+
+	logged := output.String()
+	if !strings.Contains(logged, "Ceph HTTP exchange") || !strings.Contains(logged, `\"user\":\"test\"`) {
+		t.Fatalf("exchange was not logged: %s", logged)
+	}
+	for _, secret := range []string{"BEARERTOKEN", "QUERYSECRET", "BODYPASSWORD", "ACCESSKEY1", "SECRETKEY1", "AQBvaBFZAAAAABAA9VHgwCg3rWn8fMaX8KL01A=="} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("log contains %s: %s", secret, logged)
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+	"unicode"
 )
 
 type CephCaps struct {
@@ -63,6 +66,192 @@ func MustCephCapsFromMap(capabilities map[string]string) CephCaps {
 	return caps
 }
 
+// cephCapPermissions matches the permissions of mon, mgr and osd grants, e.g.
+// "r", "rw" or "rwx", which Ceph only accepts in that order.
+var cephCapPermissions = regexp.MustCompile(`^r?w?x?$`)
+
+// cephMDSCapPermissions are the permissions of mds grants.
+var cephMDSCapPermissions = []string{"r", "rw", "rwp", "rws", "rwps"}
+
+// cephCapKeywords are the words of a grant that take an argument, e.g.
+// "pool foo", "profile rbd" or `command "osd tree"`.
+var cephCapKeywords = []string{"class", "command", "module", "namespace", "network", "object_prefix", "pool", "profile", "service", "tag"}
+
+// cephCapMatchKeys are the names of key=value clauses, which Ceph also
+// accepts for most keywords, e.g. "module=prometheus" or "profile=rbd". The
+// arguments of a command after "with" and the tag after "tag <application>"
+// can have any name.
+var cephCapMatchKeys = []string{"command", "fsname", "gids", "module", "namespace", "object_prefix", "path", "pool", "profile", "service", "uid"}
+
+// validateCephCap checks the structure of a mon, mgr, osd or mds cap such as
+// "allow rw pool=foo, profile rbd". It catches typos and malformed clauses
+// that the monitors would reject with a bare EINVAL, without implementing
+// each daemon's full grammar, so that valid but unusual caps still pass.
+func validateCephCap(capType, value string) error {
+	grants, err := splitCephCapGrants(value)
+	if err != nil {
+		return err
+	}
+
+	for _, grant := range grants {
+		if err := validateCephCapGrant(capType, grant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitCephCapGrants splits a cap into its comma or semicolon separated
+// grants and each grant into its words, removing quotes.
+func splitCephCapGrants(value string) ([][]string, error) {
+	var grants [][]string
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	endWord := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+	endGrant := func() error {
+		endWord()
+		if len(words) == 0 {
+			return errors.New("contains an empty grant")
+		}
+		grants = append(grants, words)
+		words = nil
+		return nil
+	}
+
+	runes := []rune(value)
+	for i, r := range runes {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case unicode.IsSpace(r):
+			endWord()
+		case r == ',' || r == ';':
+			// MDS caps list group IDs with commas, e.g. "gids=1000,1001".
+			if strings.HasPrefix(word.String(), "gids=") && i+1 < len(runes) && unicode.IsDigit(runes[i+1]) {
+				word.WriteRune(r)
+				continue
+			}
+			if err := endGrant(); err != nil {
+				return nil, err
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("contains an unterminated quote")
+	}
+	if err := endGrant(); err != nil {
+		return nil, err
+	}
+
+	return grants, nil
+}
+
+func validateCephCapGrant(capType string, words []string) error {
+	grant := strings.Join(words, " ")
+
+	switch words[0] {
+	case "allow":
+		if len(words) == 1 {
+			return fmt.Errorf("grant %q does not say what it allows", grant)
+		}
+		words = words[1:]
+	case "profile":
+	default:
+		// "profile=rbd" is the same grant as "profile rbd".
+		if !strings.HasPrefix(words[0], "profile=") {
+			return fmt.Errorf("grant %q must start with \"allow\" or \"profile\"", grant)
+		}
+	}
+
+	anyKeys := false
+	commandArgs := false
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+
+		// The arguments of a command can also be matched by prefix or by
+		// regular expression, e.g. "with entity prefix client.".
+		if commandArgs && !strings.Contains(word, "=") && i+1 < len(words) && (words[i+1] == "prefix" || words[i+1] == "regex") {
+			if i+2 == len(words) {
+				return fmt.Errorf("grant %q has no value for %s %s", grant, word, words[i+1])
+			}
+			i += 2
+			continue
+		}
+
+		if key, value, ok := strings.Cut(word, "="); ok {
+			switch {
+			case key == "":
+				return fmt.Errorf("grant %q has a clause without a name: %q", grant, word)
+			case !anyKeys && !slices.Contains(cephCapMatchKeys, key):
+				return fmt.Errorf("grant %q has an unknown clause %q", grant, key)
+			case value == "" && key != "namespace":
+				return fmt.Errorf("grant %q has no value for %s", grant, key)
+			}
+			continue
+		}
+
+		switch {
+		case word == "with":
+			anyKeys = true
+			commandArgs = true
+		case word == "root_squash" && capType == "mds":
+		case slices.Contains(cephCapKeywords, word):
+			if i+1 == len(words) || strings.Contains(words[i+1], "=") {
+				return fmt.Errorf("grant %q has no argument for %s", grant, word)
+			}
+			i++
+			switch word {
+			case "tag":
+				anyKeys = true
+			case "class":
+				// The class can be followed by one of its methods.
+				if i+1 < len(words) && !strings.Contains(words[i+1], "=") && !slices.Contains(cephCapKeywords, words[i+1]) && !validCephCapPermissions(capType, words[i+1]) {
+					i++
+				}
+			}
+		case !validCephCapPermissions(capType, word):
+			return fmt.Errorf("grant %q has unknown permissions or clause %q", grant, word)
+		}
+	}
+	return nil
+}
+
+func validCephCapPermissions(capType, word string) bool {
+	switch word {
+	case "*", "all":
+		return true
+	}
+
+	switch capType {
+	case "mds":
+		return slices.Contains(cephMDSCapPermissions, word)
+	case "osd":
+		if word == "class-read" || word == "class-write" {
+			return true
+		}
+	}
+	return word != "" && cephCapPermissions.MatchString(word)
+}
+
 type CephUser struct {
 	Entity string   `json:"entity"`
 	Key    string   `json:"key"`
@@ -289,3 +289,89 @@ func TestFormatParseRoundTrip(t *testing.T) {
 		t.Errorf("Re-serialization changed output:\nFirst:  %q\nSecond: %q", serialized, reserialized)
 	}
 }
+
+func TestValidateCephCap(t *testing.T) {
+	valid := map[string][]string{
+		"mon": {
+			"allow *",
+			"allow r",
+			"profile rbd",
+			"allow r, allow command \"osd blocklist\"",
+			`allow command "auth get" with entity=client.foo`,
+			"allow service mon rw",
+			"allow r network 10.0.0.0/8",
+			"allow r fsname=cephfs",
+			`allow command="osd tree"`,
+			"allow service=mon r",
+			"profile=rbd",
+			"allow profile=rbd",
+			`allow command "auth get" with entity prefix client.foo`,
+			`allow command "auth get" with entity regex "^client\.foo"`,
+			`allow command "config set" with who=osd name prefix osd_`,
+		},
+		"mgr": {
+			"profile rbd pool=rbd namespace=ns",
+			"allow module prometheus rw",
+			"allow module=prometheus rw",
+			"allow rw tag cephfs data=cephfs",
+		},
+		"osd": {
+			"allow rwx pool=rbd; allow r pool=images",
+			"allow rw pool foo namespace bar",
+			"allow class-read object_prefix rbd_children",
+			"allow rw pool=rbd namespace=\"\"",
+			"allow x class rbd metadata_list",
+			"allow rw tag cephfs data=cephfs",
+		},
+		"mds": {
+			"allow *",
+			"allow rwps fsname=cephfs path=/volumes uid=1000 gids=1000,1001",
+			"allow rw path=/foo root_squash",
+		},
+	}
+	for capType, caps := range valid {
+		for _, capValue := range caps {
+			if err := validateCephCap(capType, capValue); err != nil {
+				t.Errorf("validateCephCap(%q, %q) error = %v, want nil", capType, capValue, err)
+			}
+		}
+	}
+
+	invalid := map[string][]string{
+		"mon": {
+			"",
+			"alow r",
+			"allow",
+			"allow r,",
+			"allow rr",
+			"allow wr",
+			"allow r pool=",
+			"allow r poool=rbd",
+			`allow command "osd tree`,
+			"allow command",
+			`allow command "auth get" with entity prefix`,
+			`allow command "auth get" with entity client.foo`,
+		},
+		"mgr": {
+			"profile",
+			"allow rw module",
+		},
+		"osd": {
+			"allow rwp pool=rbd",
+			"allow rw pool",
+			"allow rw =rbd",
+			"profile=",
+		},
+		"mds": {
+			"allow rwx",
+			"allow class-read",
+		},
+	}
+	for capType, caps := range invalid {
+		for _, capValue := range caps {
+			if err := validateCephCap(capType, capValue); err == nil {
+				t.Errorf("validateCephCap(%q, %q) error = nil, want an error", capType, capValue)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
@@ -16,7 +17,9 @@ func main() {
 		Address: "registry.terraform.io/josh/ceph",
 	}
 
-	err := providerserver.Serve(context.Background(), providerFunc, opts)
+	p := providerFunc().(*CephProvider)
+	err := providerserver.Serve(context.Background(), func() provider.Provider { return p }, opts)
+	p.metrics.report()
 
 	if err != nil {
 		log.Fatal(err.Error())
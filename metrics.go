@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// prometheusLabelEscaper escapes label values for the Prometheus text format.
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// metricsDurationBuckets are the upper bounds, in seconds, of the request
+// duration histogram.
+var metricsDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// apiMetrics counts the requests, retries and failures of each API endpoint
+// and how long they took.
+type apiMetrics struct {
+	mu        sync.Mutex
+	endpoints map[apiMetricsEndpoint]*apiEndpointMetrics
+
+	// logCtx carries the logger report writes the summary to, and file is
+	// where it stores the metrics in the Prometheus text format.
+	logCtx context.Context
+	file   string
+}
+
+type metricsRouteContextKey struct{}
+
+// withMetricsRoute marks requests made with ctx as requests for route, such as
+// "/api/pool/{pool_name}", so that the requests for e.g. every pool are
+// counted under one endpoint.
+func withMetricsRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, metricsRouteContextKey{}, route)
+}
+
+type apiMetricsEndpoint struct {
+	method string
+	route  string
+}
+
+type apiEndpointMetrics struct {
+	// requests counts responses by status code, or "error" for requests
+	// that got no response.
+	requests map[string]int
+	retries  int
+
+	// buckets counts durations up to each of metricsDurationBuckets and
+	// beyond the last one.
+	buckets  []int
+	duration time.Duration
+	max      time.Duration
+}
+
+func newAPIMetrics() *apiMetrics {
+	return &apiMetrics{
+		endpoints: make(map[apiMetricsEndpoint]*apiEndpointMetrics),
+	}
+}
+
+func (m *apiMetrics) endpoint(req *http.Request) *apiEndpointMetrics {
+	key := apiMetricsEndpoint{method: req.Method, route: metricsRoute(req)}
+	endpoint, ok := m.endpoints[key]
+	if !ok {
+		endpoint = &apiEndpointMetrics{
+			requests: make(map[string]int),
+			buckets:  make([]int, len(metricsDurationBuckets)+1),
+		}
+		m.endpoints[key] = endpoint
+	}
+	return endpoint
+}
+
+func (m *apiMetrics) observe(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	endpoint := m.endpoint(req)
+	endpoint.requests[code]++
+	endpoint.duration += duration
+	endpoint.max = max(endpoint.max, duration)
+	bucket, _ := slices.BinarySearch(metricsDurationBuckets, duration.Seconds())
+	endpoint.buckets[bucket]++
+}
+
+// retried counts a retry of req. m may be nil.
+func (m *apiMetrics) retried(req *http.Request) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.endpoint(req).retries++
+}
+
+// setReport sets where report logs and writes the metrics. ctx is the
+// context of Configure; its logger outlives the request, so report can still
+// log through it once Terraform stops the plugin.
+func (m *apiMetrics) setReport(ctx context.Context, file string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.logCtx = ctx
+	m.file = file
+}
+
+// metricsRoute returns the route req was marked with by withMetricsRoute, or
+// its path without any URL prefix. Requests for paths without names, such as
+// "/api/pool", need no route.
+func metricsRoute(req *http.Request) string {
+	if route, ok := req.Context().Value(metricsRouteContextKey{}).(string); ok {
+		return route
+	}
+	return strings.TrimSuffix(cephAPIPath(req.URL.Path), "/")
+}
+
+// sortedEndpoints returns the endpoints ordered by compare. m.mu must be
+// held.
+func (m *apiMetrics) sortedEndpoints(compare func(a, b apiMetricsEndpoint) int) []apiMetricsEndpoint {
+	keys := make([]apiMetricsEndpoint, 0, len(m.endpoints))
+	for key := range m.endpoints {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, compare)
+	return keys
+}
+
+// summary returns a line per endpoint for the debug log, slowest first.
+func (m *apiMetrics) summary() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var lines []string
+	slowest := func(a, b apiMetricsEndpoint) int {
+		return cmp.Or(
+			cmp.Compare(m.endpoints[b].duration, m.endpoints[a].duration),
+			cmp.Compare(a.route, b.route),
+			cmp.Compare(a.method, b.method),
+		)
+	}
+	for _, key := range m.sortedEndpoints(slowest) {
+		endpoint := m.endpoints[key]
+		var requests, failed int
+		for code, count := range endpoint.requests {
+			requests += count
+			if status, err := strconv.Atoi(code); err != nil || status >= http.StatusBadRequest {
+				failed += count
+			}
+		}
+		lines = append(lines, fmt.Sprintf(
+			"%s %s: %d requests, %d failed, %d retries, %s total, %s average, %s max",
+			key.method, key.route, requests, failed, endpoint.retries,
+			endpoint.duration.Round(time.Millisecond),
+			(endpoint.duration/time.Duration(max(requests, 1))).Round(time.Millisecond),
+			endpoint.max.Round(time.Millisecond),
+		))
+	}
+	return lines
+}
+
+// writePrometheus writes the metrics in the Prometheus text format.
+func (m *apiMetrics) writePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := m.sortedEndpoints(func(a, b apiMetricsEndpoint) int {
+		return cmp.Or(cmp.Compare(a.route, b.route), cmp.Compare(a.method, b.method))
+	})
+	labels := func(key apiMetricsEndpoint) string {
+		return fmt.Sprintf(`method="%s",route="%s"`, prometheusLabelEscaper.Replace(key.method), prometheusLabelEscaper.Replace(key.route))
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "# HELP ceph_provider_api_requests_total Ceph API requests by endpoint and response status code.")
+	fmt.Fprintln(&buf, "# TYPE ceph_provider_api_requests_total counter")
+	for _, key := range keys {
+		codes := make([]string, 0, len(m.endpoints[key].requests))
+		for code := range m.endpoints[key].requests {
+			codes = append(codes, code)
+		}
+		slices.Sort(codes)
+		for _, code := range codes {
+			fmt.Fprintf(&buf, "ceph_provider_api_requests_total{%s,code=\"%s\"} %d\n", labels(key), code, m.endpoints[key].requests[code])
+		}
+	}
+
+	fmt.Fprintln(&buf, "# HELP ceph_provider_api_retries_total Ceph API requests retried after a retryable response.")
+	fmt.Fprintln(&buf, "# TYPE ceph_provider_api_retries_total counter")
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "ceph_provider_api_retries_total{%s} %d\n", labels(key), m.endpoints[key].retries)
+	}
+
+	fmt.Fprintln(&buf, "# HELP ceph_provider_api_request_duration_seconds Time until the response to a Ceph API request arrived, including retries.")
+	fmt.Fprintln(&buf, "# TYPE ceph_provider_api_request_duration_seconds histogram")
+	for _, key := range keys {
+		endpoint := m.endpoints[key]
+		count := 0
+		for i, bucket := range endpoint.buckets {
+			count += bucket
+			le := "+Inf"
+			if i < len(metricsDurationBuckets) {
+				le = strconv.FormatFloat(metricsDurationBuckets[i], 'g', -1, 64)
+			}
+			fmt.Fprintf(&buf, "ceph_provider_api_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", labels(key), le, count)
+		}
+		fmt.Fprintf(&buf, "ceph_provider_api_request_duration_seconds_sum{%s} %g\n", labels(key), endpoint.duration.Seconds())
+		fmt.Fprintf(&buf, "ceph_provider_api_request_duration_seconds_count{%s} %d\n", labels(key), count)
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// report logs the summary at DEBUG level and writes the metrics file, if one
+// is configured. It is called from main once Terraform stops the plugin.
+func (m *apiMetrics) report() {
+	m.mu.Lock()
+	ctx, file := m.logCtx, m.file
+	m.mu.Unlock()
+	if ctx == nil {
+		return
+	}
+
+	lines := m.summary()
+	if len(lines) == 0 {
+		return
+	}
+
+	for _, line := range lines {
+		tflog.Debug(ctx, "Ceph API metrics: "+line)
+	}
+
+	if file == "" {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := m.writePrometheus(&buf); err != nil {
+		tflog.Warn(ctx, "Unable to format Ceph API metrics", map[string]any{"error": err.Error()})
+		return
+	}
+	if err := os.WriteFile(file, buf.Bytes(), 0o644); err != nil {
+		tflog.Warn(ctx, "Unable to write Ceph API metrics", map[string]any{"error": err.Error()})
+	}
+}
+
+// metricsTransport records every request in metrics. It sits outside the
+// retries, so a request's duration includes its retries.
+type metricsTransport struct {
+	base    http.RoundTripper
+	metrics *apiMetrics
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	t.metrics.observe(req, resp, err, time.Since(start))
+	return resp, err
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsRoute(t *testing.T) {
+	endpoint, err := url.Parse("https://mgr.example.com:8443/dashboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &CephAPIClient{endpoint: endpoint}
+
+	routes := []struct {
+		route   string
+		params  []string
+		wantURL string
+	}{
+		{"/api/pool/{pool_name}", []string{"rbd"}, "https://mgr.example.com:8443/dashboard/api/pool/rbd"},
+		{"/api/pool/{pool_name}/configuration", []string{"rbd"}, "https://mgr.example.com:8443/dashboard/api/pool/rbd/configuration"},
+		{"/api/rgw/bucket/{bucket}", []string{"tenant/bucket"}, "https://mgr.example.com:8443/dashboard/api/rgw/bucket/tenant%2Fbucket"},
+		{"/api/cephfs/subvolume/snapshot/{vol_name}/{subvol_name}/info", []string{"cephfs", "sv"}, "https://mgr.example.com:8443/dashboard/api/cephfs/subvolume/snapshot/cephfs/sv/info"},
+		{"/api/block/mirroring/pool/{pool_name}/bootstrap/token", []string{"rbd"}, "https://mgr.example.com:8443/dashboard/api/block/mirroring/pool/rbd/bootstrap/token"},
+	}
+	for _, tt := range routes {
+		ctx, reqURL := client.apiURL(t.Context(), tt.route, tt.params...)
+		if reqURL.String() != tt.wantURL {
+			t.Errorf("apiURL(%q, %q) = %q, want %q", tt.route, tt.params, reqURL.String(), tt.wantURL)
+		}
+		req, err := http.NewRequestWithContext(ctx, "GET", reqURL.String(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := metricsRoute(req); got != tt.route {
+			t.Errorf("metricsRoute(%q) = %q, want %q", reqURL, got, tt.route)
+		}
+	}
+
+	paths := map[string]string{
+		"/api/pool":                "/api/pool",
+		"/dashboard/api/summary":   "/api/summary",
+		"/api/cluster/user/export": "/api/cluster/user/export",
+		"/admin/user/":             "/admin/user",
+	}
+	for urlPath, want := range paths {
+		req, err := http.NewRequestWithContext(t.Context(), "GET", "https://mgr.example.com:8443"+urlPath, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := metricsRoute(req); got != want {
+			t.Errorf("metricsRoute(%q) = %q, want %q", urlPath, got, want)
+		}
+	}
+}
+
+func TestMetricsTransport(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/pool/rbd":
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/api/pool/missing":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	metrics := newAPIMetrics()
+	client := &http.Client{Transport: &metricsTransport{
+		base: &retryTransport{
+			base:       http.DefaultTransport,
+			maxRetries: defaultMaxRetries,
+			backoff:    time.Millisecond,
+			metrics:    metrics,
+		},
+		metrics: metrics,
+	}}
+	for _, path := range []string{"/api/pool/rbd", "/api/pool/missing", "/api/pool"} {
+		ctx := t.Context()
+		if path != "/api/pool" {
+			ctx = withMetricsRoute(ctx, "/api/pool/{pool_name}")
+		}
+		req, err := http.NewRequestWithContext(ctx, "GET", server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		resp.Body.Close() //nolint:errcheck
+	}
+
+	var output bytes.Buffer
+	if err := metrics.writePrometheus(&output); err != nil {
+		t.Fatalf("writePrometheus() error = %v", err)
+	}
+	for _, want := range []string{
+		`ceph_provider_api_requests_total{method="GET",route="/api/pool",code="200"} 1`,
+		`ceph_provider_api_requests_total{method="GET",route="/api/pool/{pool_name}",code="200"} 1`,
+		`ceph_provider_api_requests_total{method="GET",route="/api/pool/{pool_name}",code="404"} 1`,
+		`ceph_provider_api_retries_total{method="GET",route="/api/pool/{pool_name}"} 1`,
+		`ceph_provider_api_request_duration_seconds_bucket{method="GET",route="/api/pool/{pool_name}",le="+Inf"} 2`,
+		`ceph_provider_api_request_duration_seconds_count{method="GET",route="/api/pool/{pool_name}"} 2`,
+	} {
+		if !strings.Contains(output.String(), want+"\n") {
+			t.Errorf("writePrometheus() output does not contain %q:\n%s", want, output.String())
+		}
+	}
+
+	summary := metrics.summary()
+	if len(summary) != 2 {
+		t.Fatalf("summary() = %q, want 2 endpoints", summary)
+	}
+	for _, line := range summary {
+		if strings.HasPrefix(line, "GET /api/pool/{pool_name}:") && !strings.Contains(line, "2 requests, 1 failed, 1 retries") {
+			t.Errorf("summary() line = %q, want 2 requests, 1 failed, 1 retries", line)
+		}
+	}
+
+	file := filepath.Join(t.TempDir(), "metrics.prom")
+	metrics.setReport(t.Context(), file)
+	metrics.report()
+	written, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("report() did not write the metrics file: %v", err)
+	}
+	if !bytes.Equal(written, output.Bytes()) {
+		t.Errorf("metrics file = %q, want %q", written, output.String())
+	}
+}
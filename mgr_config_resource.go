@@ -0,0 +1,434 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &MgrConfigResource{}
+	_ resource.ResourceWithImportState = &MgrConfigResource{}
+	_ resource.ResourceWithIdentity    = &MgrConfigResource{}
+)
+
+func newMgrConfigResource() resource.Resource {
+	return &MgrConfigResource{}
+}
+
+// MgrConfigResource manages mgr/<module>/<option> entries through the
+// cluster config database. Unlike ceph_mgr_module_config, which goes through
+// the module API and only affects the active mgr, it can target individual
+// mgr daemons and masks.
+type MgrConfigResource struct {
+	client *CephAPIClient
+}
+
+type MgrConfigResourceModel struct {
+	ModuleName types.String `tfsdk:"module_name"`
+	Section    types.String `tfsdk:"section"`
+	Mask       types.String `tfsdk:"mask"`
+	Config     types.Map    `tfsdk:"config"`
+}
+
+type MgrConfigResourceIdentityModel struct {
+	ModuleName types.String `tfsdk:"module_name"`
+	Section    types.String `tfsdk:"section"`
+	Mask       types.String `tfsdk:"mask"`
+}
+
+func (m MgrConfigResourceModel) who() string {
+	if m.Mask.ValueString() == "" {
+		return m.Section.ValueString()
+	}
+	return m.Section.ValueString() + "/" + m.Mask.ValueString()
+}
+
+func (m MgrConfigResourceModel) optionName(name string) string {
+	return "mgr/" + m.ModuleName.ValueString() + "/" + name
+}
+
+func (m MgrConfigResourceModel) identity() MgrConfigResourceIdentityModel {
+	return MgrConfigResourceIdentityModel{ModuleName: m.ModuleName, Section: m.Section, Mask: m.Mask}
+}
+
+func (r *MgrConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mgr_config"
+}
+
+func (r *MgrConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages MGR module options (`mgr/<module>/<option>`) in the cluster configuration database. " +
+			"Unlike `ceph_mgr_module_config`, options can be scoped to a single mgr daemon and restricted with a mask.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"module_name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the MGR module (e.g., 'dashboard', 'prometheus').",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^/]+$`), "must be a module name"),
+				},
+			},
+			"section": resourceSchema.StringAttribute{
+				MarkdownDescription: "The section to apply the options to, either 'mgr' for all mgr daemons or 'mgr.<id>' for a single daemon. Defaults to 'mgr'.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("mgr"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^mgr(\.[^/]+)?$`), "must be 'mgr' or 'mgr.<id>'"),
+				},
+			},
+			"mask": resourceSchema.StringAttribute{
+				MarkdownDescription: "Restricts the options to matching daemons (e.g., 'host:mgr01'). Reading masked configuration requires the provider `cli_backend`, as the dashboard API does not report masks.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"config": resourceSchema.MapAttribute{
+				MarkdownDescription: "Map of module option names, without the `mgr/<module>/` prefix, to values (e.g., `server_port = \"8443\"`).",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^/]+$`), "must be an option name without the mgr/<module>/ prefix"),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *MgrConfigResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"module_name": identityschema.StringAttribute{
+				Description:       "The name of the MGR module",
+				RequiredForImport: true,
+			},
+			"section": identityschema.StringAttribute{
+				Description:       "The mgr section (mgr or mgr.<id>)",
+				OptionalForImport: true,
+			},
+			"mask": identityschema.StringAttribute{
+				Description:       "The configuration mask (e.g. host:mgr01)",
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+func (r *MgrConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *MgrConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data MgrConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Mask.ValueString() != "" {
+		if _, err := r.client.CLI(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("mask"),
+				"CLI Backend Required",
+				fmt.Sprintf("Unable to manage masked configuration: %s", err),
+			)
+			return
+		}
+	}
+
+	var configs map[string]string
+	resp.Diagnostics.Append(data.Config.ElementsAs(ctx, &configs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	who := data.who()
+	var createdConfigs []string
+
+	for name, value := range configs {
+		err := r.client.ClusterUpdateConf(ctx, data.optionName(name), who, value)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to set %s for %s: %s", data.optionName(name), who, err),
+			)
+
+			for _, createdName := range createdConfigs {
+				rollbackErr := r.client.ClusterDeleteConf(ctx, data.optionName(createdName), who)
+				if rollbackErr != nil {
+					resp.Diagnostics.AddError(
+						"Rollback Failed",
+						fmt.Sprintf("Failed to rollback %s for %s: %s. Cluster may be in an inconsistent state. Manual intervention may be required.", data.optionName(createdName), who, rollbackErr),
+					)
+					return
+				}
+			}
+			return
+		}
+
+		createdConfigs = append(createdConfigs, name)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *MgrConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MgrConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var configs map[string]string
+	resp.Diagnostics.Append(data.Config.ElementsAs(ctx, &configs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	moduleConfigs, err := r.moduleConfigs(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read configuration for %s: %s", data.who(), err),
+		)
+		return
+	}
+
+	updatedConfigs := make(map[string]string)
+	for name := range configs {
+		value, ok := moduleConfigs[name]
+		if !ok {
+			resp.Diagnostics.AddWarning(
+				"Configuration Drift Detected",
+				fmt.Sprintf("Configuration %s/%s no longer exists in cluster. Removing from state.", data.who(), data.optionName(name)),
+			)
+			continue
+		}
+		updatedConfigs[name] = value
+	}
+
+	if len(updatedConfigs) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	configValue, diags := types.MapValueFrom(ctx, types.StringType, updatedConfigs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Config = configValue
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *MgrConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var oldData, newData MgrConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &oldData)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &newData)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var oldConfigs, newConfigs map[string]string
+	resp.Diagnostics.Append(oldData.Config.ElementsAs(ctx, &oldConfigs, false)...)
+	resp.Diagnostics.Append(newData.Config.ElementsAs(ctx, &newConfigs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	who := newData.who()
+
+	for name, newValue := range newConfigs {
+		if oldValue, exists := oldConfigs[name]; exists && oldValue == newValue {
+			continue
+		}
+
+		err := r.client.ClusterUpdateConf(ctx, newData.optionName(name), who, newValue)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to set %s for %s: %s", newData.optionName(name), who, err),
+			)
+			return
+		}
+	}
+
+	for name := range oldConfigs {
+		if _, exists := newConfigs[name]; exists {
+			continue
+		}
+
+		err := r.client.ClusterDeleteConf(ctx, newData.optionName(name), who)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to remove %s for %s: %s. Update operation failed.", newData.optionName(name), who, err),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newData)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newData.identity())...)
+}
+
+func (r *MgrConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data MgrConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var configs map[string]string
+	resp.Diagnostics.Append(data.Config.ElementsAs(ctx, &configs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name := range configs {
+		err := r.client.ClusterDeleteConf(ctx, data.optionName(name), data.who())
+		if err != nil && !isCephAPINotFound(err) {
+			resp.Diagnostics.AddWarning(
+				"API Request Warning",
+				fmt.Sprintf("Unable to remove %s for %s: %s. Continuing with remaining deletions.", data.optionName(name), data.who(), err),
+			)
+		}
+	}
+}
+
+func (r *MgrConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var moduleName, section, mask string
+	if req.ID == "" && req.Identity != nil {
+		var identity MgrConfigResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		moduleName = identity.ModuleName.ValueString()
+		section = identity.Section.ValueString()
+		mask = identity.Mask.ValueString()
+	} else {
+		parts := strings.SplitN(strings.TrimSpace(req.ID), "/", 3)
+		moduleName = parts[0]
+		if len(parts) > 1 {
+			section = parts[1]
+		}
+		if len(parts) > 2 {
+			mask = parts[2]
+		}
+	}
+
+	if section == "" {
+		section = "mgr"
+	}
+
+	if moduleName == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in format 'module_name', 'module_name/section' or 'module_name/section/mask', got: %s", req.ID),
+		)
+		return
+	}
+
+	data := MgrConfigResourceModel{
+		ModuleName: types.StringValue(moduleName),
+		Section:    types.StringValue(section),
+		Mask:       types.StringNull(),
+	}
+	if mask != "" {
+		data.Mask = types.StringValue(mask)
+	}
+
+	moduleConfigs, err := r.moduleConfigs(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read configuration for %s during import: %s", data.who(), err),
+		)
+		return
+	}
+
+	if len(moduleConfigs) == 0 {
+		resp.Diagnostics.AddError(
+			"No Configurations Found",
+			fmt.Sprintf("No mgr/%s/ options are set for '%s'.", moduleName, data.who()),
+		)
+		return
+	}
+
+	configValue, diags := types.MapValueFrom(ctx, types.StringType, moduleConfigs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Config = configValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// moduleConfigs returns the options set for the resource's module in its
+// section and mask, keyed by option name without the mgr/<module>/ prefix.
+func (r *MgrConfigResource) moduleConfigs(ctx context.Context, data MgrConfigResourceModel) (map[string]string, error) {
+	values, err := listSectionConfig(ctx, r.client, data.Section.ValueString(), data.Mask.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := data.optionName("")
+	moduleConfigs := make(map[string]string)
+	for name, value := range values {
+		if option, ok := strings.CutPrefix(name, prefix); ok {
+			moduleConfigs[option] = value
+		}
+	}
+
+	return moduleConfigs, nil
+}
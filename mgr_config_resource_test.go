@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func testAccCheckCephMgrConfigDestroy(t *testing.T) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := t.Context()
+
+		configDump, err := cephTestClusterCLI.ConfigDump(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get config dump: %w", err)
+		}
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "ceph_mgr_config" {
+				continue
+			}
+
+			moduleName := rs.Primary.Attributes["module_name"]
+			section := rs.Primary.Attributes["section"]
+			mask := rs.Primary.Attributes["mask"]
+
+			for key := range rs.Primary.Attributes {
+				if !strings.HasPrefix(key, "config.") || key == "config.%" {
+					continue
+				}
+
+				fullKey := fmt.Sprintf("mgr/%s/%s", moduleName, strings.TrimPrefix(key, "config."))
+
+				for _, entry := range configDump {
+					if entry.Section == section && entry.Mask == mask && entry.Name == fullKey {
+						return fmt.Errorf("config %s still exists for %s after destroy", fullKey, section)
+					}
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestAccCephMgrConfigResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	contact1 := acctest.RandomWithPrefix("contact")
+	contact2 := acctest.RandomWithPrefix("contact")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephMgrConfigDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_mgr_config" "test" {
+						module_name = "telemetry"
+						config = {
+							contact = %q
+						}
+					}
+				`, contact1),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_mgr_config.test",
+						tfjsonpath.New("section"),
+						knownvalue.StringExact("mgr"),
+					),
+					statecheck.ExpectKnownValue(
+						"ceph_mgr_config.test",
+						tfjsonpath.New("config"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"contact": knownvalue.StringExact(contact1),
+						}),
+					),
+				},
+				Check: func(s *terraform.State) error {
+					value, err := cephTestClusterCLI.ConfigGetFromDump(t.Context(), "mgr", "mgr/telemetry/contact")
+					if err != nil {
+						return err
+					}
+					if value != contact1 {
+						return fmt.Errorf("expected mgr/telemetry/contact to be %q, got %q", contact1, value)
+					}
+					return nil
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_mgr_config" "test" {
+						module_name = "telemetry"
+						config = {
+							contact     = %q
+							description = "terraform acceptance test"
+						}
+					}
+				`, contact2),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_mgr_config.test",
+						tfjsonpath.New("config"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"contact":     knownvalue.StringExact(contact2),
+							"description": knownvalue.StringExact("terraform acceptance test"),
+						}),
+					),
+				},
+			},
+			{
+				ConfigVariables:                      testAccProviderConfig(),
+				ResourceName:                         "ceph_mgr_config.test",
+				ImportState:                          true,
+				ImportStateId:                        "telemetry/mgr",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "module_name",
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_mgr_config" "test" {
+						module_name = "telemetry"
+						config = {
+							contact     = %q
+							description = "terraform acceptance test"
+						}
+					}
+				`, contact2),
+			},
+		},
+	})
+}
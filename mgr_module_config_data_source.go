@@ -73,6 +73,8 @@ func (d *MgrModuleConfigDataSource) Configure(ctx context.Context, req datasourc
 }
 
 func (d *MgrModuleConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withReadCache(ctx)
+
 	var data MgrModuleConfigDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
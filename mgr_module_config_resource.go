@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -15,6 +16,7 @@ import (
 var (
 	_ resource.Resource                = &MgrModuleConfigResource{}
 	_ resource.ResourceWithImportState = &MgrModuleConfigResource{}
+	_ resource.ResourceWithIdentity    = &MgrModuleConfigResource{}
 )
 
 func newMgrModuleConfigResource() resource.Resource {
@@ -31,6 +33,10 @@ type MgrModuleConfigResourceModel struct {
 	ID         types.String `tfsdk:"id"`
 }
 
+type MgrModuleConfigResourceIdentityModel struct {
+	ModuleName types.String `tfsdk:"module_name"`
+}
+
 func (r *MgrModuleConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_mgr_module_config"
 }
@@ -65,6 +71,17 @@ func (r *MgrModuleConfigResource) Schema(ctx context.Context, req resource.Schem
 	}
 }
 
+func (r *MgrModuleConfigResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"module_name": identityschema.StringAttribute{
+				Description:       "The name of the MGR module",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
 func (r *MgrModuleConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -149,6 +166,7 @@ func (r *MgrModuleConfigResource) Create(ctx context.Context, req resource.Creat
 	data.Configs = configsValue
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MgrModuleConfigResourceIdentityModel{ModuleName: data.ModuleName})...)
 }
 
 func (r *MgrModuleConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -201,6 +219,7 @@ func (r *MgrModuleConfigResource) Read(ctx context.Context, req resource.ReadReq
 	data.Configs = configsValue
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MgrModuleConfigResourceIdentityModel{ModuleName: data.ModuleName})...)
 }
 
 func (r *MgrModuleConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -267,6 +286,7 @@ func (r *MgrModuleConfigResource) Update(ctx context.Context, req resource.Updat
 	data.Configs = configsValue
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MgrModuleConfigResourceIdentityModel{ModuleName: data.ModuleName})...)
 }
 
 func (r *MgrModuleConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -290,7 +310,7 @@ func (r *MgrModuleConfigResource) Delete(ctx context.Context, req resource.Delet
 		configName := fmt.Sprintf("mgr/%s/%s", moduleName, key)
 
 		err := r.client.ClusterDeleteConf(ctx, configName, "mgr")
-		if err != nil {
+		if err != nil && !isCephAPINotFound(err) {
 			resp.Diagnostics.AddError(
 				"API Request Error",
 				fmt.Sprintf("Unable to delete MGR module config '%s': %s", configName, err),
@@ -302,6 +322,14 @@ func (r *MgrModuleConfigResource) Delete(ctx context.Context, req resource.Delet
 
 func (r *MgrModuleConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	moduleName := req.ID
+	if moduleName == "" && req.Identity != nil {
+		var identity MgrModuleConfigResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		moduleName = identity.ModuleName.ValueString()
+	}
 
 	readConfigs, err := r.client.MgrGetModuleConfig(ctx, moduleName)
 	if err != nil {
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// mgrModuleOption ties a mgr module option to the model field holding it, for
+// resources that manage a fixed set of options of one module. value is a
+// *types.Bool, *types.Int64, *types.Float64 or *types.String; a null field
+// leaves the option unmanaged.
+type mgrModuleOption struct {
+	name  string
+	value any
+}
+
+func (o mgrModuleOption) isNull() bool {
+	switch v := o.value.(type) {
+	case *types.Bool:
+		return v.IsNull()
+	case *types.Int64:
+		return v.IsNull()
+	case *types.Float64:
+		return v.IsNull()
+	case *types.String:
+		return v.IsNull()
+	}
+	return true
+}
+
+func (o mgrModuleOption) format() string {
+	switch v := o.value.(type) {
+	case *types.Bool:
+		return strconv.FormatBool(v.ValueBool())
+	case *types.Int64:
+		return strconv.FormatInt(v.ValueInt64(), 10)
+	case *types.Float64:
+		return strconv.FormatFloat(v.ValueFloat64(), 'g', -1, 64)
+	case *types.String:
+		return v.ValueString()
+	}
+	return ""
+}
+
+// parse stores a value as returned by the module config API in the field.
+func (o mgrModuleOption) parse(val any) error {
+	value, err := formatMgrModuleConfigValue(val)
+	if err != nil {
+		return err
+	}
+
+	switch v := o.value.(type) {
+	case *types.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*v = types.BoolValue(b)
+	case *types.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		*v = types.Int64Value(n)
+	case *types.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*v = types.Float64Value(f)
+	case *types.String:
+		*v = types.StringValue(value)
+	}
+	return nil
+}
+
+// applyMgrModuleOptions sets every option that is configured in options and
+// resets the ones that were managed in prior but are no longer configured.
+// Both slices must list the same options in the same order.
+func applyMgrModuleOptions(ctx context.Context, client *CephAPIClient, moduleName string, options, prior []mgrModuleOption) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	moduleConfig := CephAPIMgrModuleConfig{}
+	var reset []string
+
+	for i, option := range options {
+		switch {
+		case !option.isNull():
+			moduleConfig[option.name] = option.format()
+		case !prior[i].isNull():
+			reset = append(reset, option.name)
+		}
+	}
+
+	if len(moduleConfig) > 0 {
+		if err := client.MgrSetModuleConfig(ctx, moduleName, moduleConfig); err != nil {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to configure the %s module: %s", moduleName, err),
+			)
+			return diags
+		}
+	}
+
+	for _, name := range reset {
+		configName := "mgr/" + moduleName + "/" + name
+		err := client.ClusterDeleteConf(ctx, configName, "mgr")
+		if err != nil && !isCephAPINotFound(err) {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to reset '%s': %s", configName, err),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// readMgrModuleOptions refreshes the managed options from the module config.
+func readMgrModuleOptions(ctx context.Context, client *CephAPIClient, moduleName string, options []mgrModuleOption) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	moduleConfig, err := client.MgrGetModuleConfig(ctx, moduleName)
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read the %s module config: %s", moduleName, err),
+		)
+		return diags
+	}
+
+	for _, option := range options {
+		if option.isNull() {
+			continue
+		}
+
+		if err := option.parse(moduleConfig[option.name]); err != nil {
+			diags.AddError(
+				"Configuration Value Formatting Error",
+				fmt.Sprintf("Unable to parse %s option '%s': %s", moduleName, option.name, err),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &MgrServicesDataSource{}
+
+func newMgrServicesDataSource() datasource.DataSource {
+	return &MgrServicesDataSource{}
+}
+
+type MgrServicesDataSource struct {
+	client *CephAPIClient
+}
+
+type MgrServicesDataSourceModel struct {
+	ActiveName    types.String `tfsdk:"active_name"`
+	Available     types.Bool   `tfsdk:"available"`
+	Standbys      types.List   `tfsdk:"standbys"`
+	Services      types.Map    `tfsdk:"services"`
+	DashboardURL  types.String `tfsdk:"dashboard_url"`
+	PrometheusURL types.String `tfsdk:"prometheus_url"`
+}
+
+func (d *MgrServicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mgr_services"
+}
+
+func (d *MgrServicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source reads the active and standby mgr daemons and the URLs their modules serve on (equivalent to `ceph mgr services`), so that outputs and other resources can point at the current active endpoints. " +
+			"The URLs move to another host when a standby takes over.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"active_name": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the active mgr daemon",
+				Computed:            true,
+			},
+			"available": dataSourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether the active mgr has finished starting up",
+				Computed:            true,
+			},
+			"standbys": dataSourceSchema.ListAttribute{
+				MarkdownDescription: "The names of the standby mgr daemons, sorted.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"services": dataSourceSchema.MapAttribute{
+				MarkdownDescription: "The URLs the active mgr serves, keyed by module, e.g. `dashboard` and `prometheus`",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"dashboard_url": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The dashboard URL. Null if the dashboard module is not serving.",
+				Computed:            true,
+			},
+			"prometheus_url": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The URL of the prometheus module metrics exporter. Null if the prometheus module is not serving.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *MgrServicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *MgrServicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MgrServicesDataSourceModel
+
+	health, err := d.client.GetHealthFull(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read the mgr map: %s", err),
+		)
+		return
+	}
+	mgrMap := health.MgrMap
+
+	standbys := make([]string, 0, len(mgrMap.Standbys))
+	for _, standby := range mgrMap.Standbys {
+		standbys = append(standbys, standby.Name)
+	}
+	slices.Sort(standbys)
+
+	services := mgrMap.Services
+	if services == nil {
+		services = map[string]string{}
+	}
+
+	data.ActiveName = types.StringValue(mgrMap.ActiveName)
+	data.Available = types.BoolValue(mgrMap.Available)
+	data.DashboardURL = mgrServiceURL(services, "dashboard")
+	data.PrometheusURL = mgrServiceURL(services, "prometheus")
+
+	listValue, diags := types.ListValueFrom(ctx, types.StringType, standbys)
+	resp.Diagnostics.Append(diags...)
+	data.Standbys = listValue
+
+	mapValue, diags := types.MapValueFrom(ctx, types.StringType, services)
+	resp.Diagnostics.Append(diags...)
+	data.Services = mapValue
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func mgrServiceURL(services map[string]string, module string) types.String {
+	if url, ok := services[module]; ok && url != "" {
+		return types.StringValue(url)
+	}
+	return types.StringNull()
+}
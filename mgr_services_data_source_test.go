@@ -0,0 +1,38 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephMgrServicesDataSource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					data "ceph_mgr_services" "test" {}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("data.ceph_mgr_services.test", tfjsonpath.New("active_name"), knownvalue.StringExact("mgr1")),
+					statecheck.ExpectKnownValue("data.ceph_mgr_services.test", tfjsonpath.New("available"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("data.ceph_mgr_services.test", tfjsonpath.New("standbys"), knownvalue.ListExact([]knownvalue.Check{})),
+					statecheck.ExpectKnownValue("data.ceph_mgr_services.test", tfjsonpath.New("dashboard_url"), knownvalue.StringRegexp(regexp.MustCompile(`^https?://`))),
+					statecheck.ExpectKnownValue("data.ceph_mgr_services.test", tfjsonpath.New("services").AtMapKey("dashboard"), knownvalue.StringRegexp(regexp.MustCompile(`^https?://`))),
+				},
+			},
+		},
+	})
+}
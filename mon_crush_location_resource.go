@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &MonCrushLocationResource{}
+	_ resource.ResourceWithImportState = &MonCrushLocationResource{}
+	_ resource.ResourceWithIdentity    = &MonCrushLocationResource{}
+)
+
+func newMonCrushLocationResource() resource.Resource {
+	return &MonCrushLocationResource{}
+}
+
+// MonCrushLocationResource manages the CRUSH location of a monitor. The
+// dashboard API does not expose it, so it always goes through the ceph CLI.
+type MonCrushLocationResource struct {
+	client *CephAPIClient
+}
+
+type MonCrushLocationResourceModel struct {
+	Name     types.String `tfsdk:"name"`
+	Location types.Map    `tfsdk:"location"`
+}
+
+type MonCrushLocationResourceIdentityModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+func (r *MonCrushLocationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mon_crush_location"
+}
+
+func (r *MonCrushLocationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the CRUSH location of a Ceph monitor, which the `connectivity` election strategy and stretch mode use to tell sites apart. " +
+			"Monitor locations are only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled. " +
+			"Ceph cannot clear a monitor location, so destroying the resource leaves it in place.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the monitor",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"location": resourceSchema.MapAttribute{
+				MarkdownDescription: "The CRUSH location as bucket type to bucket name, e.g. `{ datacenter = \"site1\" }`",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+					mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+func (r *MonCrushLocationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				Description:       "The name of the monitor",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *MonCrushLocationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *MonCrushLocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data MonCrushLocationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setLocation(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MonCrushLocationResourceIdentityModel{Name: data.Name})...)
+}
+
+func (r *MonCrushLocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MonCrushLocationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read monitor location: %s", err),
+		)
+		return
+	}
+
+	monDump, err := cli.MonDump(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read monitor location: %s", err),
+		)
+		return
+	}
+
+	var mon *MonDumpMon
+	for i := range monDump.Mons {
+		if monDump.Mons[i].Name == data.Name.ValueString() {
+			mon = &monDump.Mons[i]
+			break
+		}
+	}
+
+	if mon == nil {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Monitor %s", data.Name.ValueString()))
+		return
+	}
+
+	locationValue, diags := types.MapValueFrom(ctx, types.StringType, parseMonCrushLocation(mon.CrushLocation))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Location = locationValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MonCrushLocationResourceIdentityModel{Name: data.Name})...)
+}
+
+func (r *MonCrushLocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data MonCrushLocationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setLocation(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MonCrushLocationResourceIdentityModel{Name: data.Name})...)
+}
+
+// Ceph has no command to clear a monitor location, so Delete only forgets it.
+func (r *MonCrushLocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *MonCrushLocationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("name"), path.Root("name"), req, resp)
+}
+
+func (r *MonCrushLocationResource) setLocation(ctx context.Context, data MonCrushLocationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		diags.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to set monitor location: %s", err),
+		)
+		return diags
+	}
+
+	location := make(map[string]string, len(data.Location.Elements()))
+	for key, value := range data.Location.Elements() {
+		location[key] = value.(types.String).ValueString()
+	}
+
+	if err := cli.MonSetLocation(ctx, data.Name.ValueString(), location); err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to set monitor location: %s", err),
+		)
+	}
+	return diags
+}
+
+// parseMonCrushLocation parses a location as printed in the mon map, e.g.
+// "{datacenter=site1,host=mon1}".
+func parseMonCrushLocation(s string) map[string]string {
+	location := map[string]string{}
+	s = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(s), "{"), "}")
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" {
+			continue
+		}
+		location[key] = value
+	}
+	return location
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestParseMonCrushLocation(t *testing.T) {
+	tests := []struct {
+		in   string
+		want map[string]string
+	}{
+		{"{}", map[string]string{}},
+		{"", map[string]string{}},
+		{"{datacenter=site1}", map[string]string{"datacenter": "site1"}},
+		{"{datacenter=site1,host=mon1}", map[string]string{"datacenter": "site1", "host": "mon1"}},
+	}
+
+	for _, tt := range tests {
+		if got := parseMonCrushLocation(tt.in); !maps.Equal(got, tt.want) {
+			t.Errorf("parseMonCrushLocation(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// The test cluster has a single monitor, which cannot be removed out of band.
+func TestAccCephMonCrushLocationResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_mon_crush_location" "test" {
+					  name     = "mon1"
+					  location = { datacenter = "site1" }
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					resource "ceph_mon_crush_location" "test" {
+					  name     = "mon1"
+					  location = { datacenter = "site1" }
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_mon_crush_location.test", tfjsonpath.New("location"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"datacenter": knownvalue.StringExact("site1"),
+					})),
+					statecheck.ExpectIdentity("ceph_mon_crush_location.test", map[string]knownvalue.Check{
+						"name": knownvalue.StringExact("mon1"),
+					}),
+				},
+				Check: checkCephMonCrushLocation(t, "mon1", "{datacenter=site1}"),
+			},
+			{
+				ConfigVariables:                      configVariables,
+				ResourceName:                         "ceph_mon_crush_location.test",
+				ImportState:                          true,
+				ImportStateId:                        "mon1",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					resource "ceph_mon_crush_location" "test" {
+					  name     = "mon1"
+					  location = { datacenter = "site2", host = "mon1" }
+					}
+				`,
+				Check: checkCephMonCrushLocation(t, "mon1", "{datacenter=site2,host=mon1}"),
+			},
+		},
+	})
+}
+
+func checkCephMonCrushLocation(t *testing.T, name, expected string) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		monDump, err := cephTestClusterCLI.MonDump(t.Context())
+		if err != nil {
+			return err
+		}
+		for _, mon := range monDump.Mons {
+			if mon.Name == name {
+				if mon.CrushLocation != expected {
+					return fmt.Errorf("mon %s crush_location = %q, want %q", name, mon.CrushLocation, expected)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("mon %s not found in mon map", name)
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const monElectionStrategyResourceID = "mon_election_strategy"
+
+// monElectionStrategies maps the election_strategy number in the mon map to
+// the name "ceph mon set election_strategy" takes.
+var monElectionStrategies = map[int]string{
+	1: "classic",
+	2: "disallow",
+	3: "connectivity",
+}
+
+var (
+	_ resource.Resource                = &MonElectionStrategyResource{}
+	_ resource.ResourceWithImportState = &MonElectionStrategyResource{}
+)
+
+func newMonElectionStrategyResource() resource.Resource {
+	return &MonElectionStrategyResource{}
+}
+
+// MonElectionStrategyResource manages how the monitors elect a leader. The
+// dashboard API does not expose it, so it always goes through the ceph CLI.
+type MonElectionStrategyResource struct {
+	client *CephAPIClient
+}
+
+type MonElectionStrategyResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Strategy types.String `tfsdk:"strategy"`
+}
+
+func (r *MonElectionStrategyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mon_election_strategy"
+}
+
+func (r *MonElectionStrategyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the monitor election strategy. Stretch mode requires `connectivity`. " +
+			"The election strategy is only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled. " +
+			"There is one strategy per cluster, so declare this resource at most once. Destroying it restores the `classic` strategy.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `mon_election_strategy`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"strategy": resourceSchema.StringAttribute{
+				MarkdownDescription: "The election strategy: `classic`, `disallow` or `connectivity`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("classic", "disallow", "connectivity"),
+				},
+			},
+		},
+	}
+}
+
+func (r *MonElectionStrategyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *MonElectionStrategyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data MonElectionStrategyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setStrategy(ctx, data.Strategy.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(monElectionStrategyResourceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MonElectionStrategyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MonElectionStrategyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read mon election strategy: %s", err),
+		)
+		return
+	}
+
+	monDump, err := cli.MonDump(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read mon election strategy: %s", err),
+		)
+		return
+	}
+
+	strategy, ok := monElectionStrategies[monDump.ElectionStrategy]
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Election Strategy",
+			fmt.Sprintf("The mon map reports unknown election strategy %d", monDump.ElectionStrategy),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(monElectionStrategyResourceID)
+	data.Strategy = types.StringValue(strategy)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MonElectionStrategyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data MonElectionStrategyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setStrategy(ctx, data.Strategy.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(monElectionStrategyResourceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MonElectionStrategyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.setStrategy(ctx, "classic")...)
+}
+
+func (r *MonElectionStrategyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != monElectionStrategyResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", monElectionStrategyResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), monElectionStrategyResourceID)...)
+}
+
+func (r *MonElectionStrategyResource) setStrategy(ctx context.Context, strategy string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		diags.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to set mon election strategy: %s", err),
+		)
+		return diags
+	}
+
+	if err := cli.MonSetElectionStrategy(ctx, strategy); err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to set mon election strategy: %s", err),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephMonElectionStrategyResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             checkCephMonElectionStrategy(t, 1),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_mon_election_strategy" "test" {
+					  strategy = "connectivity"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					resource "ceph_mon_election_strategy" "test" {
+					  strategy = "connectivity"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_mon_election_strategy.test", tfjsonpath.New("strategy"), knownvalue.StringExact("connectivity")),
+				},
+				Check: checkCephMonElectionStrategy(t, 3),
+			},
+			{
+				ConfigVariables:   configVariables,
+				ResourceName:      "ceph_mon_election_strategy.test",
+				ImportState:       true,
+				ImportStateId:     "mon_election_strategy",
+				ImportStateVerify: true,
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					resource "ceph_mon_election_strategy" "test" {
+					  strategy = "disallow"
+					}
+				`,
+				Check: checkCephMonElectionStrategy(t, 2),
+			},
+		},
+	})
+}
+
+func checkCephMonElectionStrategy(t *testing.T, expected int) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		monDump, err := cephTestClusterCLI.MonDump(t.Context())
+		if err != nil {
+			return err
+		}
+		if monDump.ElectionStrategy != expected {
+			return fmt.Errorf("election_strategy = %d, want %d", monDump.ElectionStrategy, expected)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &NFSClustersDataSource{}
+
+func newNFSClustersDataSource() datasource.DataSource {
+	return &NFSClustersDataSource{}
+}
+
+type NFSClustersDataSource struct {
+	client *CephAPIClient
+}
+
+type NFSClustersDataSourceModel struct {
+	Clusters types.List `tfsdk:"clusters"`
+}
+
+func (d *NFSClustersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nfs_clusters"
+}
+
+func (d *NFSClustersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source lists the NFS Ganesha clusters (equivalent to `ceph nfs cluster ls`). Listing them requires an orchestrator backend.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"clusters": dataSourceSchema.ListAttribute{
+				MarkdownDescription: "The NFS cluster IDs, sorted.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *NFSClustersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NFSClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withReadCache(ctx)
+
+	var data NFSClustersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusters, err := d.client.ListNFSClusters(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to list NFS clusters from Ceph API: %s", err),
+		)
+		return
+	}
+	slices.Sort(clusters)
+
+	clustersValue, diags := types.ListValueFrom(ctx, types.StringType, clusters)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Clusters = clustersValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
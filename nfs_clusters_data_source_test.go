@@ -0,0 +1,31 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The nfs module lists clusters through the orchestrator, which the test
+// cluster does not have.
+func TestAccCephNFSClustersDataSource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					data "ceph_nfs_clusters" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`Unable to list NFS clusters`),
+			},
+		},
+	})
+}
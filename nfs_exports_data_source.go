@@ -0,0 +1,231 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &NFSExportsDataSource{}
+
+func newNFSExportsDataSource() datasource.DataSource {
+	return &NFSExportsDataSource{}
+}
+
+type NFSExportsDataSource struct {
+	client *CephAPIClient
+}
+
+type NFSExportsDataSourceModel struct {
+	ClusterID types.String    `tfsdk:"cluster_id"`
+	Exports   []NFSExportItem `tfsdk:"exports"`
+}
+
+type NFSExportItem struct {
+	ExportID      types.Int64       `tfsdk:"export_id"`
+	ClusterID     types.String      `tfsdk:"cluster_id"`
+	Path          types.String      `tfsdk:"path"`
+	Pseudo        types.String      `tfsdk:"pseudo"`
+	AccessType    types.String      `tfsdk:"access_type"`
+	Squash        types.String      `tfsdk:"squash"`
+	SecurityLabel types.Bool        `tfsdk:"security_label"`
+	Protocols     []types.Int64     `tfsdk:"protocols"`
+	Transports    []types.String    `tfsdk:"transports"`
+	FSALName      types.String      `tfsdk:"fsal_name"`
+	FsName        types.String      `tfsdk:"fs_name"`
+	UserID        types.String      `tfsdk:"user_id"`
+	Clients       []NFSExportClient `tfsdk:"clients"`
+}
+
+type NFSExportClient struct {
+	Addresses  []types.String `tfsdk:"addresses"`
+	AccessType types.String   `tfsdk:"access_type"`
+	Squash     types.String   `tfsdk:"squash"`
+}
+
+func (d *NFSExportsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nfs_exports"
+}
+
+func (d *NFSExportsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source lists NFS Ganesha exports (equivalent to `ceph nfs export ls --detailed`). RGW export credentials are not included.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"cluster_id": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "Optional filter to only return the exports of one NFS cluster",
+				Optional:            true,
+			},
+			"exports": dataSourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The exports, ordered by cluster and export ID.",
+				Computed:            true,
+				NestedObject: dataSourceSchema.NestedAttributeObject{
+					Attributes: map[string]dataSourceSchema.Attribute{
+						"export_id": dataSourceSchema.Int64Attribute{
+							MarkdownDescription: "The export ID, unique within the cluster.",
+							Computed:            true,
+						},
+						"cluster_id": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The NFS cluster serving the export.",
+							Computed:            true,
+						},
+						"path": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The exported CephFS path or RGW bucket.",
+							Computed:            true,
+						},
+						"pseudo": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The NFSv4 pseudo path clients mount.",
+							Computed:            true,
+						},
+						"access_type": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The default access type (e.g., 'RW', 'RO', 'NONE').",
+							Computed:            true,
+						},
+						"squash": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The default squash mode (e.g., 'none', 'root_squash').",
+							Computed:            true,
+						},
+						"security_label": dataSourceSchema.BoolAttribute{
+							MarkdownDescription: "Whether security labels are enabled.",
+							Computed:            true,
+						},
+						"protocols": dataSourceSchema.ListAttribute{
+							MarkdownDescription: "The NFS protocol versions.",
+							Computed:            true,
+							ElementType:         types.Int64Type,
+						},
+						"transports": dataSourceSchema.ListAttribute{
+							MarkdownDescription: "The transports (e.g., 'TCP', 'UDP').",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"fsal_name": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The backend of the export, 'CEPH' or 'RGW'.",
+							Computed:            true,
+						},
+						"fs_name": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The exported CephFS filesystem. Empty for RGW exports.",
+							Computed:            true,
+						},
+						"user_id": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The cephx or RGW user the export accesses storage as.",
+							Computed:            true,
+						},
+						"clients": dataSourceSchema.ListNestedAttribute{
+							MarkdownDescription: "Per-client overrides of the access type and squash mode.",
+							Computed:            true,
+							NestedObject: dataSourceSchema.NestedAttributeObject{
+								Attributes: map[string]dataSourceSchema.Attribute{
+									"addresses": dataSourceSchema.ListAttribute{
+										MarkdownDescription: "The client addresses or networks.",
+										Computed:            true,
+										ElementType:         types.StringType,
+									},
+									"access_type": dataSourceSchema.StringAttribute{
+										MarkdownDescription: "The access type of these clients.",
+										Computed:            true,
+									},
+									"squash": dataSourceSchema.StringAttribute{
+										MarkdownDescription: "The squash mode of these clients.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *NFSExportsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NFSExportsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withReadCache(ctx)
+
+	var data NFSExportsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exports, err := d.client.ListNFSExports(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to list NFS exports from Ceph API: %s", err),
+		)
+		return
+	}
+
+	slices.SortFunc(exports, func(a, b CephAPINFSExport) int {
+		return cmp.Or(cmp.Compare(a.ClusterID, b.ClusterID), cmp.Compare(a.ExportID, b.ExportID))
+	})
+
+	data.Exports = []NFSExportItem{}
+	for _, export := range exports {
+		if !data.ClusterID.IsNull() && export.ClusterID != data.ClusterID.ValueString() {
+			continue
+		}
+
+		item := NFSExportItem{
+			ExportID:      types.Int64Value(int64(export.ExportID)),
+			ClusterID:     types.StringValue(export.ClusterID),
+			Path:          types.StringValue(export.Path),
+			Pseudo:        types.StringValue(export.Pseudo),
+			AccessType:    types.StringValue(export.AccessType),
+			Squash:        types.StringValue(export.Squash),
+			SecurityLabel: types.BoolValue(export.SecurityLabel),
+			Protocols:     []types.Int64{},
+			Transports:    []types.String{},
+			FSALName:      types.StringValue(export.FSAL.Name),
+			FsName:        types.StringValue(export.FSAL.FsName),
+			UserID:        types.StringValue(export.FSAL.UserID),
+			Clients:       []NFSExportClient{},
+		}
+		for _, protocol := range export.Protocols {
+			item.Protocols = append(item.Protocols, types.Int64Value(int64(protocol)))
+		}
+		for _, transport := range export.Transports {
+			item.Transports = append(item.Transports, types.StringValue(transport))
+		}
+		for _, client := range export.Clients {
+			addresses := []types.String{}
+			for _, address := range client.Addresses {
+				addresses = append(addresses, types.StringValue(address))
+			}
+			item.Clients = append(item.Clients, NFSExportClient{
+				Addresses:  addresses,
+				AccessType: types.StringValue(client.AccessType),
+				Squash:     types.StringValue(client.Squash),
+			})
+		}
+
+		data.Exports = append(data.Exports, item)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
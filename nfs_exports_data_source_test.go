@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The test cluster runs no NFS Ganesha, so there are no exports to list.
+func TestAccCephNFSExportsDataSource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					data "ceph_nfs_exports" "all" {}
+
+					data "ceph_nfs_exports" "filtered" {
+					  cluster_id = "missing"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_nfs_exports.all", "exports.#", "0"),
+					resource.TestCheckResourceAttr("data.ceph_nfs_exports.filtered", "exports.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &OrchDaemonsDataSource{}
+
+func newOrchDaemonsDataSource() datasource.DataSource {
+	return &OrchDaemonsDataSource{}
+}
+
+type OrchDaemonsDataSource struct {
+	client *CephAPIClient
+}
+
+type OrchDaemonsDataSourceModel struct {
+	ServiceName types.String     `tfsdk:"service_name"`
+	DaemonType  types.String     `tfsdk:"daemon_type"`
+	Hostname    types.String     `tfsdk:"hostname"`
+	Daemons     []OrchDaemonItem `tfsdk:"daemons"`
+}
+
+type OrchDaemonItem struct {
+	Name        types.String `tfsdk:"name"`
+	DaemonType  types.String `tfsdk:"daemon_type"`
+	DaemonID    types.String `tfsdk:"daemon_id"`
+	ServiceName types.String `tfsdk:"service_name"`
+	Hostname    types.String `tfsdk:"hostname"`
+	Status      types.String `tfsdk:"status"`
+	Version     types.String `tfsdk:"version"`
+}
+
+func (d *OrchDaemonsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_orch_daemons"
+}
+
+func (d *OrchDaemonsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source lists the daemons the orchestrator manages (equivalent to `ceph orch ps`). " +
+			"The orchestrator is only available through the ceph CLI, so this data source requires the provider `cli_backend` to be enabled.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"service_name": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "Optional filter to only return the daemons of one service, e.g. `rgw.default`",
+				Optional:            true,
+			},
+			"daemon_type": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "Optional filter to only return daemons of one type, e.g. `osd`",
+				Optional:            true,
+			},
+			"hostname": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "Optional filter to only return the daemons on one host",
+				Optional:            true,
+			},
+			"daemons": dataSourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The daemons, ordered by name.",
+				Computed:            true,
+				NestedObject: dataSourceSchema.NestedAttributeObject{
+					Attributes: map[string]dataSourceSchema.Attribute{
+						"name": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The daemon name, e.g. `osd.3`.",
+							Computed:            true,
+						},
+						"daemon_type": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The daemon type, e.g. `osd`.",
+							Computed:            true,
+						},
+						"daemon_id": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The daemon ID, e.g. `3`.",
+							Computed:            true,
+						},
+						"service_name": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The service the daemon belongs to.",
+							Computed:            true,
+						},
+						"hostname": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The host the daemon runs on.",
+							Computed:            true,
+						},
+						"status": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The daemon status, e.g. `running`, `stopped` or `error`.",
+							Computed:            true,
+						},
+						"version": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The Ceph version the daemon runs. Empty for daemons that are not part of Ceph, such as `node-exporter`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrchDaemonsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrchDaemonsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrchDaemonsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := d.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to list orchestrator daemons: %s", err),
+		)
+		return
+	}
+
+	daemons, err := cli.OrchPs(ctx, OrchPsOptions{
+		ServiceName: data.ServiceName.ValueString(),
+		DaemonType:  data.DaemonType.ValueString(),
+		Hostname:    data.Hostname.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to list orchestrator daemons: %s", err),
+		)
+		return
+	}
+
+	slices.SortFunc(daemons, func(a, b OrchDaemon) int {
+		return cmp.Compare(a.DaemonName, b.DaemonName)
+	})
+
+	data.Daemons = []OrchDaemonItem{}
+	for _, daemon := range daemons {
+		data.Daemons = append(data.Daemons, OrchDaemonItem{
+			Name:        types.StringValue(daemon.DaemonName),
+			DaemonType:  types.StringValue(daemon.DaemonType),
+			DaemonID:    types.StringValue(daemon.DaemonID),
+			ServiceName: types.StringValue(daemon.ServiceName),
+			Hostname:    types.StringValue(daemon.Hostname),
+			Status:      types.StringValue(daemon.StatusDesc),
+			Version:     types.StringValue(daemon.Version),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &OrchServicesDataSource{}
+
+func newOrchServicesDataSource() datasource.DataSource {
+	return &OrchServicesDataSource{}
+}
+
+type OrchServicesDataSource struct {
+	client *CephAPIClient
+}
+
+type OrchServicesDataSourceModel struct {
+	ServiceType types.String      `tfsdk:"service_type"`
+	Services    []OrchServiceItem `tfsdk:"services"`
+}
+
+type OrchServiceItem struct {
+	ServiceName types.String `tfsdk:"service_name"`
+	ServiceType types.String `tfsdk:"service_type"`
+	ServiceID   types.String `tfsdk:"service_id"`
+	Unmanaged   types.Bool   `tfsdk:"unmanaged"`
+	Running     types.Int64  `tfsdk:"running"`
+	Size        types.Int64  `tfsdk:"size"`
+	Image       types.String `tfsdk:"container_image_name"`
+	Spec        types.String `tfsdk:"spec"`
+}
+
+func (d *OrchServicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_orch_services"
+}
+
+func (d *OrchServicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source lists the services the orchestrator manages and their applied specs (equivalent to `ceph orch ls`). " +
+			"The orchestrator is only available through the ceph CLI, so this data source requires the provider `cli_backend` to be enabled.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"service_type": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "Optional filter to only return services of one type, e.g. `rgw`",
+				Optional:            true,
+			},
+			"services": dataSourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The services, ordered by name.",
+				Computed:            true,
+				NestedObject: dataSourceSchema.NestedAttributeObject{
+					Attributes: map[string]dataSourceSchema.Attribute{
+						"service_name": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The service name, e.g. `rgw.default`, as taken by the `ceph_daemon_restart` action.",
+							Computed:            true,
+						},
+						"service_type": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The service type, e.g. `rgw`.",
+							Computed:            true,
+						},
+						"service_id": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The service ID, e.g. `default`. Empty for services such as `mon` that have none.",
+							Computed:            true,
+						},
+						"unmanaged": dataSourceSchema.BoolAttribute{
+							MarkdownDescription: "Whether the orchestrator leaves the daemons of the service alone.",
+							Computed:            true,
+						},
+						"running": dataSourceSchema.Int64Attribute{
+							MarkdownDescription: "The number of running daemons.",
+							Computed:            true,
+						},
+						"size": dataSourceSchema.Int64Attribute{
+							MarkdownDescription: "The number of daemons the placement asks for.",
+							Computed:            true,
+						},
+						"container_image_name": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The container image the daemons run.",
+							Computed:            true,
+						},
+						"spec": dataSourceSchema.StringAttribute{
+							MarkdownDescription: "The applied service spec as JSON.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrchServicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrchServicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrchServicesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := d.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to list orchestrator services: %s", err),
+		)
+		return
+	}
+
+	services, err := cli.OrchLs(ctx, data.ServiceType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to list orchestrator services: %s", err),
+		)
+		return
+	}
+
+	slices.SortFunc(services, func(a, b OrchService) int {
+		return cmp.Compare(a.ServiceName, b.ServiceName)
+	})
+
+	data.Services = []OrchServiceItem{}
+	for _, service := range services {
+		spec, err := json.Marshal(service.Spec)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Spec Encoding Error",
+				fmt.Sprintf("Unable to encode the spec of service %s: %s", service.ServiceName, err),
+			)
+			return
+		}
+
+		data.Services = append(data.Services, OrchServiceItem{
+			ServiceName: types.StringValue(service.ServiceName),
+			ServiceType: types.StringValue(service.ServiceType),
+			ServiceID:   types.StringValue(service.ServiceID),
+			Unmanaged:   types.BoolValue(service.Unmanaged),
+			Running:     types.Int64Value(int64(service.Status.Running)),
+			Size:        types.Int64Value(int64(service.Status.Size)),
+			Image:       types.StringValue(service.Status.ContainerImageName),
+			Spec:        types.StringValue(string(spec)),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
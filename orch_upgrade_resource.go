@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const orchUpgradeResourceID = "upgrade"
+
+var cephVersionPattern = regexp.MustCompile(`^ceph version (\d+\.\d+\.\d+)`)
+
+var (
+	_ resource.Resource                = &OrchUpgradeResource{}
+	_ resource.ResourceWithImportState = &OrchUpgradeResource{}
+)
+
+func newOrchUpgradeResource() resource.Resource {
+	return &OrchUpgradeResource{}
+}
+
+// OrchUpgradeResource makes the cluster version part of the configuration.
+// An upgrade is started whenever the running version differs from
+// target_version and no upgrade is in progress.
+type OrchUpgradeResource struct {
+	client *CephAPIClient
+}
+
+type OrchUpgradeResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	TargetVersion  types.String `tfsdk:"target_version"`
+	Paused         types.Bool   `tfsdk:"paused"`
+	CurrentVersion types.String `tfsdk:"current_version"`
+	InProgress     types.Bool   `tfsdk:"in_progress"`
+	Progress       types.String `tfsdk:"progress"`
+	Message        types.String `tfsdk:"message"`
+}
+
+func (r *OrchUpgradeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_orch_upgrade"
+}
+
+func (r *OrchUpgradeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Upgrades a cephadm-managed cluster to a Ceph release, as with `ceph orch upgrade start --ceph-version`. " +
+			"An upgrade is started when the version of the active mgr differs from `target_version` and no upgrade is in progress; apply returns once the upgrade has started rather than waiting for it to finish. " +
+			"There is one upgrade per cluster, so declare this resource at most once. Destroying it stops an upgrade in progress but does not downgrade the cluster.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `upgrade`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"target_version": resourceSchema.StringAttribute{
+				MarkdownDescription: "The Ceph version to run, e.g. `19.2.1`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^\d+\.\d+\.\d+$`), "must be a Ceph version such as 19.2.1"),
+				},
+			},
+			"paused": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether the upgrade in progress is paused, as with `ceph orch upgrade pause` and `ceph orch upgrade resume`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"current_version": resourceSchema.StringAttribute{
+				MarkdownDescription: "The version of the active mgr, which is upgraded first",
+				Computed:            true,
+			},
+			"in_progress": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether an upgrade is in progress",
+				Computed:            true,
+			},
+			"progress": resourceSchema.StringAttribute{
+				MarkdownDescription: "The progress of the upgrade in progress, e.g. `3/10 daemons upgraded`",
+				Computed:            true,
+			},
+			"message": resourceSchema.StringAttribute{
+				MarkdownDescription: "The status message of the upgrade, such as the reason it was paused after an error",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *OrchUpgradeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OrchUpgradeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrchUpgradeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrchUpgradeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrchUpgradeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, diags := r.read(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !status.InProgress && data.CurrentVersion.ValueString() != "" {
+		data.TargetVersion = data.CurrentVersion
+	}
+	if data.Paused.IsNull() {
+		data.Paused = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrchUpgradeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OrchUpgradeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrchUpgradeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	status, err := r.client.OrchUpgradeStatus(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read upgrade status: %s", err),
+		)
+		return
+	}
+
+	if !status.InProgress {
+		return
+	}
+
+	if err := r.client.OrchUpgradeControl(ctx, "stop"); err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to stop upgrade: %s", err),
+		)
+		return
+	}
+}
+
+func (r *OrchUpgradeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != orchUpgradeResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", orchUpgradeResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), orchUpgradeResourceID)...)
+}
+
+// apply starts an upgrade to target_version unless the cluster already runs
+// it, replacing an upgrade in progress to another version, then pauses or
+// resumes the upgrade in progress to match paused.
+func (r *OrchUpgradeResource) apply(ctx context.Context, data *OrchUpgradeResourceModel) diag.Diagnostics {
+	status, diags := r.read(ctx, data)
+	if diags.HasError() {
+		return diags
+	}
+
+	targetVersion := data.TargetVersion.ValueString()
+	if data.CurrentVersion.ValueString() != targetVersion || status.InProgress {
+		if status.InProgress && !orchUpgradeTargets(status, targetVersion) {
+			if err := r.client.OrchUpgradeControl(ctx, "stop"); err != nil {
+				diags.AddError(
+					"API Request Error",
+					fmt.Sprintf("Unable to stop the upgrade in progress: %s", err),
+				)
+				return diags
+			}
+			status.InProgress = false
+		}
+
+		if !status.InProgress {
+			if err := r.client.OrchUpgradeStart(ctx, CephAPIOrchUpgradeStartRequest{Version: targetVersion}); err != nil {
+				diags.AddError(
+					"API Request Error",
+					fmt.Sprintf("Unable to start upgrade to %s: %s", targetVersion, err),
+				)
+				return diags
+			}
+			status.InProgress = true
+			status.IsPaused = false
+		}
+
+		if data.Paused.ValueBool() != status.IsPaused {
+			action := "resume"
+			if data.Paused.ValueBool() {
+				action = "pause"
+			}
+			if err := r.client.OrchUpgradeControl(ctx, action); err != nil {
+				diags.AddError(
+					"API Request Error",
+					fmt.Sprintf("Unable to %s upgrade: %s", action, err),
+				)
+				return diags
+			}
+		}
+	}
+
+	paused := data.Paused
+	_, readDiags := r.read(ctx, data)
+	diags.Append(readDiags...)
+	data.TargetVersion = types.StringValue(targetVersion)
+	data.Paused = paused
+	return diags
+}
+
+// read fills the computed attributes from the upgrade status and the version
+// reported by the dashboard summary.
+func (r *OrchUpgradeResource) read(ctx context.Context, data *OrchUpgradeResourceModel) (*CephAPIOrchUpgradeStatus, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	status, err := r.client.OrchUpgradeStatus(ctx)
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read upgrade status: %s", err),
+		)
+		return nil, diags
+	}
+
+	summary, err := r.client.GetSummary(ctx)
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read the cluster version: %s", err),
+		)
+		return nil, diags
+	}
+
+	data.ID = types.StringValue(orchUpgradeResourceID)
+	data.CurrentVersion = types.StringValue(parseCephVersion(summary.Version))
+	data.InProgress = types.BoolValue(status.InProgress)
+	data.Progress = types.StringValue(status.Progress)
+	data.Message = types.StringValue(status.Message)
+	if status.InProgress {
+		data.Paused = types.BoolValue(status.IsPaused)
+	}
+
+	return status, diags
+}
+
+// parseCephVersion returns the version number from a version string such as
+// "ceph version 19.2.1 (58a7fab8be0a062d730ad7da874972fd3fba59fb) squid
+// (stable)", or "" if it has another form.
+func parseCephVersion(version string) string {
+	match := cephVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// orchUpgradeTargets reports whether the upgrade in progress is to version.
+// The status only has the target image, which is tagged with the version
+// when started with --ceph-version.
+func orchUpgradeTargets(status *CephAPIOrchUpgradeStatus, version string) bool {
+	return regexp.MustCompile(`:v?` + regexp.QuoteMeta(version) + `$`).MatchString(status.TargetImage)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestParseCephVersion(t *testing.T) {
+	tests := map[string]string{
+		"ceph version 19.2.1 (58a7fab8be0a062d730ad7da874972fd3fba59fb) squid (stable)": "19.2.1",
+		"ceph version 18.2.4-123-gabcdef (abcdef) reef (stable)":                        "18.2.4",
+		"ceph version Development (no_version) squid (dev)":                             "",
+		"": "",
+	}
+	for version, expected := range tests {
+		if got := parseCephVersion(version); got != expected {
+			t.Errorf("parseCephVersion(%q) = %q, want %q", version, got, expected)
+		}
+	}
+}
+
+func TestOrchUpgradeTargets(t *testing.T) {
+	status := &CephAPIOrchUpgradeStatus{TargetImage: "quay.io/ceph/ceph:v19.2.1"}
+	if !orchUpgradeTargets(status, "19.2.1") {
+		t.Errorf("orchUpgradeTargets(%q, 19.2.1) = false, want true", status.TargetImage)
+	}
+	if orchUpgradeTargets(status, "19.2.10") || orchUpgradeTargets(status, "9.2.1") {
+		t.Errorf("orchUpgradeTargets(%q) matched another version", status.TargetImage)
+	}
+}
+
+// The test cluster is not deployed with cephadm, so this only covers the
+// error path.
+func TestAccCephOrchUpgradeResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_orch_upgrade" "test" {
+					  target_version = "19.2.1"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Unable to read upgrade status`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_orch_upgrade" "test" {
+					  target_version = "squid"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`must be a Ceph version`),
+			},
+		},
+	})
+}
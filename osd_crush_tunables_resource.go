@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const osdCrushTunablesResourceID = "osd_crush_tunables"
+
+var (
+	_ resource.Resource                = &OSDCrushTunablesResource{}
+	_ resource.ResourceWithImportState = &OSDCrushTunablesResource{}
+)
+
+func newOSDCrushTunablesResource() resource.Resource {
+	return &OSDCrushTunablesResource{}
+}
+
+// OSDCrushTunablesResource manages the CRUSH tunables profile and the minimum
+// client release the OSD map requires. The dashboard API exposes neither, so
+// it always goes through the ceph CLI.
+type OSDCrushTunablesResource struct {
+	client *CephAPIClient
+}
+
+type OSDCrushTunablesResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	Profile                types.String `tfsdk:"profile"`
+	RequireMinCompatClient types.String `tfsdk:"require_min_compat_client"`
+}
+
+func (r *OSDCrushTunablesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_osd_crush_tunables"
+}
+
+func (r *OSDCrushTunablesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the CRUSH tunables profile of the cluster, for example to move clusters upgraded from old releases to the current profile. " +
+			"The `require-min-compat-client` setting is managed by `ceph_cluster_features`; the `require_min_compat_client` attribute of this resource is deprecated. " +
+			"These settings are only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled. " +
+			"There is one set per cluster, so declare this resource at most once. Destroying it leaves the cluster settings unchanged.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `osd_crush_tunables`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"profile": resourceSchema.StringAttribute{
+				MarkdownDescription: "The CRUSH tunables profile, named after the release that introduced it (`legacy`, `argonaut`, `bobtail`, `firefly`, `hammer` or `jewel`). Changing it moves data.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("legacy", "argonaut", "bobtail", "firefly", "hammer", "jewel"),
+				},
+			},
+			"require_min_compat_client": resourceSchema.StringAttribute{
+				MarkdownDescription: "The oldest client release allowed to connect. `upmap` balancing requires `luminous` or later. Ceph refuses to raise it while older clients are connected. Deprecated: set `require_min_compat_client` on `ceph_cluster_features` instead, since two resources managing the setting undo each other's changes.",
+				DeprecationMessage:  "Use require_min_compat_client on ceph_cluster_features instead. Setting it on both resources makes them undo each other's changes.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("firefly", "hammer", "jewel", "kraken", "luminous", "mimic", "nautilus", "octopus", "pacific", "quincy", "reef", "squid"),
+				},
+			},
+		},
+	}
+}
+
+func (r *OSDCrushTunablesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OSDCrushTunablesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OSDCrushTunablesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, OSDCrushTunablesResourceModel{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OSDCrushTunablesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OSDCrushTunablesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read CRUSH tunables: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, cli, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OSDCrushTunablesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state OSDCrushTunablesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Neither setting can be unset, so Delete only forgets the resource.
+func (r *OSDCrushTunablesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *OSDCrushTunablesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != osdCrushTunablesResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", osdCrushTunablesResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), osdCrushTunablesResourceID)...)
+}
+
+// apply changes the settings in data that differ from prior and reads the
+// result back into data. The compat client is raised first because newer
+// tunables profiles can require it.
+func (r *OSDCrushTunablesResource) apply(ctx context.Context, data *OSDCrushTunablesResourceModel, prior OSDCrushTunablesResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		diags.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to manage CRUSH tunables: %s", err),
+		)
+		return diags
+	}
+
+	if !data.RequireMinCompatClient.IsUnknown() && !data.RequireMinCompatClient.Equal(prior.RequireMinCompatClient) {
+		if err := cli.OsdSetRequireMinCompatClient(ctx, data.RequireMinCompatClient.ValueString()); err != nil {
+			diags.AddError(
+				"CLI Request Error",
+				fmt.Sprintf("Unable to set require-min-compat-client: %s", err),
+			)
+			return diags
+		}
+	}
+
+	if !data.Profile.IsUnknown() && !data.Profile.Equal(prior.Profile) {
+		if err := cli.OsdCrushTunables(ctx, data.Profile.ValueString()); err != nil {
+			diags.AddError(
+				"CLI Request Error",
+				fmt.Sprintf("Unable to set CRUSH tunables profile: %s", err),
+			)
+			return diags
+		}
+	}
+
+	data.ID = types.StringValue(osdCrushTunablesResourceID)
+	diags.Append(r.read(ctx, cli, data)...)
+	return diags
+}
+
+func (r *OSDCrushTunablesResource) read(ctx context.Context, cli *CephCLI, data *OSDCrushTunablesResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tunables, err := cli.OsdCrushShowTunables(ctx)
+	if err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read CRUSH tunables: %s", err),
+		)
+		return diags
+	}
+	data.Profile = types.StringValue(tunables.Profile)
+
+	minCompatClient, err := cli.OsdGetRequireMinCompatClient(ctx)
+	if err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read require-min-compat-client: %s", err),
+		)
+		return diags
+	}
+	data.RequireMinCompatClient = types.StringValue(minCompatClient)
+
+	return diags
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephOSDCrushTunablesResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_osd_crush_tunables" "test" {
+					  profile = "jewel"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					resource "ceph_osd_crush_tunables" "test" {
+					  profile                   = "jewel"
+					  require_min_compat_client = "luminous"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_osd_crush_tunables.test", tfjsonpath.New("profile"), knownvalue.StringExact("jewel")),
+					statecheck.ExpectKnownValue("ceph_osd_crush_tunables.test", tfjsonpath.New("require_min_compat_client"), knownvalue.StringExact("luminous")),
+				},
+				Check: checkCephRequireMinCompatClient(t, "luminous"),
+			},
+			{
+				ConfigVariables:   configVariables,
+				Config:            testAccCLIProviderConfigBlock,
+				ResourceName:      "ceph_osd_crush_tunables.test",
+				ImportState:       true,
+				ImportStateId:     "osd_crush_tunables",
+				ImportStateVerify: true,
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					resource "ceph_osd_crush_tunables" "test" {
+					  profile                   = "jewel"
+					  require_min_compat_client = "mimic"
+					}
+				`,
+				Check: checkCephRequireMinCompatClient(t, "mimic"),
+			},
+		},
+	})
+}
+
+func checkCephRequireMinCompatClient(t *testing.T, expected string) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		release, err := cephTestClusterCLI.OsdGetRequireMinCompatClient(t.Context())
+		if err != nil {
+			return err
+		}
+		if release != expected {
+			return fmt.Errorf("require_min_compat_client = %q, want %q", release, expected)
+		}
+		return nil
+	}
+}
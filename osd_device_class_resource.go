@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &OSDDeviceClassResource{}
+	_ resource.ResourceWithImportState = &OSDDeviceClassResource{}
+	_ resource.ResourceWithIdentity    = &OSDDeviceClassResource{}
+)
+
+// crushNameRegexp matches the names Ceph accepts for CRUSH items, including
+// device classes.
+var crushNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+func newOSDDeviceClassResource() resource.Resource {
+	return &OSDDeviceClassResource{}
+}
+
+// OSDDeviceClassResource manages a CRUSH device class and the OSDs in it.
+// The dashboard API does not expose device classes, so it always goes
+// through the ceph CLI.
+type OSDDeviceClassResource struct {
+	client *CephAPIClient
+}
+
+type OSDDeviceClassResourceModel struct {
+	Name types.String `tfsdk:"name"`
+	OSDs types.Set    `tfsdk:"osds"`
+}
+
+type OSDDeviceClassResourceIdentityModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+func (r *OSDDeviceClassResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_osd_device_class"
+}
+
+func (r *OSDDeviceClassResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages a CRUSH device class and the OSDs assigned to it, so that CRUSH rules can place data on a subset of devices with `device_class`. " +
+			"Device classes are only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled. " +
+			"Destroying the resource removes the class from its OSDs, which get their detected class back the next time they start, and then removes the class. Ceph refuses to remove a class that a CRUSH rule uses.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the device class, e.g. `nvme-fast`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(crushNameRegexp, "must only contain letters, digits, '_', '.' and '-'"),
+				},
+			},
+			"osds": resourceSchema.SetAttribute{
+				MarkdownDescription: "The IDs of the OSDs in the class. OSDs are moved out of their current class. OSDs removed from the set are left without a class. If not set, the OSDs Ceph assigned to the class are read but not managed.",
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				Validators: []validator.Set{
+					setvalidator.ValueInt64sAre(int64validator.AtLeast(0)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *OSDDeviceClassResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				Description:       "The name of the device class",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *OSDDeviceClassResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OSDDeviceClassResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OSDDeviceClassResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to create device class: %s", err),
+		)
+		return
+	}
+
+	name := data.Name.ValueString()
+	if err := cli.OsdCrushClassCreate(ctx, name); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to create device class: %s", err),
+		)
+		return
+	}
+
+	if !data.OSDs.IsUnknown() {
+		var osds []int64
+		resp.Diagnostics.Append(data.OSDs.ElementsAs(ctx, &osds, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if err := cli.OsdCrushSetDeviceClass(ctx, name, osds); err != nil {
+			resp.Diagnostics.AddError(
+				"CLI Request Error",
+				fmt.Sprintf("Unable to assign OSDs to device class %s: %s", name, err),
+			)
+			return
+		}
+	}
+
+	osds, err := cli.OsdCrushClassListOSDs(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read device class %s after creation: %s", name, err),
+		)
+		return
+	}
+
+	osdsValue, diags := types.SetValueFrom(ctx, types.Int64Type, osds)
+	resp.Diagnostics.Append(diags...)
+	data.OSDs = osdsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OSDDeviceClassResourceIdentityModel{Name: data.Name})...)
+}
+
+func (r *OSDDeviceClassResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OSDDeviceClassResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read device class: %s", err),
+		)
+		return
+	}
+
+	name := data.Name.ValueString()
+	classes, err := cli.OsdCrushClassList(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read device class %s: %s", name, err),
+		)
+		return
+	}
+
+	if !slices.Contains(classes, name) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Device class %s", name))
+		return
+	}
+
+	osds, err := cli.OsdCrushClassListOSDs(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read device class %s: %s", name, err),
+		)
+		return
+	}
+
+	osdsValue, diags := types.SetValueFrom(ctx, types.Int64Type, osds)
+	resp.Diagnostics.Append(diags...)
+	data.OSDs = osdsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OSDDeviceClassResourceIdentityModel{Name: data.Name})...)
+}
+
+func (r *OSDDeviceClassResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state OSDDeviceClassResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to update device class: %s", err),
+		)
+		return
+	}
+
+	var planOSDs, stateOSDs []int64
+	resp.Diagnostics.Append(data.OSDs.ElementsAs(ctx, &planOSDs, false)...)
+	resp.Diagnostics.Append(state.OSDs.ElementsAs(ctx, &stateOSDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var added, removed []int64
+	for _, osd := range planOSDs {
+		if !slices.Contains(stateOSDs, osd) {
+			added = append(added, osd)
+		}
+	}
+	for _, osd := range stateOSDs {
+		if !slices.Contains(planOSDs, osd) {
+			removed = append(removed, osd)
+		}
+	}
+
+	name := data.Name.ValueString()
+	if err := cli.OsdCrushRemoveDeviceClass(ctx, removed); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to remove OSDs from device class %s: %s", name, err),
+		)
+		return
+	}
+	if err := cli.OsdCrushSetDeviceClass(ctx, name, added); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to assign OSDs to device class %s: %s", name, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OSDDeviceClassResourceIdentityModel{Name: data.Name})...)
+}
+
+func (r *OSDDeviceClassResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OSDDeviceClassResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to delete device class: %s", err),
+		)
+		return
+	}
+
+	name := data.Name.ValueString()
+	classes, err := cli.OsdCrushClassList(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to delete device class %s: %s", name, err),
+		)
+		return
+	}
+	if !slices.Contains(classes, name) {
+		return
+	}
+
+	// OSDs may have joined the class outside Terraform, so clear every OSD
+	// that is in it now.
+	osds, err := cli.OsdCrushClassListOSDs(ctx, name)
+	if err == nil {
+		err = cli.OsdCrushRemoveDeviceClass(ctx, osds)
+	}
+	if err == nil {
+		err = cli.OsdCrushClassRemove(ctx, name)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to delete device class %s: %s", name, err),
+		)
+	}
+}
+
+func (r *OSDDeviceClassResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("name"), path.Root("name"), req, resp)
+}
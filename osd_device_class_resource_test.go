@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephOSDDeviceClassResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	className := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlphaNum))
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephOSDDeviceClassDestroy(t, className),
+		PreCheck: func() {
+			originalClass, err := cephTestClusterCLI.OsdCrushGetDeviceClass(t.Context(), 0)
+			if err != nil {
+				t.Fatalf("Failed to get the device class of osd.0: %v", err)
+			}
+
+			testCleanup(t, func(ctx context.Context) {
+				if err := cephTestClusterCLI.OsdCrushSetDeviceClass(ctx, originalClass, []int64{0}); err != nil {
+					t.Errorf("Failed to restore the device class of osd.0: %v", err)
+				}
+			})
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_osd_device_class" "test" {
+					  name = %q
+					}
+				`, className),
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_osd_device_class" "test" {
+					  name = %q
+					  osds = [0]
+					}
+				`, className),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_osd_device_class.test", tfjsonpath.New("osds"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.Int64Exact(0),
+					})),
+					statecheck.ExpectIdentity("ceph_osd_device_class.test", map[string]knownvalue.Check{
+						"name": knownvalue.StringExact(className),
+					}),
+				},
+				Check: checkCephOSDDeviceClassOSDs(t, className, []int64{0}),
+			},
+			{
+				ConfigVariables:                      configVariables,
+				ResourceName:                         "ceph_osd_device_class.test",
+				ImportState:                          true,
+				ImportStateId:                        className,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_osd_device_class" "test" {
+					  name = %q
+					  osds = []
+					}
+				`, className),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_osd_device_class.test", tfjsonpath.New("osds"), knownvalue.SetSizeExact(0)),
+				},
+				Check: checkCephOSDDeviceClassOSDs(t, className, nil),
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.OsdCrushClassRemove(t.Context(), className); err != nil {
+						t.Fatalf("Failed to remove device class out of band: %v", err)
+					}
+				},
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_osd_device_class" "test" {
+					  name = %q
+					  osds = []
+					}
+				`, className),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_osd_device_class.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func checkCephOSDDeviceClassOSDs(t *testing.T, className string, expected []int64) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		osds, err := cephTestClusterCLI.OsdCrushClassListOSDs(t.Context(), className)
+		if err != nil {
+			return err
+		}
+		if !slices.Equal(osds, expected) {
+			return fmt.Errorf("device class %s OSDs = %v, want %v", className, osds, expected)
+		}
+		return nil
+	}
+}
+
+func testAccCheckCephOSDDeviceClassDestroy(t *testing.T, className string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		classes, err := cephTestClusterCLI.OsdCrushClassList(t.Context())
+		if err != nil {
+			return fmt.Errorf("failed to list device classes: %w", err)
+		}
+		if slices.Contains(classes, className) {
+			return fmt.Errorf("device class %q still exists in Ceph", className)
+		}
+		return nil
+	}
+}
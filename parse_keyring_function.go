@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ParseKeyringFunction{}
+
+func newParseKeyringFunction() function.Function {
+	return &ParseKeyringFunction{}
+}
+
+type ParseKeyringFunction struct{}
+
+type parseKeyringEntry struct {
+	Key  string            `tfsdk:"key"`
+	Caps map[string]string `tfsdk:"caps"`
+}
+
+func (f *ParseKeyringFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_keyring"
+}
+
+func (f *ParseKeyringFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a Ceph keyring",
+		MarkdownDescription: "Parses a keyring such as the `keyring` attribute of `ceph_auth` and returns a map from entity to an object with its `key` and `caps`. " +
+			"Terraform keeps the result sensitive when the keyring is.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "keyring",
+				MarkdownDescription: "The keyring content",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"key":  types.StringType,
+					"caps": types.MapType{ElemType: types.StringType},
+				},
+			},
+		},
+	}
+}
+
+func (f *ParseKeyringFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var keyring string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &keyring))
+	if resp.Error != nil {
+		return
+	}
+
+	users, err := parseCephKeyring(keyring)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	entries := make(map[string]parseKeyringEntry, len(users))
+	for _, user := range users {
+		entries[user.Entity] = parseKeyringEntry{
+			Key:  user.Key,
+			Caps: user.Caps.Map(),
+		}
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, entries))
+}
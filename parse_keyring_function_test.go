@@ -0,0 +1,89 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCephParseKeyringFunction(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					data "ceph_auth" "admin" {
+					  entity = "client.admin"
+					}
+
+					output "admin" {
+					  value     = provider::ceph::parse_keyring(data.ceph_auth.admin.keyring)
+					  sensitive = true
+					}
+
+					output "multiple" {
+					  value = provider::ceph::parse_keyring(<<-EOT
+					    [osd.0]
+					    	key = AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==
+					    	caps mon = "allow profile osd"
+					    [client.foo]
+					    	key = AQBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB==
+					  EOT
+					  )
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue(
+						"admin",
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"client.admin": knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"key": knownvalue.StringExact("AQB5m89objcKIxAAda2ULz/l3NH+mv9XzKePHQ=="),
+								"caps": knownvalue.MapExact(map[string]knownvalue.Check{
+									"mds": knownvalue.StringExact("allow *"),
+									"mgr": knownvalue.StringExact("allow *"),
+									"mon": knownvalue.StringExact("allow *"),
+									"osd": knownvalue.StringExact("allow *"),
+								}),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownOutputValue(
+						"multiple",
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"osd.0": knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"key": knownvalue.StringExact("AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=="),
+								"caps": knownvalue.MapExact(map[string]knownvalue.Check{
+									"mon": knownvalue.StringExact("allow profile osd"),
+								}),
+							}),
+							"client.foo": knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"key":  knownvalue.StringExact("AQBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB=="),
+								"caps": knownvalue.MapExact(map[string]knownvalue.Check{}),
+							}),
+						}),
+					),
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					output "invalid" {
+					  value = provider::ceph::parse_keyring("not a keyring")
+					}
+				`,
+				ExpectError: regexp.MustCompile(`parse error`),
+			},
+		},
+	})
+}
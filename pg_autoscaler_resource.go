@@ -0,0 +1,345 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	pgAutoscalerResourceID       = "pg_autoscaler"
+	pgAutoscalerTargetPGOption   = "mon_target_pg_per_osd"
+	pgAutoscalerDefaultMode      = "osd_pool_default_pg_autoscale_mode"
+	pgAutoscalerThresholdOption  = "threshold"
+	pgAutoscalerThresholdSection = "mgr"
+)
+
+var (
+	_ resource.Resource                = &PGAutoscalerResource{}
+	_ resource.ResourceWithImportState = &PGAutoscalerResource{}
+)
+
+func newPGAutoscalerResource() resource.Resource {
+	return &PGAutoscalerResource{}
+}
+
+// PGAutoscalerResource manages the cluster-wide PG autoscaler defaults. Each
+// attribute maps to a single config option that is only touched when set, so
+// options left out of the configuration keep whatever value they have.
+type PGAutoscalerResource struct {
+	client *CephAPIClient
+}
+
+type PGAutoscalerResourceModel struct {
+	ID             types.String  `tfsdk:"id"`
+	TargetPGPerOSD types.Int64   `tfsdk:"target_pg_per_osd"`
+	DefaultMode    types.String  `tfsdk:"default_mode"`
+	Threshold      types.Float64 `tfsdk:"threshold"`
+}
+
+func (r *PGAutoscalerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pg_autoscaler"
+}
+
+func (r *PGAutoscalerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the cluster-wide defaults of the PG autoscaler. " +
+			"There is one autoscaler per cluster, so declare this resource at most once. " +
+			"Attributes that are not set are left unmanaged, and destroying the resource restores the Ceph defaults of the managed ones. " +
+			"Use the pool `bulk` flag to make individual pools start with a full complement of PGs.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `pg_autoscaler`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"target_pg_per_osd": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The number of PGs per OSD the autoscaler aims for, stored as the global `mon_target_pg_per_osd` option.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"default_mode": resourceSchema.StringAttribute{
+				MarkdownDescription: "The autoscale mode of newly created pools (`on`, `off` or `warn`), stored as the global `osd_pool_default_pg_autoscale_mode` option.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("on", "off", "warn"),
+				},
+			},
+			"threshold": resourceSchema.Float64Attribute{
+				MarkdownDescription: "How far a pool's PG count must be from its target, as a factor, before the autoscaler changes it. Stored as the `pg_autoscaler` module `threshold` option.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *PGAutoscalerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PGAutoscalerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PGAutoscalerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, PGAutoscalerResourceModel{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(pgAutoscalerResourceID)
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PGAutoscalerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PGAutoscalerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PGAutoscalerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state PGAutoscalerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(pgAutoscalerResourceID)
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PGAutoscalerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PGAutoscalerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, PGAutoscalerResourceModel{}, data)...)
+}
+
+func (r *PGAutoscalerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != pgAutoscalerResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", pgAutoscalerResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), pgAutoscalerResourceID)...)
+}
+
+// apply sets every option configured in data and resets the ones that were
+// managed in prior but are no longer configured.
+func (r *PGAutoscalerResource) apply(ctx context.Context, data PGAutoscalerResourceModel, prior PGAutoscalerResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	globalOptions := []struct {
+		name  string
+		value string
+		set   bool
+		reset bool
+	}{
+		{
+			name:  pgAutoscalerTargetPGOption,
+			value: strconv.FormatInt(data.TargetPGPerOSD.ValueInt64(), 10),
+			set:   !data.TargetPGPerOSD.IsNull(),
+			reset: data.TargetPGPerOSD.IsNull() && !prior.TargetPGPerOSD.IsNull(),
+		},
+		{
+			name:  pgAutoscalerDefaultMode,
+			value: data.DefaultMode.ValueString(),
+			set:   !data.DefaultMode.IsNull(),
+			reset: data.DefaultMode.IsNull() && !prior.DefaultMode.IsNull(),
+		},
+	}
+
+	for _, option := range globalOptions {
+		var err error
+		switch {
+		case option.set:
+			err = r.client.ClusterUpdateConf(ctx, option.name, "global", option.value)
+		case option.reset:
+			err = r.client.ClusterDeleteConf(ctx, option.name, "global")
+			if isCephAPINotFound(err) {
+				err = nil
+			}
+		}
+		if err != nil {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to configure '%s': %s", option.name, err),
+			)
+			return diags
+		}
+	}
+
+	switch {
+	case !data.Threshold.IsNull():
+		threshold := strconv.FormatFloat(data.Threshold.ValueFloat64(), 'g', -1, 64)
+		err := r.client.MgrSetModuleConfig(ctx, "pg_autoscaler", CephAPIMgrModuleConfig{pgAutoscalerThresholdOption: threshold})
+		if err != nil {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to configure the pg_autoscaler module: %s", err),
+			)
+			return diags
+		}
+	case !prior.Threshold.IsNull():
+		configName := "mgr/pg_autoscaler/" + pgAutoscalerThresholdOption
+		err := r.client.ClusterDeleteConf(ctx, configName, pgAutoscalerThresholdSection)
+		if err != nil && !isCephAPINotFound(err) {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to reset '%s': %s", configName, err),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func (r *PGAutoscalerResource) read(ctx context.Context, data *PGAutoscalerResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !data.TargetPGPerOSD.IsNull() {
+		value, err := r.globalOption(ctx, pgAutoscalerTargetPGOption)
+		if err != nil {
+			diags.AddError("API Request Error", err.Error())
+			return diags
+		}
+		data.TargetPGPerOSD = types.Int64Null()
+		if value != "" {
+			targetPGPerOSD, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				diags.AddError(
+					"Configuration Value Formatting Error",
+					fmt.Sprintf("Unable to parse '%s' value %q: %s", pgAutoscalerTargetPGOption, value, err),
+				)
+				return diags
+			}
+			data.TargetPGPerOSD = types.Int64Value(targetPGPerOSD)
+		}
+	}
+
+	if !data.DefaultMode.IsNull() {
+		value, err := r.globalOption(ctx, pgAutoscalerDefaultMode)
+		if err != nil {
+			diags.AddError("API Request Error", err.Error())
+			return diags
+		}
+		data.DefaultMode = types.StringNull()
+		if value != "" {
+			data.DefaultMode = types.StringValue(value)
+		}
+	}
+
+	if !data.Threshold.IsNull() {
+		moduleConfig, err := r.client.MgrGetModuleConfig(ctx, "pg_autoscaler")
+		if err != nil {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to read the pg_autoscaler module config: %s", err),
+			)
+			return diags
+		}
+
+		value, err := formatMgrModuleConfigValue(moduleConfig[pgAutoscalerThresholdOption])
+		if err == nil {
+			var threshold float64
+			threshold, err = strconv.ParseFloat(value, 64)
+			data.Threshold = types.Float64Value(threshold)
+		}
+		if err != nil {
+			diags.AddError(
+				"Configuration Value Formatting Error",
+				fmt.Sprintf("Unable to parse pg_autoscaler option '%s': %s", pgAutoscalerThresholdOption, err),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// globalOption returns the value set in the global section, or "" if the
+// option is not set there.
+func (r *PGAutoscalerResource) globalOption(ctx context.Context, name string) (string, error) {
+	conf, err := r.client.ClusterGetConf(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("unable to read '%s': %w", name, err)
+	}
+
+	value, _ := conf.SectionValue("global")
+	return value, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephPGAutoscalerResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			checkCephConfigAbsent(t, "global", pgAutoscalerTargetPGOption),
+			checkCephConfigAbsent(t, "global", pgAutoscalerDefaultMode),
+			checkCephConfigAbsent(t, "mgr", "mgr/pg_autoscaler/threshold"),
+		),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_pg_autoscaler" "test" {
+					  target_pg_per_osd = 200
+					  default_mode      = "warn"
+					  threshold         = 2.5
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_pg_autoscaler.test", tfjsonpath.New("id"), knownvalue.StringExact("pg_autoscaler")),
+					statecheck.ExpectKnownValue("ceph_pg_autoscaler.test", tfjsonpath.New("target_pg_per_osd"), knownvalue.Int64Exact(200)),
+					statecheck.ExpectKnownValue("ceph_pg_autoscaler.test", tfjsonpath.New("default_mode"), knownvalue.StringExact("warn")),
+					statecheck.ExpectKnownValue("ceph_pg_autoscaler.test", tfjsonpath.New("threshold"), knownvalue.Float64Exact(2.5)),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigValue(t, "global", pgAutoscalerTargetPGOption, "200"),
+					checkCephConfigValue(t, "global", pgAutoscalerDefaultMode, "warn"),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_pg_autoscaler" "test" {
+					  target_pg_per_osd = 150
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_pg_autoscaler.test", tfjsonpath.New("target_pg_per_osd"), knownvalue.Int64Exact(150)),
+					statecheck.ExpectKnownValue("ceph_pg_autoscaler.test", tfjsonpath.New("default_mode"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("ceph_pg_autoscaler.test", tfjsonpath.New("threshold"), knownvalue.Null()),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigValue(t, "global", pgAutoscalerTargetPGOption, "150"),
+					checkCephConfigAbsent(t, "global", pgAutoscalerDefaultMode),
+					checkCephConfigAbsent(t, "mgr", "mgr/pg_autoscaler/threshold"),
+				),
+			},
+		},
+	})
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -11,6 +13,9 @@ import (
 
 var _ datasource.DataSource = &PoolDataSource{}
 
+// cephPoolFlagBulk is FLAG_BULK from pg_pool_t, set by "osd pool set <pool> bulk true".
+const cephPoolFlagBulk = 1 << 17
+
 func newPoolDataSource() datasource.DataSource {
 	return &PoolDataSource{}
 }
@@ -27,7 +32,8 @@ type PoolDataSourceModel struct {
 	PGNum                    types.Int64   `tfsdk:"pg_num"`
 	CrushRule                types.String  `tfsdk:"crush_rule"`
 	PrimaryAffinity          types.Float64 `tfsdk:"primary_affinity"`
-	ApplicationMetadata      types.List    `tfsdk:"application_metadata"`
+	ApplicationMetadata      types.Set     `tfsdk:"application_metadata"`
+	Applications             types.Map     `tfsdk:"applications"`
 	Flags                    types.Int64   `tfsdk:"flags"`
 	ErasureCodeProfile       types.String  `tfsdk:"erasure_code_profile"`
 	AutoscaleMode            types.String  `tfsdk:"autoscale_mode"`
@@ -38,6 +44,12 @@ type PoolDataSourceModel struct {
 	CompressionRequiredRatio types.Float64 `tfsdk:"compression_required_ratio"`
 	CompressionMinBlobSize   types.Int64   `tfsdk:"compression_min_blob_size"`
 	CompressionMaxBlobSize   types.Int64   `tfsdk:"compression_max_blob_size"`
+	FastRead                 types.Bool    `tfsdk:"fast_read"`
+	Bulk                     types.Bool    `tfsdk:"bulk"`
+	RecoveryPriority         types.Int64   `tfsdk:"recovery_priority"`
+	ScrubMinInterval         types.Float64 `tfsdk:"scrub_min_interval"`
+	ScrubMaxInterval         types.Float64 `tfsdk:"scrub_max_interval"`
+	DeepScrubInterval        types.Float64 `tfsdk:"deep_scrub_interval"`
 }
 
 func (d *PoolDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -76,11 +88,17 @@ func (d *PoolDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "The primary affinity of the pool.",
 				Computed:            true,
 			},
-			"application_metadata": dataSourceSchema.ListAttribute{
-				MarkdownDescription: "The list of applications enabled on the pool (e.g. 'rbd', 'rgw', 'cephfs').",
+			"application_metadata": dataSourceSchema.SetAttribute{
+				MarkdownDescription: "The set of applications enabled on the pool (e.g. 'rbd', 'rgw', 'cephfs'). See `applications` for their metadata.",
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"applications": dataSourceSchema.MapAttribute{
+				MarkdownDescription: "The applications enabled on the pool, each mapped to the key/value pairs set on it with `ceph osd pool application set`. " +
+					"The dashboard API only reports application names, so this is read through the CLI backend and is null when it is not configured.",
+				Computed:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+			},
 			"flags": dataSourceSchema.Int64Attribute{
 				MarkdownDescription: "The flags of the pool.",
 				Computed:            true,
@@ -121,6 +139,30 @@ func (d *PoolDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "The compression maximum blob size of the pool.",
 				Computed:            true,
 			},
+			"fast_read": dataSourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether reads from the erasure coded pool are served from the first shards to answer.",
+				Computed:            true,
+			},
+			"bulk": dataSourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether the pool is flagged as bulk, so the PG autoscaler starts it with a full complement of PGs instead of scaling up from a minimum.",
+				Computed:            true,
+			},
+			"recovery_priority": dataSourceSchema.Int64Attribute{
+				MarkdownDescription: "The recovery priority of the pool, or null if not set on the pool.",
+				Computed:            true,
+			},
+			"scrub_min_interval": dataSourceSchema.Float64Attribute{
+				MarkdownDescription: "The minimum scrub interval of the pool in seconds, or null if the OSD default applies.",
+				Computed:            true,
+			},
+			"scrub_max_interval": dataSourceSchema.Float64Attribute{
+				MarkdownDescription: "The maximum scrub interval of the pool in seconds, or null if the OSD default applies.",
+				Computed:            true,
+			},
+			"deep_scrub_interval": dataSourceSchema.Float64Attribute{
+				MarkdownDescription: "The deep scrub interval of the pool in seconds, or null if the OSD default applies.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -144,6 +186,8 @@ func (d *PoolDataSource) Configure(ctx context.Context, req datasource.Configure
 }
 
 func (d *PoolDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withReadCache(ctx)
+
 	var data PoolDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -176,16 +220,51 @@ func (d *PoolDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	data.CompressionRequiredRatio = types.Float64Value(pool.Options.CompressionRequiredRatio)
 	data.CompressionMinBlobSize = types.Int64Value(int64(pool.Options.CompressionMinBlobSize))
 	data.CompressionMaxBlobSize = types.Int64Value(int64(pool.Options.CompressionMaxBlobSize))
+	data.FastRead = types.BoolValue(pool.FastRead)
+	data.Bulk = types.BoolValue(cephPoolBulk(pool))
+	data.RecoveryPriority = types.Int64Null()
+	if pool.Options.RecoveryPriority != nil {
+		data.RecoveryPriority = types.Int64Value(int64(*pool.Options.RecoveryPriority))
+	}
+	data.ScrubMinInterval = types.Float64PointerValue(pool.Options.ScrubMinInterval)
+	data.ScrubMaxInterval = types.Float64PointerValue(pool.Options.ScrubMaxInterval)
+	data.DeepScrubInterval = types.Float64PointerValue(pool.Options.DeepScrubInterval)
 
 	data.Flags = types.Int64Value(int64(pool.Flags))
 
 	appMetaStrings := pool.ApplicationMetadata
-	appMeta, diags := types.ListValueFrom(ctx, types.StringType, appMetaStrings)
+	appMeta, diags := types.SetValueFrom(ctx, types.StringType, appMetaStrings)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	data.ApplicationMetadata = appMeta
 
+	data.Applications = types.MapNull(types.MapType{ElemType: types.StringType})
+	if cli, err := d.client.CLI(); err == nil {
+		apps, err := cli.PoolApplicationMetadata(ctx, data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"CLI Request Error",
+				fmt.Sprintf("Unable to get the applications of pool '%s': %s", data.Name.ValueString(), err),
+			)
+			return
+		}
+		data.Applications, diags = types.MapValueFrom(ctx, types.MapType{ElemType: types.StringType}, apps)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// cephPoolBulk reports whether the bulk flag is set on a pool, preferring the
+// flag names Ceph reports over the bit in flags.
+func cephPoolBulk(pool *CephAPIPool) bool {
+	if pool.FlagsNames != "" {
+		return slices.Contains(strings.Split(pool.FlagsNames, ","), "bulk")
+	}
+	return pool.Flags&cephPoolFlagBulk != 0
+}
@@ -6,10 +6,27 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
+func TestCephPoolBulk(t *testing.T) {
+	for _, tc := range []struct {
+		pool CephAPIPool
+		want bool
+	}{
+		{CephAPIPool{FlagsNames: "hashpspool,ec_overwrites,bulk", Flags: 1 | 1<<13 | 1<<17}, true},
+		{CephAPIPool{FlagsNames: "hashpspool", Flags: 1 | 1<<21}, false},
+		{CephAPIPool{Flags: 1 << 17}, true},
+		{CephAPIPool{Flags: 1 << 21}, false},
+	} {
+		if got := cephPoolBulk(&tc.pool); got != tc.want {
+			t.Errorf("cephPoolBulk(%+v) = %v, want %v", tc.pool, got, tc.want)
+		}
+	}
+}
+
 func TestAccCephPoolDataSource(t *testing.T) {
 	detachLogs := cephDaemonLogs.AttachTestFunction(t)
 	defer detachLogs()
@@ -29,6 +46,28 @@ func TestAccCephPoolDataSource(t *testing.T) {
 				t.Fatalf("Failed to disable autoscaler: %v", err)
 			}
 
+			if err := cephTestClusterCLI.PoolSet(t.Context(), poolName, "recovery_priority", "5"); err != nil {
+				t.Fatalf("Failed to set recovery priority: %v", err)
+			}
+
+			if err := cephTestClusterCLI.PoolSet(t.Context(), poolName, "scrub_min_interval", "3600"); err != nil {
+				t.Fatalf("Failed to set scrub interval: %v", err)
+			}
+
+			if err := cephTestClusterCLI.PoolApplicationEnable(t.Context(), poolName, "rbd"); err != nil {
+				t.Fatalf("Failed to enable application: %v", err)
+			}
+
+			if _, err := cephTestClusterCLI.MonCommand(t.Context(), map[string]any{
+				"prefix": "osd pool application set",
+				"pool":   poolName,
+				"app":    "rbd",
+				"key":    "owner",
+				"value":  "terraform",
+			}); err != nil {
+				t.Fatalf("Failed to set application metadata: %v", err)
+			}
+
 			testCleanup(t, func(ctx context.Context) {
 				if err := cephTestClusterCLI.PoolDelete(ctx, poolName); err != nil {
 					t.Errorf("Failed to cleanup pool %s: %v", poolName, err)
@@ -73,6 +112,62 @@ func TestAccCephPoolDataSource(t *testing.T) {
 						"crush_rule",
 						"replicated_rule",
 					),
+					resource.TestCheckResourceAttr(
+						"data.ceph_pool.test",
+						"fast_read",
+						"false",
+					),
+					resource.TestCheckResourceAttr(
+						"data.ceph_pool.test",
+						"bulk",
+						"false",
+					),
+					resource.TestCheckResourceAttr(
+						"data.ceph_pool.test",
+						"recovery_priority",
+						"5",
+					),
+					resource.TestCheckResourceAttr(
+						"data.ceph_pool.test",
+						"scrub_min_interval",
+						"3600",
+					),
+					resource.TestCheckNoResourceAttr(
+						"data.ceph_pool.test",
+						"deep_scrub_interval",
+					),
+					resource.TestCheckTypeSetElemAttr(
+						"data.ceph_pool.test",
+						"application_metadata.*",
+						"rbd",
+					),
+					resource.TestCheckNoResourceAttr(
+						"data.ceph_pool.test",
+						"applications",
+					),
+				),
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint":  config.StringVariable(testDashboardURL),
+					"ceph_conf": config.StringVariable(testConfPath),
+				},
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					data "ceph_pool" "test" {
+						name = "%s"
+					}
+				`, poolName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.ceph_pool.test",
+						"applications.%",
+						"1",
+					),
+					resource.TestCheckResourceAttr(
+						"data.ceph_pool.test",
+						"applications.rbd.owner",
+						"terraform",
+					),
 				),
 			},
 		},
@@ -98,6 +193,14 @@ func TestAccCephPoolDataSource_erasureCoded(t *testing.T) {
 				t.Fatalf("Failed to disable autoscaler: %v", err)
 			}
 
+			if err := cephTestClusterCLI.PoolSet(t.Context(), poolName, "bulk", "true"); err != nil {
+				t.Fatalf("Failed to set bulk flag: %v", err)
+			}
+
+			if err := cephTestClusterCLI.PoolSet(t.Context(), poolName, "fast_read", "1"); err != nil {
+				t.Fatalf("Failed to enable fast read: %v", err)
+			}
+
 			testCleanup(t, func(ctx context.Context) {
 				if err := cephTestClusterCLI.PoolDelete(ctx, poolName); err != nil {
 					t.Errorf("Failed to cleanup pool %s: %v", poolName, err)
@@ -131,6 +234,16 @@ func TestAccCephPoolDataSource_erasureCoded(t *testing.T) {
 						"pg_num",
 						"8",
 					),
+					resource.TestCheckResourceAttr(
+						"data.ceph_pool.test",
+						"bulk",
+						"true",
+					),
+					resource.TestCheckResourceAttr(
+						"data.ceph_pool.test",
+						"fast_read",
+						"true",
+					),
 				),
 			},
 		},
@@ -189,9 +302,9 @@ func TestAccCephPoolDataSource_withApplication(t *testing.T) {
 						"application_metadata.#",
 						"1",
 					),
-					resource.TestCheckResourceAttr(
+					resource.TestCheckTypeSetElemAttr(
 						"data.ceph_pool.test",
-						"application_metadata.0",
+						"application_metadata.*",
 						"rbd",
 					),
 				),
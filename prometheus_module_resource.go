@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	prometheusModuleResourceID = "prometheus"
+	prometheusModule           = "prometheus"
+)
+
+var (
+	_ resource.Resource                = &PrometheusModuleResource{}
+	_ resource.ResourceWithImportState = &PrometheusModuleResource{}
+)
+
+func newPrometheusModuleResource() resource.Resource {
+	return &PrometheusModuleResource{}
+}
+
+// PrometheusModuleResource enables the prometheus mgr module and manages its
+// options. Options left out of the configuration are not touched.
+type PrometheusModuleResource struct {
+	client *CephAPIClient
+}
+
+type PrometheusModuleResourceModel struct {
+	ID              types.String  `tfsdk:"id"`
+	ServerAddr      types.String  `tfsdk:"server_addr"`
+	ServerPort      types.Int64   `tfsdk:"server_port"`
+	ScrapeInterval  types.Float64 `tfsdk:"scrape_interval"`
+	RBDStatsPools   types.Set     `tfsdk:"rbd_stats_pools"`
+	MetricsEndpoint types.String  `tfsdk:"metrics_endpoint"`
+}
+
+// options lists the scalar module options. rbd_stats_pools is stored as a
+// single comma-separated option and converted separately.
+func (m *PrometheusModuleResourceModel) options() []mgrModuleOption {
+	return []mgrModuleOption{
+		{"server_addr", &m.ServerAddr},
+		{"server_port", &m.ServerPort},
+		{"scrape_interval", &m.ScrapeInterval},
+	}
+}
+
+func (r *PrometheusModuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prometheus_module"
+}
+
+func (r *PrometheusModuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Enables the `prometheus` mgr module and manages its settings. " +
+			"There is one module per cluster, so declare this resource at most once. " +
+			"Attributes that are not set are left unmanaged. Destroying the resource restores the Ceph defaults of the managed settings but leaves the module enabled.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `prometheus`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_addr": resourceSchema.StringAttribute{
+				MarkdownDescription: "The address the metrics exporter listens on, e.g. `::` for all addresses.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"server_port": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The port the metrics exporter listens on.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"scrape_interval": resourceSchema.Float64Attribute{
+				MarkdownDescription: "How often the module collects metrics, in seconds. Should match the Prometheus scrape interval.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(1),
+				},
+			},
+			"rbd_stats_pools": resourceSchema.SetAttribute{
+				MarkdownDescription: "Pools to collect per-image RBD statistics for, as `pool` or `pool/namespace`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"metrics_endpoint": resourceSchema.StringAttribute{
+				MarkdownDescription: "The URL the active mgr serves metrics on, as published in the mgr map. Null until the module has started.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *PrometheusModuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PrometheusModuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PrometheusModuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, PrometheusModuleResourceModel{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.MgrEnableModule(ctx, prometheusModule); err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to enable the prometheus module: %s", err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(prometheusModuleResourceID)
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PrometheusModuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PrometheusModuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PrometheusModuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state PrometheusModuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(prometheusModuleResourceID)
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PrometheusModuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PrometheusModuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, PrometheusModuleResourceModel{}, data)...)
+}
+
+func (r *PrometheusModuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != prometheusModuleResourceID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID '%s', got: %s", prometheusModuleResourceID, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), prometheusModuleResourceID)...)
+}
+
+// apply sets every option configured in data and resets the ones that were
+// managed in prior but are no longer configured.
+func (r *PrometheusModuleResource) apply(ctx context.Context, data PrometheusModuleResourceModel, prior PrometheusModuleResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rbdStatsPools, d := joinRBDStatsPools(ctx, data.RBDStatsPools)
+	diags.Append(d...)
+	priorRBDStatsPools, d := joinRBDStatsPools(ctx, prior.RBDStatsPools)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	options := append(data.options(), mgrModuleOption{"rbd_stats_pools", &rbdStatsPools})
+	priorOptions := append(prior.options(), mgrModuleOption{"rbd_stats_pools", &priorRBDStatsPools})
+
+	diags.Append(applyMgrModuleOptions(ctx, r.client, prometheusModule, options, priorOptions)...)
+	return diags
+}
+
+func (r *PrometheusModuleResource) read(ctx context.Context, data *PrometheusModuleResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rbdStatsPools, d := joinRBDStatsPools(ctx, data.RBDStatsPools)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	options := append(data.options(), mgrModuleOption{"rbd_stats_pools", &rbdStatsPools})
+	diags.Append(readMgrModuleOptions(ctx, r.client, prometheusModule, options)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if !rbdStatsPools.IsNull() {
+		data.RBDStatsPools, d = types.SetValueFrom(ctx, types.StringType, splitRBDStatsPools(rbdStatsPools.ValueString()))
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	health, err := r.client.GetHealthFull(ctx)
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read the mgr map: %s", err),
+		)
+		return diags
+	}
+
+	data.MetricsEndpoint = types.StringNull()
+	if endpoint, ok := health.MgrMap.Services[prometheusModule]; ok {
+		data.MetricsEndpoint = types.StringValue(endpoint)
+	}
+
+	return diags
+}
+
+// joinRBDStatsPools converts the rbd_stats_pools set to the comma-separated
+// form the module option uses, keeping null as null.
+func joinRBDStatsPools(ctx context.Context, pools types.Set) (types.String, diag.Diagnostics) {
+	if pools.IsNull() || pools.IsUnknown() {
+		return types.StringNull(), nil
+	}
+
+	var values []string
+	diags := pools.ElementsAs(ctx, &values, false)
+	slices.Sort(values)
+	return types.StringValue(strings.Join(values, ",")), diags
+}
+
+// splitRBDStatsPools parses the rbd_stats_pools option, which Ceph accepts
+// separated by commas or spaces.
+func splitRBDStatsPools(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestSplitRBDStatsPools(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", []string{}},
+		{"rbd", []string{"rbd"}},
+		{"rbd,images/ns1", []string{"rbd", "images/ns1"}},
+		{"rbd images, volumes", []string{"rbd", "images", "volumes"}},
+	}
+
+	for _, tt := range tests {
+		if got := splitRBDStatsPools(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("splitRBDStatsPools(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAccCephPrometheusModuleResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			checkCephConfigAbsent(t, "mgr", "mgr/prometheus/server_port"),
+			checkCephConfigAbsent(t, "mgr", "mgr/prometheus/scrape_interval"),
+			checkCephConfigAbsent(t, "mgr", "mgr/prometheus/rbd_stats_pools"),
+		),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_prometheus_module" "test" {
+					  server_port     = 9284
+					  scrape_interval = 30
+					  rbd_stats_pools = ["rbd", "images"]
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_prometheus_module.test", tfjsonpath.New("id"), knownvalue.StringExact("prometheus")),
+					statecheck.ExpectKnownValue("ceph_prometheus_module.test", tfjsonpath.New("server_port"), knownvalue.Int64Exact(9284)),
+					statecheck.ExpectKnownValue("ceph_prometheus_module.test", tfjsonpath.New("scrape_interval"), knownvalue.Float64Exact(30)),
+					statecheck.ExpectKnownValue("ceph_prometheus_module.test", tfjsonpath.New("rbd_stats_pools"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact("images"),
+						knownvalue.StringExact("rbd"),
+					})),
+					statecheck.ExpectKnownValue("ceph_prometheus_module.test", tfjsonpath.New("server_addr"), knownvalue.Null()),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigValue(t, "mgr", "mgr/prometheus/server_port", "9284"),
+					checkCephConfigValue(t, "mgr", "mgr/prometheus/rbd_stats_pools", "images,rbd"),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_prometheus_module" "test" {
+					  server_port = 9285
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_prometheus_module.test", tfjsonpath.New("server_port"), knownvalue.Int64Exact(9285)),
+					statecheck.ExpectKnownValue("ceph_prometheus_module.test", tfjsonpath.New("rbd_stats_pools"), knownvalue.Null()),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigValue(t, "mgr", "mgr/prometheus/server_port", "9285"),
+					checkCephConfigAbsent(t, "mgr", "mgr/prometheus/scrape_interval"),
+					checkCephConfigAbsent(t, "mgr", "mgr/prometheus/rbd_stats_pools"),
+				),
+			},
+		},
+	})
+}
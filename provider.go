@@ -2,39 +2,81 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	providerSchema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func providerFunc() provider.Provider {
 	return &CephProvider{
 		version: version,
+		metrics: newAPIMetrics(),
 	}
 }
 
 var (
 	_ provider.Provider                       = &CephProvider{}
 	_ provider.ProviderWithEphemeralResources = &CephProvider{}
+	_ provider.ProviderWithActions            = &CephProvider{}
+	_ provider.ProviderWithFunctions          = &CephProvider{}
 )
 
 type CephProvider struct {
 	version string
+
+	// metrics collects the API metrics of the provider instance, which main
+	// reports once Terraform stops the plugin.
+	metrics *apiMetrics
 }
 
 type CephProviderModel struct {
-	Endpoint  types.String `tfsdk:"endpoint"`
-	Endpoints types.List   `tfsdk:"endpoints"`
-	Token     types.String `tfsdk:"token"`
-	Username  types.String `tfsdk:"username"`
-	Password  types.String `tfsdk:"password"`
+	Endpoint           types.String `tfsdk:"endpoint"`
+	Endpoints          types.List   `tfsdk:"endpoints"`
+	Token              types.String `tfsdk:"token"`
+	Username           types.String `tfsdk:"username"`
+	Password           types.String `tfsdk:"password"`
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+	ReadCacheTTL       types.String `tfsdk:"read_cache_ttl"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	CACertificate      types.String `tfsdk:"ca_certificate"`
+	CLIBackend         types.Bool   `tfsdk:"cli_backend"`
+	CephConf           types.String `tfsdk:"ceph_conf"`
+	CephKeyring        types.String `tfsdk:"ceph_keyring"`
+	CephClientName     types.String `tfsdk:"ceph_client_name"`
+	RGWAdminEndpoint   types.String `tfsdk:"rgw_admin_endpoint"`
+	RGWAdminAccessKey  types.String `tfsdk:"rgw_admin_access_key"`
+	RGWAdminSecretKey  types.String `tfsdk:"rgw_admin_secret_key"`
+	RGWAdminRegion     types.String `tfsdk:"rgw_admin_region"`
+	RGWDaemon          types.String `tfsdk:"rgw_daemon"`
+	MaxConcurrent      types.Int64  `tfsdk:"max_concurrent_requests"`
+	RequireHealth      types.String `tfsdk:"require_health"`
+	APIVersions        types.Map    `tfsdk:"api_versions"`
+	TokenCache         types.String `tfsdk:"token_cache"`
+	TokenCacheDir      types.String `tfsdk:"token_cache_dir"`
+	DebugHTTP          types.Bool   `tfsdk:"debug_http"`
+	RequestID          types.String `tfsdk:"request_id"`
+	RequestHeaders     types.Map    `tfsdk:"request_headers"`
+	MetricsFile        types.String `tfsdk:"metrics_file"`
 }
 
 func (p *CephProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -46,28 +88,138 @@ func (p *CephProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 	resp.Schema = providerSchema.Schema{
 		Attributes: map[string]providerSchema.Attribute{
 			"endpoint": providerSchema.StringAttribute{
-				MarkdownDescription: "The Ceph API endpoint URL",
+				MarkdownDescription: "The Ceph API endpoint URL. Can also be set with the `CEPH_ENDPOINT` environment variable.",
 				Optional:            true,
 			},
 			"endpoints": providerSchema.ListAttribute{
 				ElementType:         types.StringType,
-				MarkdownDescription: "The Ceph API endpoint URLs",
+				MarkdownDescription: "The Ceph API endpoint URLs. Can also be set with the `CEPH_ENDPOINTS` environment variable as a comma-separated list.",
 				Optional:            true,
 			},
 			"token": providerSchema.StringAttribute{
-				MarkdownDescription: "The token to use for the provider",
+				MarkdownDescription: "The token to use for the provider. Can also be set with the `CEPH_TOKEN` environment variable.",
 				Optional:            true,
 				Sensitive:           true,
 			},
 			"username": providerSchema.StringAttribute{
-				MarkdownDescription: "The username for Ceph authentication",
+				MarkdownDescription: "The username for Ceph authentication. Can also be set with the `CEPH_USERNAME` environment variable.",
 				Optional:            true,
 			},
 			"password": providerSchema.StringAttribute{
-				MarkdownDescription: "The password for Ceph authentication",
+				MarkdownDescription: "The password for Ceph authentication. Can also be set with the `CEPH_PASSWORD` environment variable.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"request_timeout": providerSchema.StringAttribute{
+				MarkdownDescription: "The timeout for each Ceph API request as a duration string (e.g. `30s`, `5m`). Defaults to `10s`. It applies to every attempt of a request; the `timeouts` block of a resource operation bounds the whole operation, including retries of timed-out reads. A single slow request, such as deleting a bucket with many objects, needs a longer `request_timeout`. Can also be set with the `CEPH_REQUEST_TIMEOUT` environment variable.",
+				Optional:            true,
+			},
+			"read_cache_ttl": providerSchema.StringAttribute{
+				MarkdownDescription: "How long data sources may reuse a dashboard API response another data source already fetched, e.g. when many modules read the same pool. Any change made through the dashboard API by this provider instance clears the cache. Set to `0s` to disable. Defaults to `30s`. Can also be set with the `CEPH_READ_CACHE_TTL` environment variable.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": providerSchema.Int64Attribute{
+				MarkdownDescription: "The maximum number of Ceph API requests in flight at once, shared across all resources. Defaults to unlimited. Lower this if the mgr throttles or rejects requests when Terraform runs many resources in parallel. Can also be set with the `CEPH_MAX_CONCURRENT_REQUESTS` environment variable.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"require_health": providerSchema.StringAttribute{
+				MarkdownDescription: "The worst cluster health status the provider will operate against: `HEALTH_OK`, `HEALTH_WARN` or `any`. When the cluster is in a worse state, the provider fails at configuration time with the active health checks. Defaults to `any`. Can also be set with the `CEPH_REQUIRE_HEALTH` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("HEALTH_OK", "HEALTH_WARN", "any"),
+				},
+			},
+			"api_versions": providerSchema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Overrides the dashboard API version requested for API paths, as path prefix to version, e.g. `{ \"/api/crush_rule\" = \"2.0\" }`. The longest matching prefix wins. Only needed when a Ceph release serves a path under a different major version than the provider requests and the new version is compatible; older minor versions are negotiated automatically.",
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^/api/`), "must be an API path starting with /api/")),
+					mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(cephAPIVersionPattern, "must be a version such as 1.0")),
+				},
+			},
+			"token_cache": providerSchema.StringAttribute{
+				MarkdownDescription: "Where to cache the dashboard token obtained with `username` and `password`, so provider instances targeting the same cluster with the same credentials reuse it instead of logging in again: `none`, `memory` (shared by the provider instances of one Terraform run) or `disk` (shared across runs and workspaces). Cached tokens are reused until shortly before they expire. Defaults to `none`. Can also be set with the `CEPH_TOKEN_CACHE` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("none", "memory", "disk"),
+				},
+			},
+			"token_cache_dir": providerSchema.StringAttribute{
+				MarkdownDescription: "The directory `token_cache = \"disk\"` stores tokens in. Anyone who can read it can use the cached tokens. Defaults to `terraform-provider-ceph` in the user cache directory (e.g. `~/.cache`). Can also be set with the `CEPH_TOKEN_CACHE_DIR` environment variable.",
+				Optional:            true,
+			},
+			"debug_http": providerSchema.BoolAttribute{
+				MarkdownDescription: "Log every HTTP request and response made to the dashboard and RGW admin APIs, including headers and bodies, at `TRACE` level (`TF_LOG_PROVIDER=TRACE`). Passwords, tokens, secret keys and keyrings are masked. Can also be set with the `CEPH_DEBUG_HTTP` environment variable.",
+				Optional:            true,
+			},
+			"request_id": providerSchema.StringAttribute{
+				MarkdownDescription: "Sent as the `X-Request-ID` header of every dashboard and RGW admin API request and included in the provider's request logs, e.g. a CI pipeline or Terraform run ID, so the requests of a run can be correlated with the logs of proxies in front of the cluster. The dashboard audit log does not record headers. Can also be set with the `CEPH_REQUEST_ID` environment variable.",
+				Optional:            true,
+			},
+			"request_headers": providerSchema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional headers sent with every dashboard and RGW admin API request, as header name to value.",
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(httpHeaderNamePattern, "must be a valid HTTP header name"),
+						stringvalidator.NoneOfCaseInsensitive("Accept", "Authorization", "Content-Length", "Content-Type", "Host", requestIDHeader),
+					),
+				},
+			},
+			"metrics_file": providerSchema.StringAttribute{
+				MarkdownDescription: "Path of a file to write metrics about the provider's API requests to in the Prometheus text format, by endpoint: request counts by status code, retries and a latency histogram. The file is written when Terraform stops the provider at the end of a plan or apply. A summary of the same metrics is always logged then at `DEBUG` level. Can also be set with the `CEPH_METRICS_FILE` environment variable.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": providerSchema.BoolAttribute{
+				MarkdownDescription: "Skip TLS certificate verification of the Ceph API endpoint. Can also be set with the `CEPH_INSECURE_SKIP_VERIFY` environment variable.",
+				Optional:            true,
+			},
+			"ca_certificate": providerSchema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificate used to verify the Ceph API endpoint. Can also be set with the `CEPH_CA_CERTIFICATE` environment variable.",
+				Optional:            true,
+			},
+			"cli_backend": providerSchema.BoolAttribute{
+				MarkdownDescription: "Enable the `ceph`/`radosgw-admin` CLI backend for operations the Ceph Dashboard API does not expose. Requires the Ceph CLI tools on the machine running Terraform. Can also be set with the `CEPH_CLI_BACKEND` environment variable.",
+				Optional:            true,
+			},
+			"ceph_conf": providerSchema.StringAttribute{
+				MarkdownDescription: "Path to the `ceph.conf` used by the CLI backend. Required when `cli_backend` is enabled. Can also be set with the `CEPH_CONF` environment variable.",
+				Optional:            true,
+			},
+			"ceph_keyring": providerSchema.StringAttribute{
+				MarkdownDescription: "Path to the keyring used by the CLI backend. Defaults to the keyring configured in `ceph_conf`. Can also be set with the `CEPH_KEYRING` environment variable.",
+				Optional:            true,
+			},
+			"ceph_client_name": providerSchema.StringAttribute{
+				MarkdownDescription: "The cephx entity the CLI backend authenticates as (e.g. `client.admin`). Can also be set with the `CEPH_CLIENT_NAME` environment variable.",
+				Optional:            true,
+			},
+			"rgw_admin_endpoint": providerSchema.StringAttribute{
+				MarkdownDescription: "The radosgw URL to use for the RGW admin ops API. When set, RGW user and bucket operations bypass the Ceph Dashboard API. Can also be set with the `CEPH_RGW_ADMIN_ENDPOINT` environment variable.",
+				Optional:            true,
+			},
+			"rgw_admin_access_key": providerSchema.StringAttribute{
+				MarkdownDescription: "The S3 access key of an RGW user with admin caps, used to sign RGW admin ops requests. Can also be set with the `CEPH_RGW_ADMIN_ACCESS_KEY` environment variable.",
+				Optional:            true,
+			},
+			"rgw_admin_secret_key": providerSchema.StringAttribute{
+				MarkdownDescription: "The S3 secret key matching `rgw_admin_access_key`. Can also be set with the `CEPH_RGW_ADMIN_SECRET_KEY` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"rgw_admin_region": providerSchema.StringAttribute{
+				MarkdownDescription: "The region used when signing RGW admin ops requests. Defaults to `us-east-1`. Can also be set with the `CEPH_RGW_ADMIN_REGION` environment variable.",
+				Optional:            true,
+			},
+			"rgw_daemon": providerSchema.StringAttribute{
+				MarkdownDescription: "The radosgw daemon (as listed by the dashboard, e.g. `rgw.zone-a.host1.abcdef`) that dashboard RGW requests are sent to. By default the dashboard picks one, which in a multisite cluster may belong to another zone. Individual `ceph_rgw_user` and `ceph_rgw_bucket` resources can override it with their own `rgw_daemon`. Has no effect on requests sent through `rgw_admin_endpoint`. Can also be set with the `CEPH_RGW_DAEMON` environment variable.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -81,10 +233,10 @@ func (p *CephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	endpoint := data.Endpoint.ValueString()
-	token := data.Token.ValueString()
-	username := data.Username.ValueString()
-	password := data.Password.ValueString()
+	endpoint := stringValueOrEnv(data.Endpoint, "CEPH_ENDPOINT")
+	token := stringValueOrEnv(data.Token, "CEPH_TOKEN")
+	username := stringValueOrEnv(data.Username, "CEPH_USERNAME")
+	password := stringValueOrEnv(data.Password, "CEPH_PASSWORD")
 
 	// Either token or username/password must be provided
 	if token == "" && (username == "" || password == "") {
@@ -102,6 +254,13 @@ func (p *CephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	for _, endpoint := range data.Endpoints.Elements() {
 		endpointStrings = append(endpointStrings, endpoint.(types.String).ValueString())
 	}
+	if data.Endpoints.IsNull() {
+		if envEndpoints := os.Getenv("CEPH_ENDPOINTS"); envEndpoints != "" {
+			for _, endpoint := range strings.Split(envEndpoints, ",") {
+				endpointStrings = append(endpointStrings, strings.TrimSpace(endpoint))
+			}
+		}
+	}
 	if len(endpointStrings) == 0 {
 		resp.Diagnostics.AddError(
 			"Missing Configuration",
@@ -113,35 +272,236 @@ func (p *CephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	// Parse and validate all endpoint strings into URL objects
 	parsedEndpoints := make([]*url.URL, 0, len(endpointStrings))
 	for _, endpointStr := range endpointStrings {
-		if endpointStr == "" {
+		parsedURL, err := parseEndpointURL(endpointStr)
+		if err != nil {
 			resp.Diagnostics.AddError(
 				"Invalid Configuration",
-				"Endpoint cannot be empty",
+				err.Error(),
 			)
 			return
 		}
-		if strings.HasSuffix(endpointStr, "/api") {
+		parsedEndpoints = append(parsedEndpoints, parsedURL)
+	}
+
+	requestTimeout := defaultRequestTimeout
+	if requestTimeoutStr := stringValueOrEnv(data.RequestTimeout, "CEPH_REQUEST_TIMEOUT"); requestTimeoutStr != "" {
+		parsedTimeout, err := time.ParseDuration(requestTimeoutStr)
+		if err != nil || parsedTimeout <= 0 {
+			resp.Diagnostics.AddError(
+				"Invalid Configuration",
+				fmt.Sprintf("request_timeout must be a positive duration (e.g. '30s'), got: %s", requestTimeoutStr),
+			)
+			return
+		}
+		requestTimeout = parsedTimeout
+	}
+
+	readCacheTTL := defaultReadCacheTTL
+	if readCacheTTLStr := stringValueOrEnv(data.ReadCacheTTL, "CEPH_READ_CACHE_TTL"); readCacheTTLStr != "" {
+		parsedTTL, err := time.ParseDuration(readCacheTTLStr)
+		if err != nil || parsedTTL < 0 {
 			resp.Diagnostics.AddError(
 				"Invalid Configuration",
-				fmt.Sprintf("Endpoint SHOULD NOT end with '/api', got: %s", endpointStr),
+				fmt.Sprintf("read_cache_ttl must be a duration (e.g. '30s'), got: %s", readCacheTTLStr),
+			)
+			return
+		}
+		readCacheTTL = parsedTTL
+	}
+
+	maxConcurrentRequests := data.MaxConcurrent.ValueInt64()
+	if data.MaxConcurrent.IsNull() {
+		if envMaxConcurrent := os.Getenv("CEPH_MAX_CONCURRENT_REQUESTS"); envMaxConcurrent != "" {
+			parsedMaxConcurrent, err := strconv.ParseInt(envMaxConcurrent, 10, 64)
+			if err != nil || parsedMaxConcurrent < 1 {
+				resp.Diagnostics.AddError(
+					"Invalid Configuration",
+					fmt.Sprintf("CEPH_MAX_CONCURRENT_REQUESTS must be a positive integer, got: %s", envMaxConcurrent),
+				)
+				return
+			}
+			maxConcurrentRequests = parsedMaxConcurrent
+		}
+	}
+
+	insecureSkipVerify := data.InsecureSkipVerify.ValueBool()
+	if data.InsecureSkipVerify.IsNull() {
+		if envInsecure := os.Getenv("CEPH_INSECURE_SKIP_VERIFY"); envInsecure != "" {
+			parsedInsecure, err := strconv.ParseBool(envInsecure)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid Configuration",
+					fmt.Sprintf("CEPH_INSECURE_SKIP_VERIFY must be a boolean, got: %s", envInsecure),
+				)
+				return
+			}
+			insecureSkipVerify = parsedInsecure
+		}
+	}
+
+	debugHTTP := data.DebugHTTP.ValueBool()
+	if data.DebugHTTP.IsNull() {
+		if envDebugHTTP := os.Getenv("CEPH_DEBUG_HTTP"); envDebugHTTP != "" {
+			parsedDebugHTTP, err := strconv.ParseBool(envDebugHTTP)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid Configuration",
+					fmt.Sprintf("CEPH_DEBUG_HTTP must be a boolean, got: %s", envDebugHTTP),
+				)
+				return
+			}
+			debugHTTP = parsedDebugHTTP
+		}
+	}
+
+	transport, err := sharedTransport(insecureSkipVerify, stringValueOrEnv(data.CACertificate, "CEPH_CA_CERTIFICATE"))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Configuration",
+			err.Error(),
+		)
+		return
+	}
+
+	cliBackend := data.CLIBackend.ValueBool()
+	if data.CLIBackend.IsNull() {
+		if envCLIBackend := os.Getenv("CEPH_CLI_BACKEND"); envCLIBackend != "" {
+			parsedCLIBackend, err := strconv.ParseBool(envCLIBackend)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid Configuration",
+					fmt.Sprintf("CEPH_CLI_BACKEND must be a boolean, got: %s", envCLIBackend),
+				)
+				return
+			}
+			cliBackend = parsedCLIBackend
+		}
+	}
+
+	var cli *CephCLI
+	if cliBackend {
+		confPath := stringValueOrEnv(data.CephConf, "CEPH_CONF")
+		if confPath == "" {
+			resp.Diagnostics.AddError(
+				"Missing Configuration",
+				"ceph_conf must be configured when cli_backend is enabled",
 			)
 			return
 		}
+		cli = &CephCLI{
+			confPath:    confPath,
+			keyringPath: stringValueOrEnv(data.CephKeyring, "CEPH_KEYRING"),
+			clientName:  stringValueOrEnv(data.CephClientName, "CEPH_CLIENT_NAME"),
+		}
+	}
 
-		parsedURL, err := url.Parse(endpointStr)
+	var cache *tokenCache
+	switch tokenCacheMode := stringValueOrEnv(data.TokenCache, "CEPH_TOKEN_CACHE"); tokenCacheMode {
+	case "", "none":
+	case "memory":
+		cache = &tokenCache{}
+	case "disk":
+		cacheDir := stringValueOrEnv(data.TokenCacheDir, "CEPH_TOKEN_CACHE_DIR")
+		if cacheDir == "" {
+			userCacheDir, err := os.UserCacheDir()
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Missing Configuration",
+					fmt.Sprintf("token_cache_dir must be configured when there is no user cache directory: %s", err),
+				)
+				return
+			}
+			cacheDir = filepath.Join(userCacheDir, "terraform-provider-ceph")
+		}
+		cache = &tokenCache{dir: cacheDir}
+	default:
+		resp.Diagnostics.AddError(
+			"Invalid Configuration",
+			fmt.Sprintf("token_cache must be one of none, memory or disk, got: %s", tokenCacheMode),
+		)
+		return
+	}
+
+	roundTripper := transport
+	if maxConcurrentRequests > 0 {
+		roundTripper = newConcurrencyLimitedTransport(transport, int(maxConcurrentRequests))
+	}
+	if debugHTTP {
+		roundTripper = newDebugHTTPTransport(roundTripper, token, password, stringValueOrEnv(data.RGWAdminSecretKey, "CEPH_RGW_ADMIN_SECRET_KEY"))
+	}
+	requestHeaders := make(map[string]string, len(data.RequestHeaders.Elements())+1)
+	for name, value := range data.RequestHeaders.Elements() {
+		requestHeaders[name] = value.(types.String).ValueString()
+	}
+	if requestID := stringValueOrEnv(data.RequestID, "CEPH_REQUEST_ID"); requestID != "" {
+		requestHeaders[requestIDHeader] = requestID
+	}
+	if len(requestHeaders) > 0 {
+		roundTripper = &headerTransport{base: roundTripper, headers: requestHeaders}
+	}
+	roundTripper = &standbyRedirectTransport{base: roundTripper}
+	apiVersions := make(map[string]string, len(data.APIVersions.Elements()))
+	for apiPath, version := range data.APIVersions.Elements() {
+		apiVersions[apiPath] = version.(types.String).ValueString()
+	}
+	roundTripper = &retryTransport{
+		base: &requestTimeoutTransport{
+			base:    newAPIVersionTransport(roundTripper, apiVersions),
+			timeout: requestTimeout,
+		},
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultRetryBackoff,
+		metrics:    p.metrics,
+	}
+	roundTripper = &metricsTransport{base: roundTripper, metrics: p.metrics}
+	p.metrics.setReport(ctx, stringValueOrEnv(data.MetricsFile, "CEPH_METRICS_FILE"))
+	if readCacheTTL > 0 {
+		roundTripper = newReadCacheTransport(roundTripper, readCacheTTL)
+	}
+	httpClient := &http.Client{Transport: roundTripper}
+
+	var rgwAdmin *RGWAdminOpsClient
+	rgwAdminEndpoint := stringValueOrEnv(data.RGWAdminEndpoint, "CEPH_RGW_ADMIN_ENDPOINT")
+	rgwAdminAccessKey := stringValueOrEnv(data.RGWAdminAccessKey, "CEPH_RGW_ADMIN_ACCESS_KEY")
+	rgwAdminSecretKey := stringValueOrEnv(data.RGWAdminSecretKey, "CEPH_RGW_ADMIN_SECRET_KEY")
+	if rgwAdminEndpoint != "" || rgwAdminAccessKey != "" || rgwAdminSecretKey != "" {
+		if rgwAdminEndpoint == "" || rgwAdminAccessKey == "" || rgwAdminSecretKey == "" {
+			resp.Diagnostics.AddError(
+				"Missing Configuration",
+				"rgw_admin_endpoint, rgw_admin_access_key and rgw_admin_secret_key must be configured together",
+			)
+			return
+		}
+		rgwAdminURL, err := parseEndpointURL(rgwAdminEndpoint)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Invalid Configuration",
-				fmt.Sprintf("Unable to parse endpoint URL %s: %s", endpointStr, err),
+				fmt.Sprintf("rgw_admin_endpoint is invalid: %s", err),
 			)
 			return
 		}
-		parsedEndpoints = append(parsedEndpoints, parsedURL)
+		rgwAdminRegion := stringValueOrEnv(data.RGWAdminRegion, "CEPH_RGW_ADMIN_REGION")
+		if rgwAdminRegion == "" {
+			rgwAdminRegion = rgwAdminOpsDefaultRegion
+		}
+		rgwAdmin = &RGWAdminOpsClient{
+			endpoint:  rgwAdminURL,
+			accessKey: rgwAdminAccessKey,
+			secretKey: rgwAdminSecretKey,
+			region:    rgwAdminRegion,
+			client:    httpClient,
+		}
 	}
 
 	// Configure the Ceph API client with authentication
-	cephClient := &CephAPIClient{}
-	err := cephClient.Configure(ctx, parsedEndpoints, username, password, token)
+	cephClient := &CephAPIClient{
+		client:     httpClient,
+		cli:        cli,
+		rgwAdmin:   rgwAdmin,
+		tokenCache: cache,
+		rgwDaemon:  stringValueOrEnv(data.RGWDaemon, "CEPH_RGW_DAEMON"),
+	}
+	err = cephClient.Configure(ctx, parsedEndpoints, username, password, token)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Authentication Error",
@@ -150,43 +510,208 @@ func (p *CephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	cephClient.detectRelease(ctx)
+
+	if requireHealth := stringValueOrEnv(data.RequireHealth, "CEPH_REQUIRE_HEALTH"); requireHealth != "" && requireHealth != "any" {
+		if _, ok := cephHealthSeverity[requireHealth]; !ok {
+			resp.Diagnostics.AddError(
+				"Invalid Configuration",
+				fmt.Sprintf("require_health must be one of HEALTH_OK, HEALTH_WARN or any, got: %s", requireHealth),
+			)
+			return
+		}
+
+		health, err := cephClient.GetHealthMinimal(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to check cluster health: %s", err),
+			)
+			return
+		}
+
+		if !cephHealthAtLeast(health.Health.Status, requireHealth) {
+			resp.Diagnostics.AddError(
+				"Cluster Health Check Failed",
+				fmt.Sprintf("Cluster health is %s but require_health is %s.%s", health.Health.Status, requireHealth, formatCephHealthChecks(health.Health.Checks)),
+			)
+			return
+		}
+	}
+
 	resp.DataSourceData = cephClient
 	resp.ResourceData = cephClient
 	resp.EphemeralResourceData = cephClient
+	resp.ActionData = cephClient
+}
+
+// parseEndpointURL validates a dashboard endpoint and normalizes its path so
+// that API paths can be joined onto dashboards served under a URL prefix.
+func parseEndpointURL(endpointStr string) (*url.URL, error) {
+	if endpointStr == "" {
+		return nil, errors.New("endpoint cannot be empty")
+	}
+
+	parsedURL, err := url.Parse(endpointStr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse endpoint URL %s: %w", endpointStr, err)
+	}
+
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return nil, fmt.Errorf("endpoint must use the http or https scheme, got: %s", endpointStr)
+	}
+	if parsedURL.Host == "" {
+		return nil, fmt.Errorf("endpoint must include a host, got: %s", endpointStr)
+	}
+	if parsedURL.RawQuery != "" || parsedURL.Fragment != "" {
+		return nil, fmt.Errorf("endpoint should not include a query or fragment, got: %s", endpointStr)
+	}
+
+	parsedURL.Path = strings.TrimRight(parsedURL.Path, "/")
+	parsedURL.RawPath = strings.TrimRight(parsedURL.RawPath, "/")
+
+	if strings.HasSuffix(parsedURL.Path, "/api") {
+		return nil, fmt.Errorf("endpoint should not end with '/api', got: %s", endpointStr)
+	}
+
+	return parsedURL, nil
+}
+
+// httpHeaderNamePattern matches the token characters RFC 9110 allows in
+// header names.
+var httpHeaderNamePattern = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+
+var cephHealthSeverity = map[string]int{
+	"HEALTH_OK":   0,
+	"HEALTH_WARN": 1,
+	"HEALTH_ERR":  2,
+}
+
+// cephHealthAtLeast reports whether status is as good as or better than
+// required. Unknown statuses are treated as failing.
+func cephHealthAtLeast(status, required string) bool {
+	statusSeverity, ok := cephHealthSeverity[status]
+	if !ok {
+		return false
+	}
+	return statusSeverity <= cephHealthSeverity[required]
+}
+
+func formatCephHealthChecks(checks []CephAPIHealthCheck) string {
+	var b strings.Builder
+	for _, check := range checks {
+		fmt.Fprintf(&b, "\n  %s %s: %s", check.Severity, check.Type, check.Summary.Message)
+	}
+	return b.String()
+}
+
+func stringValueOrEnv(value types.String, envVar string) string {
+	if value.IsNull() {
+		return os.Getenv(envVar)
+	}
+	return value.ValueString()
+}
+
+func (p *CephProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		newDaemonRestartAction,
+	}
+}
+
+func (p *CephProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newCephFSCapsFunction,
+		newParseKeyringFunction,
+		newRBDCapsFunction,
+		newRecommendedPGNumFunction,
+	}
 }
 
 func (p *CephProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
 	return []func() ephemeral.EphemeralResource{
 		newAuthEphemeralResource,
+		newAuthTokenEphemeralResource,
 	}
 }
 
 func (p *CephProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		newAlertSilenceResource,
 		newAuthResource,
+		newBalancerResource,
+		newCephadmContainerImageResource,
+		newCephadmRegistryLoginResource,
+		newCephadmSSHResource,
+		newClusterFeaturesResource,
+		newCommandResource,
 		newConfigResource,
+		newCrashArchiveResource,
+		newCrashResource,
 		newCrushRuleResource,
+		newDashboardFeatureTogglesResource,
+		newDashboardMonitoringResource,
+		newDashboardSSOResource,
+		newDeviceHealthResource,
 		newErasureCodeProfileResource,
+		newFsMirrorPeerResource,
+		newFsMirrorResource,
+		newFsQuotaResource,
+		newFsSubvolumeCloneResource,
+		newFsSubvolumeSnapshotResource,
+		newHostLabelResource,
+		newMgrConfigResource,
 		newMgrModuleConfigResource,
+		newMonCrushLocationResource,
+		newMonElectionStrategyResource,
+		newOSDCrushTunablesResource,
+		newOSDDeviceClassResource,
+		newOrchUpgradeResource,
+		newPGAutoscalerResource,
+		newPrometheusModuleResource,
+		newRadosNamespaceResource,
+		newRBDConfigResource,
+		newRBDTrashPurgeScheduleResource,
+		newRGWAccountResource,
 		newRGWBucketResource,
+		newRGWBucketACLResource,
+		newRGWBucketCORSResource,
+		newRGWBucketEncryptionResource,
+		newRGWBucketSyncResource,
+		newRGWGCLCTuningResource,
 		newRGWS3KeyResource,
+		newRGWSyncGroupResource,
 		newRGWUserResource,
+		newRGWUserMFAResource,
+		newStretchModeResource,
 	}
 }
 
 func (p *CephProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		newAuthDataSource,
+		newCephFSFilesystemsDataSource,
+		newClientConfDataSource,
 		newConfigDataSource,
 		newConfigValueDataSource,
+		newCSIConfigDataSource,
 		newCrushRuleDataSource,
+		newCrushTreeDataSource,
+		newDeviceInventoryDataSource,
 		newErasureCodeProfileDataSource,
 		newMgrModuleConfigDataSource,
+		newMgrServicesDataSource,
+		newNFSClustersDataSource,
+		newNFSExportsDataSource,
+		newOrchDaemonsDataSource,
+		newOrchServicesDataSource,
 		newPoolDataSource,
+		newRBDMirrorBootstrapTokenDataSource,
 		newRGWBucketDataSource,
+		newRGWBucketStatsDataSource,
 		newRGWS3KeyDataSource,
 		newRGWSubuserDataSource,
 		newRGWSwiftKeyDataSource,
+		newRGWUsageDataSource,
 		newRGWUserDataSource,
 	}
 }
@@ -149,6 +149,10 @@ func startCephCluster(ctx context.Context, tmpDir string, out io.Writer) (string
 		return "", "", nil, err
 	}
 
+	if err := startCephMds(&wg, ctx, confPath, out); err != nil {
+		return "", "", nil, err
+	}
+
 	dashboardURL, err := enableCephDashboard(startupCtx, confPath, out)
 	if err != nil {
 		return "", "", nil, err
@@ -202,6 +206,10 @@ func setupCephDir(ctx context.Context, tmpDir string, out io.Writer) (string, er
 			"osd_objectstore": "memstore",
 			"debug_osd":       "0",
 		},
+		"mds": {
+			"mds_data":  filepath.Join(tmpDir, "mds", "ceph-$id"),
+			"debug_mds": "0",
+		},
 		"client.rgw.rgw1": {
 			"rgw_data":      filepath.Join(tmpDir, "rgw", "ceph-rgw1"),
 			"rgw_frontends": "beast port=7480",
@@ -227,6 +235,13 @@ func setupCephDir(ctx context.Context, tmpDir string, out io.Writer) (string, er
 			"caps osd": "allow *",
 			"caps mds": "allow *",
 		},
+		"mds.mds1": {
+			"key":      "AQDSm89oNP7bAxAA6TgZ1toOkhDjUNEkRL18Gg==",
+			"caps mon": "allow profile mds",
+			"caps osd": "allow rwx",
+			"caps mds": "allow *",
+			"caps mgr": "allow profile mds",
+		},
 		"client.rgw.rgw1": {
 			"key":      "AQDRm89oNP7bAxAA6TgZ1toOkhDjUNEkRL18Gg==",
 			"caps mon": "allow rw",
@@ -266,6 +281,11 @@ func setupCephDir(ctx context.Context, tmpDir string, out io.Writer) (string, er
 		return confPath, err
 	}
 
+	err = os.MkdirAll(filepath.Join(tmpDir, "mds", "ceph-mds1"), 0o755)
+	if err != nil {
+		return confPath, err
+	}
+
 	err = os.MkdirAll(filepath.Join(tmpDir, "run"), 0o755)
 	if err != nil {
 		return confPath, err
@@ -537,6 +557,27 @@ func waitForCephRgw(ctx context.Context) error {
 	}
 }
 
+// startCephMds starts a single MDS. It stays in standby until a test creates
+// a filesystem for it to serve.
+func startCephMds(wg *sync.WaitGroup, ctx context.Context, confPath string, out io.Writer) error {
+	cmd := exec.CommandContext(ctx, "ceph-mds", "--conf", confPath, "--id", "mds1", "--foreground")
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	err := cmd.Start()
+	if err != nil {
+		return fmt.Errorf("failed to start MDS: %w", err)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = cmd.Wait()
+	}()
+
+	return nil
+}
+
 func enableCephDashboard(ctx context.Context, confPath string, out io.Writer) (string, error) {
 	cmd := exec.CommandContext(ctx, "ceph", "--conf", confPath, "mgr", "module", "enable", "dashboard")
 	cmd.Stdout = out
@@ -631,6 +672,28 @@ func testCleanup(t *testing.T, fn func(context.Context)) {
 	})
 }
 
+// recreateTestPool deletes a pool out of band and creates an empty pool of
+// the same name, with the given application enabled unless it is empty.
+func recreateTestPool(t *testing.T, poolName, application string) {
+	t.Helper()
+
+	if err := cephTestClusterCLI.PoolDelete(t.Context(), poolName); err != nil {
+		t.Fatalf("Failed to delete pool out of band: %v", err)
+	}
+
+	if err := cephTestClusterCLI.PoolCreate(t.Context(), poolName, 8, ""); err != nil {
+		t.Fatalf("Failed to recreate pool: %v", err)
+	}
+
+	if application == "" {
+		return
+	}
+
+	if err := cephTestClusterCLI.PoolApplicationEnable(t.Context(), poolName, application); err != nil {
+		t.Fatalf("Failed to enable %s application: %v", application, err)
+	}
+}
+
 type TestWriter struct {
 	t *testing.T
 }
@@ -870,3 +933,464 @@ func TestAccProvider_tokenAuthentication(t *testing.T) {
 		},
 	})
 }
+
+func TestAccProvider_invalidRequestTimeout(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ceph" {
+					  endpoint        = "https://ceph.example.com"
+					  username        = "admin"
+					  password        = "password"
+					  request_timeout = "soon"
+					}
+
+					data "ceph_auth" "test" {
+					  entity = "client.admin"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)request_timeout must be a positive duration`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_requestTimeout(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config: `
+					variable "endpoint" {
+					  type = string
+					}
+
+					provider "ceph" {
+					  endpoint        = var.endpoint
+					  username        = "admin"
+					  password        = "password"
+					  request_timeout = "2m"
+					}
+
+					data "ceph_auth" "test" {
+					  entity = "client.admin"
+					}
+				`,
+			},
+		},
+	})
+}
+
+func TestAccProvider_environmentVariables(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			t.Setenv("CEPH_ENDPOINTS", "http://127.0.0.1:1,"+testDashboardURL)
+			t.Setenv("CEPH_USERNAME", "admin")
+			t.Setenv("CEPH_PASSWORD", "password")
+			t.Setenv("CEPH_REQUEST_TIMEOUT", "30s")
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ceph" {}
+
+					data "ceph_auth" "test" {
+					  entity = "client.admin"
+					}
+				`,
+			},
+		},
+	})
+}
+
+func TestAccProvider_environmentVariablesOverriddenByConfig(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			t.Setenv("CEPH_ENDPOINT", testDashboardURL)
+			t.Setenv("CEPH_USERNAME", "admin")
+			t.Setenv("CEPH_PASSWORD", "password")
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ceph" {
+					  password = "wrongpassword"
+					}
+
+					data "ceph_auth" "test" {
+					  entity = "client.admin"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)failed to configure ceph api client`),
+			},
+		},
+	})
+}
+
+func TestParseEndpointURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+		wantErr  string
+	}{
+		{endpoint: "https://ceph.example.com", want: "https://ceph.example.com"},
+		{endpoint: "https://ceph.example.com/", want: "https://ceph.example.com"},
+		{endpoint: "https://ceph.example.com/dashboard", want: "https://ceph.example.com/dashboard"},
+		{endpoint: "https://ceph.example.com/dashboard/", want: "https://ceph.example.com/dashboard"},
+		{endpoint: "http://10.0.0.1:8080/ceph/dashboard//", want: "http://10.0.0.1:8080/ceph/dashboard"},
+		{endpoint: "", wantErr: "endpoint cannot be empty"},
+		{endpoint: "://invalid-url", wantErr: "unable to parse endpoint URL"},
+		{endpoint: "ceph.example.com/dashboard", wantErr: "http or https scheme"},
+		{endpoint: "https:///dashboard", wantErr: "must include a host"},
+		{endpoint: "https://ceph.example.com/dashboard?x=1", wantErr: "query or fragment"},
+		{endpoint: "https://ceph.example.com/api", wantErr: "should not end with '/api'"},
+		{endpoint: "https://ceph.example.com/dashboard/api/", wantErr: "should not end with '/api'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			got, err := parseEndpointURL(tt.endpoint)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseEndpointURL() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseEndpointURL() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("parseEndpointURL() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestAccProvider_cliBackendMissingConf(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	t.Setenv("CEPH_CONF", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config: `
+					variable "endpoint" {
+					  type = string
+					}
+
+					provider "ceph" {
+					  endpoint    = var.endpoint
+					  username    = "admin"
+					  password    = "password"
+					  cli_backend = true
+					}
+
+					data "ceph_auth" "test" {
+					  entity = "client.admin"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)ceph_conf must be configured when cli_backend is enabled`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_maxConcurrentRequests(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config: `
+					variable "endpoint" {
+					  type = string
+					}
+
+					provider "ceph" {
+					  endpoint                = var.endpoint
+					  username                = "admin"
+					  password                = "password"
+					  max_concurrent_requests = 1
+					}
+
+					data "ceph_auth" "admin" {
+					  entity = "client.admin"
+					}
+
+					data "ceph_config_value" "test" {
+					  name    = "mon_max_pg_per_osd"
+					  section = "global"
+					}
+				`,
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config: `
+					variable "endpoint" {
+					  type = string
+					}
+
+					provider "ceph" {
+					  endpoint                = var.endpoint
+					  username                = "admin"
+					  password                = "password"
+					  max_concurrent_requests = 0
+					}
+
+					data "ceph_auth" "admin" {
+					  entity = "client.admin"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)must be at least 1`),
+			},
+		},
+	})
+}
+
+func TestCephHealthAtLeast(t *testing.T) {
+	tests := []struct {
+		status   string
+		required string
+		want     bool
+	}{
+		{status: "HEALTH_OK", required: "HEALTH_OK", want: true},
+		{status: "HEALTH_OK", required: "HEALTH_WARN", want: true},
+		{status: "HEALTH_WARN", required: "HEALTH_OK", want: false},
+		{status: "HEALTH_WARN", required: "HEALTH_WARN", want: true},
+		{status: "HEALTH_ERR", required: "HEALTH_WARN", want: false},
+		{status: "", required: "HEALTH_WARN", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := cephHealthAtLeast(tt.status, tt.required); got != tt.want {
+			t.Errorf("cephHealthAtLeast(%q, %q) = %v, want %v", tt.status, tt.required, got, tt.want)
+		}
+	}
+}
+
+func TestAccProvider_requireHealth(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config: `
+					variable "endpoint" {
+					  type = string
+					}
+
+					provider "ceph" {
+					  endpoint       = var.endpoint
+					  username       = "admin"
+					  password       = "password"
+					  require_health = "HEALTH_WARN"
+					}
+
+					data "ceph_auth" "test" {
+					  entity = "client.admin"
+					}
+				`,
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config: `
+					variable "endpoint" {
+					  type = string
+					}
+
+					provider "ceph" {
+					  endpoint       = var.endpoint
+					  username       = "admin"
+					  password       = "password"
+					  require_health = "HEALTH_ERR"
+					}
+
+					data "ceph_auth" "test" {
+					  entity = "client.admin"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)value must be one of`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_apiVersions(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	providerConfig := func(version string) string {
+		return fmt.Sprintf(`
+			variable "endpoint" {
+			  type = string
+			}
+
+			provider "ceph" {
+			  endpoint = var.endpoint
+			  username = "admin"
+			  password = "password"
+			  api_versions = {
+			    "/api/cluster_conf" = %q
+			  }
+			}
+
+			data "ceph_config_value" "test" {
+			  name    = "mon_max_pg_per_osd"
+			  section = "global"
+			}
+		`, version)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config: providerConfig("1.0"),
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config:      providerConfig("9.0"),
+				ExpectError: regexp.MustCompile(`(?s)serves /api/cluster_conf.*as version 1\.\d+ but the provider requested 9\.0`),
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config:      providerConfig("latest"),
+				ExpectError: regexp.MustCompile(`must be a version such as 1.0`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_debugHTTP(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config: `
+					variable "endpoint" {
+					  type = string
+					}
+
+					provider "ceph" {
+					  endpoint   = var.endpoint
+					  username   = "admin"
+					  password   = "password"
+					  debug_http = true
+					}
+
+					data "ceph_auth" "test" {
+					  entity = "client.admin"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ceph_auth.test", "key"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_requestHeaders(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	providerConfig := func(header string) string {
+		return fmt.Sprintf(`
+			variable "endpoint" {
+			  type = string
+			}
+
+			provider "ceph" {
+			  endpoint   = var.endpoint
+			  username   = "admin"
+			  password   = "password"
+			  request_id = "terraform-test"
+			  request_headers = {
+			    %q = "test"
+			  }
+			}
+
+			data "ceph_auth" "test" {
+			  entity = "client.admin"
+			}
+		`, header)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config: providerConfig("X-Terraform-Workspace"),
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config:      providerConfig("Authorization"),
+				ExpectError: regexp.MustCompile(`(?i)value must be none of`),
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint": config.StringVariable(testDashboardURL),
+				},
+				Config:      providerConfig("X Terraform"),
+				ExpectError: regexp.MustCompile(`must be a valid HTTP header name`),
+			},
+		},
+	})
+}
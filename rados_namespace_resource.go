@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &RadosNamespaceResource{}
+	_ resource.ResourceWithImportState = &RadosNamespaceResource{}
+)
+
+// radosNamespaceRegexp matches namespace names that can be written unquoted
+// in the namespace= clause of an osd cap.
+var radosNamespaceRegexp = regexp.MustCompile(`^[^\s,;'"=/]+$`)
+
+func newRadosNamespaceResource() resource.Resource {
+	return &RadosNamespaceResource{}
+}
+
+// RadosNamespaceResource records a RADOS namespace within a pool. RADOS
+// namespaces exist implicitly as soon as an object is written to them, so
+// the resource only checks that the pool exists and that the namespace can be
+// used in cephx caps.
+type RadosNamespaceResource struct {
+	client *CephAPIClient
+}
+
+type RadosNamespaceResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Pool   types.String `tfsdk:"pool"`
+	Name   types.String `tfsdk:"name"`
+	OSDCap types.String `tfsdk:"osd_cap"`
+}
+
+func (r *RadosNamespaceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rados_namespace"
+}
+
+func (r *RadosNamespaceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Declares a RADOS namespace within a pool, so that tenants sharing a pool can be given cephx caps scoped to their own namespace. " +
+			"RADOS namespaces have no existence of their own in Ceph: they come into being when an object is written to them. " +
+			"The resource checks that the pool exists and that the namespace can be used in caps, and provides an `osd_cap` for `ceph_auth`. " +
+			"It is removed from the state when the pool is deleted. Destroying the resource leaves the objects in the namespace untouched.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The namespace ID in the form `<pool>/<name>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pool": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the pool",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the namespace, e.g. `tenant-a`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(radosNamespaceRegexp, "must not contain whitespace, quotes or any of ',', ';', '=' and '/'"),
+				},
+			},
+			"osd_cap": resourceSchema.StringAttribute{
+				MarkdownDescription: "An `osd` cap granting read and write access to the namespace only, e.g. `allow rw pool=rbd namespace=tenant-a`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RadosNamespaceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RadosNamespaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RadosNamespaceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	poolName := data.Pool.ValueString()
+	_, err := r.client.GetPool(ctx, poolName)
+	if isCephAPINotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pool"),
+			"Pool Not Found",
+			fmt.Sprintf("Pool '%s' does not exist", poolName),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read pool '%s': %s", poolName, err),
+		)
+		return
+	}
+
+	osdCap := radosNamespaceOSDCap(poolName, data.Name.ValueString())
+	if err := validateCephCap("osd", osdCap); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Namespace",
+			fmt.Sprintf("Unable to scope cephx caps to namespace '%s' of pool '%s': %s", data.Name.ValueString(), poolName, err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(poolName + "/" + data.Name.ValueString())
+	data.OSDCap = types.StringValue(osdCap)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(recordPoolSeen(ctx, resp.Private)...)
+}
+
+func (r *RadosNamespaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RadosNamespaceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.GetPool(ctx, data.Pool.ValueString())
+	if isCephAPINotFound(err) {
+		removeMissingPoolResource(ctx, req, resp, data.Pool.ValueString())
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read pool '%s': %s", data.Pool.ValueString(), err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.Pool.ValueString() + "/" + data.Name.ValueString())
+	data.OSDCap = types.StringValue(radosNamespaceOSDCap(data.Pool.ValueString(), data.Name.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(refreshPoolSeen(ctx, req, resp)...)
+}
+
+func (r *RadosNamespaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RadosNamespaceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(recordPoolSeen(ctx, resp.Private)...)
+}
+
+func (r *RadosNamespaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *RadosNamespaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	pool, name, ok := strings.Cut(req.ID, "/")
+	if !ok || pool == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the form '<pool>/<name>', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pool"), pool)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+func radosNamespaceOSDCap(pool, namespace string) string {
+	return fmt.Sprintf("allow rw pool=%s namespace=%s", pool, namespace)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephRadosNamespaceResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	poolName := acctest.RandString(8)
+	testEntity := fmt.Sprintf("client.tenant-%s", acctest.RandString(8))
+	expectedCap := fmt.Sprintf("allow rw pool=%s namespace=tenant-a", poolName)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+
+			if err := cephTestClusterCLI.PoolCreate(t.Context(), poolName, 8, ""); err != nil {
+				t.Fatalf("Failed to create pool: %v", err)
+			}
+
+			testCleanup(t, func(ctx context.Context) {
+				if err := cephTestClusterCLI.PoolDelete(ctx, poolName); err != nil {
+					t.Errorf("Failed to cleanup pool %s: %v", poolName, err)
+				}
+			})
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_rados_namespace" "test" {
+					  pool = "does-not-exist"
+					  name = "tenant-a"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)pool 'does-not-exist' does\s+not\s+exist`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rados_namespace" "test" {
+					  pool = %q
+					  name = "tenant-a"
+					}
+
+					resource "ceph_auth" "tenant" {
+					  entity = %q
+					  caps = {
+					    mon = "allow r"
+					    osd = ceph_rados_namespace.test.osd_cap
+					  }
+					}
+				`, poolName, testEntity),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rados_namespace.test", tfjsonpath.New("id"), knownvalue.StringExact(poolName+"/tenant-a")),
+					statecheck.ExpectKnownValue("ceph_rados_namespace.test", tfjsonpath.New("osd_cap"), knownvalue.StringExact(expectedCap)),
+					statecheck.ExpectKnownValue("ceph_auth.tenant", tfjsonpath.New("caps").AtMapKey("osd"), knownvalue.StringExact(expectedCap)),
+				},
+			},
+			{
+				ConfigVariables:                      testAccProviderConfig(),
+				ResourceName:                         "ceph_rados_namespace.test",
+				ImportState:                          true,
+				ImportStateId:                        poolName + "/tenant-a",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "id",
+			},
+			{
+				PreConfig: func() {
+					recreateTestPool(t, poolName, "")
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rados_namespace" "test" {
+					  pool = %q
+					  name = "tenant-a"
+					}
+				`, poolName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rados_namespace.test", plancheck.ResourceActionCreate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rados_namespace.test", tfjsonpath.New("osd_cap"), knownvalue.StringExact(expectedCap)),
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rados_namespace" "test" {
+					  pool = %q
+					  name = "tenant a"
+					}
+				`, poolName),
+				ExpectError: regexp.MustCompile(`must not contain\s+whitespace`),
+			},
+		},
+	})
+}
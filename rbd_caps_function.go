@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &RBDCapsFunction{}
+
+func newRBDCapsFunction() function.Function {
+	return &RBDCapsFunction{}
+}
+
+// RBDCapsFunction builds the caps `ceph auth get-or-create` is usually given
+// for RBD clients, based on the rbd profiles.
+type RBDCapsFunction struct{}
+
+func (f *RBDCapsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rbd_caps"
+}
+
+func (f *RBDCapsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build cephx caps for an RBD client",
+		MarkdownDescription: "Returns the `mon`, `osd` and `mgr` caps for a client using RBD images in a pool, optionally restricted to one RADOS namespace, for the `caps` of `ceph_auth`. " +
+			"For example `rbd_caps(\"rbd\", null)` returns `{ mon = \"profile rbd\", osd = \"profile rbd pool=rbd\", mgr = \"profile rbd pool=rbd\" }`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "pool",
+				MarkdownDescription: "The RBD pool",
+			},
+			function.StringParameter{
+				Name:                "namespace",
+				MarkdownDescription: "The RADOS namespace within the pool, or `null` for the whole pool",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *RBDCapsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pool string
+	var namespace *string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &pool, &namespace))
+	if resp.Error != nil {
+		return
+	}
+
+	if pool == "" || strings.ContainsAny(pool, " ,") {
+		resp.Error = function.NewArgumentFuncError(0, "pool must be a non-empty pool name")
+		return
+	}
+	if namespace != nil && (*namespace == "" || strings.ContainsAny(*namespace, " ,")) {
+		resp.Error = function.NewArgumentFuncError(1, "namespace must be null or a non-empty namespace name")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, rbdCaps(pool, namespace)))
+}
+
+func rbdCaps(pool string, namespace *string) map[string]string {
+	match := "pool=" + pool
+	if namespace != nil {
+		match += " namespace=" + *namespace
+	}
+
+	return map[string]string{
+		"mon": "profile rbd",
+		"osd": "profile rbd " + match,
+		"mgr": "profile rbd " + match,
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCephRBDCapsFunction(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testEntity := acctest.RandomWithPrefix("client.test-rbd-caps")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		CheckDestroy: testAccCheckCephAuthDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_auth" "test" {
+					  entity = %q
+					  caps   = provider::ceph::rbd_caps("rbd", "k8s")
+					}
+
+					output "pool" {
+					  value = provider::ceph::rbd_caps("rbd", null)
+					}
+				`, testEntity),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_auth.test",
+						tfjsonpath.New("caps"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"mon": knownvalue.StringExact("profile rbd"),
+							"osd": knownvalue.StringExact("profile rbd pool=rbd namespace=k8s"),
+							"mgr": knownvalue.StringExact("profile rbd pool=rbd namespace=k8s"),
+						}),
+					),
+					statecheck.ExpectKnownOutputValue(
+						"pool",
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"mon": knownvalue.StringExact("profile rbd"),
+							"osd": knownvalue.StringExact("profile rbd pool=rbd"),
+							"mgr": knownvalue.StringExact("profile rbd pool=rbd"),
+						}),
+					),
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					output "invalid" {
+					  value = provider::ceph::rbd_caps("", null)
+					}
+				`,
+				ExpectError: regexp.MustCompile(`pool must be a non-empty pool name`),
+			},
+		},
+	})
+}
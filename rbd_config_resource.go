@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &RBDConfigResource{}
+	_ resource.ResourceWithImportState = &RBDConfigResource{}
+	_ resource.ResourceWithIdentity    = &RBDConfigResource{}
+)
+
+func newRBDConfigResource() resource.Resource {
+	return &RBDConfigResource{}
+}
+
+// RBDConfigResource manages pool-level RBD config overrides, which apply to
+// every image in the pool that does not override them itself. Overrides left
+// out of the configuration are not touched.
+type RBDConfigResource struct {
+	client *CephAPIClient
+}
+
+type RBDConfigResourceModel struct {
+	Pool              types.String `tfsdk:"pool"`
+	QoSIOPSLimit      types.Int64  `tfsdk:"qos_iops_limit"`
+	QoSIOPSBurst      types.Int64  `tfsdk:"qos_iops_burst"`
+	QoSBPSLimit       types.Int64  `tfsdk:"qos_bps_limit"`
+	QoSBPSBurst       types.Int64  `tfsdk:"qos_bps_burst"`
+	QoSReadIOPSLimit  types.Int64  `tfsdk:"qos_read_iops_limit"`
+	QoSWriteIOPSLimit types.Int64  `tfsdk:"qos_write_iops_limit"`
+	QoSReadBPSLimit   types.Int64  `tfsdk:"qos_read_bps_limit"`
+	QoSWriteBPSLimit  types.Int64  `tfsdk:"qos_write_bps_limit"`
+}
+
+type RBDConfigResourceIdentityModel struct {
+	Pool types.String `tfsdk:"pool"`
+}
+
+// rbdConfigOption ties an RBD config option to the model field holding it.
+type rbdConfigOption struct {
+	name  string
+	value *types.Int64
+}
+
+func (m *RBDConfigResourceModel) options() []rbdConfigOption {
+	return []rbdConfigOption{
+		{"rbd_qos_iops_limit", &m.QoSIOPSLimit},
+		{"rbd_qos_iops_burst", &m.QoSIOPSBurst},
+		{"rbd_qos_bps_limit", &m.QoSBPSLimit},
+		{"rbd_qos_bps_burst", &m.QoSBPSBurst},
+		{"rbd_qos_read_iops_limit", &m.QoSReadIOPSLimit},
+		{"rbd_qos_write_iops_limit", &m.QoSWriteIOPSLimit},
+		{"rbd_qos_read_bps_limit", &m.QoSReadBPSLimit},
+		{"rbd_qos_write_bps_limit", &m.QoSWriteBPSLimit},
+	}
+}
+
+func (r *RBDConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rbd_config"
+}
+
+func (r *RBDConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	qosAttribute := func(description string) resourceSchema.Int64Attribute {
+		return resourceSchema.Int64Attribute{
+			MarkdownDescription: description + " `0` means unlimited. Left unmanaged if not set.",
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		}
+	}
+
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages RBD QoS overrides on a pool, as with `rbd config pool set`. They apply to every image in the pool that does not override them itself. " +
+			"Attributes that are not set are left unmanaged, and destroying the resource removes the managed overrides. " +
+			"Per-image QoS overrides are not covered, because the provider has no `ceph_rbd_image` resource to manage them on.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"pool": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the RBD pool",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"qos_iops_limit":       qosAttribute("The maximum I/O operations per second per image."),
+			"qos_iops_burst":       qosAttribute("The I/O operations per second an image may burst to."),
+			"qos_bps_limit":        qosAttribute("The maximum bytes per second per image."),
+			"qos_bps_burst":        qosAttribute("The bytes per second an image may burst to."),
+			"qos_read_iops_limit":  qosAttribute("The maximum read operations per second per image."),
+			"qos_write_iops_limit": qosAttribute("The maximum write operations per second per image."),
+			"qos_read_bps_limit":   qosAttribute("The maximum bytes read per second per image."),
+			"qos_write_bps_limit":  qosAttribute("The maximum bytes written per second per image."),
+		},
+	}
+}
+
+func (r *RBDConfigResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"pool": identityschema.StringAttribute{
+				Description:       "The name of the RBD pool",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *RBDConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RBDConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RBDConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, RBDConfigResourceModel{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RBDConfigResourceIdentityModel{Pool: data.Pool})...)
+	resp.Diagnostics.Append(recordPoolSeen(ctx, resp.Private)...)
+}
+
+func (r *RBDConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RBDConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.GetPool(ctx, data.Pool.ValueString())
+	if isCephAPINotFound(err) {
+		removeMissingPoolResource(ctx, req, resp, data.Pool.ValueString())
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read pool '%s': %s", data.Pool.ValueString(), err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RBDConfigResourceIdentityModel{Pool: data.Pool})...)
+	resp.Diagnostics.Append(refreshPoolSeen(ctx, req, resp)...)
+}
+
+func (r *RBDConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RBDConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RBDConfigResourceIdentityModel{Pool: data.Pool})...)
+	resp.Diagnostics.Append(recordPoolSeen(ctx, resp.Private)...)
+}
+
+func (r *RBDConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RBDConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := r.apply(ctx, RBDConfigResourceModel{Pool: data.Pool}, data)
+	if diags.HasError() {
+		// The overrides are gone along with the pool.
+		if _, err := r.client.GetPool(ctx, data.Pool.ValueString()); isCephAPINotFound(err) {
+			return
+		}
+	}
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *RBDConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("pool"), path.Root("pool"), req, resp)
+}
+
+// apply sets every override configured in data and removes the ones that were
+// managed in prior but are no longer configured.
+func (r *RBDConfigResource) apply(ctx context.Context, data RBDConfigResourceModel, prior RBDConfigResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	configuration := map[string]*string{}
+	priorOptions := prior.options()
+	for i, option := range data.options() {
+		switch {
+		case !option.value.IsNull():
+			value := strconv.FormatInt(option.value.ValueInt64(), 10)
+			configuration[option.name] = &value
+		case !priorOptions[i].value.IsNull():
+			configuration[option.name] = nil
+		}
+	}
+
+	if len(configuration) == 0 {
+		return diags
+	}
+
+	err := r.client.UpdatePool(ctx, data.Pool.ValueString(), CephAPIPoolUpdateRequest{Configuration: configuration})
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to set RBD configuration of pool '%s': %s", data.Pool.ValueString(), err),
+		)
+	}
+	return diags
+}
+
+// read refreshes the managed overrides. An override removed outside
+// Terraform reads back as null.
+func (r *RBDConfigResource) read(ctx context.Context, data *RBDConfigResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	configuration, err := r.client.GetPoolConfiguration(ctx, data.Pool.ValueString())
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read RBD configuration of pool '%s': %s", data.Pool.ValueString(), err),
+		)
+		return diags
+	}
+
+	overrides := map[string]any{}
+	for _, item := range configuration {
+		if item.Source == CephAPIPoolConfigSourcePool {
+			overrides[item.Name] = item.Value
+		}
+	}
+
+	for _, option := range data.options() {
+		if option.value.IsNull() {
+			continue
+		}
+
+		value, ok := overrides[option.name]
+		if !ok {
+			*option.value = types.Int64Null()
+			continue
+		}
+
+		formatted, err := formatMgrModuleConfigValue(value)
+		var n int64
+		if err == nil {
+			n, err = strconv.ParseInt(formatted, 10, 64)
+		}
+		if err != nil {
+			diags.AddError(
+				"Configuration Value Formatting Error",
+				fmt.Sprintf("Unable to parse RBD option '%s': %s", option.name, err),
+			)
+			return diags
+		}
+		*option.value = types.Int64Value(n)
+	}
+
+	return diags
+}
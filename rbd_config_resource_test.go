@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephRBDConfigResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	poolName := acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+
+			if err := cephTestClusterCLI.PoolCreate(t.Context(), poolName, 8, ""); err != nil {
+				t.Fatalf("Failed to create pool: %v", err)
+			}
+
+			if err := cephTestClusterCLI.PoolApplicationEnable(t.Context(), poolName, "rbd"); err != nil {
+				t.Fatalf("Failed to enable rbd application: %v", err)
+			}
+
+			testCleanup(t, func(ctx context.Context) {
+				if err := cephTestClusterCLI.PoolDelete(ctx, poolName); err != nil {
+					t.Errorf("Failed to cleanup pool %s: %v", poolName, err)
+				}
+			})
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rbd_config" "test" {
+					  pool           = %q
+					  qos_iops_limit = 1000
+					  qos_iops_burst = 2000
+					  qos_bps_limit  = 104857600
+					}
+				`, poolName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rbd_config.test", tfjsonpath.New("qos_iops_limit"), knownvalue.Int64Exact(1000)),
+					statecheck.ExpectKnownValue("ceph_rbd_config.test", tfjsonpath.New("qos_iops_burst"), knownvalue.Int64Exact(2000)),
+					statecheck.ExpectKnownValue("ceph_rbd_config.test", tfjsonpath.New("qos_bps_limit"), knownvalue.Int64Exact(104857600)),
+					statecheck.ExpectKnownValue("ceph_rbd_config.test", tfjsonpath.New("qos_write_iops_limit"), knownvalue.Null()),
+					statecheck.ExpectIdentity("ceph_rbd_config.test", map[string]knownvalue.Check{
+						"pool": knownvalue.StringExact(poolName),
+					}),
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rbd_config" "test" {
+					  pool                 = %q
+					  qos_iops_limit       = 500
+					  qos_write_iops_limit = 100
+					}
+				`, poolName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rbd_config.test", tfjsonpath.New("qos_iops_limit"), knownvalue.Int64Exact(500)),
+					statecheck.ExpectKnownValue("ceph_rbd_config.test", tfjsonpath.New("qos_iops_burst"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("ceph_rbd_config.test", tfjsonpath.New("qos_bps_limit"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("ceph_rbd_config.test", tfjsonpath.New("qos_write_iops_limit"), knownvalue.Int64Exact(100)),
+				},
+			},
+			{
+				PreConfig: func() {
+					recreateTestPool(t, poolName, "rbd")
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rbd_config" "test" {
+					  pool                 = %q
+					  qos_iops_limit       = 500
+					  qos_write_iops_limit = 100
+					}
+				`, poolName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rbd_config.test", plancheck.ResourceActionCreate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rbd_config.test", tfjsonpath.New("qos_iops_limit"), knownvalue.Int64Exact(500)),
+					statecheck.ExpectKnownValue("ceph_rbd_config.test", tfjsonpath.New("qos_write_iops_limit"), knownvalue.Int64Exact(100)),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &RBDMirrorBootstrapTokenDataSource{}
+
+func newRBDMirrorBootstrapTokenDataSource() datasource.DataSource {
+	return &RBDMirrorBootstrapTokenDataSource{}
+}
+
+type RBDMirrorBootstrapTokenDataSource struct {
+	client *CephAPIClient
+}
+
+type RBDMirrorBootstrapTokenDataSourceModel struct {
+	Pool  types.String `tfsdk:"pool"`
+	Token types.String `tfsdk:"token"`
+}
+
+func (d *RBDMirrorBootstrapTokenDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rbd_mirror_bootstrap_token"
+}
+
+func (d *RBDMirrorBootstrapTokenDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source creates an RBD mirroring bootstrap token for a pool, as with `rbd mirror pool peer bootstrap create`. " +
+			"Import the token on the peer cluster, for example through a second provider alias, to set up mirroring in a single plan. " +
+			"Mirroring must already be enabled on the pool. The token contains a cephx key and is stored in state.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"pool": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the mirrored RBD pool.",
+				Required:            true,
+			},
+			"token": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The base64 encoded bootstrap token.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (d *RBDMirrorBootstrapTokenDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RBDMirrorBootstrapTokenDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RBDMirrorBootstrapTokenDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := d.client.CreateRBDMirrorBootstrapToken(ctx, data.Pool.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to create RBD mirroring bootstrap token for pool '%s': %s", data.Pool.ValueString(), err),
+		)
+		return
+	}
+
+	data.Token = types.StringValue(token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
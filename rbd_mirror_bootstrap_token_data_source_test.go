@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCephRBDMirrorBootstrapTokenDataSource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	poolName := acctest.RandString(8)
+	unmirroredPoolName := acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+
+			for _, name := range []string{poolName, unmirroredPoolName} {
+				if err := cephTestClusterCLI.PoolCreate(t.Context(), name, 8, ""); err != nil {
+					t.Fatalf("Failed to create pool: %v", err)
+				}
+
+				if err := cephTestClusterCLI.PoolApplicationEnable(t.Context(), name, "rbd"); err != nil {
+					t.Fatalf("Failed to enable rbd application: %v", err)
+				}
+
+				testCleanup(t, func(ctx context.Context) {
+					if err := cephTestClusterCLI.PoolDelete(ctx, name); err != nil {
+						t.Errorf("Failed to cleanup pool %s: %v", name, err)
+					}
+				})
+			}
+
+			if err := cephTestClusterCLI.RBDMirrorPoolEnable(t.Context(), poolName, "image"); err != nil {
+				t.Fatalf("Failed to enable mirroring: %v", err)
+			}
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					data "ceph_rbd_mirror_bootstrap_token" "test" {
+					  pool = %q
+					}
+				`, poolName),
+				Check: resource.TestCheckResourceAttrWith(
+					"data.ceph_rbd_mirror_bootstrap_token.test",
+					"token",
+					checkRBDMirrorBootstrapToken,
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					data "ceph_rbd_mirror_bootstrap_token" "test" {
+					  pool = %q
+					}
+				`, unmirroredPoolName),
+				ExpectError: regexp.MustCompile(`Unable to create RBD mirroring bootstrap token`),
+			},
+		},
+	})
+}
+
+// checkRBDMirrorBootstrapToken checks the token decodes to the JSON document
+// rbd-mirror expects.
+func checkRBDMirrorBootstrapToken(value string) error {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("token is not base64: %w", err)
+	}
+
+	var token struct {
+		FSID     string `json:"fsid"`
+		ClientID string `json:"client_id"`
+		Key      string `json:"key"`
+		MonHost  string `json:"mon_host"`
+	}
+	if err := json.Unmarshal(decoded, &token); err != nil {
+		return fmt.Errorf("token is not JSON: %w", err)
+	}
+	if token.FSID == "" || token.ClientID == "" || token.Key == "" || token.MonHost == "" {
+		return fmt.Errorf("token is missing fields: %s", decoded)
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &RBDTrashPurgeScheduleResource{}
+	_ resource.ResourceWithImportState = &RBDTrashPurgeScheduleResource{}
+)
+
+func newRBDTrashPurgeScheduleResource() resource.Resource {
+	return &RBDTrashPurgeScheduleResource{}
+}
+
+// RBDTrashPurgeScheduleResource manages one trash purge schedule of the
+// rbd_support mgr module. The dashboard API does not expose the schedules, so
+// it always goes through the ceph CLI. Every attribute identifies the
+// schedule, so any change replaces it.
+type RBDTrashPurgeScheduleResource struct {
+	client *CephAPIClient
+}
+
+type RBDTrashPurgeScheduleResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Pool      types.String `tfsdk:"pool"`
+	Namespace types.String `tfsdk:"namespace"`
+	Interval  types.String `tfsdk:"interval"`
+	StartTime types.String `tfsdk:"start_time"`
+}
+
+func (m RBDTrashPurgeScheduleResourceModel) levelSpec() string {
+	return m.Pool.ValueString() + "/" + m.Namespace.ValueString()
+}
+
+func (r *RBDTrashPurgeScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rbd_trash_purge_schedule"
+}
+
+func (r *RBDTrashPurgeScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages an RBD trash purge schedule, as with `rbd trash purge schedule add`, which periodically removes expired images from the trash of a pool or namespace. " +
+			"Trash purge schedules are only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The pool, namespace and interval in the form `<pool>/<namespace>/<interval>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pool": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the RBD pool",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^/]+$`), "must be a pool name"),
+				},
+			},
+			"namespace": resourceSchema.StringAttribute{
+				MarkdownDescription: "The RBD namespace within the pool. Defaults to the whole pool.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^/]+$`), "must be a namespace name"),
+				},
+			},
+			"interval": resourceSchema.StringAttribute{
+				MarkdownDescription: "How often to purge the trash, in days, hours or minutes, e.g. `1d`, `12h` or `30m`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[1-9][0-9]*[dhm]$`), "must be a number of days, hours or minutes, e.g. 1d, 12h or 30m"),
+				},
+			},
+			"start_time": resourceSchema.StringAttribute{
+				MarkdownDescription: "The time the schedule is anchored to, e.g. `02:00` or an ISO 8601 timestamp",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *RBDTrashPurgeScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RBDTrashPurgeScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RBDTrashPurgeScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to add trash purge schedule: %s", err),
+		)
+		return
+	}
+
+	err = cli.RBDTrashPurgeScheduleAdd(ctx, data.levelSpec(), data.Interval.ValueString(), data.StartTime.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to add trash purge schedule: %s", err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.levelSpec() + "/" + data.Interval.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(recordPoolSeen(ctx, resp.Private)...)
+}
+
+func (r *RBDTrashPurgeScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RBDTrashPurgeScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read trash purge schedule: %s", err),
+		)
+		return
+	}
+
+	exists, err := cli.PoolExists(ctx, data.Pool.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read pool '%s': %s", data.Pool.ValueString(), err),
+		)
+		return
+	}
+	if !exists {
+		removeMissingPoolResource(ctx, req, resp, data.Pool.ValueString())
+		return
+	}
+
+	levels, err := cli.RBDTrashPurgeScheduleList(ctx, data.levelSpec())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read trash purge schedule: %s", err),
+		)
+		return
+	}
+
+	schedule := findRBDTrashPurgeSchedule(levels, data.levelSpec(), data.Interval.ValueString())
+	if schedule == nil {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Trash purge schedule %s", data.ID.ValueString()))
+		return
+	}
+
+	// Ceph normalizes the start time, so it is only read back on import.
+	if data.StartTime.IsNull() && schedule.StartTime != nil {
+		data.StartTime = types.StringValue(*schedule.StartTime)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(refreshPoolSeen(ctx, req, resp)...)
+}
+
+// Every attribute requires replacement, so Update is never called.
+func (r *RBDTrashPurgeScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+func (r *RBDTrashPurgeScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RBDTrashPurgeScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to remove trash purge schedule: %s", err),
+		)
+		return
+	}
+
+	err = cli.RBDTrashPurgeScheduleRemove(ctx, data.levelSpec(), data.Interval.ValueString(), data.StartTime.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to remove trash purge schedule: %s", err),
+		)
+		return
+	}
+}
+
+func (r *RBDTrashPurgeScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) == 2 {
+		parts = []string{parts[0], "", parts[1]}
+	}
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the form '<pool>/<interval>' or '<pool>/<namespace>/<interval>', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[0]+"/"+parts[1]+"/"+parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pool"), parts[0])...)
+	if parts[1] != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), parts[1])...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("interval"), parts[2])...)
+}
+
+// findRBDTrashPurgeSchedule returns the schedule of levelSpec with the given
+// interval. The list also includes the schedules of child levels, and Ceph
+// reports intervals in their largest whole unit, so 24h is listed as 1d.
+func findRBDTrashPurgeSchedule(levels map[string]RBDTrashPurgeScheduleLevel, levelSpec, interval string) *RBDTrashPurgeSchedule {
+	want, ok := rbdScheduleIntervalMinutes(interval)
+	if !ok {
+		return nil
+	}
+
+	for _, level := range levels {
+		if strings.TrimSuffix(level.Name, "/") != strings.TrimSuffix(levelSpec, "/") {
+			continue
+		}
+		for i, schedule := range level.Schedule {
+			if got, ok := rbdScheduleIntervalMinutes(schedule.Interval); ok && got == want {
+				return &level.Schedule[i]
+			}
+		}
+	}
+	return nil
+}
+
+// rbdScheduleIntervalMinutes converts an rbd_support interval such as 1d,
+// 12h or 30m to minutes.
+func rbdScheduleIntervalMinutes(interval string) (int, bool) {
+	if len(interval) < 2 {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil {
+		return 0, false
+	}
+
+	switch interval[len(interval)-1] {
+	case 'd':
+		return n * 24 * 60, true
+	case 'h':
+		return n * 60, true
+	case 'm':
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestFindRBDTrashPurgeSchedule(t *testing.T) {
+	startTime := "02:00:00"
+	levels := map[string]RBDTrashPurgeScheduleLevel{
+		"2": {
+			Name:     "rbd/",
+			Schedule: []RBDTrashPurgeSchedule{{Interval: "1d"}, {Interval: "30m", StartTime: &startTime}},
+		},
+		"2/ns": {
+			Name:     "rbd/ns/",
+			Schedule: []RBDTrashPurgeSchedule{{Interval: "12h"}},
+		},
+	}
+
+	tests := []struct {
+		levelSpec, interval string
+		want                string
+	}{
+		{"rbd/", "1d", "1d"},
+		{"rbd/", "24h", "1d"},
+		{"rbd/", "1440m", "1d"},
+		{"rbd/", "30m", "30m"},
+		{"rbd/", "12h", ""},
+		{"rbd/ns", "12h", "12h"},
+		{"rbd/ns/", "12h", "12h"},
+		{"other/", "1d", ""},
+		{"rbd/", "1x", ""},
+	}
+
+	for _, tt := range tests {
+		got := findRBDTrashPurgeSchedule(levels, tt.levelSpec, tt.interval)
+		switch {
+		case tt.want == "" && got != nil:
+			t.Errorf("findRBDTrashPurgeSchedule(%q, %q) = %v, want nil", tt.levelSpec, tt.interval, got.Interval)
+		case tt.want != "" && (got == nil || got.Interval != tt.want):
+			t.Errorf("findRBDTrashPurgeSchedule(%q, %q) = %v, want %s", tt.levelSpec, tt.interval, got, tt.want)
+		}
+	}
+}
+
+func TestAccCephRBDTrashPurgeScheduleResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	poolName := acctest.RandString(8)
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+
+			if err := cephTestClusterCLI.PoolCreate(t.Context(), poolName, 8, ""); err != nil {
+				t.Fatalf("Failed to create pool: %v", err)
+			}
+
+			if err := cephTestClusterCLI.PoolApplicationEnable(t.Context(), poolName, "rbd"); err != nil {
+				t.Fatalf("Failed to enable rbd application: %v", err)
+			}
+
+			testCleanup(t, func(ctx context.Context) {
+				if err := cephTestClusterCLI.PoolDelete(ctx, poolName); err != nil {
+					t.Errorf("Failed to cleanup pool %s: %v", poolName, err)
+				}
+			})
+		},
+		CheckDestroy: checkCephRBDTrashPurgeSchedule(t, poolName+"/", "1d", false),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rbd_trash_purge_schedule" "test" {
+					  pool     = %q
+					  interval = "1d"
+					}
+				`, poolName),
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rbd_trash_purge_schedule" "test" {
+					  pool     = %q
+					  interval = "1 day"
+					}
+				`, poolName),
+				ExpectError: regexp.MustCompile(`must be a number of days, hours or minutes`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rbd_trash_purge_schedule" "test" {
+					  pool     = %q
+					  interval = "1d"
+					}
+				`, poolName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rbd_trash_purge_schedule.test", tfjsonpath.New("id"), knownvalue.StringExact(poolName+"//1d")),
+					statecheck.ExpectKnownValue("ceph_rbd_trash_purge_schedule.test", tfjsonpath.New("start_time"), knownvalue.Null()),
+				},
+				Check: checkCephRBDTrashPurgeSchedule(t, poolName+"/", "1d", true),
+			},
+			{
+				ConfigVariables:   configVariables,
+				ResourceName:      "ceph_rbd_trash_purge_schedule.test",
+				ImportState:       true,
+				ImportStateId:     poolName + "/1d",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					recreateTestPool(t, poolName, "rbd")
+				},
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rbd_trash_purge_schedule" "test" {
+					  pool     = %q
+					  interval = "1d"
+					}
+				`, poolName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rbd_trash_purge_schedule.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkCephRBDTrashPurgeSchedule(t, poolName+"/", "1d", true),
+			},
+		},
+	})
+}
+
+func checkCephRBDTrashPurgeSchedule(t *testing.T, levelSpec, interval string, exists bool) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		levels, err := cephTestClusterCLI.RBDTrashPurgeScheduleList(t.Context(), levelSpec)
+		if err != nil {
+			return err
+		}
+		if found := findRBDTrashPurgeSchedule(levels, levelSpec, interval) != nil; found != exists {
+			return fmt.Errorf("trash purge schedule %s of %s exists = %v, want %v", interval, levelSpec, found, exists)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const defaultReadCacheTTL = 30 * time.Second
+
+type readCacheContextKey struct{}
+
+// withReadCache lets GET requests made with ctx be answered from the read
+// cache. Data sources opt in, since a module tree can read the same pool or
+// CRUSH rule dozens of times in one plan; resources always read the cluster
+// so they never see a value cached before their own change.
+func withReadCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readCacheContextKey{}, true)
+}
+
+// readCacheTransport caches successful GET responses for a short time. Only
+// one request is sent for concurrent reads of the same URL, and any other
+// request through the client clears the cache, since it may change what a
+// cached read returned.
+type readCacheTransport struct {
+	base http.RoundTripper
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*readCacheEntry
+}
+
+type readCacheEntry struct {
+	// ready is closed once the response has been read, or the request
+	// failed and ok is false.
+	ready   chan struct{}
+	ok      bool
+	expires time.Time
+	status  int
+	header  http.Header
+	body    []byte
+}
+
+func newReadCacheTransport(base http.RoundTripper, ttl time.Duration) *readCacheTransport {
+	return &readCacheTransport{
+		base:    base,
+		ttl:     ttl,
+		entries: make(map[string]*readCacheEntry),
+	}
+}
+
+func (t *readCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		t.mu.Lock()
+		clear(t.entries)
+		t.mu.Unlock()
+		return t.base.RoundTrip(req)
+	}
+
+	if cacheable, _ := req.Context().Value(readCacheContextKey{}).(bool); !cacheable {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String() + "\x00" + req.Header.Get("Accept")
+
+	t.mu.Lock()
+	entry, found := t.entries[key]
+	if found && entry.expired() {
+		found = false
+	}
+	if !found {
+		entry = &readCacheEntry{ready: make(chan struct{})}
+		t.entries[key] = entry
+	}
+	t.mu.Unlock()
+
+	if found {
+		select {
+		case <-entry.ready:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if entry.ok {
+			return entry.response(req), nil
+		}
+		return t.base.RoundTrip(req)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusOK {
+		entry.body, err = io.ReadAll(resp.Body)
+		resp.Body.Close() //nolint:errcheck
+		if err == nil {
+			entry.ok = true
+			entry.status = resp.StatusCode
+			entry.header = resp.Header
+			entry.expires = time.Now().Add(t.ttl)
+			close(entry.ready)
+			return entry.response(req), nil
+		}
+		resp = nil
+	}
+
+	t.mu.Lock()
+	if t.entries[key] == entry {
+		delete(t.entries, key)
+	}
+	t.mu.Unlock()
+	close(entry.ready)
+	return resp, err
+}
+
+// expired reports whether a completed entry is too old to use. Entries still
+// being fetched have no expiry yet.
+func (e *readCacheEntry) expired() bool {
+	select {
+	case <-e.ready:
+		return !e.ok || time.Now().After(e.expires)
+	default:
+		return false
+	}
+}
+
+func (e *readCacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(e.status) + " " + http.StatusText(e.status),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
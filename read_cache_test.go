@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadCacheTransport(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/api/pool/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+		io.WriteString(w, `[{"pool_name":"rbd"}]`) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	get := func(ctx context.Context, client *http.Client, path string) (string, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", server.URL+path, nil)
+		if err != nil {
+			return "", err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close() //nolint:errcheck
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	t.Run("concurrent reads", func(t *testing.T) {
+		requests.Store(0)
+		client := &http.Client{Transport: newReadCacheTransport(http.DefaultTransport, time.Minute)}
+		ctx := withReadCache(t.Context())
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				body, err := get(ctx, client, "/api/pool")
+				if err != nil || body != `[{"pool_name":"rbd"}]` {
+					t.Errorf("get() = %q, %v", body, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := requests.Load(); got != 1 {
+			t.Errorf("requests = %d, want 1", got)
+		}
+	})
+
+	t.Run("uncached requests", func(t *testing.T) {
+		requests.Store(0)
+		client := &http.Client{Transport: newReadCacheTransport(http.DefaultTransport, time.Minute)}
+
+		if _, err := get(t.Context(), client, "/api/pool"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := get(t.Context(), client, "/api/pool"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := get(withReadCache(t.Context()), client, "/api/pool/missing"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := get(withReadCache(t.Context()), client, "/api/pool/missing"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := requests.Load(); got != 4 {
+			t.Errorf("requests = %d, want 4", got)
+		}
+	})
+
+	t.Run("writes clear the cache", func(t *testing.T) {
+		requests.Store(0)
+		client := &http.Client{Transport: newReadCacheTransport(http.DefaultTransport, time.Minute)}
+		ctx := withReadCache(t.Context())
+
+		if _, err := get(ctx, client, "/api/pool"); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Post(server.URL+"/api/pool", "application/json", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close() //nolint:errcheck
+		if _, err := get(ctx, client, "/api/pool"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := requests.Load(); got != 3 {
+			t.Errorf("requests = %d, want 3", got)
+		}
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		requests.Store(0)
+		client := &http.Client{Transport: newReadCacheTransport(http.DefaultTransport, time.Millisecond)}
+		ctx := withReadCache(t.Context())
+
+		if _, err := get(ctx, client, "/api/pool"); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(5 * time.Millisecond)
+		if _, err := get(ctx, client, "/api/pool"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := requests.Load(); got != 2 {
+			t.Errorf("requests = %d, want 2", got)
+		}
+	})
+}
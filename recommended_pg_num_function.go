@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &RecommendedPGNumFunction{}
+
+func newRecommendedPGNumFunction() function.Function {
+	return &RecommendedPGNumFunction{}
+}
+
+// RecommendedPGNumFunction implements the pgcalc formula for sizing the
+// pg_num of a pool that holds all of the cluster's data.
+type RecommendedPGNumFunction struct{}
+
+func (f *RecommendedPGNumFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "recommended_pg_num"
+}
+
+func (f *RecommendedPGNumFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Calculate a pool's pg_num",
+		MarkdownDescription: "Returns `target_pgs_per_osd * osd_count / replica_size` rounded to the nearest power of two, rounding up instead when the nearest power of two is more than 25% below the raw value, as the pgcalc tool does. " +
+			"For example `recommended_pg_num(12, 3, 100)` returns `512`.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "osd_count",
+				MarkdownDescription: "The number of OSDs the pool's CRUSH rule maps to",
+			},
+			function.Int64Parameter{
+				Name:                "replica_size",
+				MarkdownDescription: "The pool `size`, or `k + m` for erasure coded pools",
+			},
+			function.Int64Parameter{
+				Name:                "target_pgs_per_osd",
+				MarkdownDescription: "The number of PGs to aim for on each OSD, usually `100`",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *RecommendedPGNumFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var osdCount, replicaSize, targetPGsPerOSD int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &osdCount, &replicaSize, &targetPGsPerOSD))
+	if resp.Error != nil {
+		return
+	}
+
+	if osdCount < 1 {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, "osd_count must be at least 1"))
+	}
+	if replicaSize < 1 {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, "replica_size must be at least 1"))
+	}
+	if targetPGsPerOSD < 1 {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(2, "target_pgs_per_osd must be at least 1"))
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, recommendedPGNum(osdCount, replicaSize, targetPGsPerOSD)))
+}
+
+// recommendedPGNum follows pgcalc: the raw value is never below one PG per
+// OSD per replica, and the nearest power of two is doubled when it falls
+// more than 25% short of the raw value.
+func recommendedPGNum(osdCount, replicaSize, targetPGsPerOSD int64) int64 {
+	raw := float64(targetPGsPerOSD) * float64(osdCount) / float64(replicaSize)
+	if minimum := float64(osdCount) / float64(replicaSize); raw < minimum {
+		raw = minimum
+	}
+
+	pgNum := math.Exp2(math.Round(math.Log2(raw)))
+	if pgNum < raw*0.75 {
+		pgNum *= 2
+	}
+	if pgNum < 1 {
+		pgNum = 1
+	}
+
+	return int64(pgNum)
+}
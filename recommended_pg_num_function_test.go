@@ -0,0 +1,68 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestRecommendedPGNum(t *testing.T) {
+	tests := []struct {
+		osdCount, replicaSize, targetPGsPerOSD int64
+		want                                   int64
+	}{
+		{12, 3, 100, 512},
+		{3, 3, 100, 128},
+		{10, 3, 100, 256},
+		{7, 2, 100, 512},
+		{100, 3, 100, 4096},
+		{1, 1, 100, 128},
+		{1, 3, 1, 1},
+		{6, 6, 1, 1},
+	}
+
+	for _, tt := range tests {
+		got := recommendedPGNum(tt.osdCount, tt.replicaSize, tt.targetPGsPerOSD)
+		if got != tt.want {
+			t.Errorf("recommendedPGNum(%d, %d, %d) = %d, want %d", tt.osdCount, tt.replicaSize, tt.targetPGsPerOSD, got, tt.want)
+		}
+	}
+}
+
+func TestAccCephRecommendedPGNumFunction(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					output "pg_num" {
+					  value = provider::ceph::recommended_pg_num(12, 3, 100)
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("pg_num", knownvalue.Int64Exact(512)),
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					output "invalid" {
+					  value = provider::ceph::recommended_pg_num(0, 3, 100)
+					}
+				`,
+				ExpectError: regexp.MustCompile(`osd_count must be at least 1`),
+			},
+		},
+	})
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// poolLastSeenKey is the private state key holding when a resource last found
+// its pool, so that the warning about a deleted pool can say when it was
+// last confirmed present.
+const poolLastSeenKey = "pool_last_seen"
+
+// poolLastSeenInterval is how old the recorded time may get before Read
+// records it again. Recording it on every refresh would rewrite the state on
+// every plan.
+const poolLastSeenInterval = 24 * time.Hour
+
+// privateStateSetter is the private state of a create, read or update
+// response.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// removeMissingResource removes a resource whose object was deleted outside
+// Terraform from the state, so that the next plan creates it again, and warns
+// about it. The description completes "<description> was not found".
+func removeMissingResource(ctx context.Context, resp *resource.ReadResponse, description string) {
+	resp.Diagnostics.AddWarning(
+		"Resource Not Found",
+		fmt.Sprintf("%s was not found in the cluster. Removing from state.", description),
+	)
+	resp.State.RemoveResource(ctx)
+}
+
+// recordPoolSeen records in the private state that the pool of a resource
+// exists now.
+func recordPoolSeen(ctx context.Context, private privateStateSetter) diag.Diagnostics {
+	value, err := json.Marshal(time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Internal Error", fmt.Sprintf("Unable to encode pool last seen time: %s", err))
+		return diags
+	}
+	return private.SetKey(ctx, poolLastSeenKey, value)
+}
+
+// refreshPoolSeen is recordPoolSeen for Read, which only records the time
+// when none is recorded or the recorded one is older than
+// poolLastSeenInterval.
+func refreshPoolSeen(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) diag.Diagnostics {
+	if lastSeen, ok := poolLastSeen(ctx, req); ok && time.Since(lastSeen) < poolLastSeenInterval {
+		return nil
+	}
+	return recordPoolSeen(ctx, resp.Private)
+}
+
+// poolLastSeen returns the time recorded by recordPoolSeen, if any.
+func poolLastSeen(ctx context.Context, req resource.ReadRequest) (time.Time, bool) {
+	value, diags := req.Private.GetKey(ctx, poolLastSeenKey)
+	var lastSeen string
+	if diags.HasError() || len(value) == 0 || json.Unmarshal(value, &lastSeen) != nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, lastSeen)
+	return t, err == nil
+}
+
+// removeMissingPoolResource is removeMissingResource for a resource whose
+// pool was deleted. The warning names the pool and, if recorded, when the
+// provider last confirmed it was present, which is at most
+// poolLastSeenInterval before the last successful refresh.
+func removeMissingPoolResource(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse, pool string) {
+	description := fmt.Sprintf("Pool '%s'", pool)
+	if lastSeen, ok := poolLastSeen(ctx, req); ok {
+		description = fmt.Sprintf("Pool '%s', last confirmed present at %s (recorded at most once a day),", pool, lastSeen.Format(time.RFC3339))
+	}
+
+	removeMissingResource(ctx, resp, description)
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &RGWAccountResource{}
+	_ resource.ResourceWithImportState = &RGWAccountResource{}
+	_ resource.ResourceWithIdentity    = &RGWAccountResource{}
+	_ resource.ResourceWithModifyPlan  = &RGWAccountResource{}
+)
+
+// rgwAccountIDRegexp matches the IDs RGW generates and accepts for accounts.
+var rgwAccountIDRegexp = regexp.MustCompile(`^RGW[0-9]{17}$`)
+
+func newRGWAccountResource() resource.Resource {
+	return &RGWAccountResource{}
+}
+
+// RGWAccountResource manages RGW accounts, added in Squid. Neither the
+// dashboard nor the admin ops API manage accounts yet, so it always goes
+// through radosgw-admin.
+type RGWAccountResource struct {
+	client *CephAPIClient
+}
+
+type RGWAccountResourceModel struct {
+	AccountID     types.String `tfsdk:"account_id"`
+	Name          types.String `tfsdk:"name"`
+	Tenant        types.String `tfsdk:"tenant"`
+	Email         types.String `tfsdk:"email"`
+	MaxUsers      types.Int64  `tfsdk:"max_users"`
+	MaxRoles      types.Int64  `tfsdk:"max_roles"`
+	MaxGroups     types.Int64  `tfsdk:"max_groups"`
+	MaxBuckets    types.Int64  `tfsdk:"max_buckets"`
+	MaxAccessKeys types.Int64  `tfsdk:"max_access_keys"`
+}
+
+type RGWAccountResourceIdentityModel struct {
+	AccountID types.String `tfsdk:"account_id"`
+}
+
+func (r *RGWAccountResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_account"
+}
+
+func rgwAccountLimitAttribute(description string) resourceSchema.Int64Attribute {
+	return resourceSchema.Int64Attribute{
+		MarkdownDescription: description + " Negative values disable the limit.",
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func (r *RGWAccountResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "This resource allows you to manage a Ceph RGW account, which owns users, roles, groups and buckets as a unit (Ceph Squid and later). " +
+			"RGW accounts can only be managed with `radosgw-admin`, so this resource requires the provider `cli_backend` to be enabled. " +
+			"Add users to the account with the `account_id` attribute of `ceph_rgw_user`.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"account_id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The account ID, `RGW` followed by 17 digits. Generated by RGW if not set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(rgwAccountIDRegexp, "must be RGW followed by 17 digits"),
+				},
+			},
+			"name": resourceSchema.StringAttribute{
+				MarkdownDescription: "The account name, unique within the tenant",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tenant": resourceSchema.StringAttribute{
+				MarkdownDescription: "The tenant of the account. Omit for the default tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email": resourceSchema.StringAttribute{
+				MarkdownDescription: "The email address of the account",
+				Optional:            true,
+			},
+			"max_users":       rgwAccountLimitAttribute("Maximum number of users in the account."),
+			"max_roles":       rgwAccountLimitAttribute("Maximum number of IAM roles in the account."),
+			"max_groups":      rgwAccountLimitAttribute("Maximum number of IAM groups in the account."),
+			"max_buckets":     rgwAccountLimitAttribute("Maximum number of buckets the account can own."),
+			"max_access_keys": rgwAccountLimitAttribute("Maximum number of access keys per user in the account."),
+		},
+	}
+}
+
+func (r *RGWAccountResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"account_id": identityschema.StringAttribute{
+				Description:       "The account ID",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *RGWAccountResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan rejects new accounts on releases without RGW accounts.
+func (r *RGWAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	if err := r.client.requireRelease("RGW accounts", cephReleaseSquid); err != nil {
+		resp.Diagnostics.AddError("Unsupported Ceph Release", err.Error())
+	}
+}
+
+func (r *RGWAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RGWAccountResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to manage RGW accounts: %s", err),
+		)
+		return
+	}
+
+	accountID := ""
+	if !data.AccountID.IsNull() && !data.AccountID.IsUnknown() {
+		accountID = data.AccountID.ValueString()
+	}
+
+	account, err := cli.RgwAccountCreate(ctx, accountID, data.Tenant.ValueString(), rgwAccountOptionsFromModel(data))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to create RGW account: %s", err),
+		)
+		return
+	}
+
+	updateModelFromRGWAccount(&data, account)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWAccountResourceIdentityModel{AccountID: data.AccountID})...)
+}
+
+func (r *RGWAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RGWAccountResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read RGW account: %s", err),
+		)
+		return
+	}
+
+	account, err := cli.RgwAccountGet(ctx, data.AccountID.ValueString())
+	if errors.Is(err, ErrRGWAccountNotFound) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("RGW account %s", data.AccountID.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read RGW account: %s", err),
+		)
+		return
+	}
+
+	updateModelFromRGWAccount(&data, account)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWAccountResourceIdentityModel{AccountID: data.AccountID})...)
+}
+
+func (r *RGWAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RGWAccountResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to manage RGW accounts: %s", err),
+		)
+		return
+	}
+
+	opts := rgwAccountOptionsFromModel(data)
+	// Send an empty email so removing it from the configuration clears it.
+	if opts.Email == nil {
+		empty := ""
+		opts.Email = &empty
+	}
+
+	account, err := cli.RgwAccountModify(ctx, data.AccountID.ValueString(), opts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to update RGW account: %s", err),
+		)
+		return
+	}
+
+	updateModelFromRGWAccount(&data, account)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWAccountResourceIdentityModel{AccountID: data.AccountID})...)
+}
+
+func (r *RGWAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RGWAccountResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to delete RGW account: %s", err),
+		)
+		return
+	}
+
+	err = cli.RgwAccountRemove(ctx, data.AccountID.ValueString())
+	if err != nil && !errors.Is(err, ErrRGWAccountNotFound) {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to delete RGW account: %s", err),
+		)
+		return
+	}
+}
+
+func (r *RGWAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("account_id"), path.Root("account_id"), req, resp)
+}
+
+func rgwAccountOptionsFromModel(data RGWAccountResourceModel) *RgwAccountOptions {
+	opts := &RgwAccountOptions{Name: data.Name.ValueString()}
+
+	if !data.Email.IsNull() && !data.Email.IsUnknown() {
+		email := data.Email.ValueString()
+		opts.Email = &email
+	}
+
+	limits := []struct {
+		value  types.Int64
+		target **int
+	}{
+		{data.MaxUsers, &opts.MaxUsers},
+		{data.MaxRoles, &opts.MaxRoles},
+		{data.MaxGroups, &opts.MaxGroups},
+		{data.MaxBuckets, &opts.MaxBuckets},
+		{data.MaxAccessKeys, &opts.MaxAccessKeys},
+	}
+	for _, limit := range limits {
+		if !limit.value.IsNull() && !limit.value.IsUnknown() {
+			v := int(limit.value.ValueInt64())
+			*limit.target = &v
+		}
+	}
+
+	return opts
+}
+
+func updateModelFromRGWAccount(data *RGWAccountResourceModel, account *RgwAccountInfo) {
+	data.AccountID = types.StringValue(account.ID)
+	data.Name = types.StringValue(account.Name)
+	if account.Tenant != "" {
+		data.Tenant = types.StringValue(account.Tenant)
+	} else {
+		data.Tenant = types.StringNull()
+	}
+	switch {
+	case account.Email != "":
+		data.Email = types.StringValue(account.Email)
+	case !data.Email.IsNull() && !data.Email.IsUnknown():
+		data.Email = types.StringValue("")
+	default:
+		data.Email = types.StringNull()
+	}
+	data.MaxUsers = types.Int64Value(int64(account.MaxUsers))
+	data.MaxRoles = types.Int64Value(int64(account.MaxRoles))
+	data.MaxGroups = types.Int64Value(int64(account.MaxGroups))
+	data.MaxBuckets = types.Int64Value(int64(account.MaxBuckets))
+	data.MaxAccessKeys = types.Int64Value(int64(account.MaxAccessKeys))
+}
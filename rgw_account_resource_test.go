@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephRGWAccountResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	accountName := acctest.RandomWithPrefix("test-account")
+	testUID := acctest.RandomWithPrefix("test-account-root")
+	var accountID string
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWAccountDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_account" "test" {
+					  name = %q
+					}
+				`, accountName),
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_account" "test" {
+					  name      = %q
+					  email     = "account@example.com"
+					  max_users = 10
+					}
+
+					resource "ceph_rgw_user" "root" {
+					  user_id      = %q
+					  display_name = "Account Root"
+					  account_id   = ceph_rgw_account.test.account_id
+					  account_root = true
+					}
+				`, accountName, testUID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rgw_account.test", tfjsonpath.New("account_id"), knownvalue.StringRegexp(rgwAccountIDRegexp)),
+					statecheck.ExpectKnownValue("ceph_rgw_account.test", tfjsonpath.New("max_users"), knownvalue.Int64Exact(10)),
+					statecheck.CompareValuePairs(
+						"ceph_rgw_account.test", tfjsonpath.New("account_id"),
+						"ceph_rgw_user.root", tfjsonpath.New("account_id"),
+						compare.ValuesSame(),
+					),
+					statecheck.ExpectKnownValue("ceph_rgw_user.root", tfjsonpath.New("account_root"), knownvalue.Bool(true)),
+				},
+			},
+			{
+				ConfigVariables:                      configVariables,
+				Config:                               testAccCLIProviderConfigBlock,
+				ResourceName:                         "ceph_rgw_account.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "account_id",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["ceph_rgw_account.test"].Primary.Attributes["account_id"], nil
+				},
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_account" "test" {
+					  name      = "%s-renamed"
+					  max_users = 20
+					}
+
+					resource "ceph_rgw_user" "root" {
+					  user_id      = %q
+					  display_name = "Account Root"
+					  account_id   = ceph_rgw_account.test.account_id
+					  account_root = true
+					}
+				`, accountName, testUID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rgw_account.test", tfjsonpath.New("name"), knownvalue.StringExact(accountName+"-renamed")),
+					statecheck.ExpectKnownValue("ceph_rgw_account.test", tfjsonpath.New("email"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("ceph_rgw_account.test", tfjsonpath.New("max_users"), knownvalue.Int64Exact(20)),
+				},
+				Check: resource.TestCheckResourceAttrWith("ceph_rgw_account.test", "account_id", func(value string) error {
+					accountID = value
+					return nil
+				}),
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.RgwUserRemove(t.Context(), testUID, false); err != nil {
+						t.Fatalf("Failed to delete account root user out of band: %v", err)
+					}
+					if err := cephTestClusterCLI.RgwAccountRemove(t.Context(), accountID); err != nil {
+						t.Fatalf("Failed to delete account out of band: %v", err)
+					}
+				},
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_account" "test" {
+					  name      = "%s-renamed"
+					  max_users = 20
+					}
+
+					resource "ceph_rgw_user" "root" {
+					  user_id      = %q
+					  display_name = "Account Root"
+					  account_id   = ceph_rgw_account.test.account_id
+					  account_root = true
+					}
+				`, accountName, testUID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_account.test", plancheck.ResourceActionCreate),
+						plancheck.ExpectResourceAction("ceph_rgw_user.root", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckCephRGWAccountDestroy(t *testing.T) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "ceph_rgw_account" {
+				continue
+			}
+
+			accountID := rs.Primary.Attributes["account_id"]
+			_, err := cephTestClusterCLI.RgwAccountGet(t.Context(), accountID)
+			if err == nil {
+				return fmt.Errorf("ceph_rgw_account %s still exists", accountID)
+			}
+			if !errors.Is(err, ErrRGWAccountNotFound) {
+				return fmt.Errorf("unexpected error checking account %s: %w", accountID, err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,436 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	rgwAdminOpsDefaultRegion = "us-east-1"
+	rgwAdminOpsService       = "s3"
+)
+
+// RGWAdminOpsClient talks to the radosgw admin ops REST API directly. It is
+// used in place of the dashboard RGW endpoints when configured, since the
+// dashboard lags behind radosgw-admin in functionality.
+type RGWAdminOpsClient struct {
+	endpoint  *url.URL
+	accessKey string
+	secretKey string
+	region    string
+	client    *http.Client
+}
+
+func (c *RGWAdminOpsClient) do(ctx context.Context, method, resource string, query url.Values) ([]byte, error) {
+	query.Set("format", "json")
+
+	reqURL := c.endpoint.JoinPath("/admin", resource)
+	reqURL.RawQuery = query.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, reqURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	emptyPayloadHash := sha256.Sum256(nil)
+	httpReq.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(emptyPayloadHash[:]))
+	signRGWAdminOpsRequest(httpReq, c.accessKey, c.secretKey, c.region, rgwAdminOpsService, time.Now().UTC())
+
+	logRequest := logAPIRequest(ctx, httpReq)
+	httpResp, err := c.client.Do(httpReq)
+	logRequest(httpResp, err)
+	if err != nil {
+		return nil, fmt.Errorf("unable to make request to RGW admin ops API: %w", err)
+	}
+	defer httpResp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response body: %w", err)
+	}
+
+	tflog.Trace(ctx, "RGW admin ops API response body", map[string]any{
+		"response_body": string(body),
+		"status_code":   httpResp.StatusCode,
+	})
+
+	if httpResp.StatusCode != http.StatusOK {
+		apiErr := &CephAPIError{
+			StatusCode: httpResp.StatusCode,
+			Component:  "rgw",
+			Detail:     string(body),
+		}
+		var errBody struct {
+			Code string `json:"Code"`
+		}
+		if err := json.Unmarshal(body, &errBody); err == nil && errBody.Code != "" {
+			apiErr.Detail = errBody.Code
+		}
+		return nil, apiErr
+	}
+
+	return body, nil
+}
+
+// doS3 sends an S3 request for a bucket subresource such as "cors", signed
+// with the admin ops credentials. RGW only allows it if the admin ops user
+// owns the bucket or has the admin flag.
+func (c *RGWAdminOpsClient) doS3(ctx context.Context, method, bucket, subresource string, reqBody []byte) ([]byte, error) {
+	reqURL := c.endpoint.JoinPath(bucket)
+	reqURL.RawPath = strings.ReplaceAll(reqURL.EscapedPath(), ":", "%3A")
+	reqURL.RawQuery = subresource
+	ctx = withMetricsRoute(ctx, "/{bucket}")
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, reqURL.String(), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	payloadHash := sha256.Sum256(reqBody)
+	httpReq.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	if reqBody != nil {
+		payloadMD5 := md5.Sum(reqBody)
+		httpReq.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(payloadMD5[:]))
+		httpReq.Header.Set("Content-Type", "application/xml")
+	}
+	signRGWAdminOpsRequest(httpReq, c.accessKey, c.secretKey, c.region, rgwAdminOpsService, time.Now().UTC())
+
+	logRequest := logAPIRequest(ctx, httpReq)
+	httpResp, err := c.client.Do(httpReq)
+	logRequest(httpResp, err)
+	if err != nil {
+		return nil, fmt.Errorf("unable to make request to RGW S3 API: %w", err)
+	}
+	defer httpResp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response body: %w", err)
+	}
+
+	tflog.Trace(ctx, "RGW S3 API response body", map[string]any{
+		"response_body": string(body),
+		"status_code":   httpResp.StatusCode,
+	})
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		apiErr := &CephAPIError{
+			StatusCode: httpResp.StatusCode,
+			Component:  "rgw",
+			Detail:     string(body),
+		}
+		var errBody struct {
+			Code string `xml:"Code"`
+		}
+		if err := xml.Unmarshal(body, &errBody); err == nil && errBody.Code != "" {
+			apiErr.Detail = errBody.Code
+		}
+		return nil, apiErr
+	}
+
+	return body, nil
+}
+
+// signRGWAdminOpsRequest adds an AWS Signature Version 4 Authorization header
+// to req. Requests with a body must set X-Amz-Content-Sha256 to its hash.
+func signRGWAdminOpsRequest(req *http.Request, accessKey, secretKey, region, service string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	payloadHash := req.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		emptyPayloadHash := sha256.Sum256(nil)
+		payloadHash = hex.EncodeToString(emptyPayloadHash[:])
+	}
+
+	headers := map[string]string{
+		"host":       req.URL.Host,
+		"x-amz-date": amzDate,
+	}
+	if value := req.Header.Get("X-Amz-Content-Sha256"); value != "" {
+		headers["x-amz-content-sha256"] = value
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalURI := req.URL.EscapedPath()
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// <https://docs.ceph.com/en/latest/radosgw/adminops/#get-user-info>
+
+func (c *RGWAdminOpsClient) GetUser(ctx context.Context, uid string) (CephAPIRGWUser, error) {
+	body, err := c.do(ctx, "GET", "user", url.Values{"uid": {uid}})
+	if err != nil {
+		return CephAPIRGWUser{}, err
+	}
+
+	var user CephAPIRGWUser
+	if err := json.Unmarshal(body, &user); err != nil {
+		return CephAPIRGWUser{}, fmt.Errorf("unable to decode JSON response: %w", err)
+	}
+
+	return user, nil
+}
+
+// <https://docs.ceph.com/en/latest/radosgw/adminops/#create-user>
+
+func (c *RGWAdminOpsClient) CreateUser(ctx context.Context, req CephAPIRGWUserCreateRequest) (CephAPIRGWUser, error) {
+	query := url.Values{
+		"uid":          {req.UID},
+		"display-name": {req.DisplayName},
+		"generate-key": {strconv.FormatBool(req.GenerateKey)},
+	}
+	if req.Email != nil {
+		query.Set("email", *req.Email)
+	}
+	if req.MaxBuckets != nil {
+		query.Set("max-buckets", strconv.Itoa(*req.MaxBuckets))
+	}
+	if req.Suspended != nil {
+		query.Set("suspended", strconv.FormatBool(*req.Suspended != 0))
+	}
+	if req.System != nil {
+		query.Set("system", strconv.FormatBool(*req.System))
+	}
+	if req.OpMask != nil {
+		query.Set("op-mask", *req.OpMask)
+	}
+	if req.DefaultPlacement != nil {
+		query.Set("default-placement", *req.DefaultPlacement)
+	}
+	if req.DefaultStorageClass != nil {
+		query.Set("default-storage-class", *req.DefaultStorageClass)
+	}
+	if req.AccountID != nil {
+		query.Set("account-id", *req.AccountID)
+	}
+	if req.AccountRootUser != nil {
+		query.Set("account-root", strconv.FormatBool(*req.AccountRootUser))
+	}
+
+	body, err := c.do(ctx, "PUT", "user", query)
+	if err != nil {
+		return CephAPIRGWUser{}, err
+	}
+
+	var user CephAPIRGWUser
+	if err := json.Unmarshal(body, &user); err != nil {
+		return CephAPIRGWUser{}, fmt.Errorf("unable to decode JSON response: %w", err)
+	}
+
+	return user, nil
+}
+
+// <https://docs.ceph.com/en/latest/radosgw/adminops/#modify-user>
+
+func (c *RGWAdminOpsClient) UpdateUser(ctx context.Context, uid string, req CephAPIRGWUserUpdateRequest) (CephAPIRGWUser, error) {
+	query := url.Values{"uid": {uid}}
+	if req.DisplayName != nil {
+		query.Set("display-name", *req.DisplayName)
+	}
+	if req.Email != nil {
+		query.Set("email", *req.Email)
+	}
+	if req.MaxBuckets != nil {
+		query.Set("max-buckets", strconv.Itoa(*req.MaxBuckets))
+	}
+	if req.Suspended != nil {
+		query.Set("suspended", strconv.FormatBool(*req.Suspended != 0))
+	}
+	if req.System != nil {
+		query.Set("system", strconv.FormatBool(*req.System))
+	}
+	if req.OpMask != nil {
+		query.Set("op-mask", *req.OpMask)
+	}
+	if req.DefaultPlacement != nil {
+		query.Set("default-placement", *req.DefaultPlacement)
+	}
+	if req.DefaultStorageClass != nil {
+		query.Set("default-storage-class", *req.DefaultStorageClass)
+	}
+	if req.AccountID != nil {
+		query.Set("account-id", *req.AccountID)
+	}
+	if req.AccountRootUser != nil {
+		query.Set("account-root", strconv.FormatBool(*req.AccountRootUser))
+	}
+
+	body, err := c.do(ctx, "POST", "user", query)
+	if err != nil {
+		return CephAPIRGWUser{}, err
+	}
+
+	var user CephAPIRGWUser
+	if err := json.Unmarshal(body, &user); err != nil {
+		return CephAPIRGWUser{}, fmt.Errorf("unable to decode JSON response: %w", err)
+	}
+
+	return user, nil
+}
+
+// <https://docs.ceph.com/en/latest/radosgw/adminops/#remove-user>
+
+func (c *RGWAdminOpsClient) DeleteUser(ctx context.Context, uid string, purgeData bool) error {
+	_, err := c.do(ctx, "DELETE", "user", url.Values{
+		"uid":        {uid},
+		"purge-data": {strconv.FormatBool(purgeData)},
+	})
+	return err
+}
+
+// <https://docs.ceph.com/en/latest/radosgw/adminops/#get-bucket-info>
+
+func (c *RGWAdminOpsClient) GetBucket(ctx context.Context, bucketName string) (CephAPIRGWBucket, error) {
+	body, err := c.do(ctx, "GET", "bucket", url.Values{"bucket": {bucketName}})
+	if err != nil {
+		return CephAPIRGWBucket{}, err
+	}
+
+	var bucket CephAPIRGWBucket
+	if err := json.Unmarshal(body, &bucket); err != nil {
+		return CephAPIRGWBucket{}, fmt.Errorf("unable to decode JSON response: %w", err)
+	}
+
+	return bucket, nil
+}
+
+// <https://docs.ceph.com/en/latest/radosgw/adminops/#link-bucket>
+
+func (c *RGWAdminOpsClient) LinkBucket(ctx context.Context, bucketName, bucketID, uid string) error {
+	_, err := c.do(ctx, "PUT", "bucket", url.Values{
+		"bucket":    {bucketName},
+		"bucket-id": {bucketID},
+		"uid":       {uid},
+	})
+	return err
+}
+
+// <https://docs.ceph.com/en/latest/radosgw/adminops/#remove-bucket>
+
+func (c *RGWAdminOpsClient) DeleteBucket(ctx context.Context, bucketName string, purgeObjects bool) error {
+	_, err := c.do(ctx, "DELETE", "bucket", url.Values{
+		"bucket":        {bucketName},
+		"purge-objects": {strconv.FormatBool(purgeObjects)},
+	})
+	return err
+}
+
+// RGWBucketCORSConfiguration is the S3 CORS configuration of a bucket.
+type RGWBucketCORSConfiguration struct {
+	XMLName xml.Name            `xml:"CORSConfiguration"`
+	Rules   []RGWBucketCORSRule `xml:"CORSRule"`
+}
+
+type RGWBucketCORSRule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedHeaders []string `xml:"AllowedHeader"`
+	ExposeHeaders  []string `xml:"ExposeHeader"`
+	MaxAgeSeconds  *int     `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// <https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketCors.html>
+
+func (c *RGWAdminOpsClient) GetBucketCORS(ctx context.Context, bucketName string) (RGWBucketCORSConfiguration, error) {
+	body, err := c.doS3(ctx, "GET", bucketName, "cors", nil)
+	if err != nil {
+		return RGWBucketCORSConfiguration{}, err
+	}
+
+	var config RGWBucketCORSConfiguration
+	if err := xml.Unmarshal(body, &config); err != nil {
+		return RGWBucketCORSConfiguration{}, fmt.Errorf("unable to decode XML response: %w", err)
+	}
+
+	return config, nil
+}
+
+// <https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketCors.html>
+
+func (c *RGWAdminOpsClient) PutBucketCORS(ctx context.Context, bucketName string, config RGWBucketCORSConfiguration) error {
+	reqBody, err := xml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("unable to marshal request: %w", err)
+	}
+
+	tflog.Trace(ctx, "RGW S3 API request body", map[string]any{
+		"request_body": string(reqBody),
+	})
+
+	_, err = c.doS3(ctx, "PUT", bucketName, "cors", reqBody)
+	return err
+}
+
+// <https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketCors.html>
+
+func (c *RGWAdminOpsClient) DeleteBucketCORS(ctx context.Context, bucketName string) error {
+	_, err := c.doS3(ctx, "DELETE", bucketName, "cors", nil)
+	return err
+}
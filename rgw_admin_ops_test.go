@@ -0,0 +1,169 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignRGWAdminOpsRequest(t *testing.T) {
+	// get-vanilla from the AWS Signature Version 4 test suite.
+	req, err := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signRGWAdminOpsRequest(req, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != expected {
+		t.Errorf("Authorization = %q, want %q", got, expected)
+	}
+	if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+		t.Errorf("X-Amz-Date = %q, want %q", got, "20150830T123600Z")
+	}
+}
+
+func TestRGWAdminOpsClient(t *testing.T) {
+	var lastRequest *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = r
+		if r.URL.Path != "/admin/user" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Query().Get("uid") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"Code":"NoSuchUser"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"user_id":"test-user","display_name":"Test User","max_buckets":1000,"suspended":0,"system":false,"admin":true,"keys":[]}`))
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &RGWAdminOpsClient{
+		endpoint:  endpoint,
+		accessKey: "access",
+		secretKey: "secret",
+		region:    rgwAdminOpsDefaultRegion,
+		client:    server.Client(),
+	}
+
+	user, err := client.GetUser(t.Context(), "test-user")
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if user.UserID != "test-user" || user.DisplayName != "Test User" || !user.Admin {
+		t.Errorf("GetUser() = %+v", user)
+	}
+	if got := lastRequest.URL.Query().Get("format"); got != "json" {
+		t.Errorf("format = %q, want %q", got, "json")
+	}
+
+	maxBuckets := 10
+	suspended := 1
+	opMask := "read"
+	accountID := "RGW12345678901234567"
+	_, err = client.CreateUser(t.Context(), CephAPIRGWUserCreateRequest{
+		UID:         "test-user",
+		DisplayName: "Test User",
+		MaxBuckets:  &maxBuckets,
+		Suspended:   &suspended,
+		OpMask:      &opMask,
+		AccountID:   &accountID,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	if lastRequest.Method != "PUT" {
+		t.Errorf("CreateUser() method = %q, want PUT", lastRequest.Method)
+	}
+	query := lastRequest.URL.Query()
+	if query.Get("display-name") != "Test User" || query.Get("max-buckets") != "10" || query.Get("suspended") != "true" || query.Get("generate-key") != "false" || query.Get("op-mask") != "read" || query.Get("account-id") != accountID || query.Has("account-root") {
+		t.Errorf("CreateUser() query = %v", query)
+	}
+
+	if err := client.DeleteUser(t.Context(), "test-user", true); err != nil {
+		t.Fatalf("DeleteUser() error = %v", err)
+	}
+	if lastRequest.Method != "DELETE" || lastRequest.URL.Query().Get("purge-data") != "true" {
+		t.Errorf("DeleteUser() request = %s %v", lastRequest.Method, lastRequest.URL.Query())
+	}
+
+	_, err = client.GetUser(t.Context(), "missing")
+	if err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("GetUser() error = %v, want status 404", err)
+	}
+}
+
+func TestRGWAdminOpsClientBucketCORS(t *testing.T) {
+	var lastRequest *http.Request
+	var lastBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = r
+		lastBody, _ = io.ReadAll(r.Body)
+		if r.URL.EscapedPath() == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchCORSConfiguration</Code></Error>`))
+			return
+		}
+		if r.Method == "GET" {
+			_, _ = w.Write([]byte(`<CORSConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><CORSRule><AllowedMethod>GET</AllowedMethod><AllowedOrigin>*</AllowedOrigin><MaxAgeSeconds>60</MaxAgeSeconds></CORSRule></CORSConfiguration>`))
+		}
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &RGWAdminOpsClient{
+		endpoint:  endpoint,
+		accessKey: "access",
+		secretKey: "secret",
+		region:    rgwAdminOpsDefaultRegion,
+		client:    server.Client(),
+	}
+
+	config, err := client.GetBucketCORS(t.Context(), "tenant:bucket")
+	if err != nil {
+		t.Fatalf("GetBucketCORS() error = %v", err)
+	}
+	if lastRequest.URL.EscapedPath() != "/tenant%3Abucket" || lastRequest.URL.RawQuery != "cors" {
+		t.Errorf("GetBucketCORS() URL = %s", lastRequest.URL)
+	}
+	if len(config.Rules) != 1 || config.Rules[0].AllowedOrigins[0] != "*" || config.Rules[0].MaxAgeSeconds == nil || *config.Rules[0].MaxAgeSeconds != 60 {
+		t.Errorf("GetBucketCORS() = %+v", config)
+	}
+
+	err = client.PutBucketCORS(t.Context(), "bucket", RGWBucketCORSConfiguration{
+		Rules: []RGWBucketCORSRule{{AllowedOrigins: []string{"https://example.com"}, AllowedMethods: []string{"GET", "PUT"}}},
+	})
+	if err != nil {
+		t.Fatalf("PutBucketCORS() error = %v", err)
+	}
+	want := `<CORSConfiguration><CORSRule><AllowedOrigin>https://example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedMethod>PUT</AllowedMethod></CORSRule></CORSConfiguration>`
+	if string(lastBody) != want {
+		t.Errorf("PutBucketCORS() body = %s, want %s", lastBody, want)
+	}
+	if lastRequest.Header.Get("Content-MD5") == "" || !strings.Contains(lastRequest.Header.Get("Authorization"), "x-amz-content-sha256") {
+		t.Errorf("PutBucketCORS() headers = %v", lastRequest.Header)
+	}
+
+	_, err = client.GetBucketCORS(t.Context(), "missing")
+	if !isCephAPINotFound(err) || !strings.Contains(err.Error(), "NoSuchCORSConfiguration") {
+		t.Errorf("GetBucketCORS() error = %v, want NoSuchCORSConfiguration", err)
+	}
+}
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	rgwACLGroupAllUsers           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	rgwACLGroupAuthenticatedUsers = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+var (
+	_ resource.Resource                = &RGWBucketACLResource{}
+	_ resource.ResourceWithImportState = &RGWBucketACLResource{}
+	_ resource.ResourceWithIdentity    = &RGWBucketACLResource{}
+)
+
+func newRGWBucketACLResource() resource.Resource {
+	return &RGWBucketACLResource{}
+}
+
+type RGWBucketACLResource struct {
+	client *CephAPIClient
+}
+
+type RGWBucketACLResourceModel struct {
+	Bucket types.String `tfsdk:"bucket"`
+	Tenant types.String `tfsdk:"tenant"`
+	ACL    types.String `tfsdk:"acl"`
+	Grants types.List   `tfsdk:"grants"`
+}
+
+type RGWBucketACLResourceIdentityModel struct {
+	Bucket types.String `tfsdk:"bucket"`
+	Tenant types.String `tfsdk:"tenant"`
+}
+
+type RGWBucketACLGrantModel struct {
+	Type       types.String `tfsdk:"type"`
+	ID         types.String `tfsdk:"id"`
+	URI        types.String `tfsdk:"uri"`
+	Permission types.String `tfsdk:"permission"`
+}
+
+var rgwBucketACLGrantObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"type":       types.StringType,
+		"id":         types.StringType,
+		"uri":        types.StringType,
+		"permission": types.StringType,
+	},
+}
+
+// rgwAccessControlPolicy is the S3 AccessControlPolicy document the dashboard
+// returns as a bucket's acl.
+type rgwAccessControlPolicy struct {
+	Owner struct {
+		ID string `xml:"ID"`
+	} `xml:"Owner"`
+	Grants []rgwACLGrant `xml:"AccessControlList>Grant"`
+}
+
+type rgwACLGrant struct {
+	Grantee struct {
+		Type string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+		ID   string `xml:"ID"`
+		URI  string `xml:"URI"`
+	} `xml:"Grantee"`
+	Permission string `xml:"Permission"`
+}
+
+func parseRGWAccessControlPolicy(policy string) (rgwAccessControlPolicy, error) {
+	var acp rgwAccessControlPolicy
+	if err := xml.Unmarshal([]byte(policy), &acp); err != nil {
+		return rgwAccessControlPolicy{}, fmt.Errorf("unable to decode access control policy: %w", err)
+	}
+	return acp, nil
+}
+
+// cannedACL returns the canned ACL whose grants match the policy, or an empty
+// string if it has grants no canned ACL produces. The owner's FULL_CONTROL
+// grant is part of every canned ACL, so it is ignored.
+func (p rgwAccessControlPolicy) cannedACL() string {
+	var grants []string
+	for _, grant := range p.Grants {
+		if grant.Grantee.Type == "CanonicalUser" && grant.Grantee.ID == p.Owner.ID && grant.Permission == "FULL_CONTROL" {
+			continue
+		}
+		if grant.Grantee.Type != "Group" {
+			return ""
+		}
+		grants = append(grants, grant.Grantee.URI+" "+grant.Permission)
+	}
+	sort.Strings(grants)
+
+	switch strings.Join(grants, ",") {
+	case "":
+		return "private"
+	case rgwACLGroupAllUsers + " READ":
+		return "public-read"
+	case rgwACLGroupAllUsers + " READ," + rgwACLGroupAllUsers + " WRITE":
+		return "public-read-write"
+	case rgwACLGroupAuthenticatedUsers + " READ":
+		return "authenticated-read"
+	default:
+		return ""
+	}
+}
+
+func (r *RGWBucketACLResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_bucket_acl"
+}
+
+func (r *RGWBucketACLResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "This resource manages the canned ACL of a Ceph RGW bucket. " +
+			"Grants added outside Terraform that no canned ACL produces show up as drift and are replaced by the configured ACL. " +
+			"Destroying the resource resets the bucket to `private`.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"bucket": resourceSchema.StringAttribute{
+				MarkdownDescription: "The bucket name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenant": resourceSchema.StringAttribute{
+				MarkdownDescription: "The tenant of the bucket. Omit for the default tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"acl": resourceSchema.StringAttribute{
+				MarkdownDescription: "The canned ACL: `private`, `public-read`, `public-read-write` or `authenticated-read`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("private", "public-read", "public-read-write", "authenticated-read"),
+				},
+			},
+			"grants": resourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The grants of the bucket's access control policy",
+				Computed:            true,
+				NestedObject: resourceSchema.NestedAttributeObject{
+					Attributes: map[string]resourceSchema.Attribute{
+						"type": resourceSchema.StringAttribute{
+							MarkdownDescription: "The grantee type, `CanonicalUser` or `Group`",
+							Computed:            true,
+						},
+						"id": resourceSchema.StringAttribute{
+							MarkdownDescription: "The user ID of a `CanonicalUser` grantee",
+							Computed:            true,
+						},
+						"uri": resourceSchema.StringAttribute{
+							MarkdownDescription: "The URI of a `Group` grantee",
+							Computed:            true,
+						},
+						"permission": resourceSchema.StringAttribute{
+							MarkdownDescription: "The permission granted, e.g. `READ` or `FULL_CONTROL`",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *RGWBucketACLResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"bucket": identityschema.StringAttribute{
+				Description:       "The name of the bucket",
+				RequiredForImport: true,
+			},
+			"tenant": identityschema.StringAttribute{
+				Description:       "The tenant of the bucket",
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+func (r *RGWBucketACLResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RGWBucketACLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RGWBucketACLResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setACL(ctx, &data, data.ACL.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := r.read(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketACLResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketACLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RGWBucketACLResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, diags := r.read(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		removeMissingResource(ctx, resp, fmt.Sprintf("RGW bucket %s", rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketACLResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketACLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RGWBucketACLResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setACL(ctx, &data, data.ACL.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := r.read(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketACLResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketACLResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RGWBucketACLResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+	if _, err := r.client.RGWGetBucket(ctx, bucketName); isCephAPINotFound(err) {
+		return
+	}
+
+	r.setACL(ctx, &data, "private", &resp.Diagnostics)
+}
+
+func (r *RGWBucketACLResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		var identity RGWBucketACLResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), identity.Bucket)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), identity.Tenant)...)
+		return
+	}
+
+	bucket := req.ID
+	if tenant, name, ok := strings.Cut(req.ID, "/"); ok {
+		bucket = name
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+}
+
+// setACL looks up the bucket's ID and owner, which the dashboard's bucket
+// update endpoint requires alongside the canned ACL.
+func (r *RGWBucketACLResource) setACL(ctx context.Context, data *RGWBucketACLResourceModel, cannedACL string, diags *diag.Diagnostics) {
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+
+	bucket, err := r.client.RGWGetBucket(ctx, bucketName)
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+
+	err = r.client.RGWSetBucketACL(ctx, bucketName, bucket.ID, bucket.Owner, cannedACL)
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to set ACL of RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+}
+
+// read fills in the ACL and grants from the bucket's access control policy.
+// It reports false if the bucket no longer exists.
+func (r *RGWBucketACLResource) read(ctx context.Context, data *RGWBucketACLResourceModel) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+	policy, err := r.client.RGWGetBucketACL(ctx, bucketName)
+	if isCephAPINotFound(err) {
+		return false, diags
+	}
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read ACL of RGW bucket %s: %s", bucketName, err),
+		)
+		return true, diags
+	}
+
+	acp, err := parseRGWAccessControlPolicy(policy)
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read ACL of RGW bucket %s: %s", bucketName, err),
+		)
+		return true, diags
+	}
+
+	grants := make([]RGWBucketACLGrantModel, 0, len(acp.Grants))
+	for _, grant := range acp.Grants {
+		grantModel := RGWBucketACLGrantModel{
+			Type:       types.StringValue(grant.Grantee.Type),
+			ID:         types.StringNull(),
+			URI:        types.StringNull(),
+			Permission: types.StringValue(grant.Permission),
+		}
+		if grant.Grantee.ID != "" {
+			grantModel.ID = types.StringValue(grant.Grantee.ID)
+		}
+		if grant.Grantee.URI != "" {
+			grantModel.URI = types.StringValue(grant.Grantee.URI)
+		}
+		grants = append(grants, grantModel)
+	}
+
+	grantsValue, d := types.ListValueFrom(ctx, rgwBucketACLGrantObjectType, grants)
+	diags.Append(d...)
+	if diags.HasError() {
+		return true, diags
+	}
+
+	data.ACL = types.StringValue(acp.cannedACL())
+	data.Grants = grantsValue
+	return true, diags
+}
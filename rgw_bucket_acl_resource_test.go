@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestRGWAccessControlPolicyCannedACL(t *testing.T) {
+	const owner = `<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>alice</ID><DisplayName>Alice</DisplayName></Grantee><Permission>FULL_CONTROL</Permission></Grant>`
+	group := func(uri, permission string) string {
+		return `<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>` + uri + `</URI></Grantee><Permission>` + permission + `</Permission></Grant>`
+	}
+	user := func(id, permission string) string {
+		return `<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>` + id + `</ID></Grantee><Permission>` + permission + `</Permission></Grant>`
+	}
+
+	tests := []struct {
+		grants string
+		want   string
+	}{
+		{owner, "private"},
+		{owner + group(rgwACLGroupAllUsers, "READ"), "public-read"},
+		{owner + group(rgwACLGroupAllUsers, "WRITE") + group(rgwACLGroupAllUsers, "READ"), "public-read-write"},
+		{owner + group(rgwACLGroupAuthenticatedUsers, "READ"), "authenticated-read"},
+		{owner + group(rgwACLGroupAllUsers, "WRITE"), ""},
+		{owner + user("bob", "READ"), ""},
+		{user("alice", "READ"), ""},
+	}
+
+	for _, tt := range tests {
+		policy := `<?xml version="1.0" encoding="UTF-8"?><AccessControlPolicy xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Owner><ID>alice</ID><DisplayName>Alice</DisplayName></Owner><AccessControlList>` + tt.grants + `</AccessControlList></AccessControlPolicy>`
+		acp, err := parseRGWAccessControlPolicy(policy)
+		if err != nil {
+			t.Fatalf("parseRGWAccessControlPolicy() error = %v", err)
+		}
+		if got := acp.cannedACL(); got != tt.want {
+			t.Errorf("cannedACL() = %q, want %q for %s", got, tt.want, tt.grants)
+		}
+	}
+
+	if _, err := parseRGWAccessControlPolicy("not xml"); err == nil {
+		t.Error("parseRGWAccessControlPolicy() error = nil, want error")
+	}
+}
+
+func TestAccCephRGWBucketACLResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-bucket-acl-owner")
+	testBucket := acctest.RandomWithPrefix("test-bucket-acl")
+
+	bucketConfig := fmt.Sprintf(`
+		resource "ceph_rgw_user" "test" {
+		  user_id      = %q
+		  display_name = "Bucket ACL Test User"
+		}
+
+		resource "ceph_rgw_s3_key" "test" {
+		  user_id = ceph_rgw_user.test.user_id
+		}
+
+		resource "ceph_rgw_bucket" "test" {
+		  bucket = %q
+		  owner  = ceph_rgw_user.test.user_id
+		  depends_on = [ceph_rgw_s3_key.test]
+		}
+	`, testUID, testBucket)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWBucketDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_acl" "test" {
+					  bucket = ceph_rgw_bucket.test.bucket
+					  acl    = "public-read"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_rgw_bucket_acl.test",
+						tfjsonpath.New("acl"),
+						knownvalue.StringExact("public-read"),
+					),
+					statecheck.ExpectKnownValue(
+						"ceph_rgw_bucket_acl.test",
+						tfjsonpath.New("grants"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"type":       knownvalue.StringExact("CanonicalUser"),
+								"id":         knownvalue.StringExact(testUID),
+								"uri":        knownvalue.Null(),
+								"permission": knownvalue.StringExact("FULL_CONTROL"),
+							}),
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"type":       knownvalue.StringExact("Group"),
+								"id":         knownvalue.Null(),
+								"uri":        knownvalue.StringExact(rgwACLGroupAllUsers),
+								"permission": knownvalue.StringExact("READ"),
+							}),
+						}),
+					),
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_acl" "test" {
+					  bucket = ceph_rgw_bucket.test.bucket
+					  acl    = "authenticated-read"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_rgw_bucket_acl.test",
+						tfjsonpath.New("acl"),
+						knownvalue.StringExact("authenticated-read"),
+					),
+					statecheck.ExpectKnownValue(
+						"ceph_rgw_bucket_acl.test",
+						tfjsonpath.New("grants").AtSliceIndex(1).AtMapKey("uri"),
+						knownvalue.StringExact(rgwACLGroupAuthenticatedUsers),
+					),
+				},
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.RgwBucketRemove(t.Context(), testBucket, true); err != nil {
+						t.Fatalf("Failed to delete bucket out of band: %v", err)
+					}
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_acl" "test" {
+					  bucket = ceph_rgw_bucket.test.bucket
+					  acl    = "authenticated-read"
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_bucket.test", plancheck.ResourceActionCreate),
+						plancheck.ExpectResourceAction("ceph_rgw_bucket_acl.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			{
+				ConfigVariables:                      testAccProviderConfig(),
+				ResourceName:                         "ceph_rgw_bucket_acl.test",
+				ImportState:                          true,
+				ImportStateId:                        testBucket,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "bucket",
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + bucketConfig,
+				Check:           checkCephRGWBucketExists(t, testBucket),
+			},
+		},
+	})
+}
@@ -0,0 +1,400 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &RGWBucketCORSResource{}
+	_ resource.ResourceWithImportState = &RGWBucketCORSResource{}
+	_ resource.ResourceWithIdentity    = &RGWBucketCORSResource{}
+)
+
+func newRGWBucketCORSResource() resource.Resource {
+	return &RGWBucketCORSResource{}
+}
+
+// RGWBucketCORSResource manages a bucket's CORS rules. The dashboard API does
+// not expose them, so it goes through the S3 API of the RGW admin ops
+// endpoint.
+type RGWBucketCORSResource struct {
+	client *CephAPIClient
+}
+
+type RGWBucketCORSResourceModel struct {
+	Bucket    types.String `tfsdk:"bucket"`
+	Tenant    types.String `tfsdk:"tenant"`
+	CORSRules types.List   `tfsdk:"cors_rules"`
+}
+
+type RGWBucketCORSResourceIdentityModel struct {
+	Bucket types.String `tfsdk:"bucket"`
+	Tenant types.String `tfsdk:"tenant"`
+}
+
+type RGWBucketCORSRuleModel struct {
+	ID             types.String `tfsdk:"id"`
+	AllowedOrigins types.Set    `tfsdk:"allowed_origins"`
+	AllowedMethods types.Set    `tfsdk:"allowed_methods"`
+	AllowedHeaders types.Set    `tfsdk:"allowed_headers"`
+	ExposeHeaders  types.Set    `tfsdk:"expose_headers"`
+	MaxAgeSeconds  types.Int64  `tfsdk:"max_age_seconds"`
+}
+
+var rgwBucketCORSRuleObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":              types.StringType,
+		"allowed_origins": types.SetType{ElemType: types.StringType},
+		"allowed_methods": types.SetType{ElemType: types.StringType},
+		"allowed_headers": types.SetType{ElemType: types.StringType},
+		"expose_headers":  types.SetType{ElemType: types.StringType},
+		"max_age_seconds": types.Int64Type,
+	},
+}
+
+func (r *RGWBucketCORSResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_bucket_cors"
+}
+
+func (r *RGWBucketCORSResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "This resource manages the CORS rules of a Ceph RGW bucket, for example to let browsers load web assets from it. " +
+			"The dashboard API does not expose CORS, so this resource requires the provider `rgw_admin_endpoint`, and the admin ops user must own the bucket or have the admin flag. " +
+			"Destroying the resource removes all CORS rules from the bucket.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"bucket": resourceSchema.StringAttribute{
+				MarkdownDescription: "The bucket name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenant": resourceSchema.StringAttribute{
+				MarkdownDescription: "The tenant of the bucket. Omit for the default tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cors_rules": resourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The CORS rules, evaluated in order",
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 100),
+				},
+				NestedObject: resourceSchema.NestedAttributeObject{
+					Attributes: map[string]resourceSchema.Attribute{
+						"id": resourceSchema.StringAttribute{
+							MarkdownDescription: "An identifier for the rule",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 255),
+							},
+						},
+						"allowed_origins": resourceSchema.SetAttribute{
+							MarkdownDescription: "The origins allowed to make cross-origin requests, e.g. `https://example.com`. An origin may contain one `*` wildcard.",
+							Required:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"allowed_methods": resourceSchema.SetAttribute{
+							MarkdownDescription: "The HTTP methods allowed: `GET`, `PUT`, `HEAD`, `POST` or `DELETE`",
+							Required:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+								setvalidator.ValueStringsAre(stringvalidator.OneOf("GET", "PUT", "HEAD", "POST", "DELETE")),
+							},
+						},
+						"allowed_headers": resourceSchema.SetAttribute{
+							MarkdownDescription: "The headers allowed in preflight `Access-Control-Request-Headers`",
+							Optional:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"expose_headers": resourceSchema.SetAttribute{
+							MarkdownDescription: "The response headers browsers may expose to scripts",
+							Optional:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"max_age_seconds": resourceSchema.Int64Attribute{
+							MarkdownDescription: "How long browsers may cache the preflight response, in seconds",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *RGWBucketCORSResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"bucket": identityschema.StringAttribute{
+				Description:       "The name of the bucket",
+				RequiredForImport: true,
+			},
+			"tenant": identityschema.StringAttribute{
+				Description:       "The tenant of the bucket",
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+func (r *RGWBucketCORSResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RGWBucketCORSResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RGWBucketCORSResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.putCORS(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketCORSResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketCORSResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RGWBucketCORSResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rgwAdmin, err := r.client.RGWAdmin()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"RGW Admin Ops Required",
+			fmt.Sprintf("Unable to read CORS rules: %s", err),
+		)
+		return
+	}
+
+	bucketName := rgwS3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+	config, err := rgwAdmin.GetBucketCORS(ctx, bucketName)
+	if isCephAPINotFound(err) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("RGW bucket %s", bucketName))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read CORS rules of RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+
+	rules := make([]RGWBucketCORSRuleModel, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		ruleModel := RGWBucketCORSRuleModel{
+			ID:            types.StringNull(),
+			MaxAgeSeconds: types.Int64Null(),
+		}
+		if rule.ID != "" {
+			ruleModel.ID = types.StringValue(rule.ID)
+		}
+		if rule.MaxAgeSeconds != nil {
+			ruleModel.MaxAgeSeconds = types.Int64Value(int64(*rule.MaxAgeSeconds))
+		}
+		ruleModel.AllowedOrigins = rgwBucketCORSSetValue(ctx, rule.AllowedOrigins, &resp.Diagnostics)
+		ruleModel.AllowedMethods = rgwBucketCORSSetValue(ctx, rule.AllowedMethods, &resp.Diagnostics)
+		ruleModel.AllowedHeaders = rgwBucketCORSSetValue(ctx, rule.AllowedHeaders, &resp.Diagnostics)
+		ruleModel.ExposeHeaders = rgwBucketCORSSetValue(ctx, rule.ExposeHeaders, &resp.Diagnostics)
+		rules = append(rules, ruleModel)
+	}
+
+	rulesValue, diags := types.ListValueFrom(ctx, rgwBucketCORSRuleObjectType, rules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.CORSRules = rulesValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketCORSResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketCORSResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RGWBucketCORSResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.putCORS(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketCORSResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketCORSResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RGWBucketCORSResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rgwAdmin, err := r.client.RGWAdmin()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"RGW Admin Ops Required",
+			fmt.Sprintf("Unable to remove CORS rules: %s", err),
+		)
+		return
+	}
+
+	bucketName := rgwS3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+	err = rgwAdmin.DeleteBucketCORS(ctx, bucketName)
+	if err != nil && !isCephAPINotFound(err) {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to remove CORS rules of RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+}
+
+func (r *RGWBucketCORSResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		var identity RGWBucketCORSResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), identity.Bucket)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), identity.Tenant)...)
+		return
+	}
+
+	bucket := req.ID
+	if tenant, name, ok := strings.Cut(req.ID, "/"); ok {
+		bucket = name
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+}
+
+// putCORS replaces the bucket's whole CORS configuration with the planned
+// rules, since S3 has no way to change a single rule.
+func (r *RGWBucketCORSResource) putCORS(ctx context.Context, data *RGWBucketCORSResourceModel, diags *diag.Diagnostics) {
+	rgwAdmin, err := r.client.RGWAdmin()
+	if err != nil {
+		diags.AddError(
+			"RGW Admin Ops Required",
+			fmt.Sprintf("Unable to set CORS rules: %s", err),
+		)
+		return
+	}
+
+	var rules []RGWBucketCORSRuleModel
+	diags.Append(data.CORSRules.ElementsAs(ctx, &rules, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	var config RGWBucketCORSConfiguration
+	for _, rule := range rules {
+		corsRule := RGWBucketCORSRule{
+			ID: rule.ID.ValueString(),
+		}
+		diags.Append(rule.AllowedOrigins.ElementsAs(ctx, &corsRule.AllowedOrigins, false)...)
+		diags.Append(rule.AllowedMethods.ElementsAs(ctx, &corsRule.AllowedMethods, false)...)
+		if !rule.AllowedHeaders.IsNull() {
+			diags.Append(rule.AllowedHeaders.ElementsAs(ctx, &corsRule.AllowedHeaders, false)...)
+		}
+		if !rule.ExposeHeaders.IsNull() {
+			diags.Append(rule.ExposeHeaders.ElementsAs(ctx, &corsRule.ExposeHeaders, false)...)
+		}
+		if !rule.MaxAgeSeconds.IsNull() {
+			maxAge := int(rule.MaxAgeSeconds.ValueInt64())
+			corsRule.MaxAgeSeconds = &maxAge
+		}
+		config.Rules = append(config.Rules, corsRule)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	bucketName := rgwS3BucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+	err = rgwAdmin.PutBucketCORS(ctx, bucketName, config)
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to set CORS rules of RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+}
+
+// rgwBucketCORSSetValue converts values read from RGW to a set, keeping an
+// absent element list null so it matches an omitted optional attribute.
+func rgwBucketCORSSetValue(ctx context.Context, values []string, diags *diag.Diagnostics) types.Set {
+	if len(values) == 0 {
+		return types.SetNull(types.StringType)
+	}
+
+	setValue, d := types.SetValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	return setValue
+}
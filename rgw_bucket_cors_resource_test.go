@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccRGWAdminProviderConfigBlock = `
+	variable "endpoint" {
+	  type = string
+	}
+
+	variable "rgw_admin_access_key" {
+	  type = string
+	}
+
+	variable "rgw_admin_secret_key" {
+	  type = string
+	}
+
+	provider "ceph" {
+	  endpoint             = var.endpoint
+	  username             = "admin"
+	  password             = "password"
+	  rgw_admin_endpoint   = "http://127.0.0.1:7480"
+	  rgw_admin_access_key = var.rgw_admin_access_key
+	  rgw_admin_secret_key = var.rgw_admin_secret_key
+	}
+`
+
+func TestAccCephRGWBucketCORSResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	adminUID := acctest.RandomWithPrefix("test-bucket-cors-admin")
+	adminAccessKey := acctest.RandStringFromCharSet(20, acctest.CharSetAlpha)
+	adminSecretKey := acctest.RandString(40)
+	testUID := acctest.RandomWithPrefix("test-bucket-cors-owner")
+	testBucket := acctest.RandomWithPrefix("test-bucket-cors")
+
+	configVariables := config.Variables{
+		"endpoint":             config.StringVariable(testDashboardURL),
+		"rgw_admin_access_key": config.StringVariable(adminAccessKey),
+		"rgw_admin_secret_key": config.StringVariable(adminSecretKey),
+	}
+
+	bucketConfig := fmt.Sprintf(`
+		resource "ceph_rgw_user" "test" {
+		  user_id      = %q
+		  display_name = "Bucket CORS Test User"
+		}
+
+		resource "ceph_rgw_s3_key" "test" {
+		  user_id = ceph_rgw_user.test.user_id
+		}
+
+		resource "ceph_rgw_bucket" "test" {
+		  bucket = %q
+		  owner  = ceph_rgw_user.test.user_id
+		  depends_on = [ceph_rgw_s3_key.test]
+		}
+	`, testUID, testBucket)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			testAccPreCheckCephHealth(t)
+
+			if err := cephTestClusterCLI.RgwUserCreate(t.Context(), adminUID, "Bucket CORS Test Admin", &RgwUserCreateOptions{
+				AccessKey: adminAccessKey,
+				SecretKey: adminSecretKey,
+			}); err != nil {
+				t.Fatal(err)
+			}
+			testCleanup(t, func(ctx context.Context) {
+				_ = cephTestClusterCLI.RgwUserRemove(ctx, adminUID, true)
+			})
+
+			admin := true
+			if err := cephTestClusterCLI.RgwUserModify(t.Context(), adminUID, &RgwUserModifyOptions{Admin: &admin}); err != nil {
+				t.Fatal(err)
+			}
+		},
+		CheckDestroy: testAccCheckCephRGWBucketDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: configVariables,
+				Config: testAccRGWAdminProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_cors" "test" {
+					  bucket = ceph_rgw_bucket.test.bucket
+
+					  cors_rules = [
+					    {
+					      allowed_origins = ["https://example.com"]
+					      allowed_methods = ["GET", "HEAD"]
+					    },
+					  ]
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_rgw_bucket_cors.test",
+						tfjsonpath.New("cors_rules"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"id":              knownvalue.Null(),
+								"allowed_origins": knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("https://example.com")}),
+								"allowed_methods": knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("GET"), knownvalue.StringExact("HEAD")}),
+								"allowed_headers": knownvalue.Null(),
+								"expose_headers":  knownvalue.Null(),
+								"max_age_seconds": knownvalue.Null(),
+							}),
+						}),
+					),
+				},
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccRGWAdminProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_cors" "test" {
+					  bucket = ceph_rgw_bucket.test.bucket
+
+					  cors_rules = [
+					    {
+					      id              = "assets"
+					      allowed_origins = ["https://example.com", "https://*.example.com"]
+					      allowed_methods = ["GET", "HEAD"]
+					      allowed_headers = ["*"]
+					      expose_headers  = ["ETag"]
+					      max_age_seconds = 3600
+					    },
+					    {
+					      allowed_origins = ["https://upload.example.com"]
+					      allowed_methods = ["PUT", "POST"]
+					    },
+					  ]
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_rgw_bucket_cors.test",
+						tfjsonpath.New("cors_rules").AtSliceIndex(0),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"id":              knownvalue.StringExact("assets"),
+							"allowed_origins": knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("https://example.com"), knownvalue.StringExact("https://*.example.com")}),
+							"allowed_methods": knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("GET"), knownvalue.StringExact("HEAD")}),
+							"allowed_headers": knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("*")}),
+							"expose_headers":  knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("ETag")}),
+							"max_age_seconds": knownvalue.Int64Exact(3600),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"ceph_rgw_bucket_cors.test",
+						tfjsonpath.New("cors_rules").AtSliceIndex(1).AtMapKey("allowed_methods"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("PUT"), knownvalue.StringExact("POST")}),
+					),
+				},
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.RgwBucketRemove(t.Context(), testBucket, true); err != nil {
+						t.Fatalf("Failed to delete bucket out of band: %v", err)
+					}
+				},
+				ConfigVariables: configVariables,
+				Config: testAccRGWAdminProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_cors" "test" {
+					  bucket = ceph_rgw_bucket.test.bucket
+
+					  cors_rules = [
+					    {
+					      allowed_origins = ["https://example.com"]
+					      allowed_methods = ["GET", "HEAD"]
+					    },
+					  ]
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_bucket.test", plancheck.ResourceActionCreate),
+						plancheck.ExpectResourceAction("ceph_rgw_bucket_cors.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			{
+				ConfigVariables:                      configVariables,
+				ResourceName:                         "ceph_rgw_bucket_cors.test",
+				ImportState:                          true,
+				ImportStateId:                        testBucket,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "bucket",
+			},
+			{
+				ConfigVariables: configVariables,
+				Config:          testAccRGWAdminProviderConfigBlock + bucketConfig,
+				Check:           checkCephRGWBucketExists(t, testBucket),
+			},
+		},
+	})
+}
+
+func TestAccCephRGWBucketCORSResource_requiresRGWAdmin(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_rgw_bucket_cors" "test" {
+					  bucket = "nonexistent"
+
+					  cors_rules = [
+					    {
+					      allowed_origins = ["*"]
+					      allowed_methods = ["GET"]
+					    },
+					  ]
+					}
+				`,
+				ExpectError: regexp.MustCompile(`requires the RGW admin ops API`),
+			},
+		},
+	})
+}
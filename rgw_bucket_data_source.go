@@ -28,6 +28,7 @@ type RGWBucketDataSourceModel struct {
 	CreationTime  types.String `tfsdk:"creation_time"`
 	ACL           types.String `tfsdk:"acl"`
 	Bid           types.String `tfsdk:"bid"`
+	Tags          types.Map    `tfsdk:"tags"`
 }
 
 func (d *RGWBucketDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -70,6 +71,11 @@ func (d *RGWBucketDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				MarkdownDescription: "The bucket ID (alternate field)",
 				Computed:            true,
 			},
+			"tags": dataSourceSchema.MapAttribute{
+				MarkdownDescription: "The bucket tags",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 		},
 	}
 }
@@ -121,5 +127,13 @@ func (d *RGWBucketDataSource) Read(ctx context.Context, req datasource.ReadReque
 	data.ACL = types.StringValue(bucket.ACL)
 	data.Bid = types.StringValue(bucket.Bid)
 
+	tagset := bucket.Tagset
+	if tagset == nil {
+		tagset = map[string]string{}
+	}
+	tags, diags := types.MapValueFrom(ctx, types.StringType, tagset)
+	resp.Diagnostics.Append(diags...)
+	data.Tags = tags
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
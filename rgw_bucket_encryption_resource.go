@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	rgwSSEAlgorithmS3  = "AES256"
+	rgwSSEAlgorithmKMS = "aws:kms"
+)
+
+var (
+	_ resource.Resource                     = &RGWBucketEncryptionResource{}
+	_ resource.ResourceWithImportState      = &RGWBucketEncryptionResource{}
+	_ resource.ResourceWithIdentity         = &RGWBucketEncryptionResource{}
+	_ resource.ResourceWithConfigValidators = &RGWBucketEncryptionResource{}
+)
+
+func newRGWBucketEncryptionResource() resource.Resource {
+	return &RGWBucketEncryptionResource{}
+}
+
+type RGWBucketEncryptionResource struct {
+	client *CephAPIClient
+}
+
+type RGWBucketEncryptionResourceModel struct {
+	Bucket       types.String `tfsdk:"bucket"`
+	Tenant       types.String `tfsdk:"tenant"`
+	SSEAlgorithm types.String `tfsdk:"sse_algorithm"`
+	KMSKeyID     types.String `tfsdk:"kms_key_id"`
+}
+
+type RGWBucketEncryptionResourceIdentityModel struct {
+	Bucket types.String `tfsdk:"bucket"`
+	Tenant types.String `tfsdk:"tenant"`
+}
+
+type rgwBucketEncryptionKeyValidator struct{}
+
+func (v rgwBucketEncryptionKeyValidator) Description(ctx context.Context) string {
+	return "validates that kms_key_id is set only for aws:kms"
+}
+
+func (v rgwBucketEncryptionKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return "Validates that `kms_key_id` is set if and only if `sse_algorithm` is `aws:kms`."
+}
+
+func (v rgwBucketEncryptionKeyValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config RGWBucketEncryptionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.SSEAlgorithm.IsUnknown() || config.KMSKeyID.IsUnknown() {
+		return
+	}
+
+	switch config.SSEAlgorithm.ValueString() {
+	case rgwSSEAlgorithmKMS:
+		if config.KMSKeyID.IsNull() {
+			resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+				path.Root("kms_key_id"),
+				"Missing KMS Key ID",
+				"kms_key_id is required when sse_algorithm is aws:kms.",
+			))
+		}
+	case rgwSSEAlgorithmS3:
+		if !config.KMSKeyID.IsNull() {
+			resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+				path.Root("kms_key_id"),
+				"Unexpected KMS Key ID",
+				"kms_key_id can only be set when sse_algorithm is aws:kms.",
+			))
+		}
+	}
+}
+
+func (r *RGWBucketEncryptionResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		rgwBucketEncryptionKeyValidator{},
+	}
+}
+
+func (r *RGWBucketEncryptionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_bucket_encryption"
+}
+
+func (r *RGWBucketEncryptionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "This resource manages the default server-side encryption of a Ceph RGW bucket, so new objects are encrypted without clients having to request it. " +
+			"RGW must be configured with an SSE-S3 or SSE-KMS backend for the chosen algorithm.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"bucket": resourceSchema.StringAttribute{
+				MarkdownDescription: "The bucket name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenant": resourceSchema.StringAttribute{
+				MarkdownDescription: "The tenant of the bucket. Omit for the default tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sse_algorithm": resourceSchema.StringAttribute{
+				MarkdownDescription: "The default encryption algorithm: `AES256` for SSE-S3 or `aws:kms` for SSE-KMS.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(rgwSSEAlgorithmS3, rgwSSEAlgorithmKMS),
+				},
+			},
+			"kms_key_id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The KMS key ID used for SSE-KMS. Required when `sse_algorithm` is `aws:kms`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *RGWBucketEncryptionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"bucket": identityschema.StringAttribute{
+				Description:       "The name of the bucket",
+				RequiredForImport: true,
+			},
+			"tenant": identityschema.StringAttribute{
+				Description:       "The tenant of the bucket",
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+func (r *RGWBucketEncryptionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RGWBucketEncryptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RGWBucketEncryptionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setEncryption(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketEncryptionResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketEncryptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RGWBucketEncryptionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+	encryption, err := r.client.RGWGetBucketEncryption(ctx, bucketName)
+	if isCephAPINotFound(err) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("RGW bucket %s", bucketName))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read encryption of RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+
+	if encryption.SSEAlgorithm == "" {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Encryption of RGW bucket %s", bucketName))
+		return
+	}
+
+	data.SSEAlgorithm = types.StringValue(encryption.SSEAlgorithm)
+	if encryption.KMSMasterKeyID != "" {
+		data.KMSKeyID = types.StringValue(encryption.KMSMasterKeyID)
+	} else {
+		data.KMSKeyID = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketEncryptionResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketEncryptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RGWBucketEncryptionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setEncryption(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketEncryptionResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketEncryptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RGWBucketEncryptionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+	err := r.client.RGWDeleteBucketEncryption(ctx, bucketName)
+	if err != nil && !isCephAPINotFound(err) {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to remove encryption of RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+}
+
+func (r *RGWBucketEncryptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		var identity RGWBucketEncryptionResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), identity.Bucket)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), identity.Tenant)...)
+		return
+	}
+
+	bucket := req.ID
+	if tenant, name, ok := strings.Cut(req.ID, "/"); ok {
+		bucket = name
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+}
+
+// setEncryption looks up the bucket's ID and owner, which the dashboard's
+// bucket update endpoint requires alongside the encryption settings.
+func (r *RGWBucketEncryptionResource) setEncryption(ctx context.Context, data *RGWBucketEncryptionResourceModel, diags *diag.Diagnostics) {
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+
+	bucket, err := r.client.RGWGetBucket(ctx, bucketName)
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+
+	err = r.client.RGWSetBucketEncryption(ctx, bucketName, bucket.ID, bucket.Owner, data.SSEAlgorithm.ValueString(), data.KMSKeyID.ValueString())
+	if err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to set encryption of RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccCephRGWBucketEncryptionResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-bucket-encryption-owner")
+	testBucket := acctest.RandomWithPrefix("test-bucket-encryption")
+
+	bucketConfig := fmt.Sprintf(`
+		resource "ceph_rgw_user" "test" {
+		  user_id      = %q
+		  display_name = "Bucket Encryption Test User"
+		}
+
+		resource "ceph_rgw_s3_key" "test" {
+		  user_id = ceph_rgw_user.test.user_id
+		}
+
+		resource "ceph_rgw_bucket" "test" {
+		  bucket = %q
+		  owner  = ceph_rgw_user.test.user_id
+		  depends_on = [ceph_rgw_s3_key.test]
+		}
+	`, testUID, testBucket)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWBucketDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_encryption" "test" {
+					  bucket        = ceph_rgw_bucket.test.bucket
+					  sse_algorithm = "AES256"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephRGWBucketExists(t, testBucket),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket_encryption.test", "sse_algorithm", "AES256"),
+					resource.TestCheckNoResourceAttr("ceph_rgw_bucket_encryption.test", "kms_key_id"),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.RgwBucketRemove(t.Context(), testBucket, true); err != nil {
+						t.Fatalf("Failed to delete bucket out of band: %v", err)
+					}
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_encryption" "test" {
+					  bucket        = ceph_rgw_bucket.test.bucket
+					  sse_algorithm = "AES256"
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_bucket.test", plancheck.ResourceActionCreate),
+						plancheck.ExpectResourceAction("ceph_rgw_bucket_encryption.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			{
+				ConfigVariables:                      testAccProviderConfig(),
+				ResourceName:                         "ceph_rgw_bucket_encryption.test",
+				ImportState:                          true,
+				ImportStateId:                        testBucket,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "bucket",
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + bucketConfig,
+				Check:           checkCephRGWBucketExists(t, testBucket),
+			},
+		},
+	})
+}
+
+func TestAccCephRGWBucketEncryptionResource_kmsKeyValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_rgw_bucket_encryption" "test" {
+					  bucket        = "any"
+					  sse_algorithm = "aws:kms"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`kms_key_id is required when sse_algorithm is aws:kms`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_rgw_bucket_encryption" "test" {
+					  bucket        = "any"
+					  sse_algorithm = "AES256"
+					  kms_key_id    = "my-key"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`kms_key_id can only be set when sse_algorithm is`),
+			},
+		},
+	})
+}
@@ -3,18 +3,28 @@ package main
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
 	_ resource.Resource                = &RGWBucketResource{}
 	_ resource.ResourceWithImportState = &RGWBucketResource{}
+	_ resource.ResourceWithIdentity    = &RGWBucketResource{}
 )
 
 func newRGWBucketResource() resource.Resource {
@@ -26,14 +36,25 @@ type RGWBucketResource struct {
 }
 
 type RGWBucketResourceModel struct {
-	Bucket        types.String `tfsdk:"bucket"`
-	Owner         types.String `tfsdk:"owner"`
-	Zonegroup     types.String `tfsdk:"zonegroup"`
-	PlacementRule types.String `tfsdk:"placement_rule"`
-	ID            types.String `tfsdk:"id"`
-	CreationTime  types.String `tfsdk:"creation_time"`
-	ACL           types.String `tfsdk:"acl"`
-	Bid           types.String `tfsdk:"bid"`
+	Bucket        types.String   `tfsdk:"bucket"`
+	Tenant        types.String   `tfsdk:"tenant"`
+	Owner         types.String   `tfsdk:"owner"`
+	Zonegroup     types.String   `tfsdk:"zonegroup"`
+	PlacementRule types.String   `tfsdk:"placement_rule"`
+	ID            types.String   `tfsdk:"id"`
+	CreationTime  types.String   `tfsdk:"creation_time"`
+	ACL           types.String   `tfsdk:"acl"`
+	Bid           types.String   `tfsdk:"bid"`
+	Tags          types.Map      `tfsdk:"tags"`
+	PurgeObjects  types.Bool     `tfsdk:"purge_objects"`
+	Force         types.Bool     `tfsdk:"force"`
+	RGWDaemon     types.String   `tfsdk:"rgw_daemon"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+type RGWBucketResourceIdentityModel struct {
+	Bucket types.String `tfsdk:"bucket"`
+	Tenant types.String `tfsdk:"tenant"`
 }
 
 func (r *RGWBucketResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,13 +72,20 @@ func (r *RGWBucketResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"owner": resourceSchema.StringAttribute{
-				MarkdownDescription: "The user ID of the bucket owner",
-				Required:            true,
+			"tenant": resourceSchema.StringAttribute{
+				MarkdownDescription: "The tenant of the bucket and its owner. Omit for the default tenant.",
+				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"owner": resourceSchema.StringAttribute{
+				MarkdownDescription: "The user ID of the bucket owner, without the tenant. Changing it links the bucket to the new owner in place.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^$]+$`), "must not contain a tenant, use the tenant attribute instead"),
+				},
+			},
 			"zonegroup": resourceSchema.StringAttribute{
 				MarkdownDescription: "The zonegroup this bucket belongs to",
 				Optional:            true,
@@ -69,14 +97,23 @@ func (r *RGWBucketResource) Schema(ctx context.Context, req resource.SchemaReque
 			"placement_rule": resourceSchema.StringAttribute{
 				MarkdownDescription: "The placement rule for this bucket",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"id": resourceSchema.StringAttribute{
 				MarkdownDescription: "The bucket ID",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"creation_time": resourceSchema.StringAttribute{
 				MarkdownDescription: "The creation timestamp of the bucket",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"acl": resourceSchema.StringAttribute{
 				MarkdownDescription: "The Access Control List for this bucket",
@@ -85,6 +122,52 @@ func (r *RGWBucketResource) Schema(ctx context.Context, req resource.SchemaReque
 			"bid": resourceSchema.StringAttribute{
 				MarkdownDescription: "The bucket ID (alternate field)",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": resourceSchema.MapAttribute{
+				MarkdownDescription: "The bucket tags. Tags set outside Terraform are removed unless they are listed here.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"purge_objects": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether to delete the bucket's objects when the bucket is destroyed. Set to `false` to make destroying a non-empty bucket fail instead. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"force": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether to delete the bucket's objects immediately on destroy, bypassing garbage collection. Useful for very large buckets. Implies `purge_objects` and requires the provider `cli_backend` to be enabled. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"rgw_daemon": resourceSchema.StringAttribute{
+				MarkdownDescription: "The radosgw daemon the dashboard sends this bucket's requests to, overriding the provider `rgw_daemon`. In a multisite cluster, set it to a daemon of the zone the bucket should be managed in. Has no effect when the provider uses `rgw_admin_endpoint`.",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]resourceSchema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *RGWBucketResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"bucket": identityschema.StringAttribute{
+				Description:       "The name of the bucket",
+				RequiredForImport: true,
+			},
+			"tenant": identityschema.StringAttribute{
+				Description:       "The tenant of the bucket",
+				OptionalForImport: true,
 			},
 		},
 	}
@@ -118,9 +201,20 @@ func (r *RGWBucketResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	ctx = withRGWDaemon(ctx, data.RGWDaemon.ValueString())
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	createReq := CephAPIRGWBucketCreateRequest{
 		Bucket: data.Bucket.ValueString(),
-		UID:    data.Owner.ValueString(),
+		UID:    rgwUserID(data.Tenant.ValueString(), data.Owner.ValueString()),
 	}
 
 	if !data.Zonegroup.IsNull() && !data.Zonegroup.IsUnknown() {
@@ -137,7 +231,7 @@ func (r *RGWBucketResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	bucketName := data.Bucket.ValueString()
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
 	bucket, err := r.client.RGWGetBucket(ctx, bucketName)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -147,9 +241,26 @@ func (r *RGWBucketResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	if len(data.Tags.Elements()) > 0 {
+		resp.Diagnostics.Append(r.setTags(ctx, bucketName, bucket, data.Tags)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		bucket, err = r.client.RGWGetBucket(ctx, bucketName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to read RGW bucket after creation: %s", err),
+			)
+			return
+		}
+	}
+
 	updateModelFromAPIBucket(&data, bucket)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
 }
 
 func (r *RGWBucketResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -161,8 +272,14 @@ func (r *RGWBucketResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	bucketName := data.Bucket.ValueString()
+	ctx = withRGWDaemon(ctx, data.RGWDaemon.ValueString())
+
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
 	bucket, err := r.client.RGWGetBucket(ctx, bucketName)
+	if isCephAPINotFound(err) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("RGW bucket %s", bucketName))
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"API Request Error",
@@ -173,14 +290,87 @@ func (r *RGWBucketResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	updateModelFromAPIBucket(&data, bucket)
 
+	// Imported state has no delete options yet.
+	if data.PurgeObjects.IsNull() {
+		data.PurgeObjects = types.BoolValue(true)
+	}
+	if data.Force.IsNull() {
+		data.Force = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
 }
 
+// Update handles owner changes, which link the bucket to the new owner, tag
+// changes and changes to the delete options. Every other attribute requires
+// replacement.
 func (r *RGWBucketResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"RGW buckets cannot be updated. All bucket attributes require replacement.",
-	)
+	var data, state RGWBucketResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withRGWDaemon(ctx, data.RGWDaemon.ValueString())
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+
+	if !data.Owner.Equal(state.Owner) {
+		err := r.client.RGWUpdateBucket(ctx, bucketName, CephAPIRGWBucketUpdateRequest{
+			BucketID: state.ID.ValueString(),
+			UID:      rgwUserID(data.Tenant.ValueString(), data.Owner.ValueString()),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to change owner of RGW bucket: %s", err),
+			)
+			return
+		}
+	}
+
+	bucket, err := r.client.RGWGetBucket(ctx, bucketName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read RGW bucket after update: %s", err),
+		)
+		return
+	}
+
+	if !data.Tags.Equal(state.Tags) {
+		resp.Diagnostics.Append(r.setTags(ctx, bucketName, bucket, data.Tags)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		bucket, err = r.client.RGWGetBucket(ctx, bucketName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to read RGW bucket after update: %s", err),
+			)
+			return
+		}
+	}
+
+	updateModelFromAPIBucket(&data, bucket)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
 }
 
 func (r *RGWBucketResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -192,9 +382,45 @@ func (r *RGWBucketResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	bucketName := data.Bucket.ValueString()
-	err := r.client.RGWDeleteBucket(ctx, bucketName)
-	if err != nil {
+	ctx = withRGWDaemon(ctx, data.RGWDaemon.ValueString())
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+
+	if data.Force.ValueBool() {
+		cli, err := r.client.CLI()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("force"),
+				"CLI Backend Required",
+				fmt.Sprintf("Unable to force delete RGW bucket: %s", err),
+			)
+			return
+		}
+
+		if _, err := r.client.RGWGetBucket(ctx, bucketName); isCephAPINotFound(err) {
+			return
+		}
+
+		if err := cli.RgwBucketRemove(ctx, bucketName, true); err != nil {
+			resp.Diagnostics.AddError(
+				"CLI Request Error",
+				fmt.Sprintf("Unable to delete RGW bucket: %s", err),
+			)
+		}
+		return
+	}
+
+	err := r.client.RGWDeleteBucket(ctx, bucketName, data.PurgeObjects.ValueBool())
+	if err != nil && !isCephAPINotFound(err) {
 		resp.Diagnostics.AddError(
 			"API Request Error",
 			fmt.Sprintf("Unable to delete RGW bucket: %s", err),
@@ -204,16 +430,72 @@ func (r *RGWBucketResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *RGWBucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
+	if req.ID == "" && req.Identity != nil {
+		var identity RGWBucketResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), identity.Bucket)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), identity.Tenant)...)
+		return
+	}
+
+	// Accept "bucket" as well as "tenant/bucket" for buckets outside the
+	// default tenant.
+	bucket := req.ID
+	if tenant, name, ok := strings.Cut(req.ID, "/"); ok {
+		bucket = name
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
 }
 
 func updateModelFromAPIBucket(data *RGWBucketResourceModel, bucket CephAPIRGWBucket) {
-	data.Bucket = types.StringValue(bucket.Bucket)
-	data.Owner = types.StringValue(bucket.Owner)
+	// Tenanted buckets and owners may be reported as "tenant/bucket" and
+	// "tenant$uid"; the tenant is tracked separately.
+	bucketName := bucket.Bucket
+	if _, name, ok := strings.Cut(bucketName, "/"); ok {
+		bucketName = name
+	}
+	_, owner := splitRGWUserID(bucket.Owner)
+
+	data.Bucket = types.StringValue(bucketName)
+	data.Owner = types.StringValue(owner)
 	data.Zonegroup = types.StringValue(bucket.Zonegroup)
 	data.PlacementRule = types.StringValue(bucket.PlacementRule)
 	data.ID = types.StringValue(bucket.ID)
 	data.CreationTime = types.StringValue(bucket.CreationTime)
 	data.ACL = types.StringValue(bucket.ACL)
 	data.Bid = types.StringValue(bucket.Bid)
+
+	// An untagged bucket matches both an omitted and an empty tags map.
+	if len(bucket.Tagset) > 0 || !data.Tags.IsNull() {
+		tags := make(map[string]attr.Value, len(bucket.Tagset))
+		for key, value := range bucket.Tagset {
+			tags[key] = types.StringValue(value)
+		}
+		data.Tags = types.MapValueMust(types.StringType, tags)
+	}
+}
+
+func (r *RGWBucketResource) setTags(ctx context.Context, bucketName string, bucket CephAPIRGWBucket, tagsValue types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tags := make(map[string]string, len(tagsValue.Elements()))
+	if !tagsValue.IsNull() {
+		diags.Append(tagsValue.ElementsAs(ctx, &tags, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if err := r.client.RGWSetBucketTags(ctx, bucketName, bucket.ID, bucket.Owner, tags); err != nil {
+		diags.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to set tags of RGW bucket: %s", err),
+		)
+	}
+
+	return diags
 }
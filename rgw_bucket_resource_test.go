@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
@@ -47,10 +48,243 @@ func TestAccCephRGWBucketResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("ceph_rgw_bucket.test", "creation_time"),
 				),
 			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.RgwBucketRemove(t.Context(), testBucket, false); err != nil {
+						t.Fatalf("Failed to delete bucket out of band: %v", err)
+					}
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_user" "test" {
+					  user_id      = %q
+					  display_name = "Bucket Test User"
+					}
+
+					resource "ceph_rgw_s3_key" "test" {
+					  user_id = ceph_rgw_user.test.user_id
+					}
+
+					resource "ceph_rgw_bucket" "test" {
+					  bucket = %q
+					  owner  = ceph_rgw_user.test.user_id
+					  depends_on = [ceph_rgw_s3_key.test]
+					}
+				`, testUID, testBucket),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_bucket.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkCephRGWBucketExists(t, testBucket),
+			},
+		},
+	})
+}
+
+func TestAccCephRGWBucketResource_timeouts(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-bucket-timeouts-owner")
+	testBucket := acctest.RandomWithPrefix("test-bucket-timeouts")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWBucketDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_user" "test" {
+					  user_id      = %q
+					  display_name = "Bucket Timeouts Test User"
+					}
+
+					resource "ceph_rgw_s3_key" "test" {
+					  user_id = ceph_rgw_user.test.user_id
+					}
+
+					resource "ceph_rgw_bucket" "test" {
+					  bucket = %q
+					  owner  = ceph_rgw_user.test.user_id
+					  depends_on = [ceph_rgw_s3_key.test]
+
+					  timeouts {
+					    create = "5m"
+					    delete = "30m"
+					  }
+					}
+				`, testUID, testBucket),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephRGWBucketExists(t, testBucket),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "timeouts.create", "5m"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "timeouts.delete", "30m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCephRGWBucketResource_changeOwner(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	firstUID := acctest.RandomWithPrefix("test-bucket-owner-a")
+	secondUID := acctest.RandomWithPrefix("test-bucket-owner-b")
+	testBucket := acctest.RandomWithPrefix("test-bucket-owner-change")
+
+	bucketConfig := func(owner string) string {
+		return testAccProviderConfigBlock + fmt.Sprintf(`
+			resource "ceph_rgw_user" "first" {
+			  user_id      = %q
+			  display_name = "First Bucket Owner"
+			}
+
+			resource "ceph_rgw_user" "second" {
+			  user_id      = %q
+			  display_name = "Second Bucket Owner"
+			}
+
+			resource "ceph_rgw_s3_key" "first" {
+			  user_id = ceph_rgw_user.first.user_id
+			}
+
+			resource "ceph_rgw_bucket" "test" {
+			  bucket = %q
+			  owner  = ceph_rgw_user.%s.user_id
+			  depends_on = [ceph_rgw_s3_key.first]
+			}
+		`, firstUID, secondUID, testBucket, owner)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWBucketDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          bucketConfig("first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephRGWBucketOwner(t, testBucket, firstUID),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "owner", firstUID),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          bucketConfig("second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_bucket.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephRGWBucketOwner(t, testBucket, secondUID),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "owner", secondUID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCephRGWBucketResource_tags(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-bucket-tags-owner")
+	testBucket := acctest.RandomWithPrefix("test-bucket-tags")
+
+	bucketConfig := func(tags string) string {
+		return testAccProviderConfigBlock + fmt.Sprintf(`
+			resource "ceph_rgw_user" "test" {
+			  user_id      = %q
+			  display_name = "Bucket Tags Test User"
+			}
+
+			resource "ceph_rgw_s3_key" "test" {
+			  user_id = ceph_rgw_user.test.user_id
+			}
+
+			resource "ceph_rgw_bucket" "test" {
+			  bucket = %q
+			  owner  = ceph_rgw_user.test.user_id
+			  %s
+			  depends_on = [ceph_rgw_s3_key.test]
+			}
+		`, testUID, testBucket, tags)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWBucketDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          bucketConfig(`tags = { team = "web", cost-center = "1234" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "tags.%", "2"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "tags.team", "web"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "tags.cost-center", "1234"),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          bucketConfig(`tags = { team = "assets" }`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_bucket.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "tags.%", "1"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "tags.team", "assets"),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				ResourceName:    "ceph_rgw_bucket.test",
+				ImportState:     true,
+				ImportStateId:   testBucket,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if got := states[0].Attributes["tags.team"]; got != "assets" {
+						return fmt.Errorf("imported tags.team = %q, want %q", got, "assets")
+					}
+					return nil
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          bucketConfig(""),
+				Check:           resource.TestCheckNoResourceAttr("ceph_rgw_bucket.test", "tags.%"),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          bucketConfig(""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
 		},
 	})
 }
 
+func checkCephRGWBucketOwner(t *testing.T, bucketName, owner string) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		bucket, err := cephTestClusterCLI.RgwBucketInfo(t.Context(), bucketName)
+		if err != nil {
+			return fmt.Errorf("RGW bucket %s does not exist: %w", bucketName, err)
+		}
+
+		if bucket.Owner != owner {
+			return fmt.Errorf("RGW bucket %s owner = %q, want %q", bucketName, bucket.Owner, owner)
+		}
+		return nil
+	}
+}
+
 func TestAccCephRGWBucketResourceImport(t *testing.T) {
 	detachLogs := cephDaemonLogs.AttachTestFunction(t)
 	defer detachLogs()
@@ -129,7 +363,7 @@ func TestAccCephRGWBucketResourceImport_nonExistent(t *testing.T) {
 				ResourceName:  "ceph_rgw_bucket.nonexistent",
 				ImportState:   true,
 				ImportStateId: testBucket,
-				ExpectError:   regexp.MustCompile(`(?i)unable to read rgw bucket`),
+				ExpectError:   regexp.MustCompile(`(?i)cannot import non-existent remote object`),
 			},
 		},
 	})
@@ -144,7 +378,7 @@ func testAccCheckCephRGWBucketDestroy(t *testing.T) resource.TestCheckFunc {
 				continue
 			}
 
-			bucketName := rs.Primary.Attributes["bucket"]
+			bucketName := rgwBucketName(rs.Primary.Attributes["tenant"], rs.Primary.Attributes["bucket"])
 
 			_, err := cephTestClusterCLI.RgwBucketInfo(ctx, bucketName)
 			if err == nil {
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &RGWBucketStatsDataSource{}
+
+func newRGWBucketStatsDataSource() datasource.DataSource {
+	return &RGWBucketStatsDataSource{}
+}
+
+type RGWBucketStatsDataSource struct {
+	client *CephAPIClient
+}
+
+type RGWBucketStatsDataSourceModel struct {
+	Bucket       types.String `tfsdk:"bucket"`
+	NumObjects   types.Int64  `tfsdk:"num_objects"`
+	Size         types.Int64  `tfsdk:"size"`
+	SizeActual   types.Int64  `tfsdk:"size_actual"`
+	SizeUtilized types.Int64  `tfsdk:"size_utilized"`
+	NumShards    types.Int64  `tfsdk:"num_shards"`
+}
+
+func (d *RGWBucketStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_bucket_stats"
+}
+
+func (d *RGWBucketStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source allows you to get the usage statistics of a Ceph RGW bucket. " +
+			"The object counts and sizes cover regular objects (the `rgw.main` usage category), like the dashboard bucket list.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"bucket": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The bucket name",
+				Required:            true,
+			},
+			"num_objects": dataSourceSchema.Int64Attribute{
+				MarkdownDescription: "The number of objects in the bucket",
+				Computed:            true,
+			},
+			"size": dataSourceSchema.Int64Attribute{
+				MarkdownDescription: "The total size of the objects in bytes",
+				Computed:            true,
+			},
+			"size_actual": dataSourceSchema.Int64Attribute{
+				MarkdownDescription: "The total size of the objects in bytes, rounded up to the 4 KiB allocation unit of each object",
+				Computed:            true,
+			},
+			"size_utilized": dataSourceSchema.Int64Attribute{
+				MarkdownDescription: "The number of bytes stored after compression",
+				Computed:            true,
+			},
+			"num_shards": dataSourceSchema.Int64Attribute{
+				MarkdownDescription: "The number of bucket index shards",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RGWBucketStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RGWBucketStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RGWBucketStatsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket, err := d.client.RGWGetBucket(ctx, data.Bucket.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to get RGW bucket from Ceph API: %s", err),
+		)
+		return
+	}
+
+	usage := bucket.Usage["rgw.main"]
+
+	data.Bucket = types.StringValue(bucket.Bucket)
+	data.NumObjects = types.Int64Value(usage.NumObjects)
+	data.Size = types.Int64Value(usage.Size)
+	data.SizeActual = types.Int64Value(usage.SizeActual)
+	data.SizeUtilized = types.Int64Value(usage.SizeUtilized)
+	data.NumShards = types.Int64Value(int64(bucket.NumShards))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
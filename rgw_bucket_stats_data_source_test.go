@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCephRGWBucketStatsDataSource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-bucket-stats-owner")
+	testBucket := acctest.RandomWithPrefix("test-bucket-stats")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_user" "test" {
+					  user_id      = %q
+					  display_name = "Bucket Stats Test User"
+					}
+
+					resource "ceph_rgw_s3_key" "test" {
+					  user_id = ceph_rgw_user.test.user_id
+					}
+
+					resource "ceph_rgw_bucket" "test" {
+					  bucket = %q
+					  owner  = ceph_rgw_user.test.user_id
+					  depends_on = [ceph_rgw_s3_key.test]
+					}
+
+					data "ceph_rgw_bucket_stats" "test" {
+					  bucket = ceph_rgw_bucket.test.bucket
+					}
+				`, testUID, testBucket),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket_stats.test", "bucket", testBucket),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket_stats.test", "num_objects", "0"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket_stats.test", "size", "0"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket_stats.test", "size_actual", "0"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket_stats.test", "size_utilized", "0"),
+					resource.TestCheckResourceAttrSet("data.ceph_rgw_bucket_stats.test", "num_shards"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCephRGWBucketStatsDataSource_nonExistent(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					data "ceph_rgw_bucket_stats" "nonexistent" {
+					  bucket = "nonexistent-bucket-12345"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?i)unable to get rgw bucket from ceph api`),
+			},
+		},
+	})
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &RGWBucketSyncResource{}
+	_ resource.ResourceWithImportState = &RGWBucketSyncResource{}
+	_ resource.ResourceWithIdentity    = &RGWBucketSyncResource{}
+)
+
+func newRGWBucketSyncResource() resource.Resource {
+	return &RGWBucketSyncResource{}
+}
+
+// RGWBucketSyncResource turns multisite data sync of a single bucket on or
+// off. The dashboard API does not expose it, so it always goes through
+// radosgw-admin.
+type RGWBucketSyncResource struct {
+	client *CephAPIClient
+}
+
+type RGWBucketSyncResourceModel struct {
+	Bucket  types.String `tfsdk:"bucket"`
+	Tenant  types.String `tfsdk:"tenant"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+type RGWBucketSyncResourceIdentityModel struct {
+	Bucket types.String `tfsdk:"bucket"`
+	Tenant types.String `tfsdk:"tenant"`
+}
+
+func (r *RGWBucketSyncResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_bucket_sync"
+}
+
+func (r *RGWBucketSyncResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Enables or disables multisite data sync for a single RGW bucket, for example to keep a scratch bucket local to one zone. " +
+			"Bucket sync is only available through radosgw-admin, so this resource requires the provider `cli_backend` to be enabled. " +
+			"Destroying the resource re-enables sync.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"bucket": resourceSchema.StringAttribute{
+				MarkdownDescription: "The bucket name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenant": resourceSchema.StringAttribute{
+				MarkdownDescription: "The tenant of the bucket. Omit for the default tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether objects in the bucket are synced to other zones",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *RGWBucketSyncResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"bucket": identityschema.StringAttribute{
+				Description:       "The name of the bucket",
+				RequiredForImport: true,
+			},
+			"tenant": identityschema.StringAttribute{
+				Description:       "The tenant of the bucket",
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+func (r *RGWBucketSyncResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RGWBucketSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RGWBucketSyncResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setSync(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketSyncResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RGWBucketSyncResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read bucket sync: %s", err),
+		)
+		return
+	}
+
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+	enabled, err := cli.RgwBucketSyncEnabled(ctx, bucketName)
+	if errors.Is(err, ErrRGWBucketNotFound) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("RGW bucket %s", bucketName))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read sync of RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+
+	data.Enabled = types.BoolValue(enabled)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketSyncResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RGWBucketSyncResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setSync(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWBucketSyncResourceIdentityModel{Bucket: data.Bucket, Tenant: data.Tenant})...)
+}
+
+func (r *RGWBucketSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RGWBucketSyncResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Enabled.ValueBool() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to re-enable bucket sync: %s", err),
+		)
+		return
+	}
+
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+	if _, err := cli.RgwBucketInfo(ctx, bucketName); errors.Is(err, ErrRGWBucketNotFound) {
+		return
+	}
+
+	if err := cli.RgwBucketSyncSet(ctx, bucketName, true); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to re-enable sync of RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+}
+
+func (r *RGWBucketSyncResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		var identity RGWBucketSyncResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), identity.Bucket)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), identity.Tenant)...)
+		return
+	}
+
+	bucket := req.ID
+	if tenant, name, ok := strings.Cut(req.ID, "/"); ok {
+		bucket = name
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+}
+
+func (r *RGWBucketSyncResource) setSync(ctx context.Context, data RGWBucketSyncResourceModel, diags *diag.Diagnostics) {
+	cli, err := r.client.CLI()
+	if err != nil {
+		diags.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to set bucket sync: %s", err),
+		)
+		return
+	}
+
+	bucketName := rgwBucketName(data.Tenant.ValueString(), data.Bucket.ValueString())
+	if err := cli.RgwBucketSyncSet(ctx, bucketName, data.Enabled.ValueBool()); err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to set sync of RGW bucket %s: %s", bucketName, err),
+		)
+		return
+	}
+}
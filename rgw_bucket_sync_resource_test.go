@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccCephRGWBucketSyncResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-bucket-sync-owner")
+	testBucket := acctest.RandomWithPrefix("test-bucket-sync")
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+	bucketConfig := fmt.Sprintf(`
+		resource "ceph_rgw_user" "test" {
+		  user_id      = %q
+		  display_name = "Bucket Sync Test User"
+		}
+
+		resource "ceph_rgw_s3_key" "test" {
+		  user_id = ceph_rgw_user.test.user_id
+		}
+
+		resource "ceph_rgw_bucket" "test" {
+		  bucket = %q
+		  owner  = ceph_rgw_user.test.user_id
+		  depends_on = [ceph_rgw_s3_key.test]
+		}
+	`, testUID, testBucket)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWBucketDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_sync" "test" {
+					  bucket  = ceph_rgw_bucket.test.bucket
+					  enabled = false
+					}
+				`,
+				ExpectError: regexp.MustCompile(`CLI Backend Required`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_sync" "test" {
+					  bucket  = ceph_rgw_bucket.test.bucket
+					  enabled = false
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephRGWBucketExists(t, testBucket),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket_sync.test", "enabled", "false"),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.RgwBucketRemove(t.Context(), testBucket, true); err != nil {
+						t.Fatalf("Failed to delete bucket out of band: %v", err)
+					}
+				},
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_sync" "test" {
+					  bucket  = ceph_rgw_bucket.test.bucket
+					  enabled = false
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_bucket.test", plancheck.ResourceActionCreate),
+						plancheck.ExpectResourceAction("ceph_rgw_bucket_sync.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			{
+				ConfigVariables:                      configVariables,
+				ResourceName:                         "ceph_rgw_bucket_sync.test",
+				ImportState:                          true,
+				ImportStateId:                        testBucket,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "bucket",
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + bucketConfig + `
+					resource "ceph_rgw_bucket_sync" "test" {
+					  bucket  = ceph_rgw_bucket.test.bucket
+					  enabled = true
+					}
+				`,
+				Check: resource.TestCheckResourceAttr("ceph_rgw_bucket_sync.test", "enabled", "true"),
+			},
+		},
+	})
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &RGWGCLCTuningResource{}
+	_ resource.ResourceWithImportState = &RGWGCLCTuningResource{}
+)
+
+func newRGWGCLCTuningResource() resource.Resource {
+	return &RGWGCLCTuningResource{}
+}
+
+// RGWGCLCTuningResource manages the RGW garbage collection and lifecycle
+// options of one config section. Each attribute maps to a single config
+// option that is only touched when set, so options left out of the
+// configuration keep whatever value they have.
+type RGWGCLCTuningResource struct {
+	client *CephAPIClient
+}
+
+type RGWGCLCTuningResourceModel struct {
+	Section            types.String `tfsdk:"section"`
+	GCMaxObjs          types.Int64  `tfsdk:"gc_max_objs"`
+	GCObjMinWait       types.Int64  `tfsdk:"gc_obj_min_wait"`
+	GCProcessorPeriod  types.Int64  `tfsdk:"gc_processor_period"`
+	GCProcessorMaxTime types.Int64  `tfsdk:"gc_processor_max_time"`
+	GCMaxConcurrentIO  types.Int64  `tfsdk:"gc_max_concurrent_io"`
+	LCMaxObjs          types.Int64  `tfsdk:"lc_max_objs"`
+	LCMaxWorker        types.Int64  `tfsdk:"lc_max_worker"`
+	LCDebugInterval    types.Int64  `tfsdk:"lc_debug_interval"`
+	LifecycleWorkTime  types.String `tfsdk:"lifecycle_work_time"`
+}
+
+// rgwTuningOption ties a config option to the model field holding it, which
+// is a *types.Int64 or a *types.String.
+type rgwTuningOption struct {
+	name  string
+	value any
+}
+
+func (m *RGWGCLCTuningResourceModel) options() []rgwTuningOption {
+	return []rgwTuningOption{
+		{"rgw_gc_max_objs", &m.GCMaxObjs},
+		{"rgw_gc_obj_min_wait", &m.GCObjMinWait},
+		{"rgw_gc_processor_period", &m.GCProcessorPeriod},
+		{"rgw_gc_processor_max_time", &m.GCProcessorMaxTime},
+		{"rgw_gc_max_concurrent_io", &m.GCMaxConcurrentIO},
+		{"rgw_lc_max_objs", &m.LCMaxObjs},
+		{"rgw_lc_max_worker", &m.LCMaxWorker},
+		{"rgw_lc_debug_interval", &m.LCDebugInterval},
+		{"rgw_lifecycle_work_time", &m.LifecycleWorkTime},
+	}
+}
+
+func (o rgwTuningOption) isNull() bool {
+	switch v := o.value.(type) {
+	case *types.Int64:
+		return v.IsNull()
+	case *types.String:
+		return v.IsNull()
+	}
+	return true
+}
+
+func (o rgwTuningOption) format() string {
+	switch v := o.value.(type) {
+	case *types.Int64:
+		return strconv.FormatInt(v.ValueInt64(), 10)
+	case *types.String:
+		return v.ValueString()
+	}
+	return ""
+}
+
+// parse stores a value read from the config database in the field, or null
+// if the option is not set.
+func (o rgwTuningOption) parse(value string, ok bool) error {
+	switch v := o.value.(type) {
+	case *types.Int64:
+		if !ok {
+			*v = types.Int64Null()
+			return nil
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		*v = types.Int64Value(n)
+	case *types.String:
+		if !ok {
+			*v = types.StringNull()
+			return nil
+		}
+		*v = types.StringValue(value)
+	}
+	return nil
+}
+
+func (r *RGWGCLCTuningResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_gc_lc_tuning"
+}
+
+func (r *RGWGCLCTuningResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	seconds := []validator.Int64{
+		int64validator.AtLeast(1),
+	}
+
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages the RGW garbage collection (GC) and lifecycle (LC) tuning options, such as `rgw_gc_processor_period` and `rgw_lc_debug_interval`, with validation that raw `ceph_config` entries lack. " +
+			"Declare this resource at most once per `section`. " +
+			"Attributes that are not set are left unmanaged, and destroying the resource restores the Ceph defaults of the managed ones. " +
+			"Most of these options only take effect when the RGW daemons restart.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"section": resourceSchema.StringAttribute{
+				MarkdownDescription: "The config section to set the options in, e.g. `client.rgw` for every RGW daemon or `client.rgw.<name>` for one. Defaults to `client.rgw`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("client.rgw"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(global|client(\.[^/\s]+)?)$`), "must be 'global', 'client' or 'client.<name>'"),
+				},
+			},
+			"gc_max_objs": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The number of GC log objects (`rgw_gc_max_objs`). Raising it spreads GC work across more objects; lowering it on an existing zone strands the entries in the dropped objects.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65521),
+				},
+			},
+			"gc_obj_min_wait": resourceSchema.Int64Attribute{
+				MarkdownDescription: "How long a deleted object waits before GC may remove its data, in seconds (`rgw_gc_obj_min_wait`)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"gc_processor_period": resourceSchema.Int64Attribute{
+				MarkdownDescription: "How often a GC cycle starts, in seconds (`rgw_gc_processor_period`)",
+				Optional:            true,
+				Validators:          seconds,
+			},
+			"gc_processor_max_time": resourceSchema.Int64Attribute{
+				MarkdownDescription: "How long a GC cycle may run, in seconds (`rgw_gc_processor_max_time`)",
+				Optional:            true,
+				Validators:          seconds,
+			},
+			"gc_max_concurrent_io": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The number of RADOS operations a GC cycle runs in parallel (`rgw_gc_max_concurrent_io`)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"lc_max_objs": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The number of lifecycle shards buckets are spread across (`rgw_lc_max_objs`)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 32768),
+				},
+			},
+			"lc_max_worker": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The number of lifecycle shards processed in parallel (`rgw_lc_max_worker`)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"lc_debug_interval": resourceSchema.Int64Attribute{
+				MarkdownDescription: "For testing only: run lifecycle processing continuously and treat this many seconds as a day in lifecycle rules (`rgw_lc_debug_interval`). `-1` disables it.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Any(
+						int64validator.OneOf(-1),
+						int64validator.AtLeast(1),
+					),
+				},
+			},
+			"lifecycle_work_time": resourceSchema.StringAttribute{
+				MarkdownDescription: "The daily window lifecycle processing runs in, as `HH:MM-HH:MM` (`rgw_lifecycle_work_time`), e.g. `00:00-06:00`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]-([01][0-9]|2[0-3]):[0-5][0-9]$`), "must be a window in the form HH:MM-HH:MM"),
+				},
+			},
+		},
+	}
+}
+
+func (r *RGWGCLCTuningResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RGWGCLCTuningResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RGWGCLCTuningResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, RGWGCLCTuningResourceModel{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RGWGCLCTuningResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RGWGCLCTuningResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RGWGCLCTuningResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RGWGCLCTuningResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RGWGCLCTuningResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RGWGCLCTuningResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, RGWGCLCTuningResourceModel{Section: data.Section}, data)...)
+}
+
+// Importing by section leaves every option unmanaged until it is configured.
+func (r *RGWGCLCTuningResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("section"), req, resp)
+}
+
+// apply sets every option configured in data and resets the ones that were
+// managed in prior but are no longer configured.
+func (r *RGWGCLCTuningResource) apply(ctx context.Context, data RGWGCLCTuningResourceModel, prior RGWGCLCTuningResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	section := data.Section.ValueString()
+	priorOptions := prior.options()
+
+	for i, option := range data.options() {
+		var err error
+		switch {
+		case !option.isNull():
+			err = r.client.ClusterUpdateConf(ctx, option.name, section, option.format())
+		case !priorOptions[i].isNull():
+			err = r.client.ClusterDeleteConf(ctx, option.name, section)
+			if isCephAPINotFound(err) {
+				err = nil
+			}
+		}
+		if err != nil {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to configure '%s' in section '%s': %s", option.name, section, err),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// read refreshes the managed options from the config database.
+func (r *RGWGCLCTuningResource) read(ctx context.Context, data *RGWGCLCTuningResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	section := data.Section.ValueString()
+
+	for _, option := range data.options() {
+		if option.isNull() {
+			continue
+		}
+
+		conf, err := r.client.ClusterGetConf(ctx, option.name)
+		if err != nil {
+			diags.AddError(
+				"API Request Error",
+				fmt.Sprintf("Unable to read '%s': %s", option.name, err),
+			)
+			return diags
+		}
+
+		value, ok := conf.SectionValue(section)
+		if err := option.parse(value, ok); err != nil {
+			diags.AddError(
+				"Configuration Value Formatting Error",
+				fmt.Sprintf("Unable to parse '%s' value %q: %s", option.name, value, err),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCephRGWGCLCTuningResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			checkCephConfigAbsent(t, "client.rgw", "rgw_gc_processor_period"),
+			checkCephConfigAbsent(t, "client.rgw", "rgw_lc_debug_interval"),
+			checkCephConfigAbsent(t, "client.rgw", "rgw_lifecycle_work_time"),
+		),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_rgw_gc_lc_tuning" "test" {
+					  lc_debug_interval = 0
+					}
+				`,
+				ExpectError: regexp.MustCompile(`lc_debug_interval`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_rgw_gc_lc_tuning" "test" {
+					  lifecycle_work_time = "1:00-6:00"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`must be a window in the form HH:MM-HH:MM`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_rgw_gc_lc_tuning" "test" {
+					  gc_processor_period = 600
+					  lc_debug_interval   = 10
+					  lifecycle_work_time = "00:00-23:59"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rgw_gc_lc_tuning.test", tfjsonpath.New("section"), knownvalue.StringExact("client.rgw")),
+					statecheck.ExpectKnownValue("ceph_rgw_gc_lc_tuning.test", tfjsonpath.New("gc_processor_period"), knownvalue.Int64Exact(600)),
+					statecheck.ExpectKnownValue("ceph_rgw_gc_lc_tuning.test", tfjsonpath.New("lc_debug_interval"), knownvalue.Int64Exact(10)),
+					statecheck.ExpectKnownValue("ceph_rgw_gc_lc_tuning.test", tfjsonpath.New("lifecycle_work_time"), knownvalue.StringExact("00:00-23:59")),
+					statecheck.ExpectKnownValue("ceph_rgw_gc_lc_tuning.test", tfjsonpath.New("gc_max_objs"), knownvalue.Null()),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigValue(t, "client.rgw", "rgw_gc_processor_period", "600"),
+					checkCephConfigValue(t, "client.rgw", "rgw_lc_debug_interval", "10"),
+					checkCephConfigValue(t, "client.rgw", "rgw_lifecycle_work_time", "00:00-23:59"),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_rgw_gc_lc_tuning" "test" {
+					  gc_processor_period = 1200
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rgw_gc_lc_tuning.test", tfjsonpath.New("gc_processor_period"), knownvalue.Int64Exact(1200)),
+					statecheck.ExpectKnownValue("ceph_rgw_gc_lc_tuning.test", tfjsonpath.New("lc_debug_interval"), knownvalue.Null()),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephConfigValue(t, "client.rgw", "rgw_gc_processor_period", "1200"),
+					checkCephConfigAbsent(t, "client.rgw", "rgw_lc_debug_interval"),
+					checkCephConfigAbsent(t, "client.rgw", "rgw_lifecycle_work_time"),
+				),
+			},
+		},
+	})
+}
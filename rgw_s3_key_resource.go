@@ -3,20 +3,30 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"regexp"
 	"strings"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
 	_ resource.Resource                = &RGWS3KeyResource{}
 	_ resource.ResourceWithImportState = &RGWS3KeyResource{}
+	_ resource.ResourceWithIdentity    = &RGWS3KeyResource{}
+	_ resource.ResourceWithModifyPlan  = &RGWS3KeyResource{}
 
 	userLocks sync.Map
 )
@@ -35,16 +45,29 @@ func (r *RGWS3KeyResource) getUserLock(uid string) *sync.RWMutex {
 }
 
 type RGWS3KeyResourceModel struct {
-	UserID     types.String `tfsdk:"user_id"`
-	AccessKey  types.String `tfsdk:"access_key"`
-	SecretKey  types.String `tfsdk:"secret_key"`
-	User       types.String `tfsdk:"user"`
-	Active     types.Bool   `tfsdk:"active"`
-	CreateDate types.String `tfsdk:"create_date"`
+	UserID             types.String `tfsdk:"user_id"`
+	Tenant             types.String `tfsdk:"tenant"`
+	AccessKey          types.String `tfsdk:"access_key"`
+	SecretKey          types.String `tfsdk:"secret_key"`
+	SecretKeyWO        types.String `tfsdk:"secret_key_wo"`
+	SecretKeyWOVersion types.Int64  `tfsdk:"secret_key_wo_version"`
+	User               types.String `tfsdk:"user"`
+	Active             types.Bool   `tfsdk:"active"`
+	CreateDate         types.String `tfsdk:"create_date"`
+	RotationTriggers   types.Map    `tfsdk:"rotation_triggers"`
+}
+
+type RGWS3KeyResourceIdentityModel struct {
+	UserID    types.String `tfsdk:"user_id"`
+	Tenant    types.String `tfsdk:"tenant"`
+	AccessKey types.String `tfsdk:"access_key"`
 }
 
 func (r *RGWS3KeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_rgw_s3_key"
+	// Rotating a key in place replaces its access key, which is part of the
+	// identity.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r *RGWS3KeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -52,11 +75,21 @@ func (r *RGWS3KeyResource) Schema(ctx context.Context, req resource.SchemaReques
 		MarkdownDescription: "This resource allows you to manage a Ceph RGW S3 access key. Similar to AWS IAM access keys, these keys provide programmatic access to the RGW S3 API.",
 		Attributes: map[string]resourceSchema.Attribute{
 			"user_id": resourceSchema.StringAttribute{
-				MarkdownDescription: "The user or subuser ID that owns this S3 key (format: 'user_id' for users or 'user_id:subuser' for subusers)",
+				MarkdownDescription: "The user or subuser ID that owns this S3 key (format: 'user_id' for users or 'user_id:subuser' for subusers), without the tenant",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^$]+$`), "must not contain a tenant, use the tenant attribute instead"),
+				},
+			},
+			"tenant": resourceSchema.StringAttribute{
+				MarkdownDescription: "The tenant of the user that owns this S3 key. Omit for the default tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"access_key": resourceSchema.StringAttribute{
 				MarkdownDescription: "The S3 access key ID. If not specified, will be auto-generated by Ceph.",
@@ -69,15 +102,43 @@ func (r *RGWS3KeyResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"secret_key": resourceSchema.StringAttribute{
-				MarkdownDescription: "The S3 secret key. If not specified, will be auto-generated by Ceph.",
+				MarkdownDescription: "The S3 secret key. If not specified, will be auto-generated by Ceph. The secret is stored in state so it can be used in outputs; use `secret_key_wo` to keep it out of state.",
 				Optional:            true,
 				Computed:            true,
 				Sensitive:           true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"secret_key_wo": resourceSchema.StringAttribute{
+				MarkdownDescription: "The S3 secret key, as a write-only attribute that is never stored in state. Requires Terraform 1.11 or later and `secret_key_wo_version`.",
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("secret_key")),
+					stringvalidator.AlsoRequires(path.MatchRoot("secret_key_wo_version")),
+				},
+			},
+			"secret_key_wo_version": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The version of `secret_key_wo`. Change it to recreate the key with a new secret. Setting it for the first time on an imported key only stops storing the secret in state.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("secret_key_wo")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+							// Keys imported or created with a stored secret
+							// can adopt a write-only secret in place.
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"Changing the version recreates the key.",
+						"Changing the version recreates the key.",
+					),
+				},
+			},
 			"user": resourceSchema.StringAttribute{
 				MarkdownDescription: "The user identifier returned by the API (matches user_id for regular users)",
 				Computed:            true,
@@ -90,6 +151,37 @@ func (r *RGWS3KeyResource) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "The creation date of the key",
 				Computed:            true,
 			},
+			"rotation_triggers": resourceSchema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that rotate the key when changed. A new key is generated before the old one is deleted, so the user is never left without valid credentials. Cannot be combined with a configured `access_key` or secret.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ConflictsWith(
+						path.MatchRoot("access_key"),
+						path.MatchRoot("secret_key"),
+						path.MatchRoot("secret_key_wo"),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *RGWS3KeyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"user_id": identityschema.StringAttribute{
+				Description:       "The user or subuser ID that owns the S3 key, without the tenant",
+				RequiredForImport: true,
+			},
+			"tenant": identityschema.StringAttribute{
+				Description:       "The tenant of the user that owns the S3 key",
+				OptionalForImport: true,
+			},
+			"access_key": identityschema.StringAttribute{
+				Description:       "The S3 access key ID",
+				RequiredForImport: true,
+			},
 		},
 	}
 }
@@ -121,7 +213,7 @@ func (r *RGWS3KeyResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	userID := data.UserID.ValueString()
+	userID := rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString())
 	parts := strings.SplitN(userID, ":", 2)
 	parentUID := parts[0]
 
@@ -150,6 +242,17 @@ func (r *RGWS3KeyResource) Create(ctx context.Context, req resource.CreateReques
 		generateKey = false
 	}
 
+	var secretKeyWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_key_wo"), &secretKeyWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !secretKeyWO.IsNull() && !secretKeyWO.IsUnknown() {
+		secretKey := secretKeyWO.ValueString()
+		secretKeyPtr = &secretKey
+		generateKey = false
+	}
+
 	existingKeys := make(map[string]bool)
 	if accessKeyPtr == nil {
 		user, err := r.client.RGWGetUser(ctx, parentUID)
@@ -213,6 +316,7 @@ func (r *RGWS3KeyResource) Create(ctx context.Context, req resource.CreateReques
 	updateModelFromAPIKey(&data, createdKey)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWS3KeyResourceIdentityModel{UserID: data.UserID, Tenant: data.Tenant, AccessKey: data.AccessKey})...)
 }
 
 func (r *RGWS3KeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -224,7 +328,7 @@ func (r *RGWS3KeyResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	userID := data.UserID.ValueString()
+	userID := rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString())
 	accessKey := data.AccessKey.ValueString()
 
 	parts := strings.SplitN(userID, ":", 2)
@@ -235,6 +339,10 @@ func (r *RGWS3KeyResource) Read(ctx context.Context, req resource.ReadRequest, r
 	defer mu.RUnlock()
 
 	user, err := r.client.RGWGetUser(ctx, parentUID)
+	if isCephAPINotFound(err) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("RGW user %s", parentUID))
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"API Request Error",
@@ -252,20 +360,202 @@ func (r *RGWS3KeyResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	if foundKey == nil {
-		resp.State.RemoveResource(ctx)
+		removeMissingResource(ctx, resp, fmt.Sprintf("S3 key %s of RGW user %s", accessKey, userID))
 		return
 	}
 
 	updateModelFromAPIKey(&data, foundKey)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWS3KeyResourceIdentityModel{UserID: data.UserID, Tenant: data.Tenant, AccessKey: data.AccessKey})...)
 }
 
+func (r *RGWS3KeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var secretKeyWOVersion types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("secret_key_wo_version"), &secretKeyWOVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !secretKeyWOVersion.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_key"), types.StringNull())...)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state RGWS3KeyResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rotationRequested(plan, state) {
+		plan.AccessKey = types.StringUnknown()
+		if plan.SecretKeyWOVersion.IsNull() {
+			plan.SecretKey = types.StringUnknown()
+		}
+		plan.User = types.StringUnknown()
+		plan.Active = types.BoolUnknown()
+		plan.CreateDate = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	}
+}
+
+func rotationRequested(plan, state RGWS3KeyResourceModel) bool {
+	return !plan.RotationTriggers.IsNull() && !plan.RotationTriggers.Equal(state.RotationTriggers)
+}
+
+// Update handles rotation_triggers changes and adopting a write-only secret on
+// a key that was imported or created with a stored secret. Every other change
+// requires replacement.
 func (r *RGWS3KeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"RGW S3 keys cannot be updated in place. To change a key, delete and recreate it.",
-	)
+	var data, state RGWS3KeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rotationRequested(data, state) {
+		r.rotateKey(ctx, &data, state.AccessKey.ValueString(), resp)
+		return
+	}
+
+	var secretKeyWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_key_wo"), &secretKeyWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString())
+	accessKey := data.AccessKey.ValueString()
+
+	parts := strings.SplitN(userID, ":", 2)
+	parentUID := parts[0]
+
+	mu := r.getUserLock(parentUID)
+	mu.RLock()
+	defer mu.RUnlock()
+
+	user, err := r.client.RGWGetUser(ctx, parentUID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read RGW user: %s", err),
+		)
+		return
+	}
+
+	var foundKey *CephAPIRGWS3Key
+	for i := range user.Keys {
+		if user.Keys[i].User == userID && user.Keys[i].AccessKey == accessKey {
+			foundKey = &user.Keys[i]
+			break
+		}
+	}
+
+	if foundKey == nil {
+		resp.Diagnostics.AddError(
+			"Key Not Found",
+			fmt.Sprintf("S3 key %s was not found for user %s", accessKey, userID),
+		)
+		return
+	}
+
+	if !secretKeyWO.IsNull() && foundKey.SecretKey != "" && secretKeyWO.ValueString() != foundKey.SecretKey {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secret_key_wo"),
+			"Secret Key Mismatch",
+			fmt.Sprintf("secret_key_wo does not match the current secret of S3 key %s. Set it to the existing secret, or replace the resource to create the key with a new secret.", accessKey),
+		)
+		return
+	}
+
+	updateModelFromAPIKey(&data, foundKey)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWS3KeyResourceIdentityModel{UserID: data.UserID, Tenant: data.Tenant, AccessKey: data.AccessKey})...)
+}
+
+// rotateKey generates a new key for the user and only then deletes the old one.
+// If the old key cannot be deleted the new key is still recorded in state so
+// it isn't leaked.
+func (r *RGWS3KeyResource) rotateKey(ctx context.Context, data *RGWS3KeyResourceModel, oldAccessKey string, resp *resource.UpdateResponse) {
+	userID := rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString())
+	parts := strings.SplitN(userID, ":", 2)
+	parentUID := parts[0]
+
+	mu := r.getUserLock(parentUID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	var subuser *string
+	if len(parts) == 2 {
+		subuser = &userID
+	}
+
+	user, err := r.client.RGWGetUser(ctx, parentUID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read RGW user: %s", err),
+		)
+		return
+	}
+
+	existingKeys := make(map[string]bool)
+	for _, key := range user.Keys {
+		if key.User == userID {
+			existingKeys[key.AccessKey] = true
+		}
+	}
+
+	generateKey := true
+	keys, err := r.client.RGWCreateS3Key(ctx, parentUID, subuser, nil, nil, &generateKey)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to create rotated RGW S3 key: %s", err),
+		)
+		return
+	}
+
+	var createdKey *CephAPIRGWS3Key
+	for i := range keys {
+		if keys[i].User == userID && !existingKeys[keys[i].AccessKey] {
+			createdKey = &keys[i]
+			break
+		}
+	}
+	if createdKey == nil {
+		resp.Diagnostics.AddError(
+			"Key Creation Error",
+			fmt.Sprintf("Could not identify newly created key in API response for user %s", userID),
+		)
+		return
+	}
+
+	updateModelFromAPIKey(data, createdKey)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWS3KeyResourceIdentityModel{UserID: data.UserID, Tenant: data.Tenant, AccessKey: data.AccessKey})...)
+
+	err = r.client.RGWDeleteS3Key(ctx, parentUID, oldAccessKey, subuser)
+	if err != nil && !isCephAPINotFound(err) {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Rotated RGW S3 key, but unable to delete the previous key %s: %s", oldAccessKey, err),
+		)
+	}
 }
 
 func (r *RGWS3KeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -277,7 +567,7 @@ func (r *RGWS3KeyResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	userID := data.UserID.ValueString()
+	userID := rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString())
 	accessKey := data.AccessKey.ValueString()
 
 	parts := strings.SplitN(userID, ":", 2)
@@ -289,7 +579,8 @@ func (r *RGWS3KeyResource) Delete(ctx context.Context, req resource.DeleteReques
 
 	user, err := r.client.RGWGetUser(ctx, parentUID)
 	if err != nil {
-		if strings.Contains(err.Error(), "status 404") || strings.Contains(err.Error(), "status 500") {
+		// The dashboard reports a missing RGW user as a 500 rather than a 404.
+		if isCephAPINotFound(err) || isCephAPIStatus(err, http.StatusInternalServerError) {
 			return
 		}
 		resp.Diagnostics.AddWarning(
@@ -316,7 +607,7 @@ func (r *RGWS3KeyResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	err = r.client.RGWDeleteS3Key(ctx, parentUID, accessKey, subuser)
-	if err != nil {
+	if err != nil && !isCephAPINotFound(err) {
 		resp.Diagnostics.AddError(
 			"API Request Error",
 			fmt.Sprintf("Unable to delete RGW S3 key: %s", err),
@@ -326,31 +617,87 @@ func (r *RGWS3KeyResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 func (r *RGWS3KeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		var identity RGWS3KeyResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		r.importKey(ctx, identity.Tenant.ValueString(), identity.UserID.ValueString(), identity.AccessKey.ValueString(), resp)
+		return
+	}
+
 	parts := strings.Split(req.ID, ":")
 
 	var userID, accessKey string
 
-	if len(parts) == 2 {
+	switch len(parts) {
+	case 2:
 		userID = parts[0]
 		accessKey = parts[1]
-	} else if len(parts) == 3 {
+	case 3:
 		userID = parts[0] + ":" + parts[1]
 		accessKey = parts[2]
-	} else {
+	}
+
+	if userID == "" || accessKey == "" || strings.HasPrefix(userID, ":") || strings.HasSuffix(userID, ":") {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
-			fmt.Sprintf("Expected import ID in format 'user_id:access_key' or 'user_id:subuser:access_key', got: %s", req.ID),
+			fmt.Sprintf("Expected import ID in format '[tenant$]user_id:access_key' or '[tenant$]user_id:subuser:access_key', got: %s", req.ID),
+		)
+		return
+	}
+
+	tenant, userID := splitRGWUserID(userID)
+	r.importKey(ctx, tenant, userID, accessKey, resp)
+}
+
+// importKey checks that the key exists before seeding state, so a typo in the
+// import ID is reported as such rather than as a vanished resource. Read fills
+// in the remaining metadata; the secret is only kept in state until the
+// configuration opts into secret_key_wo.
+func (r *RGWS3KeyResource) importKey(ctx context.Context, tenant, userID, accessKey string, resp *resource.ImportStateResponse) {
+	fullUserID := rgwUserID(tenant, userID)
+	parentUID := strings.SplitN(fullUserID, ":", 2)[0]
+
+	user, err := r.client.RGWGetUser(ctx, parentUID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"API Request Error",
+			fmt.Sprintf("Unable to read RGW user %s: %s", parentUID, err),
+		)
+		return
+	}
+
+	var found bool
+	for i := range user.Keys {
+		if user.Keys[i].User == fullUserID && user.Keys[i].AccessKey == accessKey {
+			found = true
+			break
+		}
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Key Not Found",
+			fmt.Sprintf("S3 key %s was not found for user %s", accessKey, fullUserID),
 		)
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+	if tenant != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("access_key"), accessKey)...)
 }
 
 func updateModelFromAPIKey(data *RGWS3KeyResourceModel, key *CephAPIRGWS3Key) {
 	data.AccessKey = types.StringValue(key.AccessKey)
 	data.SecretKey = types.StringValue(key.SecretKey)
+	if !data.SecretKeyWOVersion.IsNull() {
+		data.SecretKey = types.StringNull()
+	}
+	data.SecretKeyWO = types.StringNull()
 	data.User = types.StringValue(key.User)
 	data.Active = types.BoolValue(key.Active)
 	if key.CreateDate != "" {
@@ -8,12 +8,15 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccCephRGWS3KeyResource(t *testing.T) {
@@ -21,6 +24,7 @@ func TestAccCephRGWS3KeyResource(t *testing.T) {
 	defer detachLogs()
 
 	testUID := acctest.RandomWithPrefix("test-s3-key-res")
+	var accessKey string
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -59,8 +63,30 @@ func TestAccCephRGWS3KeyResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("ceph_rgw_s3_key.test", "secret_key"),
 					resource.TestCheckResourceAttr("ceph_rgw_s3_key.test", "user", testUID),
 					resource.TestCheckResourceAttr("ceph_rgw_s3_key.test", "active", "true"),
+					resource.TestCheckResourceAttrWith("ceph_rgw_s3_key.test", "access_key", func(value string) error {
+						accessKey = value
+						return nil
+					}),
 				),
 			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.RgwKeyRemove(t.Context(), testUID, accessKey); err != nil {
+						t.Fatalf("Failed to delete key out of band: %v", err)
+					}
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_s3_key" "test" {
+					  user_id = %q
+					}
+				`, testUID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_s3_key.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
 		},
 	})
 }
@@ -277,6 +303,61 @@ func TestAccCephRGWS3KeyResource_rotationWorkflow(t *testing.T) {
 	})
 }
 
+func TestAccCephRGWS3KeyResource_rotationTriggers(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-key-triggers")
+	accessKeyChanges := statecheck.CompareValue(compare.ValuesDiffer())
+
+	keyConfig := func(version string) string {
+		return testAccProviderConfigBlock + fmt.Sprintf(`
+			resource "ceph_rgw_s3_key" "test" {
+			  user_id = %q
+
+			  rotation_triggers = {
+			    version = %q
+			  }
+			}
+		`, testUID, version)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWS3KeyDestroy(t),
+		PreCheck: func() {
+			createTestRGWUserWithoutKeys(t, testUID, "Key Rotation Triggers Test User")
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          keyConfig("1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					accessKeyChanges.AddStateValue("ceph_rgw_s3_key.test", tfjsonpath.New("access_key")),
+				},
+				Check: checkCephRGWUserKeyCount(t, testUID, 1),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          keyConfig("2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_s3_key.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("ceph_rgw_s3_key.test", tfjsonpath.New("access_key")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					accessKeyChanges.AddStateValue("ceph_rgw_s3_key.test", tfjsonpath.New("access_key")),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ceph_rgw_s3_key.test", "secret_key"),
+					checkCephRGWUserKeyCount(t, testUID, 1),
+				),
+			},
+		},
+	})
+}
+
 func TestAccCephRGWS3KeyResource_customKeyValidation(t *testing.T) {
 	detachLogs := cephDaemonLogs.AttachTestFunction(t)
 	defer detachLogs()
@@ -542,7 +623,7 @@ func testAccCheckCephRGWS3KeyDestroy(t *testing.T) resource.TestCheckFunc {
 				continue
 			}
 
-			userID := rs.Primary.Attributes["user_id"]
+			userID := rgwUserID(rs.Primary.Attributes["tenant"], rs.Primary.Attributes["user_id"])
 			accessKey := rs.Primary.Attributes["access_key"]
 
 			parts := strings.SplitN(userID, ":", 2)
@@ -562,3 +643,117 @@ func testAccCheckCephRGWS3KeyDestroy(t *testing.T) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func TestAccCephRGWS3KeyResource_writeOnlySecretKey(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-s3-key-wo")
+	customAccessKey := acctest.RandString(20)
+	customSecretKey := acctest.RandString(40)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWS3KeyDestroy(t),
+		PreCheck: func() {
+			createTestRGWUserWithoutKeys(t, testUID, "Test S3 Key Write-Only User")
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_s3_key" "wo" {
+					  user_id               = %q
+					  access_key            = %q
+					  secret_key_wo         = %q
+					  secret_key_wo_version = 1
+					}
+				`, testUID, customAccessKey, customSecretKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_s3_key.wo", "access_key", customAccessKey),
+					resource.TestCheckNoResourceAttr("ceph_rgw_s3_key.wo", "secret_key"),
+					resource.TestCheckNoResourceAttr("ceph_rgw_s3_key.wo", "secret_key_wo"),
+					resource.TestCheckResourceAttr("ceph_rgw_s3_key.wo", "secret_key_wo_version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCephRGWS3KeyResource_importAdoptWriteOnly(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-import-wo")
+	accessKey := acctest.RandString(20)
+	secretKey := acctest.RandString(40)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWS3KeyDestroy(t),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		PreCheck: func() {
+			createTestRGWUserWithoutKeys(t, testUID, "Import Write-Only Test User")
+			createRGWS3Key(t, testUID, accessKey, secretKey)
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_s3_key" "test" {
+					  user_id = %q
+					}
+				`, testUID),
+				ResourceName:       "ceph_rgw_s3_key.test",
+				ImportState:        true,
+				ImportStateId:      fmt.Sprintf("%s:%s", testUID, accessKey),
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(states))
+					}
+					if got := states[0].Attributes["secret_key"]; got != secretKey {
+						return fmt.Errorf("secret_key = %q, want the existing secret", got)
+					}
+					return nil
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_s3_key" "test" {
+					  user_id               = %q
+					  access_key            = %q
+					  secret_key_wo         = %q
+					  secret_key_wo_version = 1
+					}
+				`, testUID, accessKey, secretKey),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_s3_key.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_s3_key.test", "access_key", accessKey),
+					resource.TestCheckNoResourceAttr("ceph_rgw_s3_key.test", "secret_key"),
+					resource.TestCheckResourceAttr("ceph_rgw_s3_key.test", "secret_key_wo_version", "1"),
+					checkCephRGWUserKeyCount(t, testUID, 1),
+				),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_s3_key" "mismatch" {
+					  user_id = %q
+					}
+				`, testUID),
+				ResourceName:  "ceph_rgw_s3_key.mismatch",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("%s:%s", testUID, "NONEXISTENTKEY"),
+				ExpectError:   regexp.MustCompile(`S3 key NONEXISTENTKEY was not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,698 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &RGWSyncGroupResource{}
+	_ resource.ResourceWithImportState = &RGWSyncGroupResource{}
+	_ resource.ResourceWithIdentity    = &RGWSyncGroupResource{}
+)
+
+func newRGWSyncGroupResource() resource.Resource {
+	return &RGWSyncGroupResource{}
+}
+
+// RGWSyncGroupResource manages a multisite sync policy group with its data
+// flows and pipes. The dashboard API does not expose sync policy, so it
+// always goes through radosgw-admin.
+type RGWSyncGroupResource struct {
+	client *CephAPIClient
+}
+
+type RGWSyncGroupResourceModel struct {
+	GroupID          types.String `tfsdk:"group_id"`
+	Bucket           types.String `tfsdk:"bucket"`
+	Status           types.String `tfsdk:"status"`
+	SymmetricalFlows types.List   `tfsdk:"symmetrical_flows"`
+	DirectionalFlows types.List   `tfsdk:"directional_flows"`
+	Pipes            types.List   `tfsdk:"pipes"`
+	CommitPeriod     types.Bool   `tfsdk:"commit_period"`
+}
+
+type RGWSyncGroupResourceIdentityModel struct {
+	GroupID types.String `tfsdk:"group_id"`
+	Bucket  types.String `tfsdk:"bucket"`
+}
+
+type RGWSyncSymmetricalFlowModel struct {
+	ID    types.String `tfsdk:"id"`
+	Zones types.Set    `tfsdk:"zones"`
+}
+
+type RGWSyncDirectionalFlowModel struct {
+	SourceZone types.String `tfsdk:"source_zone"`
+	DestZone   types.String `tfsdk:"dest_zone"`
+}
+
+type RGWSyncPipeModel struct {
+	ID           types.String `tfsdk:"id"`
+	SourceZones  types.Set    `tfsdk:"source_zones"`
+	SourceBucket types.String `tfsdk:"source_bucket"`
+	DestZones    types.Set    `tfsdk:"dest_zones"`
+	DestBucket   types.String `tfsdk:"dest_bucket"`
+}
+
+var rgwSyncSymmetricalFlowObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":    types.StringType,
+		"zones": types.SetType{ElemType: types.StringType},
+	},
+}
+
+var rgwSyncDirectionalFlowObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"source_zone": types.StringType,
+		"dest_zone":   types.StringType,
+	},
+}
+
+var rgwSyncPipeObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":            types.StringType,
+		"source_zones":  types.SetType{ElemType: types.StringType},
+		"source_bucket": types.StringType,
+		"dest_zones":    types.SetType{ElemType: types.StringType},
+		"dest_bucket":   types.StringType,
+	},
+}
+
+func (r *RGWSyncGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_sync_group"
+}
+
+func (r *RGWSyncGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Manages a multisite sync policy group, which selects the zones and buckets RGW replicates between. " +
+			"A group belongs to the zonegroup policy, or to a single bucket's policy when `bucket` is set. " +
+			"Sync policy is only available through radosgw-admin, so this resource requires the provider `cli_backend` to be enabled.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"group_id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The sync group ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"bucket": resourceSchema.StringAttribute{
+				MarkdownDescription: "The bucket whose policy the group belongs to, as `bucket` or `tenant/bucket`. Omit for the zonegroup policy.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": resourceSchema.StringAttribute{
+				MarkdownDescription: "`enabled` to sync, `allowed` to let bucket groups enable sync, or `forbidden` to prevent it",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("enabled", "allowed", "forbidden"),
+				},
+			},
+			"symmetrical_flows": resourceSchema.ListNestedAttribute{
+				MarkdownDescription: "Data flows that sync in both directions between all of their zones",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: resourceSchema.NestedAttributeObject{
+					Attributes: map[string]resourceSchema.Attribute{
+						"id": resourceSchema.StringAttribute{
+							MarkdownDescription: "The flow ID",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"zones": resourceSchema.SetAttribute{
+							MarkdownDescription: "The zones that sync with each other",
+							Required:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(2),
+							},
+						},
+					},
+				},
+			},
+			"directional_flows": resourceSchema.ListNestedAttribute{
+				MarkdownDescription: "Data flows that sync from one zone to another",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: resourceSchema.NestedAttributeObject{
+					Attributes: map[string]resourceSchema.Attribute{
+						"source_zone": resourceSchema.StringAttribute{
+							MarkdownDescription: "The zone data is synced from",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"dest_zone": resourceSchema.StringAttribute{
+							MarkdownDescription: "The zone data is synced to",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"pipes": resourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The buckets and zones the data flows apply to",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: resourceSchema.NestedAttributeObject{
+					Attributes: map[string]resourceSchema.Attribute{
+						"id": resourceSchema.StringAttribute{
+							MarkdownDescription: "The pipe ID",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"source_zones": resourceSchema.SetAttribute{
+							MarkdownDescription: "The zones to sync from, or `[\"*\"]` for all zones",
+							Required:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"source_bucket": resourceSchema.StringAttribute{
+							MarkdownDescription: "The bucket to sync from. Omit for all buckets.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.NoneOf("", "*"),
+							},
+						},
+						"dest_zones": resourceSchema.SetAttribute{
+							MarkdownDescription: "The zones to sync to, or `[\"*\"]` for all zones",
+							Required:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"dest_bucket": resourceSchema.StringAttribute{
+							MarkdownDescription: "The bucket to sync to. Omit for the same bucket as the source.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.NoneOf("", "*"),
+							},
+						},
+					},
+				},
+			},
+			"commit_period": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether to run `radosgw-admin period update --commit` after changing a zonegroup group, which is needed for the change to reach other zones of a realm. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *RGWSyncGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"group_id": identityschema.StringAttribute{
+				Description:       "The sync group ID",
+				RequiredForImport: true,
+			},
+			"bucket": identityschema.StringAttribute{
+				Description:       "The bucket whose policy the group belongs to",
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+func (r *RGWSyncGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RGWSyncGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RGWSyncGroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to create sync group: %s", err),
+		)
+		return
+	}
+
+	group, diags := rgwSyncGroupFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := data.Bucket.ValueString()
+	if err := cli.RgwSyncGroupCreate(ctx, bucket, group.ID, group.Status); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to create sync group: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, cli, bucket, RgwSyncGroup{}, group)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.commitPeriod(ctx, cli, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWSyncGroupResourceIdentityModel{GroupID: data.GroupID, Bucket: data.Bucket})...)
+}
+
+func (r *RGWSyncGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RGWSyncGroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read sync group: %s", err),
+		)
+		return
+	}
+
+	policy, err := cli.RgwSyncPolicyGet(ctx, data.Bucket.ValueString())
+	if errors.Is(err, ErrRGWBucketNotFound) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("RGW bucket %s", data.Bucket.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read sync group: %s", err),
+		)
+		return
+	}
+
+	group := policy.Group(data.GroupID.ValueString())
+	if group == nil {
+		removeMissingResource(ctx, resp, fmt.Sprintf("Sync group %s", data.GroupID.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(rgwSyncGroupToModel(ctx, *group, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.CommitPeriod.IsNull() {
+		data.CommitPeriod = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWSyncGroupResourceIdentityModel{GroupID: data.GroupID, Bucket: data.Bucket})...)
+}
+
+func (r *RGWSyncGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RGWSyncGroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to update sync group: %s", err),
+		)
+		return
+	}
+
+	group, diags := rgwSyncGroupFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	prior, diags := rgwSyncGroupFromModel(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := data.Bucket.ValueString()
+	if group.Status != prior.Status {
+		if err := cli.RgwSyncGroupModify(ctx, bucket, group.ID, group.Status); err != nil {
+			resp.Diagnostics.AddError(
+				"CLI Request Error",
+				fmt.Sprintf("Unable to update sync group: %s", err),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, cli, bucket, prior, group)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.commitPeriod(ctx, cli, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWSyncGroupResourceIdentityModel{GroupID: data.GroupID, Bucket: data.Bucket})...)
+}
+
+func (r *RGWSyncGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RGWSyncGroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to remove sync group: %s", err),
+		)
+		return
+	}
+
+	err = cli.RgwSyncGroupRemove(ctx, data.Bucket.ValueString(), data.GroupID.ValueString())
+	if errors.Is(err, ErrRGWBucketNotFound) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to remove sync group: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.commitPeriod(ctx, cli, data)...)
+}
+
+// ImportState accepts "group" for zonegroup groups and "bucket:group" for
+// bucket groups. Bucket names cannot contain ":".
+func (r *RGWSyncGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		var identity RGWSyncGroupResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), identity.GroupID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), identity.Bucket)...)
+		return
+	}
+
+	groupID := req.ID
+	if bucket, id, ok := strings.Cut(req.ID, ":"); ok {
+		groupID = id
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), groupID)...)
+}
+
+// apply removes the flows and pipes of prior that are missing from or
+// differ in group, then creates those of group that are new or changed.
+// Creating an existing flow or pipe adds to its zones rather than replacing
+// them, so changed ones are removed first.
+func (r *RGWSyncGroupResource) apply(ctx context.Context, cli *CephCLI, bucket string, prior, group RgwSyncGroup) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	contains := func(list, item any) bool {
+		items := reflect.ValueOf(list)
+		for i := range items.Len() {
+			if reflect.DeepEqual(items.Index(i).Interface(), item) {
+				return true
+			}
+		}
+		return false
+	}
+
+	addError := func(err error) diag.Diagnostics {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to update sync group %s: %s", group.ID, err),
+		)
+		return diags
+	}
+
+	for _, flow := range prior.DataFlow.Symmetrical {
+		if !contains(group.DataFlow.Symmetrical, flow) {
+			if err := cli.RgwSyncSymmetricalFlowRemove(ctx, bucket, group.ID, flow.ID); err != nil {
+				return addError(err)
+			}
+		}
+	}
+	for _, flow := range prior.DataFlow.Directional {
+		if !contains(group.DataFlow.Directional, flow) {
+			if err := cli.RgwSyncDirectionalFlowRemove(ctx, bucket, group.ID, flow); err != nil {
+				return addError(err)
+			}
+		}
+	}
+	for _, pipe := range prior.Pipes {
+		if !contains(group.Pipes, pipe) {
+			if err := cli.RgwSyncPipeRemove(ctx, bucket, group.ID, pipe.ID); err != nil {
+				return addError(err)
+			}
+		}
+	}
+
+	for _, flow := range group.DataFlow.Symmetrical {
+		if !contains(prior.DataFlow.Symmetrical, flow) {
+			if err := cli.RgwSyncSymmetricalFlowCreate(ctx, bucket, group.ID, flow); err != nil {
+				return addError(err)
+			}
+		}
+	}
+	for _, flow := range group.DataFlow.Directional {
+		if !contains(prior.DataFlow.Directional, flow) {
+			if err := cli.RgwSyncDirectionalFlowCreate(ctx, bucket, group.ID, flow); err != nil {
+				return addError(err)
+			}
+		}
+	}
+	for _, pipe := range group.Pipes {
+		if !contains(prior.Pipes, pipe) {
+			if err := cli.RgwSyncPipeCreate(ctx, bucket, group.ID, pipe); err != nil {
+				return addError(err)
+			}
+		}
+	}
+
+	return diags
+}
+
+// Bucket groups are stored with the bucket rather than in the period, so
+// only zonegroup groups are committed.
+func (r *RGWSyncGroupResource) commitPeriod(ctx context.Context, cli *CephCLI, data RGWSyncGroupResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !data.CommitPeriod.ValueBool() || data.Bucket.ValueString() != "" {
+		return diags
+	}
+
+	if err := cli.RgwPeriodCommit(ctx); err != nil {
+		diags.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to commit period: %s", err),
+		)
+	}
+	return diags
+}
+
+// rgwSyncGroupFromModel converts data to the radosgw-admin representation
+// with sorted zones, so groups can be compared with reflect.DeepEqual.
+func rgwSyncGroupFromModel(ctx context.Context, data RGWSyncGroupResourceModel) (RgwSyncGroup, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	group := RgwSyncGroup{
+		ID:     data.GroupID.ValueString(),
+		Status: data.Status.ValueString(),
+	}
+
+	sortedZones := func(set types.Set) []string {
+		var zones []string
+		diags.Append(set.ElementsAs(ctx, &zones, false)...)
+		sort.Strings(zones)
+		return zones
+	}
+
+	if !data.SymmetricalFlows.IsNull() {
+		var flows []RGWSyncSymmetricalFlowModel
+		diags.Append(data.SymmetricalFlows.ElementsAs(ctx, &flows, false)...)
+		for _, flow := range flows {
+			group.DataFlow.Symmetrical = append(group.DataFlow.Symmetrical, RgwSyncSymmetricalFlow{
+				ID:    flow.ID.ValueString(),
+				Zones: sortedZones(flow.Zones),
+			})
+		}
+	}
+
+	if !data.DirectionalFlows.IsNull() {
+		var flows []RGWSyncDirectionalFlowModel
+		diags.Append(data.DirectionalFlows.ElementsAs(ctx, &flows, false)...)
+		for _, flow := range flows {
+			group.DataFlow.Directional = append(group.DataFlow.Directional, RgwSyncDirectionalFlow{
+				SourceZone: flow.SourceZone.ValueString(),
+				DestZone:   flow.DestZone.ValueString(),
+			})
+		}
+	}
+
+	if !data.Pipes.IsNull() {
+		var pipes []RGWSyncPipeModel
+		diags.Append(data.Pipes.ElementsAs(ctx, &pipes, false)...)
+		for _, pipe := range pipes {
+			group.Pipes = append(group.Pipes, RgwSyncGroupPipe{
+				ID: pipe.ID.ValueString(),
+				Source: RgwSyncGroupPipeEnd{
+					Bucket: pipe.SourceBucket.ValueString(),
+					Zones:  sortedZones(pipe.SourceZones),
+				},
+				Dest: RgwSyncGroupPipeEnd{
+					Bucket: pipe.DestBucket.ValueString(),
+					Zones:  sortedZones(pipe.DestZones),
+				},
+			})
+		}
+	}
+
+	return group, diags
+}
+
+// rgwSyncGroupToModel fills data from group. radosgw-admin reports a pipe
+// without a bucket as "*", which is stored as null.
+func rgwSyncGroupToModel(ctx context.Context, group RgwSyncGroup, data *RGWSyncGroupResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	bucketValue := func(bucket string) types.String {
+		if bucket == "" || bucket == "*" {
+			return types.StringNull()
+		}
+		return types.StringValue(bucket)
+	}
+
+	zonesValue := func(zones []string) types.Set {
+		value, d := types.SetValueFrom(ctx, types.StringType, slices.Clone(zones))
+		diags.Append(d...)
+		return value
+	}
+
+	data.Status = types.StringValue(group.Status)
+
+	data.SymmetricalFlows = types.ListNull(rgwSyncSymmetricalFlowObjectType)
+	if len(group.DataFlow.Symmetrical) > 0 {
+		flows := make([]RGWSyncSymmetricalFlowModel, 0, len(group.DataFlow.Symmetrical))
+		for _, flow := range group.DataFlow.Symmetrical {
+			flows = append(flows, RGWSyncSymmetricalFlowModel{
+				ID:    types.StringValue(flow.ID),
+				Zones: zonesValue(flow.Zones),
+			})
+		}
+		value, d := types.ListValueFrom(ctx, rgwSyncSymmetricalFlowObjectType, flows)
+		diags.Append(d...)
+		data.SymmetricalFlows = value
+	}
+
+	data.DirectionalFlows = types.ListNull(rgwSyncDirectionalFlowObjectType)
+	if len(group.DataFlow.Directional) > 0 {
+		flows := make([]RGWSyncDirectionalFlowModel, 0, len(group.DataFlow.Directional))
+		for _, flow := range group.DataFlow.Directional {
+			flows = append(flows, RGWSyncDirectionalFlowModel{
+				SourceZone: types.StringValue(flow.SourceZone),
+				DestZone:   types.StringValue(flow.DestZone),
+			})
+		}
+		value, d := types.ListValueFrom(ctx, rgwSyncDirectionalFlowObjectType, flows)
+		diags.Append(d...)
+		data.DirectionalFlows = value
+	}
+
+	data.Pipes = types.ListNull(rgwSyncPipeObjectType)
+	if len(group.Pipes) > 0 {
+		pipes := make([]RGWSyncPipeModel, 0, len(group.Pipes))
+		for _, pipe := range group.Pipes {
+			pipes = append(pipes, RGWSyncPipeModel{
+				ID:           types.StringValue(pipe.ID),
+				SourceZones:  zonesValue(pipe.Source.Zones),
+				SourceBucket: bucketValue(pipe.Source.Bucket),
+				DestZones:    zonesValue(pipe.Dest.Zones),
+				DestBucket:   bucketValue(pipe.Dest.Bucket),
+			})
+		}
+		value, d := types.ListValueFrom(ctx, rgwSyncPipeObjectType, pipes)
+		diags.Append(d...)
+		data.Pipes = value
+	}
+
+	return diags
+}
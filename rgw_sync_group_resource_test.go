@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccCephRGWSyncGroupResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-sync-group-owner")
+	testBucket := acctest.RandomWithPrefix("test-sync-group")
+	testGroup := acctest.RandomWithPrefix("test-group")
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+	bucketConfig := fmt.Sprintf(`
+		resource "ceph_rgw_user" "test" {
+		  user_id      = %q
+		  display_name = "Sync Group Test User"
+		}
+
+		resource "ceph_rgw_s3_key" "test" {
+		  user_id = ceph_rgw_user.test.user_id
+		}
+
+		resource "ceph_rgw_bucket" "test" {
+		  bucket = %q
+		  owner  = ceph_rgw_user.test.user_id
+		  depends_on = [ceph_rgw_s3_key.test]
+		}
+	`, testUID, testBucket)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWBucketDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + bucketConfig + fmt.Sprintf(`
+					resource "ceph_rgw_sync_group" "test" {
+					  bucket   = ceph_rgw_bucket.test.bucket
+					  group_id = %q
+					  status   = "allowed"
+
+					  pipes = [{
+					    id           = "all"
+					    source_zones = ["*"]
+					    dest_zones   = ["*"]
+					  }]
+					}
+				`, testGroup),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_sync_group.test", "status", "allowed"),
+					resource.TestCheckResourceAttr("ceph_rgw_sync_group.test", "pipes.#", "1"),
+					resource.TestCheckResourceAttr("ceph_rgw_sync_group.test", "pipes.0.source_zones.0", "*"),
+					resource.TestCheckNoResourceAttr("ceph_rgw_sync_group.test", "pipes.0.source_bucket"),
+					resource.TestCheckNoResourceAttr("ceph_rgw_sync_group.test", "symmetrical_flows"),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.RgwSyncGroupRemove(t.Context(), testBucket, testGroup); err != nil {
+						t.Fatalf("Failed to delete sync group out of band: %v", err)
+					}
+				},
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + bucketConfig + fmt.Sprintf(`
+					resource "ceph_rgw_sync_group" "test" {
+					  bucket   = ceph_rgw_bucket.test.bucket
+					  group_id = %q
+					  status   = "allowed"
+
+					  pipes = [{
+					    id           = "all"
+					    source_zones = ["*"]
+					    dest_zones   = ["*"]
+					  }]
+					}
+				`, testGroup),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_sync_group.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			{
+				ConfigVariables:                      configVariables,
+				ResourceName:                         "ceph_rgw_sync_group.test",
+				ImportState:                          true,
+				ImportStateId:                        testBucket + ":" + testGroup,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "group_id",
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + bucketConfig + fmt.Sprintf(`
+					resource "ceph_rgw_sync_group" "test" {
+					  bucket   = ceph_rgw_bucket.test.bucket
+					  group_id = %q
+					  status   = "forbidden"
+
+					  directional_flows = [{
+					    source_zone = "zone-a"
+					    dest_zone   = "zone-b"
+					  }]
+
+					  pipes = [{
+					    id           = "to-backup"
+					    source_zones = ["zone-a"]
+					    dest_zones   = ["zone-b"]
+					    dest_bucket  = "backup"
+					  }]
+					}
+				`, testGroup),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_sync_group.test", "status", "forbidden"),
+					resource.TestCheckResourceAttr("ceph_rgw_sync_group.test", "directional_flows.0.source_zone", "zone-a"),
+					resource.TestCheckResourceAttr("ceph_rgw_sync_group.test", "pipes.#", "1"),
+					resource.TestCheckResourceAttr("ceph_rgw_sync_group.test", "pipes.0.id", "to-backup"),
+					resource.TestCheckResourceAttr("ceph_rgw_sync_group.test", "pipes.0.dest_bucket", "backup"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataSourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &RGWUsageDataSource{}
+
+func newRGWUsageDataSource() datasource.DataSource {
+	return &RGWUsageDataSource{}
+}
+
+type RGWUsageDataSource struct {
+	client *CephAPIClient
+}
+
+type RGWUsageDataSourceModel struct {
+	UserID  types.String         `tfsdk:"user_id"`
+	Bucket  types.String         `tfsdk:"bucket"`
+	Start   types.String         `tfsdk:"start"`
+	End     types.String         `tfsdk:"end"`
+	Users   []RGWUsageUserItem   `tfsdk:"users"`
+	Buckets []RGWUsageBucketItem `tfsdk:"buckets"`
+}
+
+type RGWUsageUserItem struct {
+	UserID        types.String `tfsdk:"user_id"`
+	BytesSent     types.Int64  `tfsdk:"bytes_sent"`
+	BytesReceived types.Int64  `tfsdk:"bytes_received"`
+	Ops           types.Int64  `tfsdk:"ops"`
+	SuccessfulOps types.Int64  `tfsdk:"successful_ops"`
+}
+
+type RGWUsageBucketItem struct {
+	UserID        types.String `tfsdk:"user_id"`
+	Bucket        types.String `tfsdk:"bucket"`
+	BytesSent     types.Int64  `tfsdk:"bytes_sent"`
+	BytesReceived types.Int64  `tfsdk:"bytes_received"`
+	Ops           types.Int64  `tfsdk:"ops"`
+	SuccessfulOps types.Int64  `tfsdk:"successful_ops"`
+}
+
+func (d *RGWUsageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_usage"
+}
+
+func rgwUsageTotalsAttributes() map[string]dataSourceSchema.Attribute {
+	return map[string]dataSourceSchema.Attribute{
+		"bytes_sent": dataSourceSchema.Int64Attribute{
+			MarkdownDescription: "The bytes sent to clients",
+			Computed:            true,
+		},
+		"bytes_received": dataSourceSchema.Int64Attribute{
+			MarkdownDescription: "The bytes received from clients",
+			Computed:            true,
+		},
+		"ops": dataSourceSchema.Int64Attribute{
+			MarkdownDescription: "The number of operations",
+			Computed:            true,
+		},
+		"successful_ops": dataSourceSchema.Int64Attribute{
+			MarkdownDescription: "The number of successful operations",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *RGWUsageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	userAttributes := rgwUsageTotalsAttributes()
+	userAttributes["user_id"] = dataSourceSchema.StringAttribute{
+		MarkdownDescription: "The user ID",
+		Computed:            true,
+	}
+
+	bucketAttributes := rgwUsageTotalsAttributes()
+	bucketAttributes["user_id"] = dataSourceSchema.StringAttribute{
+		MarkdownDescription: "The user ID that made the requests",
+		Computed:            true,
+	}
+	bucketAttributes["bucket"] = dataSourceSchema.StringAttribute{
+		MarkdownDescription: "The bucket name, empty for requests not made against a bucket such as listing buckets",
+		Computed:            true,
+	}
+
+	resp.Schema = dataSourceSchema.Schema{
+		MarkdownDescription: "This data source aggregates the RGW usage log (equivalent to `radosgw-admin usage show`). " +
+			"The usage log is only written when `rgw_enable_usage_log` is enabled and is flushed periodically, so recent requests may be missing. " +
+			"The dashboard API does not expose the usage log, so this data source requires the provider `cli_backend` to be enabled.",
+		Attributes: map[string]dataSourceSchema.Attribute{
+			"user_id": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "Optional filter to only return the usage of one user",
+				Optional:            true,
+			},
+			"bucket": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "Optional filter to only return the usage of one bucket",
+				Optional:            true,
+			},
+			"start": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The RFC 3339 start of the time window. The usage log has hourly granularity.",
+				Optional:            true,
+				Validators: []validator.String{
+					RFC3339Timestamp(),
+				},
+			},
+			"end": dataSourceSchema.StringAttribute{
+				MarkdownDescription: "The RFC 3339 end of the time window, exclusive",
+				Optional:            true,
+				Validators: []validator.String{
+					RFC3339Timestamp(),
+				},
+			},
+			"users": dataSourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The usage totals of each user over the time window",
+				Computed:            true,
+				NestedObject: dataSourceSchema.NestedAttributeObject{
+					Attributes: userAttributes,
+				},
+			},
+			"buckets": dataSourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The usage totals of each user and bucket over the time window",
+				Computed:            true,
+				NestedObject: dataSourceSchema.NestedAttributeObject{
+					Attributes: bucketAttributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *RGWUsageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RGWUsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RGWUsageDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := d.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read RGW usage: %s", err),
+		)
+		return
+	}
+
+	var start, end time.Time
+	if !data.Start.IsNull() {
+		start, _ = time.Parse(time.RFC3339, data.Start.ValueString())
+	}
+	if !data.End.IsNull() {
+		end, _ = time.Parse(time.RFC3339, data.End.ValueString())
+	}
+
+	usage, err := cli.RgwUsageShow(ctx, data.UserID.ValueString(), data.Bucket.ValueString(), start, end)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read RGW usage: %s", err),
+		)
+		return
+	}
+
+	data.Users = []RGWUsageUserItem{}
+	for _, summary := range usage.Summary {
+		data.Users = append(data.Users, RGWUsageUserItem{
+			UserID:        types.StringValue(summary.User),
+			BytesSent:     types.Int64Value(summary.Total.BytesSent),
+			BytesReceived: types.Int64Value(summary.Total.BytesReceived),
+			Ops:           types.Int64Value(summary.Total.Ops),
+			SuccessfulOps: types.Int64Value(summary.Total.SuccessfulOps),
+		})
+	}
+
+	data.Buckets = []RGWUsageBucketItem{}
+	for _, total := range rgwUsageBucketTotals(usage) {
+		data.Buckets = append(data.Buckets, RGWUsageBucketItem{
+			UserID:        types.StringValue(total.User),
+			Bucket:        types.StringValue(total.Bucket),
+			BytesSent:     types.Int64Value(total.BytesSent),
+			BytesReceived: types.Int64Value(total.BytesReceived),
+			Ops:           types.Int64Value(total.Ops),
+			SuccessfulOps: types.Int64Value(total.SuccessfulOps),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+type rgwUsageBucketTotal struct {
+	User   string
+	Bucket string
+	RgwUsageCategory
+}
+
+// rgwUsageBucketTotals sums the hourly, per-category usage entries into one
+// total per user and bucket, in the order they first appear.
+func rgwUsageBucketTotals(usage *RgwUsage) []rgwUsageBucketTotal {
+	var totals []rgwUsageBucketTotal
+	index := map[[2]string]int{}
+
+	for _, entry := range usage.Entries {
+		for _, bucket := range entry.Buckets {
+			key := [2]string{entry.User, bucket.Bucket}
+			i, ok := index[key]
+			if !ok {
+				i = len(totals)
+				index[key] = i
+				totals = append(totals, rgwUsageBucketTotal{User: entry.User, Bucket: bucket.Bucket})
+			}
+			for _, category := range bucket.Categories {
+				totals[i].BytesSent += category.BytesSent
+				totals[i].BytesReceived += category.BytesReceived
+				totals[i].Ops += category.Ops
+				totals[i].SuccessfulOps += category.SuccessfulOps
+			}
+		}
+	}
+
+	return totals
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRGWUsageBucketTotals(t *testing.T) {
+	usage := &RgwUsage{
+		Entries: []RgwUsageEntry{
+			{
+				User: "alice",
+				Buckets: []RgwUsageBucket{
+					{
+						Bucket: "photos",
+						Time:   "2025-01-01 10:00:00.000000Z",
+						Categories: []RgwUsageCategory{
+							{Category: "put_obj", BytesReceived: 100, Ops: 2, SuccessfulOps: 2},
+							{Category: "get_obj", BytesSent: 50, Ops: 1, SuccessfulOps: 1},
+						},
+					},
+					{
+						Bucket: "",
+						Time:   "2025-01-01 10:00:00.000000Z",
+						Categories: []RgwUsageCategory{
+							{Category: "list_buckets", BytesSent: 10, Ops: 1, SuccessfulOps: 1},
+						},
+					},
+					{
+						Bucket: "photos",
+						Time:   "2025-01-01 11:00:00.000000Z",
+						Categories: []RgwUsageCategory{
+							{Category: "get_obj", BytesSent: 25, Ops: 2, SuccessfulOps: 1},
+						},
+					},
+				},
+			},
+			{
+				User: "bob",
+				Buckets: []RgwUsageBucket{
+					{
+						Bucket: "photos",
+						Categories: []RgwUsageCategory{
+							{Category: "get_obj", BytesSent: 5, Ops: 1, SuccessfulOps: 1},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []rgwUsageBucketTotal{
+		{User: "alice", Bucket: "photos", RgwUsageCategory: RgwUsageCategory{BytesSent: 75, BytesReceived: 100, Ops: 5, SuccessfulOps: 4}},
+		{User: "alice", Bucket: "", RgwUsageCategory: RgwUsageCategory{BytesSent: 10, Ops: 1, SuccessfulOps: 1}},
+		{User: "bob", Bucket: "photos", RgwUsageCategory: RgwUsageCategory{BytesSent: 5, Ops: 1, SuccessfulOps: 1}},
+	}
+	if got := rgwUsageBucketTotals(usage); !reflect.DeepEqual(got, expected) {
+		t.Errorf("rgwUsageBucketTotals() = %+v, want %+v", got, expected)
+	}
+
+	if got := rgwUsageBucketTotals(&RgwUsage{}); len(got) != 0 {
+		t.Errorf("rgwUsageBucketTotals() = %+v, want empty", got)
+	}
+}
+
+func TestAccCephRGWUsageDataSource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-usage")
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					data "ceph_rgw_usage" "test" {
+					  user_id = %q
+					  start   = "2025-01-01T00:00:00Z"
+					  end     = "2025-01-02T00:00:00Z"
+					}
+				`, testUID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_usage.test", "users.#", "0"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_usage.test", "buckets.#", "0"),
+				),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					data "ceph_rgw_usage" "test" {
+					  start = "yesterday"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`not a valid RFC 3339 timestamp`),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					data "ceph_rgw_usage" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`CLI Backend Required`),
+			},
+		},
+	})
+}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &RGWUserMFAResource{}
+	_ resource.ResourceWithImportState = &RGWUserMFAResource{}
+	_ resource.ResourceWithIdentity    = &RGWUserMFAResource{}
+)
+
+func newRGWUserMFAResource() resource.Resource {
+	return &RGWUserMFAResource{}
+}
+
+// RGWUserMFAResource manages TOTP tokens on an RGW user. Neither the dashboard
+// nor the admin ops API expose MFA, so it always goes through radosgw-admin.
+type RGWUserMFAResource struct {
+	client *CephAPIClient
+}
+
+type RGWUserMFAResourceModel struct {
+	UserID        types.String `tfsdk:"user_id"`
+	Tenant        types.String `tfsdk:"tenant"`
+	Serial        types.String `tfsdk:"serial"`
+	SeedWO        types.String `tfsdk:"seed_wo"`
+	SeedWOVersion types.Int64  `tfsdk:"seed_wo_version"`
+	SeedType      types.String `tfsdk:"seed_type"`
+	Seconds       types.Int64  `tfsdk:"seconds"`
+	Window        types.Int64  `tfsdk:"window"`
+}
+
+type RGWUserMFAResourceIdentityModel struct {
+	UserID types.String `tfsdk:"user_id"`
+	Tenant types.String `tfsdk:"tenant"`
+	Serial types.String `tfsdk:"serial"`
+}
+
+func (m RGWUserMFAResourceModel) identity() RGWUserMFAResourceIdentityModel {
+	return RGWUserMFAResourceIdentityModel{UserID: m.UserID, Tenant: m.Tenant, Serial: m.Serial}
+}
+
+func (r *RGWUserMFAResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_user_mfa"
+}
+
+func (r *RGWUserMFAResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "This resource allows you to manage a TOTP MFA token on a Ceph RGW user, as required by MFA delete on versioned buckets. " +
+			"RGW MFA can only be managed with `radosgw-admin`, so this resource requires the provider `cli_backend` to be enabled.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"user_id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The user the token belongs to, without the tenant",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^$:]+$`), "must be a user ID without a tenant or subuser"),
+				},
+			},
+			"tenant": resourceSchema.StringAttribute{
+				MarkdownDescription: "The tenant of the user. Omit for the default tenant.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"serial": resourceSchema.StringAttribute{
+				MarkdownDescription: "The serial of the token, passed as the first part of the `x-amz-mfa` header",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"seed_wo": resourceSchema.StringAttribute{
+				MarkdownDescription: "The TOTP seed, as a write-only attribute that is never stored in state. Requires Terraform 1.11 or later. Change `seed_wo_version` to recreate the token with a new seed.",
+				Required:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+			},
+			"seed_wo_version": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The version of `seed_wo`. Changing it recreates the token.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"seed_type": resourceSchema.StringAttribute{
+				MarkdownDescription: "The encoding of `seed_wo`, either `hex` or `base32`. Defaults to `hex`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("hex"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("hex", "base32"),
+				},
+			},
+			"seconds": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The TOTP time step in seconds. Defaults to 30.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(30),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"window": resourceSchema.Int64Attribute{
+				MarkdownDescription: "The number of time steps before and after the current one that are also accepted. Defaults to 2.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(2),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+func (r *RGWUserMFAResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"user_id": identityschema.StringAttribute{
+				Description:       "The user the token belongs to, without the tenant",
+				RequiredForImport: true,
+			},
+			"tenant": identityschema.StringAttribute{
+				Description:       "The tenant of the user",
+				OptionalForImport: true,
+			},
+			"serial": identityschema.StringAttribute{
+				Description:       "The serial of the token",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *RGWUserMFAResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RGWUserMFAResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RGWUserMFAResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to manage RGW MFA tokens: %s", err),
+		)
+		return
+	}
+
+	var seed types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("seed_wo"), &seed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uid := rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString())
+	err = cli.RgwMfaCreate(ctx, uid, data.Serial.ValueString(), seed.ValueString(), &RgwMfaCreateOptions{
+		SeedType: data.SeedType.ValueString(),
+		Seconds:  int(data.Seconds.ValueInt64()),
+		Window:   int(data.Window.ValueInt64()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to create RGW MFA token: %s", err),
+		)
+		return
+	}
+
+	data.SeedWO = types.StringNull()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *RGWUserMFAResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RGWUserMFAResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read RGW MFA tokens: %s", err),
+		)
+		return
+	}
+
+	uid := rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString())
+	entries, err := cli.RgwMfaList(ctx, uid)
+	if errors.Is(err, ErrRGWUserNotFound) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("RGW user %s", uid))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read RGW MFA tokens: %s", err),
+		)
+		return
+	}
+
+	entry := findRGWMfaEntry(entries, data.Serial.ValueString())
+	if entry == nil {
+		removeMissingResource(ctx, resp, fmt.Sprintf("MFA token %s of RGW user %s", data.Serial.ValueString(), uid))
+		return
+	}
+
+	updateModelFromRGWMfaEntry(&data, entry)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+// Every attribute requires replacement, so Update only carries the plan over.
+func (r *RGWUserMFAResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RGWUserMFAResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.SeedWO = types.StringNull()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *RGWUserMFAResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RGWUserMFAResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to delete RGW MFA token: %s", err),
+		)
+		return
+	}
+
+	uid := rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString())
+	entries, err := cli.RgwMfaList(ctx, uid)
+	if errors.Is(err, ErrRGWUserNotFound) {
+		return
+	}
+	if err == nil && findRGWMfaEntry(entries, data.Serial.ValueString()) == nil {
+		return
+	}
+
+	if err := cli.RgwMfaRemove(ctx, uid, data.Serial.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to delete RGW MFA token: %s", err),
+		)
+		return
+	}
+}
+
+func (r *RGWUserMFAResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var tenant, userID, serial string
+	if req.ID == "" && req.Identity != nil {
+		var identity RGWUserMFAResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tenant = identity.Tenant.ValueString()
+		userID = identity.UserID.ValueString()
+		serial = identity.Serial.ValueString()
+	} else {
+		var uid string
+		uid, serial, _ = strings.Cut(req.ID, ":")
+		tenant, userID = splitRGWUserID(uid)
+	}
+
+	if userID == "" || serial == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in format '[tenant$]user_id:serial', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+	if tenant != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("serial"), serial)...)
+}
+
+func findRGWMfaEntry(entries []RgwMfaEntry, serial string) *RgwMfaEntry {
+	for i := range entries {
+		if entries[i].ID == serial {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+func updateModelFromRGWMfaEntry(data *RGWUserMFAResourceModel, entry *RgwMfaEntry) {
+	data.Serial = types.StringValue(entry.ID)
+	data.SeedWO = types.StringNull()
+	if entry.SeedType != "" {
+		data.SeedType = types.StringValue(entry.SeedType)
+	}
+	data.Seconds = types.Int64Value(int64(entry.StepSize))
+	data.Window = types.Int64Value(int64(entry.Window))
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const testAccCLIProviderConfigBlock = `
+	variable "endpoint" {
+	  type = string
+	}
+
+	variable "ceph_conf" {
+	  type = string
+	}
+
+	provider "ceph" {
+	  endpoint    = var.endpoint
+	  username    = "admin"
+	  password    = "password"
+	  cli_backend = true
+	  ceph_conf   = var.ceph_conf
+	}
+`
+
+func TestAccCephRGWUserMFAResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-user-mfa")
+	serial := acctest.RandomWithPrefix("totp")
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+	resourceConfig := fmt.Sprintf(`
+		resource "ceph_rgw_user" "test" {
+		  user_id      = %q
+		  display_name = "MFA User"
+		}
+
+		resource "ceph_rgw_user_mfa" "test" {
+		  user_id = ceph_rgw_user.test.user_id
+		  serial  = %q
+		  seed_wo = "23456723456723456723456723456723"
+		}
+	`, testUID, serial)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: testAccCheckCephRGWUserDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + resourceConfig,
+				ExpectError:     regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config:          testAccCLIProviderConfigBlock + resourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rgw_user_mfa.test", tfjsonpath.New("serial"), knownvalue.StringExact(serial)),
+					statecheck.ExpectKnownValue("ceph_rgw_user_mfa.test", tfjsonpath.New("seed_type"), knownvalue.StringExact("hex")),
+					statecheck.ExpectKnownValue("ceph_rgw_user_mfa.test", tfjsonpath.New("seconds"), knownvalue.Int64Exact(30)),
+					statecheck.ExpectKnownValue("ceph_rgw_user_mfa.test", tfjsonpath.New("seed_wo"), knownvalue.Null()),
+				},
+				Check: checkCephRGWUserMFAExists(t, testUID, serial, true),
+			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.RgwMfaRemove(t.Context(), testUID, serial); err != nil {
+						t.Fatalf("Failed to delete MFA device out of band: %v", err)
+					}
+				},
+				ConfigVariables: configVariables,
+				Config:          testAccCLIProviderConfigBlock + resourceConfig,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_user_mfa.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkCephRGWUserMFAExists(t, testUID, serial, true),
+			},
+			{
+				ConfigVariables:                      configVariables,
+				Config:                               testAccCLIProviderConfigBlock + resourceConfig,
+				ResourceName:                         "ceph_rgw_user_mfa.test",
+				ImportState:                          true,
+				ImportStateId:                        testUID + ":" + serial,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "serial",
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_user" "test" {
+					  user_id      = %q
+					  display_name = "MFA User"
+					}
+				`, testUID),
+				Check: checkCephRGWUserMFAExists(t, testUID, serial, false),
+			},
+		},
+	})
+}
+
+func checkCephRGWUserMFAExists(t *testing.T, userID, serial string, expected bool) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		entries, err := cephTestClusterCLI.RgwMfaList(t.Context(), userID)
+		if err != nil {
+			return fmt.Errorf("radosgw-admin failed to list mfa tokens: %w", err)
+		}
+
+		if found := findRGWMfaEntry(entries, serial) != nil; found != expected {
+			return fmt.Errorf("MFA token %s for user %s: exists = %v, want %v", serial, userID, found, expected)
+		}
+		return nil
+	}
+}
+
+func TestAccCephRGWUserMFAResource_invalidImportID(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + `
+					resource "ceph_rgw_user_mfa" "test" {
+					  user_id = "nobody"
+					  serial  = "none"
+					  seed_wo = "23456723456723456723456723456723"
+					}
+				`,
+				ResourceName:  "ceph_rgw_user_mfa.test",
+				ImportState:   true,
+				ImportStateId: "missing-serial",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+		},
+	})
+}
@@ -2,19 +2,35 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-
+	"maps"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &RGWUserResource{}
-	_ resource.ResourceWithImportState = &RGWUserResource{}
+	_ resource.Resource                 = &RGWUserResource{}
+	_ resource.ResourceWithImportState  = &RGWUserResource{}
+	_ resource.ResourceWithIdentity     = &RGWUserResource{}
+	_ resource.ResourceWithUpgradeState = &RGWUserResource{}
+	_ resource.ResourceWithModifyPlan   = &RGWUserResource{}
 )
 
 func newRGWUserResource() resource.Resource {
@@ -26,6 +42,42 @@ type RGWUserResource struct {
 }
 
 type RGWUserResourceModel struct {
+	UserID              types.String   `tfsdk:"user_id"`
+	DisplayName         types.String   `tfsdk:"display_name"`
+	Email               types.String   `tfsdk:"email"`
+	MaxBuckets          types.Int64    `tfsdk:"max_buckets"`
+	System              types.Bool     `tfsdk:"system"`
+	Suspended           types.Bool     `tfsdk:"suspended"`
+	Tenant              types.String   `tfsdk:"tenant"`
+	Admin               types.Bool     `tfsdk:"admin"`
+	OpMask              types.String   `tfsdk:"op_mask"`
+	DefaultPlacement    types.String   `tfsdk:"default_placement"`
+	DefaultStorageClass types.String   `tfsdk:"default_storage_class"`
+	Keys                types.List     `tfsdk:"keys"`
+	AccountID           types.String   `tfsdk:"account_id"`
+	AccountRoot         types.Bool     `tfsdk:"account_root"`
+	PurgeData           types.Bool     `tfsdk:"purge_data"`
+	RGWDaemon           types.String   `tfsdk:"rgw_daemon"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+type RGWUserKeyModel struct {
+	AccessKey  types.String `tfsdk:"access_key"`
+	Active     types.Bool   `tfsdk:"active"`
+	CreateDate types.String `tfsdk:"create_date"`
+}
+
+var rgwUserKeyObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"access_key":  types.StringType,
+		"active":      types.BoolType,
+		"create_date": types.StringType,
+	},
+}
+
+// rgwUserResourceModelV0 is the state layout before the timeouts block was
+// added and admin became configurable.
+type rgwUserResourceModelV0 struct {
 	UserID      types.String `tfsdk:"user_id"`
 	DisplayName types.String `tfsdk:"display_name"`
 	Email       types.String `tfsdk:"email"`
@@ -36,20 +88,29 @@ type RGWUserResourceModel struct {
 	Admin       types.Bool   `tfsdk:"admin"`
 }
 
+type RGWUserResourceIdentityModel struct {
+	UserID types.String `tfsdk:"user_id"`
+	Tenant types.String `tfsdk:"tenant"`
+}
+
 func (r *RGWUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_rgw_user"
 }
 
 func (r *RGWUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceSchema.Schema{
+		Version:             2,
 		MarkdownDescription: "This resource allows you to manage a Ceph RGW user.",
 		Attributes: map[string]resourceSchema.Attribute{
 			"user_id": resourceSchema.StringAttribute{
-				MarkdownDescription: "The user identifier for this RGW user",
+				MarkdownDescription: "The user identifier for this RGW user, without the tenant",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^$]+$`), "must not contain a tenant, use the tenant attribute instead"),
+				},
 			},
 			"display_name": resourceSchema.StringAttribute{
 				MarkdownDescription: "The display name of the user",
@@ -75,13 +136,192 @@ func (r *RGWUserResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Computed:            true,
 			},
 			"tenant": resourceSchema.StringAttribute{
-				MarkdownDescription: "The tenant this user belongs to (empty string for default tenant in multi-tenancy configurations)",
+				MarkdownDescription: "The tenant this user belongs to. Empty for the default tenant.",
+				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"admin": resourceSchema.BoolAttribute{
-				MarkdownDescription: "Whether this user has admin privileges (can only be set via radosgw-admin CLI)",
+				MarkdownDescription: "Whether this user has admin privileges. The dashboard API cannot change this flag, so setting it requires the provider `cli_backend` to be enabled.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"op_mask": resourceSchema.StringAttribute{
+				MarkdownDescription: "The operations this user may perform, as a comma-separated list of `read`, `write` and `delete` (or `*` for all). Set to `read` for a read-only user.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(rgwOpMaskRegexp, "must be a comma-separated list of read, write, delete or *"),
+				},
+			},
+			"default_placement": resourceSchema.StringAttribute{
+				MarkdownDescription: "The placement target used for new buckets created by this user. Empty uses the zonegroup default.",
+				Optional:            true,
 				Computed:            true,
 			},
+			"default_storage_class": resourceSchema.StringAttribute{
+				MarkdownDescription: "The storage class used for new objects written by this user. Empty uses the placement target default.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"account_id": resourceSchema.StringAttribute{
+				MarkdownDescription: "The ID of the RGW account this user belongs to (Ceph Squid and later). An existing user can be moved into an account, but RGW cannot move it between accounts, so changing a set account recreates the user.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.ValueString() != ""
+						},
+						"Users cannot leave an RGW account, so changing the account replaces the user.",
+						"Users cannot leave an RGW account, so changing the account replaces the user.",
+					),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(rgwAccountIDRegexp, "must be RGW followed by 17 digits"),
+				},
+			},
+			"account_root": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether this user is the root user of its account, with full access to the account's resources. Requires `account_id`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("account_id")),
+				},
+			},
+			"purge_data": resourceSchema.BoolAttribute{
+				MarkdownDescription: "Whether to delete the user's buckets and objects when the user is destroyed. Without it, destroying a user that still owns buckets fails. Purging through the dashboard API requires the provider `cli_backend` to be enabled. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"rgw_daemon": resourceSchema.StringAttribute{
+				MarkdownDescription: "The radosgw daemon the dashboard sends this user's requests to, overriding the provider `rgw_daemon`. In a multisite cluster, set it to a daemon of the zone the user should be managed in. Has no effect when the provider uses `rgw_admin_endpoint`.",
+				Optional:            true,
+			},
+			"keys": resourceSchema.ListNestedAttribute{
+				MarkdownDescription: "The S3 keys currently attached to this user, including keys not managed by Terraform. Secrets are not exposed.",
+				Computed:            true,
+				NestedObject: resourceSchema.NestedAttributeObject{
+					Attributes: map[string]resourceSchema.Attribute{
+						"access_key": resourceSchema.StringAttribute{
+							MarkdownDescription: "The access key ID",
+							Computed:            true,
+						},
+						"active": resourceSchema.BoolAttribute{
+							MarkdownDescription: "Whether the key is active",
+							Computed:            true,
+						},
+						"create_date": resourceSchema.StringAttribute{
+							MarkdownDescription: "The creation date of the key",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]resourceSchema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *RGWUserResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &resourceSchema.Schema{
+				Attributes: map[string]resourceSchema.Attribute{
+					"user_id":      resourceSchema.StringAttribute{Required: true},
+					"display_name": resourceSchema.StringAttribute{Required: true},
+					"email":        resourceSchema.StringAttribute{Optional: true},
+					"max_buckets":  resourceSchema.Int64Attribute{Optional: true, Computed: true},
+					"system":       resourceSchema.BoolAttribute{Optional: true, Computed: true},
+					"suspended":    resourceSchema.BoolAttribute{Optional: true, Computed: true},
+					"tenant":       resourceSchema.StringAttribute{Computed: true},
+					"admin":        resourceSchema.BoolAttribute{Computed: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior rgwUserResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := RGWUserResourceModel{
+					UserID:      prior.UserID,
+					DisplayName: prior.DisplayName,
+					Email:       prior.Email,
+					MaxBuckets:  prior.MaxBuckets,
+					System:      prior.System,
+					Suspended:   prior.Suspended,
+					Tenant:      prior.Tenant,
+					Admin:       prior.Admin,
+					Keys:        types.ListNull(rgwUserKeyObjectType),
+					Timeouts: timeouts.Value{
+						Object: types.ObjectNull(map[string]attr.Type{
+							"create": types.StringType,
+							"update": types.StringType,
+							"delete": types.StringType,
+						}),
+					},
+				}
+				splitRGWUserModelTenant(&upgraded)
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+		// Version 1 kept tenanted users as "tenant$uid" in user_id.
+		1: {
+			PriorSchema: &schemaResp.Schema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var data RGWUserResourceModel
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				splitRGWUserModelTenant(&data)
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+			},
+		},
+	}
+}
+
+func splitRGWUserModelTenant(data *RGWUserResourceModel) {
+	if tenant, uid := splitRGWUserID(data.UserID.ValueString()); tenant != "" {
+		data.UserID = types.StringValue(uid)
+		data.Tenant = types.StringValue(tenant)
+	}
+}
+
+func (r *RGWUserResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"user_id": identityschema.StringAttribute{
+				Description:       "The user identifier for the RGW user, without the tenant",
+				RequiredForImport: true,
+			},
+			"tenant": identityschema.StringAttribute{
+				Description:       "The tenant of the RGW user",
+				OptionalForImport: true,
+			},
 		},
 	}
 }
@@ -104,6 +344,23 @@ func (r *RGWUserResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.client = client
 }
 
+// ModifyPlan rejects account_id on releases without RGW accounts.
+func (r *RGWUserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var accountID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("account_id"), &accountID)...)
+	if resp.Diagnostics.HasError() || accountID.IsNull() {
+		return
+	}
+
+	if err := r.client.requireRelease("RGW accounts", cephReleaseSquid); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("account_id"), "Unsupported Ceph Release", err.Error())
+	}
+}
+
 func (r *RGWUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data RGWUserResourceModel
 
@@ -113,8 +370,19 @@ func (r *RGWUserResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	ctx = withRGWDaemon(ctx, data.RGWDaemon.ValueString())
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	createReq := CephAPIRGWUserCreateRequest{
-		UID:         data.UserID.ValueString(),
+		UID:         rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString()),
 		DisplayName: data.DisplayName.ValueString(),
 	}
 
@@ -141,8 +409,46 @@ func (r *RGWUserResource) Create(ctx context.Context, req resource.CreateRequest
 		createReq.Suspended = &suspended
 	}
 
+	if !data.OpMask.IsNull() && !data.OpMask.IsUnknown() {
+		opMask := data.OpMask.ValueString()
+		createReq.OpMask = &opMask
+	}
+
+	if !data.DefaultPlacement.IsNull() && !data.DefaultPlacement.IsUnknown() {
+		defaultPlacement := data.DefaultPlacement.ValueString()
+		createReq.DefaultPlacement = &defaultPlacement
+	}
+
+	if !data.DefaultStorageClass.IsNull() && !data.DefaultStorageClass.IsUnknown() {
+		defaultStorageClass := data.DefaultStorageClass.ValueString()
+		createReq.DefaultStorageClass = &defaultStorageClass
+	}
+
+	if !data.AccountID.IsNull() && !data.AccountID.IsUnknown() {
+		accountID := data.AccountID.ValueString()
+		createReq.AccountID = &accountID
+	}
+
+	if !data.AccountRoot.IsNull() && !data.AccountRoot.IsUnknown() {
+		accountRoot := data.AccountRoot.ValueBool()
+		createReq.AccountRootUser = &accountRoot
+	}
+
 	createReq.GenerateKey = false
 
+	// Check the CLI backend up front so a missing backend doesn't leave a
+	// half-configured user behind.
+	if !data.Admin.IsNull() && !data.Admin.IsUnknown() && data.Admin.ValueBool() {
+		if _, err := r.client.CLI(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("admin"),
+				"CLI Backend Required",
+				fmt.Sprintf("Unable to set admin on RGW user: %s", err),
+			)
+			return
+		}
+	}
+
 	user, err := r.client.RGWCreateUser(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -152,9 +458,22 @@ func (r *RGWUserResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	updateModelFromAPIUser(&data, user)
+	user, err = r.setUserAdmin(ctx, data.Admin, user)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to set admin on RGW user: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(updateModelFromAPIUser(ctx, &data, user)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWUserResourceIdentityModel{UserID: data.UserID, Tenant: data.Tenant})...)
 }
 
 func (r *RGWUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -166,8 +485,14 @@ func (r *RGWUserResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	userID := data.UserID.ValueString()
+	ctx = withRGWDaemon(ctx, data.RGWDaemon.ValueString())
+
+	userID := rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString())
 	user, err := r.client.RGWGetUser(ctx, userID)
+	if isCephAPINotFound(err) {
+		removeMissingResource(ctx, resp, fmt.Sprintf("RGW user %s", userID))
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"API Request Error",
@@ -176,21 +501,42 @@ func (r *RGWUserResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	updateModelFromAPIUser(&data, user)
+	resp.Diagnostics.Append(updateModelFromAPIUser(ctx, &data, user)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported and upgraded state has no purge_data yet.
+	if data.PurgeData.IsNull() {
+		data.PurgeData = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWUserResourceIdentityModel{UserID: data.UserID, Tenant: data.Tenant})...)
 }
 
 func (r *RGWUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data RGWUserResourceModel
+	var data, state RGWUserResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withRGWDaemon(ctx, data.RGWDaemon.ValueString())
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	userID := data.UserID.ValueString()
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	userID := rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString())
 	updateReq := CephAPIRGWUserUpdateRequest{}
 
 	if !data.DisplayName.IsNull() && !data.DisplayName.IsUnknown() {
@@ -221,6 +567,32 @@ func (r *RGWUserResource) Update(ctx context.Context, req resource.UpdateRequest
 		updateReq.Suspended = &suspended
 	}
 
+	if !data.OpMask.IsNull() && !data.OpMask.IsUnknown() {
+		opMask := data.OpMask.ValueString()
+		updateReq.OpMask = &opMask
+	}
+
+	if !data.DefaultPlacement.IsNull() && !data.DefaultPlacement.IsUnknown() {
+		defaultPlacement := data.DefaultPlacement.ValueString()
+		updateReq.DefaultPlacement = &defaultPlacement
+	}
+
+	if !data.DefaultStorageClass.IsNull() && !data.DefaultStorageClass.IsUnknown() {
+		defaultStorageClass := data.DefaultStorageClass.ValueString()
+		updateReq.DefaultStorageClass = &defaultStorageClass
+	}
+
+	// Only an existing user outside any account can be moved into one.
+	if !data.AccountID.IsNull() && !data.AccountID.IsUnknown() && !data.AccountID.Equal(state.AccountID) {
+		accountID := data.AccountID.ValueString()
+		updateReq.AccountID = &accountID
+	}
+
+	if !data.AccountRoot.IsNull() && !data.AccountRoot.IsUnknown() {
+		accountRoot := data.AccountRoot.ValueBool()
+		updateReq.AccountRootUser = &accountRoot
+	}
+
 	if !data.Suspended.IsNull() && !data.Suspended.IsUnknown() {
 		suspended := 0
 		if data.Suspended.ValueBool() {
@@ -238,9 +610,22 @@ func (r *RGWUserResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	updateModelFromAPIUser(&data, user)
+	user, err = r.setUserAdmin(ctx, data.Admin, user)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to set admin on RGW user: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(updateModelFromAPIUser(ctx, &data, user)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RGWUserResourceIdentityModel{UserID: data.UserID, Tenant: data.Tenant})...)
 }
 
 func (r *RGWUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -252,9 +637,20 @@ func (r *RGWUserResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	userID := data.UserID.ValueString()
-	err := r.client.RGWDeleteUser(ctx, userID)
-	if err != nil {
+	ctx = withRGWDaemon(ctx, data.RGWDaemon.ValueString())
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	userID := rgwUserID(data.Tenant.ValueString(), data.UserID.ValueString())
+	err := r.client.RGWDeleteUser(ctx, userID, data.PurgeData.ValueBool())
+	if err != nil && !isCephAPINotFound(err) && !errors.Is(err, ErrRGWUserNotFound) {
 		resp.Diagnostics.AddError(
 			"API Request Error",
 			fmt.Sprintf("Unable to delete RGW user: %s", err),
@@ -264,11 +660,46 @@ func (r *RGWUserResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *RGWUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("user_id"), req, resp)
+	if req.ID == "" && req.Identity != nil {
+		var identity RGWUserResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), identity.UserID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), identity.Tenant)...)
+		return
+	}
+
+	// Accept "uid" as well as "tenant$uid" for users outside the default tenant.
+	tenant, uid := splitRGWUserID(req.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), uid)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+}
+
+// setUserAdmin applies the configured admin flag through the CLI backend when
+// it differs from the user's current value, and returns the refreshed user.
+func (r *RGWUserResource) setUserAdmin(ctx context.Context, admin types.Bool, user CephAPIRGWUser) (CephAPIRGWUser, error) {
+	if admin.IsNull() || admin.IsUnknown() || admin.ValueBool() == user.Admin {
+		return user, nil
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		return user, err
+	}
+
+	adminValue := admin.ValueBool()
+	if err := cli.RgwUserModify(ctx, user.UserID, &RgwUserModifyOptions{Admin: &adminValue}); err != nil {
+		return user, err
+	}
+
+	return r.client.RGWGetUser(ctx, user.UserID)
 }
 
-func updateModelFromAPIUser(data *RGWUserResourceModel, user CephAPIRGWUser) {
-	data.UserID = types.StringValue(user.UserID)
+func updateModelFromAPIUser(ctx context.Context, data *RGWUserResourceModel, user CephAPIRGWUser) diag.Diagnostics {
+	_, uid := splitRGWUserID(user.UserID)
+	data.UserID = types.StringValue(uid)
 	data.DisplayName = types.StringValue(user.DisplayName)
 	switch {
 	case user.Email != "":
@@ -283,4 +714,48 @@ func updateModelFromAPIUser(data *RGWUserResourceModel, user CephAPIRGWUser) {
 	data.Admin = types.BoolValue(user.Admin)
 	data.Suspended = types.BoolValue(user.Suspended == 1)
 	data.Tenant = types.StringValue(user.Tenant)
+	// RGW reports the mask in its own canonical spelling, e.g. "*" comes
+	// back as "read, write, delete".
+	if data.OpMask.IsNull() || data.OpMask.IsUnknown() || !rgwOpMaskEqual(data.OpMask.ValueString(), user.OpMask) {
+		data.OpMask = types.StringValue(user.OpMask)
+	}
+	data.DefaultPlacement = types.StringValue(user.DefaultPlacement)
+	data.DefaultStorageClass = types.StringValue(user.DefaultStorageClass)
+	data.AccountID = types.StringValue(user.AccountID)
+	data.AccountRoot = types.BoolValue(user.Type == "root")
+
+	keys := make([]RGWUserKeyModel, 0, len(user.Keys))
+	for _, key := range user.Keys {
+		keys = append(keys, RGWUserKeyModel{
+			AccessKey:  types.StringValue(key.AccessKey),
+			Active:     types.BoolValue(key.Active),
+			CreateDate: types.StringValue(key.CreateDate),
+		})
+	}
+
+	keysValue, diags := types.ListValueFrom(ctx, rgwUserKeyObjectType, keys)
+	data.Keys = keysValue
+	return diags
+}
+
+var rgwOpMaskRegexp = regexp.MustCompile(`^[\s,]*((read|write|delete|\*)[\s,]*)*$`)
+
+// parseRGWOpMask turns an op mask string into the set of operations it
+// grants, mirroring rgw_parse_op_type_list.
+func parseRGWOpMask(mask string) map[string]bool {
+	ops := map[string]bool{}
+	for _, op := range strings.FieldsFunc(mask, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	}) {
+		if op == "*" {
+			ops["read"], ops["write"], ops["delete"] = true, true, true
+			continue
+		}
+		ops[op] = true
+	}
+	return ops
+}
+
+func rgwOpMaskEqual(a, b string) bool {
+	return maps.Equal(parseRGWOpMask(a), parseRGWOpMask(b))
 }
@@ -7,12 +7,21 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccCephRGWUserResource(t *testing.T) {
@@ -291,6 +300,155 @@ func TestAccCephRGWUserResource_systemUser(t *testing.T) {
 	})
 }
 
+func TestAccCephRGWUserResource_opMaskAndPlacement(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-opmask")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWUserDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_user" "test" {
+					  user_id               = %q
+					  display_name          = "Read Only User"
+					  op_mask               = "read"
+					  default_placement     = "default-placement"
+					  default_storage_class = "STANDARD"
+					}
+				`, testUID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_rgw_user.test",
+						tfjsonpath.New("op_mask"),
+						knownvalue.StringExact("read"),
+					),
+					statecheck.ExpectKnownValue(
+						"ceph_rgw_user.test",
+						tfjsonpath.New("default_placement"),
+						knownvalue.StringExact("default-placement"),
+					),
+					statecheck.ExpectKnownValue(
+						"ceph_rgw_user.test",
+						tfjsonpath.New("default_storage_class"),
+						knownvalue.StringExact("STANDARD"),
+					),
+				},
+				Check: checkCephRGWUserExists(t, testUID),
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_user" "test" {
+					  user_id               = %q
+					  display_name          = "Read Only User"
+					  op_mask               = "*"
+					  default_placement     = "default-placement"
+					  default_storage_class = "STANDARD"
+					}
+				`, testUID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"ceph_rgw_user.test",
+						tfjsonpath.New("op_mask"),
+						knownvalue.StringExact("*"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccCephRGWUserResource_purgeData(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-user-purge")
+	testBucket := acctest.RandomWithPrefix("test-bucket-purge")
+
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0),
+		},
+		CheckDestroy: testAccCheckCephRGWUserDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_user" "test" {
+					  user_id      = %q
+					  display_name = "Purge Test User"
+					  purge_data   = true
+					}
+
+					resource "ceph_rgw_s3_key" "test" {
+					  user_id = ceph_rgw_user.test.user_id
+					}
+
+					resource "ceph_rgw_bucket" "test" {
+					  bucket     = %q
+					  owner      = ceph_rgw_user.test.user_id
+					  depends_on = [ceph_rgw_s3_key.test]
+					}
+				`, testUID, testBucket),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "purge_data", "true"),
+					checkCephRGWBucketExists(t, testBucket),
+				),
+			},
+			{
+				// Forget the bucket so destroying the user has to purge it.
+				ConfigVariables: configVariables,
+				Config: testAccCLIProviderConfigBlock + `
+					removed {
+					  from = ceph_rgw_bucket.test
+
+					  lifecycle {
+					    destroy = false
+					  }
+					}
+				`,
+				Check: func(s *terraform.State) error {
+					if _, err := cephTestClusterCLI.RgwBucketInfo(t.Context(), testBucket); err == nil {
+						return fmt.Errorf("bucket %s still exists after purging user %s", testBucket, testUID)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestRGWOpMaskEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"read", "read", true},
+		{"*", "read, write, delete", true},
+		{"write,read", "read, write", true},
+		{"read", "read, write", false},
+		{"", "", true},
+		{"", "read", false},
+	}
+
+	for _, tt := range tests {
+		if got := rgwOpMaskEqual(tt.a, tt.b); got != tt.want {
+			t.Errorf("rgwOpMaskEqual(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
 func TestAccCephRGWUserResourceImport(t *testing.T) {
 	detachLogs := cephDaemonLogs.AttachTestFunction(t)
 	defer detachLogs()
@@ -351,7 +509,7 @@ func TestAccCephRGWUserResourceImport_nonExistent(t *testing.T) {
 				ResourceName:  "ceph_rgw_user.nonexistent",
 				ImportState:   true,
 				ImportStateId: testUID,
-				ExpectError:   regexp.MustCompile(`(?i)unable to read rgw user`),
+				ExpectError:   regexp.MustCompile(`(?i)cannot import non-existent remote object`),
 			},
 		},
 	})
@@ -395,7 +553,7 @@ func testAccCheckCephRGWUserDestroy(t *testing.T) resource.TestCheckFunc {
 				continue
 			}
 
-			userID := rs.Primary.Attributes["user_id"]
+			userID := rgwUserID(rs.Primary.Attributes["tenant"], rs.Primary.Attributes["user_id"])
 
 			_, err := cephTestClusterCLI.RgwUserInfo(ctx, userID)
 			if err == nil {
@@ -476,6 +634,16 @@ func TestAccCephRGWUserResource_managedS3Keys(t *testing.T) {
 					resource.TestCheckResourceAttr("ceph_rgw_s3_key.test", "user_id", testUID),
 				),
 			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "keys.#", "1"),
+					resource.TestCheckResourceAttrPair("ceph_rgw_user.test", "keys.0.access_key", "ceph_rgw_s3_key.test", "access_key"),
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "keys.0.active", "true"),
+					resource.TestCheckResourceAttrSet("ceph_rgw_user.test", "keys.0.create_date"),
+					resource.TestCheckNoResourceAttr("ceph_rgw_user.test", "keys.0.secret_key"),
+				),
+			},
 		},
 	})
 }
@@ -992,6 +1160,336 @@ func TestAccCephRGWUserResource_driftDetection(t *testing.T) {
 					resource.TestCheckResourceAttr("ceph_rgw_user.test", "max_buckets", "100"),
 				),
 			},
+			{
+				PreConfig: func() {
+					if err := cephTestClusterCLI.RgwUserRemove(t.Context(), testUID, false); err != nil {
+						t.Fatalf("Failed to delete user out of band: %v", err)
+					}
+				},
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_user" "test" {
+					  user_id      = %q
+					  display_name = "Original Display Name"
+					  max_buckets  = 100
+					}
+				`, testUID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_user.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkCephRGWUserExists(t, testUID),
+			},
+		},
+	})
+}
+
+func TestAccCephRGWUserResource_admin(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-user-admin")
+
+	configBlock := `
+		variable "endpoint" {
+		  type = string
+		}
+
+		variable "ceph_conf" {
+		  type = string
+		}
+
+		provider "ceph" {
+		  endpoint    = var.endpoint
+		  username    = "admin"
+		  password    = "password"
+		  cli_backend = true
+		  ceph_conf   = var.ceph_conf
+		}
+	`
+	configVariables := config.Variables{
+		"endpoint":  config.StringVariable(testDashboardURL),
+		"ceph_conf": config.StringVariable(testConfPath),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWUserDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config: testAccProviderConfigBlock + fmt.Sprintf(`
+					resource "ceph_rgw_user" "test" {
+					  user_id      = %q
+					  display_name = "Admin User"
+					  admin        = true
+					}
+				`, testUID),
+				ExpectError: regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: configBlock + fmt.Sprintf(`
+					resource "ceph_rgw_user" "test" {
+					  user_id      = %q
+					  display_name = "Admin User"
+					  admin        = true
+					}
+				`, testUID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephRGWUserAdmin(t, testUID, true),
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "admin", "true"),
+				),
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: configBlock + fmt.Sprintf(`
+					resource "ceph_rgw_user" "test" {
+					  user_id      = %q
+					  display_name = "Admin User"
+					  admin        = false
+					}
+				`, testUID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCephRGWUserAdmin(t, testUID, false),
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "admin", "false"),
+				),
+			},
+		},
+	})
+}
+
+func checkCephRGWUserAdmin(t *testing.T, userID string, expected bool) resource.TestCheckFunc {
+	t.Helper()
+	return func(s *terraform.State) error {
+		user, err := cephTestClusterCLI.RgwUserInfo(t.Context(), userID)
+		if err != nil {
+			return fmt.Errorf("radosgw-admin failed to get user info: %w", err)
+		}
+		if user.Admin != expected {
+			return fmt.Errorf("expected admin=%v for user %s, got %v", expected, userID, user.Admin)
+		}
+		return nil
+	}
+}
+
+func TestAccCephRGWUserResource_identity(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	testUID := acctest.RandomWithPrefix("test-user-identity")
+	testConfig := testAccProviderConfigBlock + fmt.Sprintf(`
+		resource "ceph_rgw_user" "test" {
+		  user_id      = %q
+		  display_name = "Identity User"
+		}
+	`, testUID)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCephRGWUserDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("ceph_rgw_user.test", map[string]knownvalue.Check{
+						"user_id": knownvalue.StringExact(testUID),
+					}),
+				},
+			},
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testConfig,
+				ResourceName:    "ceph_rgw_user.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestRGWUserResourceUpgradeStateV0(t *testing.T) {
+	ctx := t.Context()
+	r := &RGWUserResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	upgrader := r.UpgradeState(ctx)[0]
+
+	prior := tfsdk.State{
+		Schema: upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	diags := prior.Set(ctx, rgwUserResourceModelV0{
+		UserID:      types.StringValue("test-user"),
+		DisplayName: types.StringValue("Test User"),
+		Email:       types.StringNull(),
+		MaxBuckets:  types.Int64Value(1000),
+		System:      types.BoolValue(false),
+		Suspended:   types.BoolValue(false),
+		Tenant:      types.StringValue(""),
+		Admin:       types.BoolValue(true),
+	})
+	if diags.HasError() {
+		t.Fatalf("unable to set prior state: %v", diags)
+	}
+
+	resp := fwresource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &prior}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("StateUpgrader() diagnostics = %v", resp.Diagnostics)
+	}
+
+	var upgraded RGWUserResourceModel
+	if diags := resp.State.Get(ctx, &upgraded); diags.HasError() {
+		t.Fatalf("unable to get upgraded state: %v", diags)
+	}
+	if upgraded.UserID.ValueString() != "test-user" || upgraded.MaxBuckets.ValueInt64() != 1000 || !upgraded.Admin.ValueBool() {
+		t.Errorf("upgraded state = %+v", upgraded)
+	}
+	if !upgraded.Email.IsNull() || !upgraded.Timeouts.IsNull() {
+		t.Errorf("upgraded state = %+v, want null email and timeouts", upgraded)
+	}
+}
+
+func TestRGWUserResourceUpgradeStateV1(t *testing.T) {
+	ctx := t.Context()
+	r := &RGWUserResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	upgrader := r.UpgradeState(ctx)[1]
+
+	prior := tfsdk.State{
+		Schema: upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	diags := prior.Set(ctx, RGWUserResourceModel{
+		UserID:      types.StringValue("acme$test-user"),
+		DisplayName: types.StringValue("Test User"),
+		Email:       types.StringNull(),
+		MaxBuckets:  types.Int64Value(1000),
+		System:      types.BoolValue(false),
+		Suspended:   types.BoolValue(false),
+		Tenant:      types.StringValue("acme"),
+		Admin:       types.BoolValue(false),
+		Keys:        types.ListNull(rgwUserKeyObjectType),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"update": types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("unable to set prior state: %v", diags)
+	}
+
+	resp := fwresource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &prior}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("StateUpgrader() diagnostics = %v", resp.Diagnostics)
+	}
+
+	var upgraded RGWUserResourceModel
+	if diags := resp.State.Get(ctx, &upgraded); diags.HasError() {
+		t.Fatalf("unable to get upgraded state: %v", diags)
+	}
+	if upgraded.UserID.ValueString() != "test-user" || upgraded.Tenant.ValueString() != "acme" {
+		t.Errorf("upgraded state = %+v, want user_id test-user in tenant acme", upgraded)
+	}
+}
+
+func TestAccCephRGWUserResource_tenant(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	tenant := acctest.RandomWithPrefix("tenant")
+	testUID := acctest.RandomWithPrefix("test-user-tenant")
+	bucketName := acctest.RandomWithPrefix("test-bucket-tenant")
+
+	resourceConfig := testAccProviderConfigBlock + fmt.Sprintf(`
+		resource "ceph_rgw_user" "test" {
+		  user_id      = %q
+		  tenant       = %q
+		  display_name = "Tenant User"
+		}
+
+		resource "ceph_rgw_s3_key" "test" {
+		  user_id = ceph_rgw_user.test.user_id
+		  tenant  = ceph_rgw_user.test.tenant
+		}
+
+		resource "ceph_rgw_bucket" "test" {
+		  bucket = %q
+		  owner  = ceph_rgw_user.test.user_id
+		  tenant = ceph_rgw_user.test.tenant
+
+		  depends_on = [ceph_rgw_s3_key.test]
+		}
+	`, testUID, tenant, bucketName)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckCephRGWBucketDestroy(t),
+			testAccCheckCephRGWS3KeyDestroy(t),
+			testAccCheckCephRGWUserDestroy(t),
+		),
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          resourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ceph_rgw_user.test", tfjsonpath.New("user_id"), knownvalue.StringExact(testUID)),
+					statecheck.ExpectKnownValue("ceph_rgw_user.test", tfjsonpath.New("tenant"), knownvalue.StringExact(tenant)),
+					statecheck.ExpectKnownValue("ceph_rgw_bucket.test", tfjsonpath.New("bucket"), knownvalue.StringExact(bucketName)),
+					statecheck.ExpectKnownValue("ceph_rgw_bucket.test", tfjsonpath.New("owner"), knownvalue.StringExact(testUID)),
+				},
+				Check: func(s *terraform.State) error {
+					if _, err := cephTestClusterCLI.RgwUserInfo(t.Context(), tenant+"$"+testUID); err != nil {
+						return err
+					}
+					_, err := cephTestClusterCLI.RgwBucketInfo(t.Context(), tenant+"/"+bucketName)
+					return err
+				},
+			},
+			{
+				ConfigVariables:                      testAccProviderConfig(),
+				Config:                               resourceConfig,
+				ResourceName:                         "ceph_rgw_user.test",
+				ImportState:                          true,
+				ImportStateId:                        tenant + "$" + testUID,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "user_id",
+			},
+			{
+				ConfigVariables:                      testAccProviderConfig(),
+				Config:                               resourceConfig,
+				ResourceName:                         "ceph_rgw_bucket.test",
+				ImportState:                          true,
+				ImportStateId:                        tenant + "/" + bucketName,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "bucket",
+			},
 		},
 	})
 }
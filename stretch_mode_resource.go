@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const stretchModeResourceID = "stretch_mode"
+
+var _ resource.Resource = &StretchModeResource{}
+
+func newStretchModeResource() resource.Resource {
+	return &StretchModeResource{}
+}
+
+// StretchModeResource enables stretch mode for two-site clusters. The
+// dashboard API does not expose it, so it always goes through the ceph CLI.
+// It cannot be imported because the mon map does not record the CRUSH rule
+// and dividing bucket stretch mode was enabled with.
+type StretchModeResource struct {
+	client *CephAPIClient
+}
+
+type StretchModeResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	TiebreakerMon  types.String `tfsdk:"tiebreaker_mon"`
+	CrushRule      types.String `tfsdk:"crush_rule"`
+	DividingBucket types.String `tfsdk:"dividing_bucket"`
+	DisableRule    types.String `tfsdk:"disable_crush_rule"`
+}
+
+func (r *StretchModeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_stretch_mode"
+}
+
+func (r *StretchModeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema.Schema{
+		MarkdownDescription: "Enables stretch mode, which keeps a cluster split across two sites available when one site is lost. " +
+			"Every monitor must have a CRUSH location (see `ceph_mon_crush_location`), the election strategy must be `connectivity` (see `ceph_mon_election_strategy`), and the CRUSH rule must place two copies in each site. " +
+			"Stretch mode is only available through the ceph CLI, so this resource requires the provider `cli_backend` to be enabled. " +
+			"Destroying the resource disables stretch mode, which requires Ceph Squid or later.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"id": resourceSchema.StringAttribute{
+				MarkdownDescription: "Always `stretch_mode`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tiebreaker_mon": resourceSchema.StringAttribute{
+				MarkdownDescription: "The name of the monitor at the third site that breaks ties between the two data sites",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"crush_rule": resourceSchema.StringAttribute{
+				MarkdownDescription: "The CRUSH rule all replicated pools are switched to when stretch mode is enabled",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"dividing_bucket": resourceSchema.StringAttribute{
+				MarkdownDescription: "The CRUSH bucket type that separates the two sites, usually `datacenter`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"disable_crush_rule": resourceSchema.StringAttribute{
+				MarkdownDescription: "The CRUSH rule pools are moved to when stretch mode is disabled. Defaults to the default replicated rule.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *StretchModeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CephAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CephAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *StretchModeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StretchModeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to enable stretch mode: %s", err),
+		)
+		return
+	}
+
+	err = cli.MonEnableStretchMode(ctx, data.TiebreakerMon.ValueString(), data.CrushRule.ValueString(), data.DividingBucket.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to enable stretch mode: %s", err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(stretchModeResourceID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StretchModeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StretchModeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to read stretch mode: %s", err),
+		)
+		return
+	}
+
+	monDump, err := cli.MonDump(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to read stretch mode: %s", err),
+		)
+		return
+	}
+
+	if !monDump.StretchMode {
+		removeMissingResource(ctx, resp, "Stretch mode")
+		return
+	}
+
+	// The mon map only records the tiebreaker; the rule and dividing bucket
+	// are kept from state.
+	data.TiebreakerMon = types.StringValue(monDump.TiebreakerMon)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Only disable_crush_rule can change in place, and it is only used on Delete.
+func (r *StretchModeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data StretchModeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StretchModeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StretchModeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.requireRelease("Disabling stretch mode", cephReleaseSquid); err != nil {
+		resp.Diagnostics.AddError(
+			"Unsupported Ceph Release",
+			fmt.Sprintf("%s. Remove the resource from state with terraform state rm to leave stretch mode enabled.", err),
+		)
+		return
+	}
+
+	cli, err := r.client.CLI()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Backend Required",
+			fmt.Sprintf("Unable to disable stretch mode: %s", err),
+		)
+		return
+	}
+
+	if err := cli.MonDisableStretchMode(ctx, data.DisableRule.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"CLI Request Error",
+			fmt.Sprintf("Unable to disable stretch mode: %s", err),
+		)
+		return
+	}
+}
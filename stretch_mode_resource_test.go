@@ -0,0 +1,44 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The test cluster has a single monitor, so stretch mode can never actually
+// be enabled, or disabled out of band; this covers the CLI requirement and
+// the error path.
+func TestAccCephStretchModeResource(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	resourceConfig := `
+		resource "ceph_stretch_mode" "test" {
+		  tiebreaker_mon  = "a"
+		  crush_rule      = "replicated_rule"
+		  dividing_bucket = "datacenter"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: testAccProviderConfig(),
+				Config:          testAccProviderConfigBlock + resourceConfig,
+				ExpectError:     regexp.MustCompile(`(?i)requires the CLI backend`),
+			},
+			{
+				ConfigVariables: config.Variables{
+					"endpoint":  config.StringVariable(testDashboardURL),
+					"ceph_conf": config.StringVariable(testConfPath),
+				},
+				Config:      testAccCLIProviderConfigBlock + resourceConfig,
+				ExpectError: regexp.MustCompile(`Unable to enable stretch mode`),
+			},
+		},
+	})
+}
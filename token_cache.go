@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/argon2"
+)
+
+// tokenCacheMinValidity is how long a cached token must remain valid to be
+// reused, so it does not expire in the middle of a plan or apply.
+const tokenCacheMinValidity = 10 * time.Minute
+
+// memoryTokens is shared by every provider instance in the plugin process.
+var memoryTokens sync.Map
+
+// tokenCache keeps dashboard tokens obtained with a username and password so
+// other provider instances targeting the same cluster can reuse them instead
+// of logging in again. Tokens are kept in memory, and also on disk when dir
+// is set. A nil cache stores nothing.
+type tokenCache struct {
+	dir string
+}
+
+// tokenCacheKey identifies the credentials a token was issued for in memory.
+// The password is part of the key so a changed password is never masked by a
+// token cached for the old one.
+func tokenCacheKey(endpoint, username, password string) string {
+	sum := sha256.Sum256([]byte(endpoint + "\x00" + username + "\x00" + password))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenCacheFileName names the file caching the token of a user. It leaves
+// out the password, since anyone able to list the cache directory could test
+// guesses against a hash of it; the password is checked against the
+// tokenFile instead.
+func tokenCacheFileName(endpoint, username string) string {
+	sum := sha256.Sum256([]byte(endpoint + "\x00" + username))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenFile is the content of a token cache file.
+type tokenFile struct {
+	Token string `json:"token"`
+	// Salt and PasswordHash, the argon2id hash of the password with Salt,
+	// tell whether Token was issued for the current password.
+	Salt         []byte `json:"salt"`
+	PasswordHash []byte `json:"password_hash"`
+}
+
+// tokenPasswordHash hashes a password with argon2id, using the parameters
+// RFC 9106 recommends when memory is constrained, so that a stolen cache
+// file is slow to test password guesses against.
+func tokenPasswordHash(salt []byte, password string) []byte {
+	return argon2.IDKey([]byte(password), salt, 3, 64*1024, 4, 32)
+}
+
+// get returns a cached token for the credentials that is still valid for at
+// least tokenCacheMinValidity.
+func (c *tokenCache) get(ctx context.Context, endpoint, username, password string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	key := tokenCacheKey(endpoint, username, password)
+
+	token, _ := memoryTokens.Load(key)
+	if s, ok := token.(string); ok && tokenUsable(s) {
+		return s, true
+	}
+
+	if c.dir == "" {
+		return "", false
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, tokenCacheFileName(endpoint, username)))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			tflog.Warn(ctx, "Unable to read cached Ceph API token", map[string]any{"error": err.Error()})
+		}
+		return "", false
+	}
+
+	var file tokenFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return "", false
+	}
+	if !tokenUsable(file.Token) || subtle.ConstantTimeCompare(file.PasswordHash, tokenPasswordHash(file.Salt, password)) != 1 {
+		return "", false
+	}
+
+	memoryTokens.Store(key, file.Token)
+	return file.Token, true
+}
+
+// put caches a token. Failing to write the cache only costs a login next
+// time, so errors are logged rather than returned.
+func (c *tokenCache) put(ctx context.Context, endpoint, username, password, token string) {
+	if c == nil {
+		return
+	}
+
+	memoryTokens.Store(tokenCacheKey(endpoint, username, password), token)
+
+	if c.dir == "" {
+		return
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		tflog.Warn(ctx, "Unable to cache Ceph API token", map[string]any{"error": err.Error()})
+		return
+	}
+	data, err := json.Marshal(tokenFile{
+		Token:        token,
+		Salt:         salt,
+		PasswordHash: tokenPasswordHash(salt, password),
+	})
+	if err == nil {
+		err = writeTokenFile(c.dir, tokenCacheFileName(endpoint, username), data)
+	}
+	if err != nil {
+		tflog.Warn(ctx, "Unable to cache Ceph API token", map[string]any{"error": err.Error()})
+	}
+}
+
+// writeTokenFile replaces the cached token atomically, so concurrent
+// provider processes never read a partial file. MkdirAll leaves the mode of
+// an existing directory alone, so it is restricted to the user explicitly.
+func writeTokenFile(dir, name string, data []byte) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	if err := os.Chmod(dir, 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) //nolint:errcheck
+
+	if _, err := f.Write(data); err != nil {
+		f.Close() //nolint:errcheck
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), filepath.Join(dir, name))
+}
+
+// tokenUsable reports whether a token remains valid for at least
+// tokenCacheMinValidity.
+func tokenUsable(token string) bool {
+	expiry, err := jwtExpiry(token)
+	return err == nil && time.Until(expiry) >= tokenCacheMinValidity
+}
+
+// jwtExpiry returns the exp claim of a JWT. The signature is not checked;
+// the dashboard does that when the token is used.
+func jwtExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to decode JWT payload: %w", err)
+	}
+
+	var claims struct {
+		Exp *int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse JWT claims: %w", err)
+	}
+	if claims.Exp == nil {
+		return time.Time{}, errors.New("JWT has no exp claim")
+	}
+
+	return time.Unix(*claims.Exp, 0), nil
+}
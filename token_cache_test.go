@@ -0,0 +1,238 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testJWT(t *testing.T, expiry time.Time) string {
+	t.Helper()
+
+	payload, err := json.Marshal(map[string]any{"username": "admin", "exp": expiry.Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func TestJWTExpiry(t *testing.T) {
+	expiry := time.Now().Add(8 * time.Hour).Truncate(time.Second)
+	got, err := jwtExpiry(testJWT(t, expiry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(expiry) {
+		t.Errorf("jwtExpiry() = %v, want %v", got, expiry)
+	}
+
+	for _, token := range []string{"", "not-a-jwt", "a.!!!.c", "a." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin"}`)) + ".c"} {
+		if _, err := jwtExpiry(token); err == nil {
+			t.Errorf("jwtExpiry(%q) succeeded, want error", token)
+		}
+	}
+}
+
+func TestTokenCache(t *testing.T) {
+	ctx := t.Context()
+	cache := &tokenCache{dir: filepath.Join(t.TempDir(), "tokens")}
+	if err := os.Mkdir(cache.dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	endpoint := fmt.Sprintf("https://%s.example.com:8443", t.Name())
+	token := testJWT(t, time.Now().Add(time.Hour))
+
+	if _, ok := cache.get(ctx, endpoint, "admin", "password"); ok {
+		t.Fatal("get() found a token in an empty cache")
+	}
+
+	cache.put(ctx, endpoint, "admin", "password", token)
+
+	// Forget the in-memory copy to read the token back from disk.
+	memoryTokens.Delete(tokenCacheKey(endpoint, "admin", "password"))
+	if got, ok := cache.get(ctx, endpoint, "admin", "password"); !ok || got != token {
+		t.Errorf("get() = %q, %v, want the cached token", got, ok)
+	}
+
+	if _, ok := cache.get(ctx, endpoint, "admin", "other-password"); ok {
+		t.Error("get() returned a token cached for another password")
+	}
+
+	// The file name must not let anyone listing the directory test
+	// password guesses.
+	entries, err := os.ReadDir(cache.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != tokenCacheFileName(endpoint, "admin") {
+		t.Errorf("token cache entries = %v, want one file named after the endpoint and user", entries)
+	}
+
+	info, err := os.Stat(filepath.Join(cache.dir, tokenCacheFileName(endpoint, "admin")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("token file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	info, err = os.Stat(cache.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o700 {
+		t.Errorf("token cache directory mode = %v, want 0700", info.Mode().Perm())
+	}
+
+	cache.put(ctx, endpoint, "admin", "password", testJWT(t, time.Now().Add(time.Minute)))
+	if _, ok := cache.get(ctx, endpoint, "admin", "password"); ok {
+		t.Error("get() returned a token about to expire")
+	}
+
+	var nilCache *tokenCache
+	nilCache.put(ctx, endpoint, "admin", "password", token)
+	if _, ok := nilCache.get(ctx, endpoint, "admin", "password"); ok {
+		t.Error("get() on a nil cache found a token")
+	}
+}
+
+func TestCephAPIClientTokenCache(t *testing.T) {
+	token := testJWT(t, time.Now().Add(8*time.Hour))
+	var logins, revoked atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.WriteHeader(http.StatusOK)
+		case "/api/auth":
+			logins.Add(1)
+			revoked.Store(0)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(CephAPIAuthResponse{Token: token})
+		case "/api/auth/check":
+			if r.URL.Query().Get("token") != token || revoked.Load() != 0 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cacheDir := t.TempDir()
+
+	configure := func() {
+		t.Helper()
+		client := &CephAPIClient{tokenCache: &tokenCache{dir: cacheDir}}
+		if err := client.Configure(t.Context(), []*url.URL{endpoint}, "admin", "password", ""); err != nil {
+			t.Fatal(err)
+		}
+		if client.token != token {
+			t.Errorf("token = %q, want %q", client.token, token)
+		}
+	}
+
+	configure()
+	configure()
+	if got := logins.Load(); got != 1 {
+		t.Errorf("logged in %d times, want 1", got)
+	}
+
+	// A token the dashboard no longer accepts is replaced by a new login.
+	revoked.Store(1)
+	configure()
+	if got := logins.Load(); got != 2 {
+		t.Errorf("logged in %d times after the token was revoked, want 2", got)
+	}
+}
+
+func TestAccProvider_tokenCache(t *testing.T) {
+	detachLogs := cephDaemonLogs.AttachTestFunction(t)
+	defer detachLogs()
+
+	cacheDir := t.TempDir()
+	configVariables := config.Variables{
+		"endpoint":        config.StringVariable(testDashboardURL),
+		"token_cache_dir": config.StringVariable(cacheDir),
+	}
+
+	providerConfig := `
+		variable "endpoint" {
+		  type = string
+		}
+
+		variable "token_cache_dir" {
+		  type = string
+		}
+
+		provider "ceph" {
+		  endpoint        = var.endpoint
+		  username        = "admin"
+		  password        = "password"
+		  token_cache     = "disk"
+		  token_cache_dir = var.token_cache_dir
+		}
+
+		data "ceph_auth" "admin" {
+		  entity = "client.admin"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ConfigVariables: configVariables,
+				Config:          providerConfig,
+				Check: func(s *terraform.State) error {
+					entries, err := os.ReadDir(cacheDir)
+					if err != nil {
+						return err
+					}
+					if len(entries) != 1 {
+						return fmt.Errorf("token cache has %d entries, want 1", len(entries))
+					}
+					return nil
+				},
+			},
+			{
+				ConfigVariables: configVariables,
+				Config:          providerConfig,
+			},
+			{
+				ConfigVariables: configVariables,
+				Config: `
+					provider "ceph" {
+					  endpoint    = "http://127.0.0.1:1"
+					  token       = "invalid"
+					  token_cache = "forever"
+					}
+
+					data "ceph_auth" "admin" {
+					  entity = "client.admin"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
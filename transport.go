@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	transportMaxIdleConns        = 100
+	transportMaxIdleConnsPerHost = 32
+	transportIdleConnTimeout     = 90 * time.Second
+	transportTLSSessionCacheSize = 64
+
+	// maxDrainBytes bounds how much of an unread response body is discarded
+	// to keep its connection; larger bodies are cheaper to abandon.
+	maxDrainBytes = 256 << 10
+)
+
+// sharedTransports holds one transport per TLS configuration, shared by every
+// provider instance in the plugin process so aliased providers targeting the
+// same cluster reuse connections and TLS sessions.
+var sharedTransports sync.Map
+
+// sharedTransport returns the transport for the given TLS settings. The
+// default transport keeps only two idle connections per host, so a plan with
+// hundreds of resources running in parallel keeps opening new connections and
+// repeating TLS handshakes; this one keeps enough idle connections for
+// Terraform's parallelism and resumes TLS sessions.
+func sharedTransport(insecureSkipVerify bool, caCertificate string) (http.RoundTripper, error) {
+	key := strconv.FormatBool(insecureSkipVerify) + "\x00" + caCertificate
+	if transport, ok := sharedTransports.Load(key); ok {
+		return transport.(http.RoundTripper), nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify, //nolint:gosec
+		ClientSessionCache: tls.NewLRUClientSessionCache(transportTLSSessionCacheSize),
+	}
+	if caCertificate != "" {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM([]byte(caCertificate)) {
+			return nil, errors.New("ca_certificate does not contain a valid PEM-encoded certificate")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.MaxIdleConns = transportMaxIdleConns
+	transport.MaxIdleConnsPerHost = transportMaxIdleConnsPerHost
+	transport.IdleConnTimeout = transportIdleConnTimeout
+
+	actual, _ := sharedTransports.LoadOrStore(key, &drainingTransport{base: transport})
+	return actual.(http.RoundTripper), nil
+}
+
+// drainingTransport reads what is left of a response body when it is closed.
+// Callers often close bodies they have no use for, such as the empty result
+// of a DELETE, and a connection is only reused once its body is read to the
+// end.
+type drainingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *drainingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	resp.Body = &drainingBody{ReadCloser: resp.Body}
+	return resp, nil
+}
+
+type drainingBody struct {
+	io.ReadCloser
+}
+
+func (b *drainingBody) Close() error {
+	io.Copy(io.Discard, io.LimitReader(b.ReadCloser, maxDrainBytes)) //nolint:errcheck
+	return b.ReadCloser.Close()
+}
+
+// requestTimeoutTransport bounds each request attempt by the provider
+// request_timeout. It sits inside retryTransport, so the deadline of an
+// operation with a timeouts block bounds the attempts and retries together
+// rather than replacing the per-request limit. An http.Client Timeout would
+// cover the retries as well.
+type requestTimeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *requestTimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return resp, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelBody releases the timeout of a request once its body is closed, so
+// that reading the body is bounded by the same timeout.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSharedTransport(t *testing.T) {
+	first, err := sharedTransport(false, "")
+	if err != nil {
+		t.Fatalf("sharedTransport() error = %v", err)
+	}
+	second, err := sharedTransport(false, "")
+	if err != nil {
+		t.Fatalf("sharedTransport() error = %v", err)
+	}
+	if first != second {
+		t.Error("sharedTransport() returned different transports for the same settings")
+	}
+
+	insecure, err := sharedTransport(true, "")
+	if err != nil {
+		t.Fatalf("sharedTransport() error = %v", err)
+	}
+	if insecure == first {
+		t.Error("sharedTransport() returned the same transport for different TLS settings")
+	}
+
+	if _, err := sharedTransport(false, "not a certificate"); err == nil {
+		t.Error("sharedTransport() with an invalid CA certificate succeeded")
+	}
+}
+
+func TestDrainingTransportReusesConnections(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, strings.Repeat("x", 64<<10)) //nolint:errcheck
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := &http.Client{Transport: &drainingTransport{base: http.DefaultTransport.(*http.Transport).Clone()}}
+	for range 3 {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		resp.Body.Close() //nolint:errcheck
+	}
+
+	if got := connections.Load(); got != 1 {
+		t.Errorf("connections = %d, want 1", got)
+	}
+}
+
+func TestRequestTimeoutTransport(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 || r.URL.Path == "/slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		io.WriteString(w, "ok") //nolint:errcheck
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryTransport{
+		base:       &requestTimeoutTransport{base: http.DefaultTransport, timeout: 20 * time.Millisecond},
+		maxRetries: 10,
+		backoff:    time.Millisecond,
+	}}
+
+	// An operation deadline does not lift the timeout of each attempt, but a
+	// read that timed out is retried while the deadline allows.
+	ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v, want the retry to succeed", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close() //nolint:errcheck
+	if err != nil || string(body) != "ok" || attempts.Load() != 2 {
+		t.Errorf("body = %q, %v after %d attempts, want ok after 2", body, err, attempts.Load())
+	}
+
+	attempts.Store(0)
+	req, err = http.NewRequestWithContext(ctx, http.MethodPut, server.URL+"/slow", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) || attempts.Load() != 1 {
+		t.Errorf("PUT error = %v after %d attempts, want the request timeout after 1", err, attempts.Load())
+	}
+
+	attempts.Store(0)
+	ctx, cancel = context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/slow", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) || attempts.Load() >= 10 {
+		t.Errorf("GET error = %v after %d attempts, want the operation deadline to stop the retries", err, attempts.Load())
+	}
+}